@@ -0,0 +1,862 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0-devel
+// 	protoc        v3.18.1
+// source: adr.proto
+
+package adrpb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type GetInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Algorithm ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Algorithm name.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetInfoResponse) Reset() {
+	*x = GetInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adr_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoResponse) ProtoMessage() {}
+
+func (x *GetInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adr_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return file_adr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetInfoResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type HandleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Region.
+	Region string `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	// DevEUI of the device.
+	DevEui []byte `protobuf:"bytes,2,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// MAC version of the device.
+	MacVersion string `protobuf:"bytes,3,opt,name=mac_version,json=macVersion,proto3" json:"mac_version,omitempty"`
+	// Regional parameter revision.
+	RegParamsRevision string `protobuf:"bytes,4,opt,name=reg_params_revision,json=regParamsRevision,proto3" json:"reg_params_revision,omitempty"`
+	// ADR defines if the device has ADR enabled.
+	Adr bool `protobuf:"varint,5,opt,name=adr,proto3" json:"adr,omitempty"`
+	// DR holds the uplink data-rate of the device.
+	Dr uint32 `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
+	// TX power index holds the current tx-power index of the device.
+	TxPowerIndex uint32 `protobuf:"varint,7,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Nb trans holds the number of transmissions for the device.
+	NbTrans uint32 `protobuf:"varint,8,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	// Max. allowed tx-power index.
+	MaxTxPowerIndex uint32 `protobuf:"varint,9,opt,name=max_tx_power_index,json=maxTxPowerIndex,proto3" json:"max_tx_power_index,omitempty"`
+	// Min. required SNR for the current data-rate.
+	RequiredSnrForDr float32 `protobuf:"fixed32,10,opt,name=required_snr_for_dr,json=requiredSnrForDr,proto3" json:"required_snr_for_dr,omitempty"`
+	// Configured installation margin.
+	InstallationMargin float32 `protobuf:"fixed32,11,opt,name=installation_margin,json=installationMargin,proto3" json:"installation_margin,omitempty"`
+	// Min. allowed data-rate.
+	MinDr uint32 `protobuf:"varint,12,opt,name=min_dr,json=minDr,proto3" json:"min_dr,omitempty"`
+	// Max. allowed data-rate.
+	MaxDr uint32 `protobuf:"varint,13,opt,name=max_dr,json=maxDr,proto3" json:"max_dr,omitempty"`
+	// Meta-data of the last uplinks, oldest first.
+	UplinkHistory []*UplinkMetaData `protobuf:"bytes,14,rep,name=uplink_history,json=uplinkHistory,proto3" json:"uplink_history,omitempty"`
+}
+
+func (x *HandleRequest) Reset() {
+	*x = HandleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adr_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandleRequest) ProtoMessage() {}
+
+func (x *HandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adr_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandleRequest.ProtoReflect.Descriptor instead.
+func (*HandleRequest) Descriptor() ([]byte, []int) {
+	return file_adr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HandleRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *HandleRequest) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+func (x *HandleRequest) GetMacVersion() string {
+	if x != nil {
+		return x.MacVersion
+	}
+	return ""
+}
+
+func (x *HandleRequest) GetRegParamsRevision() string {
+	if x != nil {
+		return x.RegParamsRevision
+	}
+	return ""
+}
+
+func (x *HandleRequest) GetAdr() bool {
+	if x != nil {
+		return x.Adr
+	}
+	return false
+}
+
+func (x *HandleRequest) GetDr() uint32 {
+	if x != nil {
+		return x.Dr
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetTxPowerIndex() uint32 {
+	if x != nil {
+		return x.TxPowerIndex
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetNbTrans() uint32 {
+	if x != nil {
+		return x.NbTrans
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetMaxTxPowerIndex() uint32 {
+	if x != nil {
+		return x.MaxTxPowerIndex
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetRequiredSnrForDr() float32 {
+	if x != nil {
+		return x.RequiredSnrForDr
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetInstallationMargin() float32 {
+	if x != nil {
+		return x.InstallationMargin
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetMinDr() uint32 {
+	if x != nil {
+		return x.MinDr
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetMaxDr() uint32 {
+	if x != nil {
+		return x.MaxDr
+	}
+	return 0
+}
+
+func (x *HandleRequest) GetUplinkHistory() []*UplinkMetaData {
+	if x != nil {
+		return x.UplinkHistory
+	}
+	return nil
+}
+
+type UplinkMetaData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Uplink frame-counter.
+	FCnt uint32 `protobuf:"varint,1,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Max. SNR of the receiving gateways.
+	MaxSnr float32 `protobuf:"fixed32,2,opt,name=max_snr,json=maxSnr,proto3" json:"max_snr,omitempty"`
+	// Max. RSSI of the receiving gateways.
+	MaxRssi int32 `protobuf:"varint,3,opt,name=max_rssi,json=maxRssi,proto3" json:"max_rssi,omitempty"`
+	// TX power index.
+	TxPowerIndex uint32 `protobuf:"varint,4,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Number of receiving gateways.
+	GatewayCount uint32 `protobuf:"varint,5,opt,name=gateway_count,json=gatewayCount,proto3" json:"gateway_count,omitempty"`
+	// Uplink data-rate.
+	Dr uint32 `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Uplink frequency (Hz).
+	Frequency uint32 `protobuf:"varint,7,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Uplink channel index.
+	// This is not set when the channel is unknown (see channel_unknown).
+	Channel uint32 `protobuf:"varint,8,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Receive timestamp.
+	Time *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=time,proto3" json:"time,omitempty"`
+	// Per gateway meta-data.
+	Gateways []*UplinkGatewayMetaData `protobuf:"bytes,10,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	// The uplink channel index is unknown.
+	ChannelUnknown bool `protobuf:"varint,11,opt,name=channel_unknown,json=channelUnknown,proto3" json:"channel_unknown,omitempty"`
+}
+
+func (x *UplinkMetaData) Reset() {
+	*x = UplinkMetaData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adr_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UplinkMetaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UplinkMetaData) ProtoMessage() {}
+
+func (x *UplinkMetaData) ProtoReflect() protoreflect.Message {
+	mi := &file_adr_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UplinkMetaData.ProtoReflect.Descriptor instead.
+func (*UplinkMetaData) Descriptor() ([]byte, []int) {
+	return file_adr_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UplinkMetaData) GetFCnt() uint32 {
+	if x != nil {
+		return x.FCnt
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetMaxSnr() float32 {
+	if x != nil {
+		return x.MaxSnr
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetMaxRssi() int32 {
+	if x != nil {
+		return x.MaxRssi
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetTxPowerIndex() uint32 {
+	if x != nil {
+		return x.TxPowerIndex
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetGatewayCount() uint32 {
+	if x != nil {
+		return x.GatewayCount
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetDr() uint32 {
+	if x != nil {
+		return x.Dr
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetFrequency() uint32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetChannel() uint32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *UplinkMetaData) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *UplinkMetaData) GetGateways() []*UplinkGatewayMetaData {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *UplinkMetaData) GetChannelUnknown() bool {
+	if x != nil {
+		return x.ChannelUnknown
+	}
+	return false
+}
+
+type UplinkGatewayMetaData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// SNR.
+	Snr float32 `protobuf:"fixed32,2,opt,name=snr,proto3" json:"snr,omitempty"`
+	// RSSI.
+	Rssi int32 `protobuf:"varint,3,opt,name=rssi,proto3" json:"rssi,omitempty"`
+}
+
+func (x *UplinkGatewayMetaData) Reset() {
+	*x = UplinkGatewayMetaData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adr_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UplinkGatewayMetaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UplinkGatewayMetaData) ProtoMessage() {}
+
+func (x *UplinkGatewayMetaData) ProtoReflect() protoreflect.Message {
+	mi := &file_adr_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UplinkGatewayMetaData.ProtoReflect.Descriptor instead.
+func (*UplinkGatewayMetaData) Descriptor() ([]byte, []int) {
+	return file_adr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UplinkGatewayMetaData) GetGatewayId() []byte {
+	if x != nil {
+		return x.GatewayId
+	}
+	return nil
+}
+
+func (x *UplinkGatewayMetaData) GetSnr() float32 {
+	if x != nil {
+		return x.Snr
+	}
+	return 0
+}
+
+func (x *UplinkGatewayMetaData) GetRssi() int32 {
+	if x != nil {
+		return x.Rssi
+	}
+	return 0
+}
+
+type HandleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Data-rate to which the device must change.
+	Dr uint32 `protobuf:"varint,1,opt,name=dr,proto3" json:"dr,omitempty"`
+	// TX power index to which the device must change.
+	TxPowerIndex uint32 `protobuf:"varint,2,opt,name=tx_power_index,json=txPowerIndex,proto3" json:"tx_power_index,omitempty"`
+	// Number of transmissions which the device must use for each uplink.
+	NbTrans uint32 `protobuf:"varint,3,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+}
+
+func (x *HandleResponse) Reset() {
+	*x = HandleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_adr_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandleResponse) ProtoMessage() {}
+
+func (x *HandleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adr_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandleResponse.ProtoReflect.Descriptor instead.
+func (*HandleResponse) Descriptor() ([]byte, []int) {
+	return file_adr_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HandleResponse) GetDr() uint32 {
+	if x != nil {
+		return x.Dr
+	}
+	return 0
+}
+
+func (x *HandleResponse) GetTxPowerIndex() uint32 {
+	if x != nil {
+		return x.TxPowerIndex
+	}
+	return 0
+}
+
+func (x *HandleResponse) GetNbTrans() uint32 {
+	if x != nil {
+		return x.NbTrans
+	}
+	return 0
+}
+
+var File_adr_proto protoreflect.FileDescriptor
+
+var file_adr_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x61, 0x64, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x61, 0x64, 0x72,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xeb, 0x03, 0x0a, 0x0d, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12,
+	0x17, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x5f, 0x65, 0x75, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x64, 0x65, 0x76, 0x45, 0x75, 0x69, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d,
+	0x61, 0x63, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x67,
+	0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x64, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x64, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x64,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x64, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x74,
+	0x78, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x78, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x62, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x6e, 0x62, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x12,
+	0x6d, 0x61, 0x78, 0x5f, 0x74, 0x78, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x54, 0x78, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d, 0x0a, 0x13, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x6e, 0x72, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x64, 0x72,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x53, 0x6e, 0x72, 0x46, 0x6f, 0x72, 0x44, 0x72, 0x12, 0x2f, 0x0a, 0x13, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x69, 0x6e,
+	0x5f, 0x64, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x44, 0x72,
+	0x12, 0x15, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x6d, 0x61, 0x78, 0x44, 0x72, 0x12, 0x3a, 0x0a, 0x0e, 0x75, 0x70, 0x6c, 0x69, 0x6e,
+	0x6b, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x61, 0x64, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x4d, 0x65, 0x74, 0x61,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x0d, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x22, 0xfd, 0x02, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x4d, 0x65,
+	0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x13, 0x0a, 0x05, 0x66, 0x5f, 0x63, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x66, 0x43, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6d,
+	0x61, 0x78, 0x5f, 0x73, 0x6e, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x6d, 0x61,
+	0x78, 0x53, 0x6e, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x73, 0x73, 0x69,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x52, 0x73, 0x73, 0x69, 0x12,
+	0x24, 0x0a, 0x0e, 0x74, 0x78, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x78, 0x50, 0x6f, 0x77, 0x65, 0x72,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x23, 0x0a, 0x0d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x64, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x64, 0x72, 0x2e, 0x55, 0x70, 0x6c, 0x69, 0x6e,
+	0x6b, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x55, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x22, 0x5c, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x47, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x4d, 0x65, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x6e, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x73, 0x6e, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x73, 0x73, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x73, 0x73,
+	0x69, 0x22, 0x61, 0x0a, 0x0e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x02, 0x64, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x78, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x78, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x62, 0x5f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6e, 0x62, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x32, 0x7c, 0x0a, 0x0a, 0x41, 0x44, 0x52, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x61, 0x64, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a,
+	0x06, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x12, 0x2e, 0x61, 0x64, 0x72, 0x2e, 0x48, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x64,
+	0x72, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6c, 0x69, 0x75, 0x68, 0x77, 0x30, 0x2f, 0x63, 0x68, 0x69, 0x72, 0x70, 0x73, 0x74, 0x61,
+	0x63, 0x6b, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2f, 0x76, 0x33, 0x2f, 0x61, 0x64, 0x72, 0x2f, 0x61, 0x64, 0x72, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_adr_proto_rawDescOnce sync.Once
+	file_adr_proto_rawDescData = file_adr_proto_rawDesc
+)
+
+func file_adr_proto_rawDescGZIP() []byte {
+	file_adr_proto_rawDescOnce.Do(func() {
+		file_adr_proto_rawDescData = protoimpl.X.CompressGZIP(file_adr_proto_rawDescData)
+	})
+	return file_adr_proto_rawDescData
+}
+
+var file_adr_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_adr_proto_goTypes = []interface{}{
+	(*GetInfoResponse)(nil),       // 0: adr.GetInfoResponse
+	(*HandleRequest)(nil),         // 1: adr.HandleRequest
+	(*UplinkMetaData)(nil),        // 2: adr.UplinkMetaData
+	(*UplinkGatewayMetaData)(nil), // 3: adr.UplinkGatewayMetaData
+	(*HandleResponse)(nil),        // 4: adr.HandleResponse
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*empty.Empty)(nil),           // 6: google.protobuf.Empty
+}
+var file_adr_proto_depIdxs = []int32{
+	2, // 0: adr.HandleRequest.uplink_history:type_name -> adr.UplinkMetaData
+	5, // 1: adr.UplinkMetaData.time:type_name -> google.protobuf.Timestamp
+	3, // 2: adr.UplinkMetaData.gateways:type_name -> adr.UplinkGatewayMetaData
+	6, // 3: adr.ADRService.GetInfo:input_type -> google.protobuf.Empty
+	1, // 4: adr.ADRService.Handle:input_type -> adr.HandleRequest
+	0, // 5: adr.ADRService.GetInfo:output_type -> adr.GetInfoResponse
+	4, // 6: adr.ADRService.Handle:output_type -> adr.HandleResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_adr_proto_init() }
+func file_adr_proto_init() {
+	if File_adr_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_adr_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adr_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adr_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UplinkMetaData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adr_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UplinkGatewayMetaData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_adr_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_adr_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_adr_proto_goTypes,
+		DependencyIndexes: file_adr_proto_depIdxs,
+		MessageInfos:      file_adr_proto_msgTypes,
+	}.Build()
+	File_adr_proto = out.File
+	file_adr_proto_rawDesc = nil
+	file_adr_proto_goTypes = nil
+	file_adr_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ADRServiceClient is the client API for ADRService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ADRServiceClient interface {
+	// GetInfo returns the ID and name of the ADR algorithm.
+	GetInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// Handle handles the ADR request.
+	Handle(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*HandleResponse, error)
+}
+
+type aDRServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewADRServiceClient(cc grpc.ClientConnInterface) ADRServiceClient {
+	return &aDRServiceClient{cc}
+}
+
+func (c *aDRServiceClient) GetInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := c.cc.Invoke(ctx, "/adr.ADRService/GetInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aDRServiceClient) Handle(ctx context.Context, in *HandleRequest, opts ...grpc.CallOption) (*HandleResponse, error) {
+	out := new(HandleResponse)
+	err := c.cc.Invoke(ctx, "/adr.ADRService/Handle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ADRServiceServer is the server API for ADRService service.
+type ADRServiceServer interface {
+	// GetInfo returns the ID and name of the ADR algorithm.
+	GetInfo(context.Context, *empty.Empty) (*GetInfoResponse, error)
+	// Handle handles the ADR request.
+	Handle(context.Context, *HandleRequest) (*HandleResponse, error)
+}
+
+// UnimplementedADRServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedADRServiceServer struct {
+}
+
+func (*UnimplementedADRServiceServer) GetInfo(context.Context, *empty.Empty) (*GetInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (*UnimplementedADRServiceServer) Handle(context.Context, *HandleRequest) (*HandleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Handle not implemented")
+}
+
+func RegisterADRServiceServer(s *grpc.Server, srv ADRServiceServer) {
+	s.RegisterService(&_ADRService_serviceDesc, srv)
+}
+
+func _ADRService_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ADRServiceServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adr.ADRService/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ADRServiceServer).GetInfo(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ADRService_Handle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ADRServiceServer).Handle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adr.ADRService/Handle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ADRServiceServer).Handle(ctx, req.(*HandleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ADRService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "adr.ADRService",
+	HandlerType: (*ADRServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _ADRService_GetInfo_Handler,
+		},
+		{
+			MethodName: "Handle",
+			Handler:    _ADRService_Handle_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "adr.proto",
+}
@@ -0,0 +1,5 @@
+//go:generate protoc -I=/protobuf/src -I=. --go_out=plugins=grpc,paths=source_relative:. adr.proto
+
+// Package adrpb contains the API definition of the remote ADR algorithm
+// service (see the adr.Handler interface for the contract).
+package adrpb
@@ -2,6 +2,7 @@ package adr
 
 import (
 	"net/rpc"
+	"time"
 
 	"github.com/liuhw0/lorawan"
 	"github.com/hashicorp/go-plugin"
@@ -62,8 +63,10 @@ type HandleRequest struct {
 	// MaxDR defines the max. allowed data-rate.
 	MaxDR int
 
-	// UplinkHistory contains the meta-data of the last uplinks.
-	// Note: this table is for the current data-date only!
+	// UplinkHistory contains the meta-data of the last uplinks, oldest first.
+	// The number of uplinks is configured by the device-profile.
+	// Note: this table is for the current data-date only! It is reset when
+	// the device changes its data-rate.
 	UplinkHistory []UplinkMetaData
 }
 
@@ -86,6 +89,29 @@ type UplinkMetaData struct {
 	MaxRSSI      int32
 	TXPowerIndex int
 	GatewayCount int
+
+	// DR holds the uplink data-rate.
+	DR int
+
+	// Frequency holds the uplink frequency (Hz).
+	Frequency uint32
+
+	// Channel holds the uplink channel index (-1 when unknown).
+	Channel int
+
+	// Time holds the (network-server) receive timestamp.
+	Time time.Time
+
+	// Gateways contains the meta-data per receiving gateway.
+	Gateways []UplinkGatewayMetaData
+}
+
+// UplinkGatewayMetaData contains the meta-data of an uplink transmission as
+// received by a single gateway.
+type UplinkGatewayMetaData struct {
+	GatewayID lorawan.EUI64
+	SNR       float32
+	RSSI      int32
 }
 
 // HandlerRPCServer implements the RPC server for the Handler interface.
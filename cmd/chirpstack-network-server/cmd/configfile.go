@@ -150,6 +150,24 @@ net_id="{{ .NetworkServer.NetID }}"
 # unable to respond to the device within its receive-window.
 deduplication_delay="{{ .NetworkServer.DeduplicationDelay }}"
 
+# Adaptive de-duplication.
+#
+# When enabled, the gateways that are expected to receive a data uplink
+# are predicted from the gateways that received the previous uplink of the
+# device(s) using the same DevAddr. The de-duplication ends as soon as all
+# expected gateways have reported the uplink, with the deduplication_delay
+# as upper bound. For other uplinks (e.g. join-requests), or when there is
+# no previous uplink, the full deduplication_delay is used.
+adaptive_deduplication={{ .NetworkServer.AdaptiveDeduplication }}
+
+# Adaptive de-duplication min. delay.
+#
+# When adaptive de-duplication is enabled, this is the min. time to wait
+# for other gateways. As the expected gateways are based on the previous
+# uplink only, this allows gateways that did not receive the previous
+# uplink to report the uplink (and to become expected gateways again).
+adaptive_deduplication_min_delay="{{ .NetworkServer.AdaptiveDeduplicationMinDelay }}"
+
 # Device session expiration.
 #
 # The TTL value defines the time after which a device-session expires
@@ -169,6 +187,97 @@ device_session_ttl="{{ .NetworkServer.DeviceSessionTTL }}"
 # unable to respond to the device within its receive-window.
 get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
 
+# Proprietary uplink plugins.
+#
+# By default, proprietary uplink frames are forwarded to all application-servers.
+# Plugins (see the proprietary.Handler interface) can parse proprietary frames
+# and decide to which application-servers (by routing-profile) these must be
+# forwarded, replace the forwarded payload (e.g. by a decoded payload), send an
+# immediate proprietary downlink or drop the frame. The plugins are called in
+# the configured order, until one of the plugins handles the frame. A plugin
+# returning an error or not responding within one second is skipped. A plugin
+# which crashes is restarted in the background on the next frame (at most once
+# every 10 seconds).
+proprietary_plugins=[{{ range $index, $element := .NetworkServer.ProprietaryPlugins }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
+
+  # Device-session KEK configuration.
+  #
+  # When a label is configured, the network session-keys (FNwkSIntKey,
+  # SNwkSIntKey and NwkSEncKey) of each device-session are stored encrypted
+  # in Redis, using the KEK (Key Encryption Key) matching this label.
+  # When left blank, the session-keys are stored in plaintext.
+  #
+  # To rotate the KEK, add a new KEK to the set and update the label. The
+  # previous KEK must remain in the set until all device-sessions have been
+  # re-encrypted. Device-sessions are re-encrypted when they are read, or
+  # all at once using the 'reencrypt-ds-keys' sub-command.
+  [network_server.device_session_kek]
+  # KEK label used for encrypting the session-keys.
+  label="{{ .NetworkServer.DeviceSessionKEK.Label }}"
+
+  # Device-session KEK set.
+  #
+  # Example (the [[network_server.device_session_kek.set]] can be repeated):
+  # [[network_server.device_session_kek.set]]
+  # # KEK label.
+  # label="kek-label"
+  #
+  # # Key Encryption Key.
+  # kek="01020304050607080102030405060708"
+  {{ range $index, $element := .NetworkServer.DeviceSessionKEK.Set }}
+  [[network_server.device_session_kek.set]]
+  label="{{ $element.Label }}"
+  kek="{{ $element.KEK }}"
+  {{ end }}
+
+
+  # Device-session persistence.
+  #
+  # When enabled, a snapshot of each device-session is persisted to
+  # PostgreSQL, in addition to Redis. On a Redis miss (e.g. after a Redis
+  # flush or failover without persistence), the device-session is restored
+  # from PostgreSQL, so that devices do not need to rejoin. Snapshots older
+  # than the device-session TTL are not restored.
+  [network_server.device_session_persistence]
+  # Enable device-session persistence.
+  enabled={{ .NetworkServer.DeviceSessionPersistence.Enabled }}
+
+  # Flush interval.
+  #
+  # This defines the interval in which pending device-session snapshots are
+  # written to PostgreSQL (write-behind). Pending snapshots are lost when
+  # the network-server exits, therefore f_cnt_gap must be set. When set to
+  # 0, the snapshots are written synchronously.
+  flush_interval="{{ .NetworkServer.DeviceSessionPersistence.FlushInterval }}"
+
+  # Frame-counter gap.
+  #
+  # As a restored snapshot might be older than the lost device-session, the
+  # downlink frame-counters of a restored device-session are incremented by
+  # this value, to make sure that frame-counters are never re-used.
+  f_cnt_gap={{ .NetworkServer.DeviceSessionPersistence.FCntGap }}
+
+
+  # Uplink worker pool.
+  #
+  # By default, each received uplink frame and downlink tx acknowledgement
+  # is handled within its own go-routine. During a gateway reconnect storm
+  # this can result in memory spikes and the exhaustion of the Redis
+  # connection-pool. When the number of workers is set, received frames are
+  # handled by a fixed number of workers. Join-, rejoin- and confirmed
+  # uplink frames are handled before unconfirmed and proprietary uplink
+  # frames. When the queue is full, received frames are dropped (before
+  # de-duplication), see the uplink_shed_counter metric.
+  [network_server.uplink_worker_pool]
+  # Number of workers (0 = unbounded).
+  workers={{ .NetworkServer.UplinkWorkerPool.Workers }}
+
+  # Queue size.
+  #
+  # The max. number of received frames waiting to be handled (per priority).
+  queue_size={{ .NetworkServer.UplinkWorkerPool.QueueSize }}
+
 
   # LoRaWAN regional band configuration.
   #
@@ -358,8 +467,40 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # By default, the 'default' ADR algorithm is available. The number of available
   # ADR algorithms can be extended through plugins. This setting can be configured
   # to a list of one or multiple plugins.
+  #
+  # A plugin which crashes is restarted in the background on the next ADR
+  # request (at most once every 10 seconds). Until it has been restarted, or
+  # when the plugin does not respond within one second, the 'default' ADR
+  # algorithm is used for the devices using this plugin.
   adr_plugins=[]
 
+  # ADR scripts directory.
+  #
+  # ADR algorithms can also be implemented as (JavaScript) scripts. When set,
+  # all the *.js files in this directory are loaded as ADR algorithm. Each
+  # script must define the 'id' and 'name' variables and the 'handle' function,
+  # which receives the ADR request and must return an object containing the
+  # dr, tx_power_index and nb_trans. Example:
+  #
+  #   var id = "my-adr";
+  #   var name = "My ADR algorithm";
+  #
+  #   function handle(req) {
+  #     return {dr: req.dr, tx_power_index: req.tx_power_index, nb_trans: req.nb_trans};
+  #   }
+  #
+  # Scripts can also be stored per device-profile, in which case the ADR
+  # algorithm of the device-profile must be set to 'device_profile_script'.
+  #
+  # Scripts do not have access to the file-system or network. When a script
+  # fails, exceeds the max. execution time, allocates more than (approx.) 64MB
+  # or returns a dr, tx_power_index or nb_trans which is out of range, the
+  # 'default' ADR algorithm is used for the request.
+  adr_scripts_dir="{{ .NetworkServer.NetworkSettings.ADRScriptsDir }}"
+
+  # Max. execution time of an ADR script.
+  adr_script_timeout="{{ .NetworkServer.NetworkSettings.ADRScriptTimeout }}"
+
 
   # Extra channel configuration.
   #
@@ -370,6 +511,10 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # The other channels (or channel / data-rate changes) will be (re)configured
   # using the NewChannelReq mac-command.
   #
+  # When the device-profile refers to a channel-plan, only the channels of the
+  # channel-plan are configured on the device. The frequencies of the
+  # channel-plan must be configured as extra channel.
+  #
   # Example:
   # [[network_server.network_settings.extra_channels]]
   # frequency=867100000
@@ -402,6 +547,37 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   max_dr={{ $element.MaxDR }}
 {{ end }}
 
+  # Remote ADR algorithms.
+  #
+  # Besides plugins, ADR algorithms can be implemented as a remote gRPC service
+  # implementing the ADRService (see adr/adrpb/adr.proto). The ID and name of
+  # the algorithm are retrieved from the service on start. When the service is
+  # unavailable on start, this is retried every 10 seconds in the background and
+  # the algorithm is registered once available. When the service
+  # does not respond within the configured timeout, or is unavailable, the
+  # 'default' ADR algorithm is used for this request.
+  #
+  # Example:
+  # [[network_server.network_settings.adr_remote_handlers]]
+  # # Hostname:port of the ADR service.
+  # server="adr-service:9000"
+  #
+  # # CA certificate, TLS certificate and key (optional).
+  # ca_cert=""
+  # tls_cert=""
+  # tls_key=""
+  #
+  # # Request timeout (when not set, 1s is used).
+  # timeout="1s"
+{{ range $index, $element := .NetworkServer.NetworkSettings.ADRRemoteHandlers }}
+  [[network_server.network_settings.adr_remote_handlers]]
+  server="{{ $element.Server }}"
+  ca_cert="{{ $element.CACert }}"
+  tls_cert="{{ $element.TLSCert }}"
+  tls_key="{{ $element.TLSKey }}"
+  timeout="{{ $element.Timeout }}"
+{{ end }}
+
   # Class B settings
   [network_server.network_settings.class_b]
   # Ping-slot data-rate.
@@ -445,6 +621,15 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # Class-C runs.
   scheduler_interval="{{ .NetworkServer.Scheduler.SchedulerInterval }}"
 
+  # Multicast max. attempts.
+  #
+  # The max. number of transmission attempts of a multicast queue-item (per
+  # gateway). When the gateway reports a transient error (e.g. COLLISION_PACKET
+  # or TOO_LATE), the queue-item is re-scheduled after the last queue-item of
+  # the multicast-group. After the last attempt, the queue-item is removed
+  # and the error is reported to the application-server.
+  multicast_max_attempts={{ .NetworkServer.Scheduler.MulticastMaxAttempts }}
+
     # Class-C settings.
     [network_server.scheduler.class_c]
     # Device downlink lock duration
@@ -470,6 +655,18 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     # gateways.
     multicast_gateway_delay="{{ .NetworkServer.Scheduler.ClassC.MulticastGatewayDelay }}"
 
+    # Class-B settings.
+    [network_server.scheduler.class_b]
+    # Multicast gateway time-sync required.
+    #
+    # Class-B multicast downlinks are emitted at a GPS epoch timestamp, which
+    # requires the gateway to be time-synchronized (e.g. GPS locked). Gateways
+    # reporting (using the 'gps_locked' or 'time_synced' stats meta-data) that
+    # they are not time-synchronized are never used for Class-B multicast.
+    # When set to true, gateways that do not report their time-sync state are
+    # not used either.
+    multicast_gateway_time_sync_required={{ .NetworkServer.Scheduler.ClassB.MulticastGatewayTimeSyncRequired }}
+
 
   # Network-server API
   #
@@ -522,6 +719,39 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # retry the downlink transmission.
   downlink_timeout="{{ .NetworkServer.Gateway.DownlinkTimeout }}"
 
+  # Packet-forwarder configuration command.
+  #
+  # The channels of the gateway-profile are pushed to the gateways using the
+  # configuration translator of the gateway (see the UpdateGatewayConfigTranslator
+  # API method). When no translator is set, only gateways using the ChirpStack
+  # Concentratord are configured. Available translators:
+  #   * concentratord: sends the gateway configuration command
+  #   * packet_forwarder: renders the SX1301_conf and gateway_conf sections of the
+  #     Semtech UDP packet-forwarder global_conf.json and sends these (as stdin)
+  #     to the command below, which must be configured in the ChirpStack Gateway
+  #     Bridge and is responsible for updating the configuration and restarting
+  #     the packet-forwarder. Note that the rendered configuration is partial:
+  #     the command must deep-merge it into the existing global_conf.json, so
+  #     that the hardware specific settings (e.g. the radio type, rssi_offset,
+  #     tx_enable, tx_freq_min / tx_freq_max, tx_notch_freq and the tx_lut_*
+  #     objects) are retained
+  #   * basic_station: renders the router_config message and sends it to the
+  #     Basics Station using the raw packet-forwarder command
+  #
+  # The configuration version is marked as applied once it is reported by the
+  # gateway stats (config_version or the config_version meta-data), or (for the
+  # packet_forwarder translator) once the command execution response without
+  # error has been received. As the Basics Station does not report its
+  # configuration version, the basic_station configuration is marked as applied
+  # once it has been sent.
+  packet_forwarder_config_command="{{ .NetworkServer.Gateway.PacketForwarderConfigCommand }}"
+
+  # Pending configuration timeout.
+  #
+  # When the gateway did not apply the sent configuration within this duration,
+  # the configuration is sent again.
+  config_pending_timeout="{{ .NetworkServer.Gateway.ConfigPendingTimeout }}"
+
 
   # Backend defines the gateway backend settings.
   #
@@ -743,6 +973,24 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   #   * Ping Redis database
   healthcheck_endpoint={{ .Monitoring.HealthcheckEndpoint }}
 
+  # Configuration reload endpoint.
+  #
+  # When set to true, the configuration reload endpoint will be served at
+  # '/config/reload' (POST). This re-reads the configuration file (as does
+  # sending a SIGHUP signal) and re-applies the following sections without
+  # restart:
+  #   * general.log_level
+  #   * network_server.network_settings
+  #   * network_server.proprietary_plugins
+  #   * join_server
+  #   * roaming (except roaming.api)
+  # The response contains the changed configuration keys which have been
+  # reloaded and those that require a restart.
+  #
+  # Note: this endpoint does not implement any authentication, make sure
+  # that the monitoring bind is not publicly accessible.
+  config_reload_endpoint={{ .Monitoring.ConfigReloadEndpoint }}
+
   # Device frame-log max history.
   #
   # When set to a value > 0, ChirpStack Network Server will log all uplink and
@@ -784,6 +1032,157 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   per_gateway_frame_log_max_history={{ .Monitoring.PerGatewayFrameLogMaxHistory }}
 
 
+  # OpenTelemetry tracing.
+  #
+  # When enabled, spans are created for the uplink, join, rejoin, downlink
+  # and downlink tx ack handling (one span per task), the Redis and
+  # PostgreSQL queries and the calls to the application-server,
+  # network-controller and join-server. The trace context is propagated to
+  # the application-server and network-controller using the gRPC meta-data.
+  # Spans are exported using OTLP (gRPC). For local testing, the
+  # docker-compose environment provides a Jaeger instance accepting OTLP
+  # at 'jaeger:4317' (UI at http://localhost:16686).
+  [monitoring.tracing]
+  # Enable tracing.
+  enabled={{ .Monitoring.Tracing.Enabled }}
+
+  # Service name.
+  service_name="{{ .Monitoring.Tracing.ServiceName }}"
+
+  # OTLP (gRPC) endpoint (hostname:port) of the collector.
+  otlp_endpoint="{{ .Monitoring.Tracing.OTLPEndpoint }}"
+
+  # Disable TLS for the OTLP connection.
+  otlp_insecure={{ .Monitoring.Tracing.OTLPInsecure }}
+
+  # Sampling ratio (0.0 - 1.0).
+  #
+  # The ratio of the traces that are sampled. Note that the sampling
+  # decision of the parent span (e.g. of an incoming API request) is
+  # respected.
+  sampling_ratio={{ .Monitoring.Tracing.SamplingRatio }}
+
+  # Device lifecycle audit events.
+  #
+  # Audit events are logged on joins, rejoins, ADR change requests,
+  # mac-command (n)ACKs, device mode changes and when a device is enabled or
+  # disabled. Each event contains the relevant device-session values before
+  # and after the change. The event history of a device can be retrieved
+  # using the GetDeviceEvents API method. Events are written to Redis and
+  # published asynchronously, in the order in which they were logged.
+  [monitoring.audit]
+  # Max. number of audit events to keep per device.
+  #
+  # The events are stored in a Redis stream per device. Setting this to 0
+  # disables the per-device event history.
+  per_device_max_history={{ .Monitoring.Audit.PerDeviceMaxHistory }}
+
+  # Max. number of audit events to keep in the global Redis stream.
+  #
+  # Setting this to 0 disables the global event stream.
+  max_history={{ .Monitoring.Audit.MaxHistory }}
+
+  # Gateway backend topic template.
+  #
+  # When set, the audit events are published (JSON encoded) to the gateway
+  # backend using this topic. Note that this is only supported by the MQTT
+  # gateway backend. Available placeholders: .DevEUI and .Type.
+  #
+  # Example:
+  # gateway_backend_topic_template="device/{{ "{{ .DevEUI }}" }}/audit/{{ "{{ .Type }}" }}"
+  gateway_backend_topic_template="{{ .Monitoring.Audit.GatewayBackendTopicTemplate }}"
+
+  # Webhook URL.
+  #
+  # When set, the audit events are POSTed (JSON encoded) to this URL.
+  webhook_url="{{ .Monitoring.Audit.WebhookURL }}"
+
+  # Webhook timeout.
+  webhook_timeout="{{ .Monitoring.Audit.WebhookTimeout }}"
+
+
+# Application-server settings.
+[application_server]
+
+  # Application-server client settings.
+  #
+  # One connection is kept per application-server (as configured in the
+  # routing-profile).
+  [application_server.client]
+  # Idle timeout.
+  #
+  # Connections which have not been used for the configured duration are
+  # closed. Set this to 0 to never close idle connections.
+  idle_timeout="{{ .ApplicationServer.Client.IdleTimeout }}"
+
+  # Health-check interval.
+  #
+  # The connections are health-checked in the configured interval using the
+  # gRPC health-checking protocol. Application-servers not implementing this
+  # protocol are considered healthy when reachable. Set this to 0 to disable
+  # the health-checks (and the closing of idle connections).
+  health_check_interval="{{ .ApplicationServer.Client.HealthCheckInterval }}"
+
+    # Circuit-breaker settings.
+    #
+    # After the configured number of consecutive failures (e.g. the
+    # application-server is unavailable), the circuit-breaker of the
+    # application-server opens and requests fail fast, such that unavailable
+    # application-servers do not stall the handling of uplinks. After the
+    # open duration, a single request is allowed to probe the
+    # application-server. A successful health-check closes the
+    # circuit-breaker.
+    [application_server.client.circuit_breaker]
+    # Failure threshold (set to 0 to disable the circuit-breaker).
+    failure_threshold={{ .ApplicationServer.Client.CircuitBreaker.FailureThreshold }}
+
+    # Open duration.
+    open_duration="{{ .ApplicationServer.Client.CircuitBreaker.OpenDuration }}"
+
+  # Retry settings.
+  #
+  # Uplink data and downlink acknowledgement events which could not be
+  # delivered because the application-server is unavailable are retried.
+  # Events which could not be delivered after the max. number of attempts
+  # (or when the application-server circuit-breaker is open) are buffered in
+  # Redis and replayed when the application-server is available again.
+  [application_server.retry]
+  # Max. number of delivery attempts before buffering the event.
+  max_attempts={{ .ApplicationServer.Retry.MaxAttempts }}
+
+  # Retry interval.
+  #
+  # This interval is multiplied by the attempt number.
+  interval="{{ .ApplicationServer.Retry.Interval }}"
+
+  # Delivery timeout.
+  #
+  # The timeout of a single delivery attempt.
+  delivery_timeout="{{ .ApplicationServer.Retry.DeliveryTimeout }}"
+
+  # Retry queue size.
+  #
+  # The max. number of events pending a retry. When the queue is full, events
+  # are buffered directly.
+  queue_size={{ .ApplicationServer.Retry.QueueSize }}
+
+  # Max. number of buffered events per routing-profile.
+  #
+  # When exceeded, the oldest events are dropped. Set this to 0 for no limit.
+  buffer_max_size={{ .ApplicationServer.Retry.BufferMaxSize }}
+
+  # Buffer TTL.
+  #
+  # The buffered events of a routing-profile expire after this duration
+  # (since the last buffered event). Set this to 0 to never expire.
+  buffer_ttl="{{ .ApplicationServer.Retry.BufferTTL }}"
+
+  # Replay interval.
+  #
+  # The interval in which the buffered events are replayed.
+  replay_interval="{{ .ApplicationServer.Retry.ReplayInterval }}"
+
+
 # Join-server settings.
 [join_server]
 # Resolve JoinEUI (experimental).
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+
+	"github.com/gofrs/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+var exportDSOpts struct {
+	output          string
+	devEUIs         []string
+	deviceProfileID string
+	keyPrefix       string
+}
+
+var exportDSCmd = &cobra.Command{
+	Use:   "export-ds",
+	Short: "Export the device-sessions, gateway rx-info sets and mac-command queues",
+	Long: `Export the device-sessions, device gateway rx-info sets and mac-command
+queues stored in Redis. The export can be imported using the import-ds command
+(e.g. to migrate to a different Redis instance, or to restore a backup).
+
+Note that the device-session keys are exported as stored. In case the
+device-session keys are encrypted, the same KEK must be configured when
+importing the export.`,
+	Example: `chirpstack-network-server export-ds --output backup.ndjson
+chirpstack-network-server export-ds --dev-eui 0102030405060708 --dev-eui 0102030405060709
+chirpstack-network-server export-ds --device-profile-id 9f2b1e2c-6d9f-4e2a-8c43-1a1f5c0e4a7b --output dp.ndjson`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := storage.Setup(config.C); err != nil {
+			log.Fatal(err)
+		}
+
+		var filters storage.DeviceSessionExportFilters
+
+		for _, s := range exportDSOpts.devEUIs {
+			var devEUI lorawan.EUI64
+			if err := devEUI.UnmarshalText([]byte(s)); err != nil {
+				log.WithError(err).Fatal("decode DevEUI error")
+			}
+			filters.DevEUIs = append(filters.DevEUIs, devEUI)
+		}
+
+		if exportDSOpts.deviceProfileID != "" {
+			id, err := uuid.FromString(exportDSOpts.deviceProfileID)
+			if err != nil {
+				log.WithError(err).Fatal("decode device-profile ID error")
+			}
+			filters.DeviceProfileID = &id
+		}
+
+		if cmd.Flags().Changed("key-prefix") {
+			filters.KeyPrefix = &exportDSOpts.keyPrefix
+		}
+
+		var w io.Writer = os.Stdout
+		if exportDSOpts.output != "" && exportDSOpts.output != "-" {
+			f, err := os.Create(exportDSOpts.output)
+			if err != nil {
+				log.WithError(err).Fatal("create output file error")
+			}
+			defer f.Close()
+			w = f
+		}
+
+		bw := bufio.NewWriter(w)
+		count, err := storage.ExportDeviceSessions(context.Background(), bw, filters)
+		if err != nil {
+			log.WithError(err).Fatal("export device-sessions error")
+		}
+		if err := bw.Flush(); err != nil {
+			log.WithError(err).Fatal("write output error")
+		}
+
+		log.WithField("count", count).Info("device-sessions exported")
+	},
+}
+
+func init() {
+	exportDSCmd.Flags().StringVarP(&exportDSOpts.output, "output", "o", "", "output file (default stdout)")
+	exportDSCmd.Flags().StringSliceVar(&exportDSOpts.devEUIs, "dev-eui", nil, "only export the given DevEUI (can be repeated)")
+	exportDSCmd.Flags().StringVar(&exportDSOpts.deviceProfileID, "device-profile-id", "", "only export the device-sessions of the given device-profile")
+	exportDSCmd.Flags().StringVar(&exportDSOpts.keyPrefix, "key-prefix", "", "read using the given Redis key prefix instead of the configured prefix")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+var importDSOpts struct {
+	input     string
+	keyPrefix string
+	overwrite bool
+}
+
+var importDSCmd = &cobra.Command{
+	Use:   "import-ds",
+	Short: "Import the device-sessions, gateway rx-info sets and mac-command queues",
+	Long: `Import the device-sessions, device gateway rx-info sets and mac-command
+queues from an export created by the export-ds command.
+
+By default, device-sessions that already exist are skipped, as these could be
+more recent than the exported state (e.g. after a re-join).`,
+	Example: `chirpstack-network-server import-ds --input backup.ndjson
+chirpstack-network-server import-ds --input backup.ndjson --key-prefix eu868:`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := storage.Setup(config.C); err != nil {
+			log.Fatal(err)
+		}
+
+		var opts storage.DeviceSessionImportOptions
+		opts.Overwrite = importDSOpts.overwrite
+		if cmd.Flags().Changed("key-prefix") {
+			opts.KeyPrefix = &importDSOpts.keyPrefix
+		}
+
+		var r io.Reader = os.Stdin
+		if importDSOpts.input != "" && importDSOpts.input != "-" {
+			f, err := os.Open(importDSOpts.input)
+			if err != nil {
+				log.WithError(err).Fatal("open input file error")
+			}
+			defer f.Close()
+			r = f
+		}
+
+		res, err := storage.ImportDeviceSessions(context.Background(), r, opts)
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"imported": res.Imported,
+				"skipped":  res.Skipped,
+			}).Fatal("import device-sessions error")
+		}
+
+		log.WithFields(log.Fields{
+			"imported": res.Imported,
+			"skipped":  res.Skipped,
+		}).Info("device-sessions imported")
+	},
+}
+
+func init() {
+	importDSCmd.Flags().StringVarP(&importDSOpts.input, "input", "i", "", "input file (default stdin)")
+	importDSCmd.Flags().StringVar(&importDSOpts.keyPrefix, "key-prefix", "", "write using the given Redis key prefix instead of the configured prefix")
+	importDSCmd.Flags().BoolVar(&importDSOpts.overwrite, "overwrite", false, "overwrite existing device-sessions")
+}
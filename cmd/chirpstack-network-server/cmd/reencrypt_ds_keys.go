@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+var reEncryptDSKeysCmd = &cobra.Command{
+	Use:   "reencrypt-ds-keys",
+	Short: "Re-encrypt the device-session keys using the configured device-session KEK",
+	Long: `Re-encrypt the session-keys of all device-sessions which are not encrypted
+using the configured device-session KEK. Use this after enabling the
+device-session key encryption, or after rotating the KEK.`,
+	Example: `chirpstack-network-server reencrypt-ds-keys`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := storage.Setup(config.C); err != nil {
+			log.Fatal(err)
+		}
+
+		count, err := storage.ReEncryptDeviceSessions(context.Background())
+		if err != nil {
+			log.WithError(err).Fatal("re-encrypt device-session keys error")
+		}
+
+		log.WithField("count", count).Info("device-session keys re-encrypted")
+	},
+}
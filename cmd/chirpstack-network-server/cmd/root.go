@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -56,12 +57,18 @@ func init() {
 	viper.SetDefault("network_server.api.bind", "0.0.0.0:8000")
 
 	viper.SetDefault("network_server.deduplication_delay", 200*time.Millisecond)
+	viper.SetDefault("network_server.adaptive_deduplication_min_delay", 50*time.Millisecond)
 	viper.SetDefault("network_server.get_downlink_data_delay", 100*time.Millisecond)
 	viper.SetDefault("network_server.device_session_ttl", time.Hour*24*31)
+	viper.SetDefault("network_server.device_session_persistence.flush_interval", time.Second)
+	viper.SetDefault("network_server.device_session_persistence.f_cnt_gap", 128)
+	viper.SetDefault("network_server.uplink_worker_pool.queue_size", 1000)
 
 	viper.SetDefault("network_server.gateway.stats.aggregation_intervals", []string{"minute", "hour", "day"})
 	viper.SetDefault("network_server.gateway.stats.create_gateway_on_stats", true)
 	viper.SetDefault("network_server.gateway.downlink_timeout", time.Second)
+	viper.SetDefault("network_server.gateway.packet_forwarder_config_command", "set_packet_forwarder_config")
+	viper.SetDefault("network_server.gateway.config_pending_timeout", time.Hour)
 	viper.SetDefault("network_server.gateway.backend.multi_downlink_feature", "hybrid")
 	viper.SetDefault("network_server.gateway.backend.mqtt.server", "tcp://localhost:1883")
 	viper.SetDefault("network_server.gateway.backend.mqtt.max_reconnect_interval", time.Minute)
@@ -69,6 +76,7 @@ func init() {
 	viper.SetDefault("join_server.default.server", "http://localhost:8003")
 
 	viper.SetDefault("network_server.network_settings.installation_margin", 10)
+	viper.SetDefault("network_server.network_settings.adr_script_timeout", 100*time.Millisecond)
 	viper.SetDefault("network_server.network_settings.rx1_delay", 1)
 	viper.SetDefault("network_server.network_settings.rx2_frequency", -1)
 	viper.SetDefault("network_server.network_settings.rx2_dr", -1)
@@ -80,6 +88,7 @@ func init() {
 	viper.SetDefault("network_server.gateway.backend.type", "mqtt")
 
 	viper.SetDefault("network_server.scheduler.scheduler_interval", 1*time.Second)
+	viper.SetDefault("network_server.scheduler.multicast_max_attempts", 3)
 	viper.SetDefault("network_server.scheduler.class_c.device_downlink_lock_duration", 2*time.Second)
 	viper.SetDefault("network_server.scheduler.class_c.multicast_gateway_delay", 2*time.Second)
 
@@ -93,6 +102,18 @@ func init() {
 	viper.SetDefault("network_server.gateway.backend.amqp.event_routing_key", "gateway.*.event.*")
 	viper.SetDefault("network_server.gateway.backend.amqp.command_routing_key_template", "gateway.{{ .GatewayID }}.command.{{ .CommandType }}")
 
+	viper.SetDefault("application_server.client.idle_timeout", 10*time.Minute)
+	viper.SetDefault("application_server.client.health_check_interval", 10*time.Second)
+	viper.SetDefault("application_server.client.circuit_breaker.failure_threshold", 5)
+	viper.SetDefault("application_server.client.circuit_breaker.open_duration", 30*time.Second)
+	viper.SetDefault("application_server.retry.max_attempts", 3)
+	viper.SetDefault("application_server.retry.interval", time.Second)
+	viper.SetDefault("application_server.retry.delivery_timeout", time.Second)
+	viper.SetDefault("application_server.retry.queue_size", 1000)
+	viper.SetDefault("application_server.retry.buffer_max_size", 10000)
+	viper.SetDefault("application_server.retry.buffer_ttl", 24*time.Hour)
+	viper.SetDefault("application_server.retry.replay_interval", 10*time.Second)
+
 	viper.SetDefault("join_server.resolve_domain_suffix", ".joineuis.lora-alliance.org")
 	viper.SetDefault("join_server.default.server", "http://localhost:8003")
 
@@ -108,10 +129,20 @@ func init() {
 	viper.SetDefault("metrics.redis.month_aggregation_ttl", time.Hour*24*730)
 	viper.SetDefault("monitoring.per_device_frame_log_max_history", 10)
 	viper.SetDefault("monitoring.per_gateway_frame_log_max_history", 10)
+	viper.SetDefault("monitoring.tracing.service_name", "chirpstack-network-server")
+	viper.SetDefault("monitoring.tracing.otlp_endpoint", "localhost:4317")
+	viper.SetDefault("monitoring.tracing.otlp_insecure", true)
+	viper.SetDefault("monitoring.tracing.sampling_ratio", 1.0)
+	viper.SetDefault("monitoring.audit.per_device_max_history", 100)
+	viper.SetDefault("monitoring.audit.webhook_timeout", 5*time.Second)
 
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(printDSCmd)
+	rootCmd.AddCommand(reEncryptDSKeysCmd)
+	rootCmd.AddCommand(exportDSCmd)
+	rootCmd.AddCommand(importDSCmd)
+	rootCmd.AddCommand(simulateADRCmd)
 }
 
 // Execute executes the root command.
@@ -126,14 +157,40 @@ func Execute(v string) {
 func initConfig() {
 	config.Version = version
 
+	for _, pair := range os.Environ() {
+		d := strings.SplitN(pair, "=", 2)
+		if strings.Contains(d[0], ".") {
+			log.Warning("Using dots in env variable is illegal and deprecated. Please use double underscore `__` for: ", d[0])
+			underscoreName := strings.ReplaceAll(d[0], ".", "__")
+			// Set only when the underscore version doesn't already exist.
+			if _, exists := os.LookupEnv(underscoreName); !exists {
+				os.Setenv(underscoreName, d[1])
+			}
+		}
+	}
+
+	viperBindEnvs(config.C)
+
+	conf, err := loadConfig()
+	if err != nil {
+		log.WithError(err).WithField("config", cfgFile).Fatal("load configuration error")
+	}
+	config.C = conf
+}
+
+// loadConfig reads the configuration file and returns the configuration.
+// This is used on startup and when reloading the configuration.
+func loadConfig() (config.Config, error) {
+	var conf config.Config
+
 	if cfgFile != "" {
 		b, err := ioutil.ReadFile(cfgFile)
 		if err != nil {
-			log.WithError(err).WithField("config", cfgFile).Fatal("error loading config file")
+			return conf, errors.Wrap(err, "read config file error")
 		}
 		viper.SetConfigType("toml")
 		if err := viper.ReadConfig(bytes.NewBuffer(b)); err != nil {
-			log.WithError(err).WithField("config", cfgFile).Fatal("error loading config file")
+			return conf, errors.Wrap(err, "read config error")
 		}
 	} else {
 		viper.SetConfigName("chirpstack-network-server")
@@ -145,57 +202,45 @@ func initConfig() {
 			case viper.ConfigFileNotFoundError:
 				log.Warning("No configuration file found, using defaults. See: https://www.chirpstack.io/network-server/install/config/")
 			default:
-				log.WithError(err).Fatal("read configuration file error")
+				return conf, errors.Wrap(err, "read configuration file error")
 			}
 		}
 	}
 
-	for _, pair := range os.Environ() {
-		d := strings.SplitN(pair, "=", 2)
-		if strings.Contains(d[0], ".") {
-			log.Warning("Using dots in env variable is illegal and deprecated. Please use double underscore `__` for: ", d[0])
-			underscoreName := strings.ReplaceAll(d[0], ".", "__")
-			// Set only when the underscore version doesn't already exist.
-			if _, exists := os.LookupEnv(underscoreName); !exists {
-				os.Setenv(underscoreName, d[1])
-			}
-		}
-	}
-
-	viperBindEnvs(config.C)
-
 	viperHooks := mapstructure.ComposeDecodeHookFunc(
 		viperDecodeJSONSlice,
 		mapstructure.StringToTimeDurationHookFunc(),
 		mapstructure.StringToSliceHookFunc(","),
 	)
 
-	if err := viper.Unmarshal(&config.C, viper.DecodeHook(viperHooks)); err != nil {
-		log.WithError(err).Fatal("unmarshal config error")
+	if err := viper.Unmarshal(&conf, viper.DecodeHook(viperHooks)); err != nil {
+		return conf, errors.Wrap(err, "unmarshal config error")
 	}
 
 	// decode netid
-	if err := config.C.NetworkServer.NetID.UnmarshalText([]byte(config.C.NetworkServer.NetIDString)); err != nil {
-		log.WithError(err).Fatal("decode net_id error")
+	if err := conf.NetworkServer.NetID.UnmarshalText([]byte(conf.NetworkServer.NetIDString)); err != nil {
+		return conf, errors.Wrap(err, "decode net_id error")
 	}
 
 	// decode roaming netids
-	for i := range config.C.Roaming.Servers {
-		if err := config.C.Roaming.Servers[i].NetID.UnmarshalText([]byte(config.C.Roaming.Servers[i].NetIDString)); err != nil {
-			log.WithError(err).Fatal("decode roaming net_id error")
+	for i := range conf.Roaming.Servers {
+		if err := conf.Roaming.Servers[i].NetID.UnmarshalText([]byte(conf.Roaming.Servers[i].NetIDString)); err != nil {
+			return conf, errors.Wrap(err, "decode roaming net_id error")
 		}
 	}
 
-	if config.C.Redis.URL != "" {
-		opt, err := redis.ParseURL(config.C.Redis.URL)
+	if conf.Redis.URL != "" {
+		opt, err := redis.ParseURL(conf.Redis.URL)
 		if err != nil {
-			log.WithError(err).Fatal("redis url error")
+			return conf, errors.Wrap(err, "redis url error")
 		}
 
-		config.C.Redis.Servers = []string{opt.Addr}
-		config.C.Redis.Database = opt.DB
-		config.C.Redis.Password = opt.Password
+		conf.Redis.Servers = []string{opt.Addr}
+		conf.Redis.Database = opt.DB
+		conf.Redis.Password = opt.Password
 	}
+
+	return conf, nil
 }
 
 func viperBindEnvs(iface interface{}, parts ...string) {
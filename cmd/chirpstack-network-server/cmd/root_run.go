@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -22,7 +23,9 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/adr"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/ns"
 	roamingapi "github.com/liuhw0/chirpstack-network-server/v3/internal/api/roaming"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver/retry"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/controller"
 	gwbackend "github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway/amqp"
@@ -35,9 +38,12 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/downlink"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/monitoring"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/reload"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/roaming"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/uplink"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/uplink/proprietary"
 )
 
 func run(cmd *cobra.Command, args []string) error {
@@ -64,20 +70,23 @@ func run(cmd *cobra.Command, args []string) error {
 		setRXParameters,
 		printStartMessage,
 		setupMonitoring,
-		enableUplinkChannels,
+		setupTracing,
 		setupStorage,
 		setGatewayBackend,
+		setupAudit,
 		setupApplicationServer,
 		setupADR,
 		setupJoinServer,
 		setupNetworkController,
 		setupUplink,
+		setupProprietaryPlugins,
 		setupDownlink,
 		setupNetworkServerAPI,
 		setupRoaming,
 		setupGateways,
 		startLoRaServer(server),
 		startQueueScheduler,
+		setupConfigReload,
 	}
 
 	for _, t := range tasks {
@@ -98,6 +107,13 @@ func run(cmd *cobra.Command, args []string) error {
 		if err := gateway.Stop(); err != nil {
 			log.Fatal(err)
 		}
+		proprietary.Close()
+		if err := storage.FlushDeviceSessionSnapshots(context.Background()); err != nil {
+			log.WithError(err).Error("flush device-session snapshots error")
+		}
+		if err := tracing.Shutdown(context.Background()); err != nil {
+			log.WithError(err).Error("shutdown tracing error")
+		}
 		exitChan <- struct{}{}
 	}()
 	select {
@@ -156,6 +172,20 @@ func setupMonitoring() error {
 	return nil
 }
 
+func setupTracing() error {
+	if err := tracing.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup tracing error")
+	}
+	return nil
+}
+
+func setupAudit() error {
+	if err := audit.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup audit error")
+	}
+	return nil
+}
+
 func setLogLevel() error {
 	log.SetLevel(log.Level(uint8(config.C.General.LogLevel)))
 	return nil
@@ -171,28 +201,6 @@ func printStartMessage() error {
 	return nil
 }
 
-func enableUplinkChannels() error {
-	if len(config.C.NetworkServer.NetworkSettings.EnabledUplinkChannels) == 0 {
-		return nil
-	}
-
-	log.Info("disabling all channels")
-	for _, c := range band.Band().GetEnabledUplinkChannelIndices() {
-		if err := band.Band().DisableUplinkChannelIndex(c); err != nil {
-			return errors.Wrap(err, "disable uplink channel error")
-		}
-	}
-
-	log.WithField("channels", config.C.NetworkServer.NetworkSettings.EnabledUplinkChannels).Info("enabling channels")
-	for _, c := range config.C.NetworkServer.NetworkSettings.EnabledUplinkChannels {
-		if err := band.Band().EnableUplinkChannelIndex(c); err != nil {
-			return errors.Wrap(err, "enable uplink channel error")
-		}
-	}
-
-	return nil
-}
-
 func setupStorage() error {
 	if err := storage.Setup(config.C); err != nil {
 		return errors.Wrap(err, "setup storage error")
@@ -235,9 +243,12 @@ func setGatewayBackend() error {
 }
 
 func setupApplicationServer() error {
-	if err := applicationserver.Setup(); err != nil {
+	if err := applicationserver.Setup(config.C); err != nil {
 		return errors.Wrap(err, "application-server setup error")
 	}
+	if err := retry.Setup(config.C); err != nil {
+		return errors.Wrap(err, "application-server retry setup error")
+	}
 	return nil
 }
 
@@ -260,6 +271,7 @@ func setupNetworkController() error {
 		}).Info("connecting to network-controller")
 		ncDialOptions := []grpc.DialOption{
 			grpc.WithBalancerName(roundrobin.Name),
+			grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
 		}
 		if config.C.NetworkController.TLSCert != "" && config.C.NetworkController.TLSKey != "" {
 			ncDialOptions = append(ncDialOptions, grpc.WithTransportCredentials(
@@ -286,6 +298,13 @@ func setupUplink() error {
 	return nil
 }
 
+func setupProprietaryPlugins() error {
+	if err := proprietary.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup proprietary plugins error")
+	}
+	return nil
+}
+
 func setupDownlink() error {
 	if err := downlink.Setup(config.C); err != nil {
 		return errors.Wrap(err, "setup downlink error")
@@ -337,6 +356,26 @@ func startQueueScheduler() error {
 	return nil
 }
 
+// setupConfigReload sets up the configuration reload, which is triggered
+// by the SIGHUP signal or the configuration reload endpoint.
+func setupConfigReload() error {
+	reload.Setup(loadConfig)
+
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+
+	go func() {
+		for s := range hupChan {
+			log.WithField("signal", s).Info("signal received, reloading configuration")
+			if _, err := reload.Reload(); err != nil {
+				log.WithError(err).Error("reload configuration error")
+			}
+		}
+	}()
+
+	return nil
+}
+
 func mustGetTransportCredentials(tlsCert, tlsKey, caCert string, verifyClientCert bool) credentials.TransportCredentials {
 	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
 	if err != nil {
@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	adrr "github.com/liuhw0/chirpstack-network-server/v3/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+var simulateADROpts struct {
+	devEUI             string
+	input              string
+	algorithm          string
+	historySize        int
+	repeat             int
+	dr                 int
+	txPowerIndex       int
+	nbTrans            int
+	minDR              int
+	maxDR              int
+	maxTxPowerIndex    int
+	installationMargin float64
+
+	synthesize   int
+	snrMean      float64
+	snrStdDev    float64
+	rssiMean     float64
+	rssiStdDev   float64
+	gatewayCount int
+	interval     time.Duration
+	seed         int64
+}
+
+var simulateADRCmd = &cobra.Command{
+	Use:   "simulate-adr",
+	Short: "Simulate the ADR decisions for a device or uplink history",
+	Long: `Run an uplink history through one of the registered ADR algorithms
+(including the configured ADR plugins, remote handlers and scripts) and print
+the DR / TxPower / NbTrans decisions step by step.
+
+The uplinks are taken from the device-session of the given DevEUI, from an
+input file or they are synthesised from the given SNR distribution. The input
+file can either contain the output of the print-ds command or a JSON array of
+uplink history items. After each uplink, the device is assumed to apply the
+decision of the ADR algorithm. The SNR of recorded uplinks is corrected for the
+difference between the recorded and the simulated tx-power.
+
+When a DevEUI is given, the device-session provides the initial DR, tx-power
+index and NbTrans and the device- and service-profile provide the ADR
+algorithm, uplink history size and min / max DR, unless overridden by flags.`,
+	Example: `chirpstack-network-server simulate-adr --dev-eui 0102030405060708 --algorithm my_plugin --repeat 5
+chirpstack-network-server print-ds 0102030405060708 > ds.json && chirpstack-network-server simulate-adr --input ds.json
+chirpstack-network-server simulate-adr --synthesize 100 --snr-mean 5 --snr-stddev 3 --max-dr 5 --interval 1h`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setupBand(); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := adr.Setup(config.C); err != nil {
+			log.WithError(err).Fatal("setup adr error")
+		}
+		defer adr.Close()
+
+		req := adrr.HandleRequest{
+			Region:             band.Band().Name(),
+			ADR:                true,
+			NbTrans:            1,
+			InstallationMargin: float32(config.C.NetworkServer.NetworkSettings.InstallationMargin),
+			MaxDR:              simulateADROpts.maxDR,
+		}
+		algorithm := "default"
+		historySize := 0
+
+		var uplinks []adrr.UplinkMetaData
+		var dp *storage.DeviceProfile
+
+		if simulateADROpts.devEUI != "" {
+			var err error
+			uplinks, dp, err = simulateADRLoadDevice(&req)
+			if err != nil {
+				log.WithError(err).Fatal("load device error")
+			}
+		}
+
+		if simulateADROpts.input != "" {
+			var err error
+			uplinks, err = simulateADRReadInput(simulateADROpts.input, &req)
+			if err != nil {
+				log.WithError(err).Fatal("read input error")
+			}
+		}
+
+		if simulateADROpts.synthesize > 0 {
+			uplinks = adr.SynthesizeUplinks(adr.SyntheticUplinkConfig{
+				Count:        simulateADROpts.synthesize,
+				SNRMean:      simulateADROpts.snrMean,
+				SNRStdDev:    simulateADROpts.snrStdDev,
+				RSSIMean:     simulateADROpts.rssiMean,
+				RSSIStdDev:   simulateADROpts.rssiStdDev,
+				GatewayCount: simulateADROpts.gatewayCount,
+				Interval:     simulateADROpts.interval,
+			}, rand.New(rand.NewSource(simulateADROpts.seed)))
+		}
+
+		if len(uplinks) == 0 {
+			log.Fatal("no uplinks to simulate, use --dev-eui, --input or --synthesize")
+		}
+
+		if dp != nil {
+			algorithm = dp.ADRAlgorithmID
+			historySize = dp.UplinkHistorySize
+		}
+
+		flags := cmd.Flags()
+		if flags.Changed("algorithm") {
+			algorithm = simulateADROpts.algorithm
+		}
+		if flags.Changed("history-size") {
+			historySize = simulateADROpts.historySize
+		}
+		if flags.Changed("dr") {
+			req.DR = simulateADROpts.dr
+		}
+		if flags.Changed("tx-power-index") {
+			req.TxPowerIndex = simulateADROpts.txPowerIndex
+		}
+		if flags.Changed("nb-trans") {
+			req.NbTrans = simulateADROpts.nbTrans
+		}
+		if flags.Changed("min-dr") {
+			req.MinDR = simulateADROpts.minDR
+		}
+		if flags.Changed("max-dr") {
+			req.MaxDR = simulateADROpts.maxDR
+		}
+		if flags.Changed("installation-margin") {
+			req.InstallationMargin = float32(simulateADROpts.installationMargin)
+		}
+		if flags.Changed("max-tx-power-index") {
+			req.MaxTxPowerIndex = simulateADROpts.maxTxPowerIndex
+		} else if req.MaxTxPowerIndex == 0 {
+			req.MaxTxPowerIndex = simulateADRMaxTxPowerIndex()
+		}
+
+		if _, ok := adr.GetADRAlgorithms()[algorithm]; !ok {
+			log.WithField("algorithm", algorithm).Fatal("unknown adr algorithm")
+		}
+
+		var replay []adrr.UplinkMetaData
+		for i := 0; i < simulateADROpts.repeat; i++ {
+			replay = append(replay, uplinks...)
+		}
+
+		handler := adr.GetHandler(algorithm)
+		if dp != nil && algorithm == dp.ADRAlgorithmID {
+			handler = adr.GetHandlerForDeviceProfile(*dp)
+		}
+
+		steps, err := adr.Simulate(handler, adr.SimulationConfig{
+			Request:       req,
+			HistorySize:   historySize,
+			RequiredSNR:   simulateADRRequiredSNR,
+			TXPowerOffset: simulateADRTXPowerOffset,
+		}, replay)
+		if err != nil {
+			log.WithError(err).Fatal("simulate adr error")
+		}
+
+		printADRSimulation(algorithm, steps)
+	},
+}
+
+func init() {
+	simulateADRCmd.Flags().StringVar(&simulateADROpts.devEUI, "dev-eui", "", "load the uplink history and device state from the device-session of the given DevEUI")
+	simulateADRCmd.Flags().StringVarP(&simulateADROpts.input, "input", "i", "", "read the uplink history from the given file (print-ds output or JSON array of uplink history items)")
+	simulateADRCmd.Flags().StringVar(&simulateADROpts.algorithm, "algorithm", "default", "ID of the ADR algorithm")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.historySize, "history-size", storage.UplinkHistorySize, "uplink history size")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.repeat, "repeat", 1, "number of times to replay the uplinks")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.dr, "dr", 0, "initial data-rate")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.txPowerIndex, "tx-power-index", 0, "initial tx-power index")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.nbTrans, "nb-trans", 1, "initial number of transmissions")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.minDR, "min-dr", 0, "min. allowed data-rate")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.maxDR, "max-dr", 5, "max. allowed data-rate")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.maxTxPowerIndex, "max-tx-power-index", 0, "max. allowed tx-power index (default from band)")
+	simulateADRCmd.Flags().Float64Var(&simulateADROpts.installationMargin, "installation-margin", 0, "installation margin (dB) (default from configuration)")
+
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.synthesize, "synthesize", 0, "synthesise the given number of uplinks")
+	simulateADRCmd.Flags().Float64Var(&simulateADROpts.snrMean, "snr-mean", 0, "mean SNR of the synthesised uplinks (at max. tx-power)")
+	simulateADRCmd.Flags().Float64Var(&simulateADROpts.snrStdDev, "snr-stddev", 2, "SNR standard deviation of the synthesised uplinks")
+	simulateADRCmd.Flags().Float64Var(&simulateADROpts.rssiMean, "rssi-mean", -100, "mean RSSI of the synthesised uplinks (at max. tx-power)")
+	simulateADRCmd.Flags().Float64Var(&simulateADROpts.rssiStdDev, "rssi-stddev", 5, "RSSI standard deviation of the synthesised uplinks")
+	simulateADRCmd.Flags().IntVar(&simulateADROpts.gatewayCount, "gateways", 1, "number of receiving gateways per synthesised uplink")
+	simulateADRCmd.Flags().DurationVar(&simulateADROpts.interval, "interval", 10*time.Minute, "interval between the synthesised uplinks")
+	simulateADRCmd.Flags().Int64Var(&simulateADROpts.seed, "seed", time.Now().UnixNano(), "random seed for the synthesised uplinks")
+}
+
+// simulateADRLoadDevice loads the device-session and the device- and
+// service-profile of the device. It returns the recorded uplink history and
+// the device-profile (nil when it could not be loaded).
+func simulateADRLoadDevice(req *adrr.HandleRequest) ([]adrr.UplinkMetaData, *storage.DeviceProfile, error) {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(simulateADROpts.devEUI)); err != nil {
+		return nil, nil, errors.Wrap(err, "decode DevEUI error")
+	}
+
+	if err := storage.Setup(config.C); err != nil {
+		return nil, nil, errors.Wrap(err, "setup storage error")
+	}
+
+	ctx := context.Background()
+
+	ds, err := storage.GetDeviceSession(ctx, devEUI)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get device-session error")
+	}
+	simulateADRSetDeviceSession(req, ds)
+
+	// The profiles only provide defaults, the flags can be used in case
+	// these are not available.
+	var dpPtr *storage.DeviceProfile
+	dp, err := storage.GetDeviceProfile(ctx, storage.DB(), ds.DeviceProfileID)
+	if err != nil {
+		log.WithError(err).Warning("get device-profile error, using algorithm and history-size flags")
+	} else {
+		req.RegParamsRevision = dp.RegParamsRevision
+		dpPtr = &dp
+	}
+
+	sp, err := storage.GetServiceProfile(ctx, storage.DB(), ds.ServiceProfileID)
+	if err != nil {
+		log.WithError(err).Warning("get service-profile error, using min / max DR flags")
+	} else {
+		req.MinDR = sp.DRMin
+		req.MaxDR = sp.DRMax
+	}
+
+	return adr.UplinkHistoryToMetaData(ds.UplinkHistory), dpPtr, nil
+}
+
+// simulateADRReadInput reads the uplink history from the given file. In
+// case the file contains a device-session, the device state is set too.
+func simulateADRReadInput(path string, req *adrr.HandleRequest) ([]adrr.UplinkMetaData, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read file error")
+	}
+	b = bytes.TrimSpace(b)
+
+	if bytes.HasPrefix(b, []byte("[")) {
+		var history []storage.UplinkHistory
+		if err := json.Unmarshal(b, &history); err != nil {
+			return nil, errors.Wrap(err, "unmarshal uplink history error")
+		}
+		return adr.UplinkHistoryToMetaData(history), nil
+	}
+
+	var ds storage.DeviceSession
+	if err := json.Unmarshal(b, &ds); err != nil {
+		return nil, errors.Wrap(err, "unmarshal device-session error")
+	}
+	simulateADRSetDeviceSession(req, ds)
+
+	return adr.UplinkHistoryToMetaData(ds.UplinkHistory), nil
+}
+
+func simulateADRSetDeviceSession(req *adrr.HandleRequest, ds storage.DeviceSession) {
+	req.DevEUI = ds.DevEUI
+	req.MACVersion = ds.MACVersion
+	req.DR = ds.DR
+	req.TxPowerIndex = ds.TXPowerIndex
+	req.NbTrans = int(ds.NbTrans)
+	req.MaxTxPowerIndex = ds.MaxSupportedTXPowerIndex
+}
+
+// simulateADRMaxTxPowerIndex returns the max. tx-power index of the band.
+func simulateADRMaxTxPowerIndex() int {
+	var maxTxPowerIndex int
+	for i := 0; ; i++ {
+		offset, err := band.Band().GetTXPowerOffset(i)
+		if err != nil {
+			break
+		}
+		if offset != 0 {
+			maxTxPowerIndex = i
+		}
+	}
+	return maxTxPowerIndex
+}
+
+func simulateADRRequiredSNR(dr int) (float32, error) {
+	d, err := band.Band().GetDataRate(dr)
+	if err != nil {
+		return 0, errors.Wrap(err, "get data-rate error")
+	}
+	return float32(config.SpreadFactorToRequiredSNRTable[d.SpreadFactor]), nil
+}
+
+func simulateADRTXPowerOffset(txPowerIndex int) (float32, error) {
+	offset, err := band.Band().GetTXPowerOffset(txPowerIndex)
+	if err != nil {
+		return 0, errors.Wrap(err, "get tx-power offset error")
+	}
+	return float32(offset), nil
+}
+
+func printADRSimulation(algorithm string, steps []adr.SimulationStep) {
+	fmt.Printf("ADR algorithm: %s (%s)\n\n", algorithm, adr.GetADRAlgorithms()[algorithm])
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "STEP\tFCNT\tMAX SNR\tMAX RSSI\tGATEWAYS\tHISTORY\tDR\tTX POWER\tNB TRANS\t\t")
+
+	var changes int
+	for i, s := range steps {
+		change := ""
+		if s.Changed() {
+			changes++
+			change = fmt.Sprintf("-> DR %d, TX POWER %d, NB TRANS %d", s.Response.DR, s.Response.TxPowerIndex, s.Response.NbTrans)
+		}
+
+		fmt.Fprintf(w, "%d\t%d\t%.1f\t%d\t%d\t%d\t%d\t%d\t%d\t\t%s\n",
+			i+1,
+			s.Uplink.FCnt,
+			s.Uplink.MaxSNR,
+			s.Uplink.MaxRSSI,
+			s.Uplink.GatewayCount,
+			s.HistoryCount,
+			s.Request.DR,
+			s.Request.TxPowerIndex,
+			s.Request.NbTrans,
+			change,
+		)
+	}
+	w.Flush()
+
+	if len(steps) != 0 {
+		last := steps[len(steps)-1].Response
+		fmt.Printf("\n%d uplinks, %d changes, final DR %d, TX POWER %d, NB TRANS %d\n", len(steps), changes, last.DR, last.TxPowerIndex, last.NbTrans)
+	}
+}
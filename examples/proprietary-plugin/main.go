@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/hashicorp/go-plugin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/proprietary"
+)
+
+// Type Handler is the proprietary uplink handler.
+type Handler struct{}
+
+// ID must return the plugin identifier.
+func (h *Handler) ID() (string, error) {
+	return "example_plugin", nil
+}
+
+// Name must return a human-readable name.
+func (h *Handler) Name() (string, error) {
+	return "Example proprietary plugin", nil
+}
+
+// Handle handles the proprietary uplink and returns what must happen with it.
+func (h *Handler) Handle(req proprietary.HandleRequest) (proprietary.HandleResponse, error) {
+	// drop empty frames, pass all other frames to the next plugin
+	if len(req.MACPayload) == 0 {
+		return proprietary.HandleResponse{
+			Action: proprietary.ActionDrop,
+		}, nil
+	}
+
+	return proprietary.HandleResponse{
+		Action: proprietary.ActionPass,
+	}, nil
+}
+
+func main() {
+	handler := &Handler{}
+
+	pluginMap := map[string]plugin.Plugin{
+		"handler": &proprietary.HandlerPlugin{Impl: handler},
+	}
+
+	log.Info("Starting proprietary plugin")
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: proprietary.HandshakeConfig,
+		Plugins:         pluginMap,
+	})
+}
@@ -2,21 +2,33 @@ package adr
 
 import (
 	"fmt"
+	"io"
 	"os/exec"
+	"sync"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/liuhw0/chirpstack-network-server/v3/adr"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tls"
 )
 
 var (
+	mu           sync.RWMutex
 	handlers     map[string]adr.Handler
 	handlerNames map[string]string
+	closers      []io.Closer
 )
 
 func init() {
+	handlers, handlerNames = getBuiltinHandlers()
+}
+
+// getBuiltinHandlers returns the built-in ADR handlers and their names (by ID).
+func getBuiltinHandlers() (map[string]adr.Handler, map[string]string) {
 	defH := &DefaultHandler{}
 	defID, _ := defH.ID()
 	defName, _ := defH.Name()
@@ -29,70 +41,181 @@ func init() {
 	loraLRFHSSID, _ := loRaLRFHSSH.ID()
 	loraLRFHSSName, _ := loRaLRFHSSH.Name()
 
-	handlers = map[string]adr.Handler{
+	h := map[string]adr.Handler{
 		defID:        defH,
 		loraLRFHSSID: loRaLRFHSSH,
 		lrFHSSID:     lrFHSSH,
 	}
 
-	handlerNames = map[string]string{
-		defID:        defName,
-		loraLRFHSSID: loraLRFHSSName,
-		lrFHSSID:     lrFHSSName,
+	names := map[string]string{
+		defID:                 defName,
+		loraLRFHSSID:          loraLRFHSSName,
+		lrFHSSID:              lrFHSSName,
+		DeviceProfileScriptID: "Device-profile script",
 	}
+
+	return h, names
 }
 
 // Setup configures the ADR package.
+// The configured ADR plugins and remote ADR handlers are only activated when
+// all of them have been loaded successfully, in which case previously loaded
+// plugins and handlers are stopped. This makes it possible to call Setup
+// again when the configuration changes.
 func Setup(conf config.Config) error {
+	newHandlers, newHandlerNames := getBuiltinHandlers()
+	var newClosers []io.Closer
+
+	register := func(h adr.Handler) {
+		id, _ := h.ID()
+		name, _ := h.Name()
+		newHandlers[id] = h
+		newHandlerNames[id] = name
+	}
+
 	for _, adrPlugin := range conf.NetworkServer.NetworkSettings.ADRPlugins {
-		client := plugin.NewClient(&plugin.ClientConfig{
-			HandshakeConfig: adr.HandshakeConfig,
-			Plugins: map[string]plugin.Plugin{
-				"handler": &adr.HandlerPlugin{},
-			},
-			Cmd: exec.Command(adrPlugin),
-		})
-
-		// connect via RPC
-		rpcClient, err := client.Client()
+		p, err := newPluginHandler(adrPlugin)
 		if err != nil {
-			return errors.Wrap(err, "plugin rpc client error")
+			closeAll(newClosers)
+			return err
 		}
+		newClosers = append(newClosers, p)
+		register(p)
+	}
 
-		// request the plugin
-		raw, err := rpcClient.Dispense("handler")
+	if dir := conf.NetworkServer.NetworkSettings.ADRScriptsDir; dir != "" {
+		scripts, err := loadScriptHandlers(dir)
 		if err != nil {
-			return errors.Wrap(err, "request handler plugin error")
+			closeAll(newClosers)
+			return errors.Wrap(err, "load adr scripts error")
 		}
-
-		// cast to Handler.
-		handler, ok := raw.(adr.Handler)
-		if !ok {
-			return fmt.Errorf("expected adr.Handler, got: %T", raw)
+		for _, h := range scripts {
+			if _, ok := newHandlers[h.id]; ok {
+				closeAll(newClosers)
+				return fmt.Errorf("adr script id already exists: %s", h.id)
+			}
+			register(h)
 		}
+	}
 
-		// get ID.
-		id, err := handler.ID()
+	// Remote ADR handlers that are unavailable are registered once they
+	// become available, so that an unavailable service does not fail the
+	// setup.
+	var pendingRemoteHandlers []*remoteHandler
+	for _, r := range conf.NetworkServer.NetworkSettings.ADRRemoteHandlers {
+		h, err := newRemoteHandler(r.Server, r.CACert, r.TLSCert, r.TLSKey, r.Timeout)
 		if err != nil {
-			return errors.Wrap(err, "get plugin id error")
+			closeAll(newClosers)
+			return errors.Wrapf(err, "setup remote adr handler error (server: %s)", r.Server)
 		}
+		newClosers = append(newClosers, h)
 
-		// get Name.
-		name, err := handler.Name()
-		if err != nil {
-			return errors.Wrap(err, "get plugin name error")
+		if err := h.getInfo(); err != nil {
+			log.WithError(err).WithField("server", r.Server).Warning("adr: remote adr service is unavailable, using default algorithm until available")
+			pendingRemoteHandlers = append(pendingRemoteHandlers, h)
+			continue
+		}
+		register(h)
+	}
+
+	mu.Lock()
+	oldClosers := closers
+	scriptTimeout = defaultScriptTimeout
+	if conf.NetworkServer.NetworkSettings.ADRScriptTimeout != 0 {
+		scriptTimeout = conf.NetworkServer.NetworkSettings.ADRScriptTimeout
+	}
+	handlers = newHandlers
+	handlerNames = newHandlerNames
+	closers = newClosers
+	mu.Unlock()
+
+	closeAll(oldClosers)
+	flushDeviceProfileScripts()
+
+	for _, h := range pendingRemoteHandlers {
+		go registerRemoteHandler(h)
+	}
+
+	return nil
+}
+
+// Validate validates the ADR configuration, without loading the ADR plugins
+// and remote ADR handlers.
+func Validate(conf config.Config) error {
+	for _, adrPlugin := range conf.NetworkServer.NetworkSettings.ADRPlugins {
+		if _, err := exec.LookPath(adrPlugin); err != nil {
+			return errors.Wrapf(err, "adr plugin error (plugin: %s)", adrPlugin)
+		}
+	}
+
+	if dir := conf.NetworkServer.NetworkSettings.ADRScriptsDir; dir != "" {
+		if _, err := loadScriptHandlers(dir); err != nil {
+			return errors.Wrap(err, "load adr scripts error")
 		}
+	}
 
-		handlers[id] = handler
-		handlerNames[id] = name
+	for _, r := range conf.NetworkServer.NetworkSettings.ADRRemoteHandlers {
+		if r.TLSCert != "" && r.TLSKey != "" {
+			if _, err := tls.GetTransportCredentials(r.CACert, r.TLSCert, r.TLSKey, false); err != nil {
+				return errors.Wrapf(err, "get transport credentials error (server: %s)", r.Server)
+			}
+		}
 	}
 
 	return nil
 }
 
+// Close stops the loaded ADR plugins and closes the connections to the
+// remote ADR handlers.
+func Close() {
+	mu.Lock()
+	oldClosers := closers
+	closers = nil
+	mu.Unlock()
+
+	closeAll(oldClosers)
+}
+
+func loadPlugin(rpcClient plugin.ClientProtocol) (string, string, adr.Handler, error) {
+	// request the plugin
+	raw, err := rpcClient.Dispense("handler")
+	if err != nil {
+		return "", "", nil, errors.Wrap(err, "request handler plugin error")
+	}
+
+	// cast to Handler.
+	handler, ok := raw.(adr.Handler)
+	if !ok {
+		return "", "", nil, fmt.Errorf("expected adr.Handler, got: %T", raw)
+	}
+
+	// get ID.
+	id, err := handler.ID()
+	if err != nil {
+		return "", "", nil, errors.Wrap(err, "get plugin id error")
+	}
+
+	// get Name.
+	name, err := handler.Name()
+	if err != nil {
+		return "", "", nil, errors.Wrap(err, "get plugin name error")
+	}
+
+	return id, name, handler, nil
+}
+
+func closeAll(closers []io.Closer) {
+	for _, c := range closers {
+		c.Close()
+	}
+}
+
 // GetHandler returns the ADR handler by its ID, failing that it returns the
 // default ADR handler.
 func GetHandler(id string) adr.Handler {
+	mu.RLock()
+	defer mu.RUnlock()
+
 	h, ok := handlers[id]
 	if !ok {
 		return &DefaultHandler{}
@@ -101,7 +224,60 @@ func GetHandler(id string) adr.Handler {
 	return h
 }
 
+// GetHandlerForDeviceProfile returns the ADR handler for the given
+// device-profile. In case the device-profile uses the device-profile script
+// algorithm, this returns the handler for its script. Failing that, it
+// returns the default ADR handler.
+func GetHandlerForDeviceProfile(dp storage.DeviceProfile) adr.Handler {
+	if dp.ADRAlgorithmID != DeviceProfileScriptID {
+		return GetHandler(dp.ADRAlgorithmID)
+	}
+
+	h, err := getDeviceProfileScriptHandler(dp.ID, dp.ADRScript)
+	if err != nil {
+		log.WithError(err).WithField("device_profile_id", dp.ID).Error("adr: device-profile script error, using default algorithm")
+		return &DefaultHandler{}
+	}
+
+	return h
+}
+
+// UplinkHistoryToMetaData converts the device-session uplink history into
+// the uplink meta-data as passed to the ADR handlers.
+func UplinkHistoryToMetaData(history []storage.UplinkHistory) []adr.UplinkMetaData {
+	var out []adr.UplinkMetaData
+
+	for _, uh := range history {
+		md := adr.UplinkMetaData{
+			FCnt:         uh.FCnt,
+			MaxSNR:       float32(uh.MaxSNR),
+			MaxRSSI:      uh.MaxRSSI,
+			TXPowerIndex: uh.TXPowerIndex,
+			GatewayCount: uh.GatewayCount,
+			DR:           uh.DR,
+			Frequency:    uh.Frequency,
+			Channel:      uh.Channel,
+			Time:         uh.Time,
+		}
+
+		for _, gw := range uh.Gateways {
+			md.Gateways = append(md.Gateways, adr.UplinkGatewayMetaData{
+				GatewayID: gw.GatewayID,
+				SNR:       float32(gw.SNR),
+				RSSI:      gw.RSSI,
+			})
+		}
+
+		out = append(out, md)
+	}
+
+	return out
+}
+
 // GetADRAlgorithms returns the available ADR algorithms.
 func GetADRAlgorithms() map[string]string {
+	mu.RLock()
+	defer mu.RUnlock()
+
 	return handlerNames
 }
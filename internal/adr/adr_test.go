@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 )
 
 func TestADR(t *testing.T) {
@@ -15,3 +17,18 @@ func TestADR(t *testing.T) {
 		assert.Equal("default", id)
 	})
 }
+
+func TestSetup(t *testing.T) {
+	assert := require.New(t)
+
+	var conf config.Config
+	conf.NetworkServer.NetworkSettings.ADRPlugins = []string{"/does/not/exist"}
+
+	// on error, the current handlers must remain untouched
+	assert.Error(Setup(conf))
+	assert.Len(GetADRAlgorithms(), 4)
+
+	conf.NetworkServer.NetworkSettings.ADRPlugins = nil
+	assert.NoError(Setup(conf))
+	assert.Len(GetADRAlgorithms(), 4)
+}
@@ -72,7 +72,7 @@ func (h *DefaultHandler) Handle(req adr.HandleRequest) (adr.HandleResponse, erro
 	// In case of negative steps the ADR algorithm will increase the TxPower
 	// if possible. To avoid up / down / up / down TxPower changes, wait until
 	// we have at least the required number of uplink history elements.
-	if nStep < 0 && h.getHistoryCount(req) != h.requiredHistoryCount() {
+	if nStep < 0 && h.getHistoryCount(req) < h.requiredHistoryCount() {
 		return resp, nil
 	}
 
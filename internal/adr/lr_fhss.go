@@ -89,7 +89,7 @@ func (h *LRFHSSHandler) Handle(req adr.HandleRequest) (adr.HandleResponse, error
 	// Note: that for RSSI (median) < -130, coding-rate 1/3 is recommended.
 	// As the median is taken from the uplink history, make sure that we
 	// take the median from a full history table.
-	if medRSSI >= -130 && len(req.UplinkHistory) == 20 {
+	if medRSSI >= -130 && len(req.UplinkHistory) >= 20 {
 		for k, v := range lrFHSSDataRates {
 			if v.CodingRate == "4/6" {
 				drs = append(drs, k)
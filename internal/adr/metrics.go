@@ -0,0 +1,26 @@
+package adr
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	prc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "adr_plugin_restart_counter",
+		Help: "The number of ADR plugin restarts (per plugin and result).",
+	}, []string{"plugin", "result"})
+
+	fc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "adr_fallback_counter",
+		Help: "The number of ADR requests handled by the default algorithm because the configured algorithm failed (per algorithm and reason).",
+	}, []string{"algorithm", "reason"})
+)
+
+func pluginRestartCounter(plugin, result string) prometheus.Counter {
+	return prc.With(prometheus.Labels{"plugin": plugin, "result": result})
+}
+
+func fallbackCounter(algorithm, reason string) prometheus.Counter {
+	return fc.With(prometheus.Labels{"algorithm": algorithm, "reason": reason})
+}
@@ -0,0 +1,108 @@
+package adr
+
+import (
+	"github.com/hashicorp/go-plugin"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/supervisor"
+)
+
+// pluginHandler implements a supervised ADR plugin. When the plugin is not
+// available (e.g. while it is being restarted after a crash), does not
+// respond in time or crashed, the request is handled by the default ADR
+// handler.
+type pluginHandler struct {
+	path   string
+	id     string
+	name   string
+	plugin *supervisor.Plugin
+}
+
+// newPluginHandler starts the given plugin.
+func newPluginHandler(path string) (*pluginHandler, error) {
+	p := pluginHandler{
+		path: path,
+	}
+
+	// the name is only read on start, as the restarted plugin must return
+	// the same id
+	var name string
+
+	var err error
+	p.plugin, err = supervisor.Start(supervisor.Config{
+		Path:            path,
+		HandshakeConfig: adr.HandshakeConfig,
+		Plugins: map[string]plugin.Plugin{
+			"handler": &adr.HandlerPlugin{},
+		},
+		Load: func(rpcClient plugin.ClientProtocol) (interface{}, string, error) {
+			id, n, handler, err := loadPlugin(rpcClient)
+			if err != nil {
+				return nil, "", err
+			}
+			name = n
+			return handler, id, nil
+		},
+		OnRestart: func(err error) {
+			if err != nil {
+				pluginRestartCounter(p.id, "error").Inc()
+			} else {
+				pluginRestartCounter(p.id, "ok").Inc()
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.id = p.plugin.ID()
+	p.name = name
+
+	return &p, nil
+}
+
+// ID returns the ID.
+func (p *pluginHandler) ID() (string, error) {
+	return p.id, nil
+}
+
+// Name returns the name.
+func (p *pluginHandler) Name() (string, error) {
+	return p.name, nil
+}
+
+// Handle handles the ADR request.
+func (p *pluginHandler) Handle(req adr.HandleRequest) (adr.HandleResponse, error) {
+	var resp adr.HandleResponse
+	err := p.plugin.Call(func(raw interface{}) error {
+		var err error
+		resp, err = raw.(adr.Handler).Handle(req)
+		return err
+	})
+
+	var reason string
+	switch errors.Cause(err) {
+	case supervisor.ErrUnavailable:
+		reason = "unavailable"
+	case supervisor.ErrTimeout:
+		reason = "timeout"
+	case supervisor.ErrCrashed:
+		reason = "crashed"
+	default:
+		return resp, err
+	}
+
+	log.WithError(err).WithFields(log.Fields{
+		"plugin":  p.path,
+		"dev_eui": req.DevEUI,
+	}).Warning("adr: plugin failed, using default algorithm")
+	fallbackCounter(p.id, reason).Inc()
+
+	return (&DefaultHandler{}).Handle(req)
+}
+
+// Close stops the plugin.
+func (p *pluginHandler) Close() error {
+	return p.plugin.Close()
+}
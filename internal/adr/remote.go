@@ -0,0 +1,226 @@
+package adr
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/adr/adrpb"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tls"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
+)
+
+// defaultRemoteTimeout defines the request timeout of a remote ADR handler
+// when not configured.
+const defaultRemoteTimeout = time.Second
+
+// remoteRegisterInterval defines the interval in which the info of a remote
+// ADR handler that was unavailable on setup is requested again.
+var remoteRegisterInterval = 10 * time.Second
+
+// remoteHandler implements an ADR handler using a remote gRPC service.
+// When the service does not respond within the configured timeout or is
+// unavailable, the request is handled by the default ADR handler.
+type remoteHandler struct {
+	server  string
+	id      string
+	name    string
+	timeout time.Duration
+	conn    *grpc.ClientConn
+	client  adrpb.ADRServiceClient
+	done    chan struct{}
+}
+
+// newRemoteHandler returns a new handler for the given ADR service. The
+// connection is established in the background, the ID and name must be
+// retrieved using getInfo.
+func newRemoteHandler(server, caCert, tlsCert, tlsKey string, timeout time.Duration) (*remoteHandler, error) {
+	if timeout == 0 {
+		timeout = defaultRemoteTimeout
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
+	}
+	if tlsCert != "" && tlsKey != "" {
+		creds, err := tls.GetTransportCredentials(caCert, tlsCert, tlsKey, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "get transport credentials error")
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(server, dialOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial adr service error")
+	}
+
+	return &remoteHandler{
+		server:  server,
+		timeout: timeout,
+		conn:    conn,
+		client:  adrpb.NewADRServiceClient(conn),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// getInfo retrieves the ID and name of the ADR service.
+func (h *remoteHandler) getInfo() error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	info, err := h.client.GetInfo(ctx, &empty.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "get adr service info error")
+	}
+
+	h.id = info.Id
+	h.name = info.Name
+
+	return nil
+}
+
+// registerRemoteHandler retries retrieving the info of the given remote ADR
+// handler until it succeeds and then registers the handler. Until then, the
+// requests for its algorithm are handled by the default ADR handler.
+func registerRemoteHandler(h *remoteHandler) {
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-time.After(remoteRegisterInterval):
+		}
+
+		if err := h.getInfo(); err != nil {
+			log.WithError(err).WithField("server", h.server).Warning("adr: remote adr service is unavailable")
+			continue
+		}
+
+		mu.Lock()
+		// the handler might have been replaced by a configuration reload
+		for _, c := range closers {
+			if c == h {
+				handlers[h.id] = h
+				handlerNames[h.id] = h.name
+				break
+			}
+		}
+		mu.Unlock()
+
+		log.WithFields(log.Fields{
+			"server": h.server,
+			"id":     h.id,
+		}).Info("adr: remote adr handler registered")
+
+		return
+	}
+}
+
+// ID returns the ID.
+func (h *remoteHandler) ID() (string, error) {
+	return h.id, nil
+}
+
+// Name returns the name.
+func (h *remoteHandler) Name() (string, error) {
+	return h.name, nil
+}
+
+// Handle handles the ADR request.
+func (h *remoteHandler) Handle(req adr.HandleRequest) (adr.HandleResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	resp, err := h.client.Handle(ctx, handleRequestToPB(req))
+	if err != nil {
+		code := status.Code(err)
+		if code == codes.DeadlineExceeded || code == codes.Unavailable {
+			log.WithError(err).WithFields(log.Fields{
+				"server":  h.server,
+				"dev_eui": req.DevEUI,
+			}).Warning("adr: remote adr service failed, using default algorithm")
+			fallbackCounter(h.id, code.String()).Inc()
+			return (&DefaultHandler{}).Handle(req)
+		}
+
+		return adr.HandleResponse{}, errors.Wrap(err, "remote adr handle error")
+	}
+
+	return adr.HandleResponse{
+		DR:           int(resp.Dr),
+		TxPowerIndex: int(resp.TxPowerIndex),
+		NbTrans:      int(resp.NbTrans),
+	}, nil
+}
+
+// Close closes the connection.
+func (h *remoteHandler) Close() error {
+	close(h.done)
+	return h.conn.Close()
+}
+
+func handleRequestToPB(req adr.HandleRequest) *adrpb.HandleRequest {
+	out := adrpb.HandleRequest{
+		Region:             req.Region,
+		DevEui:             req.DevEUI[:],
+		MacVersion:         req.MACVersion,
+		RegParamsRevision:  req.RegParamsRevision,
+		Adr:                req.ADR,
+		Dr:                 uint32(req.DR),
+		TxPowerIndex:       uint32(req.TxPowerIndex),
+		NbTrans:            uint32(req.NbTrans),
+		MaxTxPowerIndex:    uint32(req.MaxTxPowerIndex),
+		RequiredSnrForDr:   req.RequiredSNRForDR,
+		InstallationMargin: req.InstallationMargin,
+		MinDr:              uint32(req.MinDR),
+		MaxDr:              uint32(req.MaxDR),
+	}
+
+	for _, uh := range req.UplinkHistory {
+		uhPB := adrpb.UplinkMetaData{
+			FCnt:         uh.FCnt,
+			MaxSnr:       uh.MaxSNR,
+			MaxRssi:      uh.MaxRSSI,
+			TxPowerIndex: uint32(uh.TXPowerIndex),
+			GatewayCount: uint32(uh.GatewayCount),
+			Dr:           uint32(uh.DR),
+			Frequency:    uh.Frequency,
+		}
+
+		// the channel is omitted when unknown
+		if uh.Channel >= 0 {
+			uhPB.Channel = uint32(uh.Channel)
+		} else {
+			uhPB.ChannelUnknown = true
+		}
+
+		if !uh.Time.IsZero() {
+			if ts, err := ptypes.TimestampProto(uh.Time); err == nil {
+				uhPB.Time = ts
+			}
+		}
+
+		for _, gw := range uh.Gateways {
+			gatewayID := gw.GatewayID
+			uhPB.Gateways = append(uhPB.Gateways, &adrpb.UplinkGatewayMetaData{
+				GatewayId: gatewayID[:],
+				Snr:       gw.SNR,
+				Rssi:      gw.RSSI,
+			})
+		}
+
+		out.UplinkHistory = append(out.UplinkHistory, &uhPB)
+	}
+
+	return &out
+}
@@ -0,0 +1,132 @@
+package adr
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/adr/adrpb"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/lorawan"
+)
+
+type testADRService struct {
+	adrpb.UnimplementedADRServiceServer
+
+	delay   time.Duration
+	request *adrpb.HandleRequest
+}
+
+func (s *testADRService) GetInfo(ctx context.Context, req *empty.Empty) (*adrpb.GetInfoResponse, error) {
+	return &adrpb.GetInfoResponse{
+		Id:   "remote",
+		Name: "Remote ADR",
+	}, nil
+}
+
+func (s *testADRService) Handle(ctx context.Context, req *adrpb.HandleRequest) (*adrpb.HandleResponse, error) {
+	s.request = req
+	time.Sleep(s.delay)
+
+	return &adrpb.HandleResponse{
+		Dr:           5,
+		TxPowerIndex: 3,
+		NbTrans:      2,
+	}, nil
+}
+
+func TestRemoteHandler(t *testing.T) {
+	assert := require.New(t)
+
+	service := testADRService{}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+
+	server := grpc.NewServer()
+	adrpb.RegisterADRServiceServer(server, &service)
+	go server.Serve(ln)
+	defer server.Stop()
+
+	var conf config.Config
+	conf.NetworkServer.NetworkSettings.ADRRemoteHandlers = append(conf.NetworkServer.NetworkSettings.ADRRemoteHandlers, struct {
+		Server  string        `mapstructure:"server"`
+		CACert  string        `mapstructure:"ca_cert"`
+		TLSCert string        `mapstructure:"tls_cert"`
+		TLSKey  string        `mapstructure:"tls_key"`
+		Timeout time.Duration `mapstructure:"timeout"`
+	}{
+		Server:  ln.Addr().String(),
+		Timeout: 100 * time.Millisecond,
+	})
+	assert.NoError(Setup(conf))
+	defer Setup(config.Config{})
+
+	assert.Equal("Remote ADR", GetADRAlgorithms()["remote"])
+
+	req := adr.HandleRequest{
+		DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ADR:             true,
+		DR:              0,
+		TxPowerIndex:    0,
+		NbTrans:         1,
+		MaxTxPowerIndex: 5,
+		MaxDR:           5,
+		UplinkHistory: []adr.UplinkMetaData{
+			{
+				FCnt:    10,
+				MaxSNR:  5,
+				Channel: 2,
+				Gateways: []adr.UplinkGatewayMetaData{
+					{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, SNR: 5, RSSI: -60},
+				},
+			},
+			{
+				FCnt:    11,
+				MaxSNR:  4,
+				Channel: -1,
+				Gateways: []adr.UplinkGatewayMetaData{
+					{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, SNR: 4, RSSI: -61},
+				},
+			},
+		},
+	}
+
+	t.Run("Handle", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := GetHandler("remote").Handle(req)
+		assert.NoError(err)
+		assert.Equal(adr.HandleResponse{
+			DR:           5,
+			TxPowerIndex: 3,
+			NbTrans:      2,
+		}, resp)
+
+		assert.Equal(req.DevEUI[:], service.request.DevEui)
+		assert.Len(service.request.UplinkHistory, 2)
+		assert.Len(service.request.UplinkHistory[0].Gateways, 1)
+		assert.EqualValues(-60, service.request.UplinkHistory[0].Gateways[0].Rssi)
+		assert.EqualValues(2, service.request.UplinkHistory[0].Channel)
+		assert.False(service.request.UplinkHistory[0].ChannelUnknown)
+		assert.EqualValues(0, service.request.UplinkHistory[1].Channel)
+		assert.True(service.request.UplinkHistory[1].ChannelUnknown)
+	})
+
+	t.Run("Timeout falls back to default", func(t *testing.T) {
+		assert := require.New(t)
+		service.delay = 200 * time.Millisecond
+
+		resp, err := GetHandler("remote").Handle(req)
+		assert.NoError(err)
+
+		expResp, err := (&DefaultHandler{}).Handle(req)
+		assert.NoError(err)
+		assert.Equal(expResp, resp)
+	})
+}
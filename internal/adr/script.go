@@ -0,0 +1,399 @@
+package adr
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/adr"
+)
+
+// DeviceProfileScriptID defines the ID of the ADR algorithm which uses the
+// script stored in the device-profile.
+const DeviceProfileScriptID = "device_profile_script"
+
+const (
+	// defaultScriptTimeout defines the max. execution time of a script when
+	// not configured.
+	defaultScriptTimeout = 100 * time.Millisecond
+
+	// scriptMaxCallStackSize defines the max. call-stack size of a script.
+	scriptMaxCallStackSize = 256
+
+	// scriptMaxAllocBytes defines the max. number of bytes which may be
+	// allocated during the execution of a script. As the allocations are
+	// measured for the whole process, this is an approximation.
+	scriptMaxAllocBytes = 64 << 20
+
+	// scriptAllocCheckInterval defines the interval in which the allocations
+	// are checked during the execution of a script.
+	scriptAllocCheckInterval = 5 * time.Millisecond
+
+	// scriptMaxNbTrans defines the max. nb_trans that can be returned by a
+	// script (the NbTrans field of the LinkADRReq is 4 bits).
+	scriptMaxNbTrans = 15
+)
+
+var (
+	scriptTimeout = defaultScriptTimeout
+
+	// deviceProfileScripts caches the compiled device-profile scripts.
+	deviceProfileScripts = struct {
+		sync.Mutex
+		items map[uuid.UUID]deviceProfileScript
+	}{
+		items: make(map[uuid.UUID]deviceProfileScript),
+	}
+)
+
+type deviceProfileScript struct {
+	hash    [sha256.Size]byte
+	handler *scriptHandler
+	err     error
+}
+
+// scriptHandler implements an ADR handler using a (JavaScript) script.
+//
+// The script must define the 'handle' function, which receives the ADR
+// request and must return an object containing the dr, tx_power_index and
+// nb_trans. Optionally, the script can define the 'id' and 'name' variables.
+// Each request is executed in a new runtime, without access to the
+// file-system or network, with a max. execution time, call-stack size and
+// (approximate) number of allocated bytes. When the script fails or returns
+// an out of range value, the request is handled by the default ADR handler.
+type scriptHandler struct {
+	id      string
+	name    string
+	program *goja.Program
+}
+
+// newScriptHandler compiles the given script.
+func newScriptHandler(filename, script string) (*scriptHandler, error) {
+	program, err := goja.Compile(filename, script, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "compile script error")
+	}
+
+	h := scriptHandler{
+		program: program,
+	}
+
+	vm, err := h.newRuntime(getScriptTimeout())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := goja.AssertFunction(vm.Get("handle")); !ok {
+		return nil, errors.New("script must define the handle function")
+	}
+
+	if v := vm.Get("id"); v != nil && !goja.IsUndefined(v) {
+		h.id = v.String()
+	}
+	if v := vm.Get("name"); v != nil && !goja.IsUndefined(v) {
+		h.name = v.String()
+	}
+	if h.name == "" {
+		h.name = h.id
+	}
+
+	return &h, nil
+}
+
+// newRuntime returns a new runtime, in which the program has been executed.
+func (h *scriptHandler) newRuntime(timeout time.Duration) (*goja.Runtime, error) {
+	vm := goja.New()
+	vm.SetMaxCallStackSize(scriptMaxCallStackSize)
+	vm.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
+
+	stop := watchRuntime(vm, timeout)
+	defer stop()
+
+	if _, err := vm.RunProgram(h.program); err != nil {
+		return nil, errors.Wrap(err, "run script error")
+	}
+
+	return vm, nil
+}
+
+// watchRuntime interrupts the given runtime when the execution exceeds the
+// given timeout or the max. number of allocated bytes. The returned function
+// must be called when the execution has completed.
+//
+// Note that the runtime can only be interrupted between instructions, not
+// during the execution of a builtin function.
+func watchRuntime(vm *goja.Runtime, timeout time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		ticker := time.NewTicker(scriptAllocCheckInterval)
+		defer ticker.Stop()
+
+		start := heapAllocBytes()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-timer.C:
+				vm.Interrupt("execution timeout")
+				return
+			case <-ticker.C:
+				if heapAllocBytes()-start > scriptMaxAllocBytes {
+					vm.Interrupt("allocation limit exceeded")
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+
+		// the interrupt might have been set after the execution completed
+		vm.ClearInterrupt()
+	}
+}
+
+// heapAllocBytes returns the cumulative number of bytes allocated on the heap
+// by the process.
+func heapAllocBytes() uint64 {
+	s := []metrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+	metrics.Read(s)
+	if s[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return s[0].Value.Uint64()
+}
+
+// getScriptTimeout returns the max. execution time of a script.
+func getScriptTimeout() time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return scriptTimeout
+}
+
+// ID returns the ID.
+func (h *scriptHandler) ID() (string, error) {
+	return h.id, nil
+}
+
+// Name returns the name.
+func (h *scriptHandler) Name() (string, error) {
+	return h.name, nil
+}
+
+// Handle handles the ADR request.
+func (h *scriptHandler) Handle(req adr.HandleRequest) (adr.HandleResponse, error) {
+	resp, err := h.handle(req)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"id":      h.id,
+			"dev_eui": req.DevEUI,
+		}).Warning("adr: script failed, using default algorithm")
+		fallbackCounter(h.id, "script_error").Inc()
+		return (&DefaultHandler{}).Handle(req)
+	}
+
+	return resp, nil
+}
+
+func (h *scriptHandler) handle(req adr.HandleRequest) (adr.HandleResponse, error) {
+	start := time.Now()
+	timeout := getScriptTimeout()
+
+	vm, err := h.newRuntime(timeout)
+	if err != nil {
+		return adr.HandleResponse{}, err
+	}
+
+	handle, ok := goja.AssertFunction(vm.Get("handle"))
+	if !ok {
+		return adr.HandleResponse{}, errors.New("script must define the handle function")
+	}
+
+	// the timeout applies to the total execution time
+	stop := watchRuntime(vm, timeout-time.Since(start))
+	v, err := handle(goja.Undefined(), vm.ToValue(handleRequestToScript(req)))
+	stop()
+	if err != nil {
+		return adr.HandleResponse{}, errors.Wrap(err, "execute handle error")
+	}
+
+	var out struct {
+		DR           *int `json:"dr"`
+		TxPowerIndex *int `json:"tx_power_index"`
+		NbTrans      *int `json:"nb_trans"`
+	}
+	if err := vm.ExportTo(v, &out); err != nil {
+		return adr.HandleResponse{}, errors.Wrap(err, "export handle response error")
+	}
+
+	if out.DR == nil || out.TxPowerIndex == nil || out.NbTrans == nil {
+		return adr.HandleResponse{}, errors.New("handle must return dr, tx_power_index and nb_trans")
+	}
+
+	resp := adr.HandleResponse{
+		DR:           *out.DR,
+		TxPowerIndex: *out.TxPowerIndex,
+		NbTrans:      *out.NbTrans,
+	}
+	if err := validateHandleResponse(req, resp); err != nil {
+		return adr.HandleResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// validateHandleResponse validates that the values returned by the script
+// are within the range of the request. Returning the current value is always
+// allowed.
+func validateHandleResponse(req adr.HandleRequest, resp adr.HandleResponse) error {
+	if resp.DR != req.DR && (resp.DR < req.MinDR || resp.DR > req.MaxDR) {
+		return fmt.Errorf("dr %d is out of range (min: %d, max: %d)", resp.DR, req.MinDR, req.MaxDR)
+	}
+
+	if resp.TxPowerIndex != req.TxPowerIndex && (resp.TxPowerIndex < 0 || resp.TxPowerIndex > req.MaxTxPowerIndex) {
+		return fmt.Errorf("tx_power_index %d is out of range (max: %d)", resp.TxPowerIndex, req.MaxTxPowerIndex)
+	}
+
+	if resp.NbTrans < 1 || resp.NbTrans > scriptMaxNbTrans {
+		return fmt.Errorf("nb_trans %d is out of range (min: 1, max: %d)", resp.NbTrans, scriptMaxNbTrans)
+	}
+
+	return nil
+}
+
+// handleRequestToScript converts the given request into the object passed
+// to the script. The field names match the remote ADR service API.
+func handleRequestToScript(req adr.HandleRequest) map[string]interface{} {
+	var uplinkHistory []interface{}
+	for _, uh := range req.UplinkHistory {
+		var gateways []interface{}
+		for _, gw := range uh.Gateways {
+			gateways = append(gateways, map[string]interface{}{
+				"gateway_id": gw.GatewayID.String(),
+				"snr":        gw.SNR,
+				"rssi":       gw.RSSI,
+			})
+		}
+
+		item := map[string]interface{}{
+			"f_cnt":          uh.FCnt,
+			"max_snr":        uh.MaxSNR,
+			"max_rssi":       uh.MaxRSSI,
+			"tx_power_index": uh.TXPowerIndex,
+			"gateway_count":  uh.GatewayCount,
+			"dr":             uh.DR,
+			"frequency":      uh.Frequency,
+			"channel":        uh.Channel,
+			"gateways":       gateways,
+		}
+		if !uh.Time.IsZero() {
+			item["time"] = uh.Time.UnixNano() / int64(time.Millisecond)
+		}
+
+		uplinkHistory = append(uplinkHistory, item)
+	}
+
+	return map[string]interface{}{
+		"region":              req.Region,
+		"dev_eui":             req.DevEUI.String(),
+		"mac_version":         req.MACVersion,
+		"reg_params_revision": req.RegParamsRevision,
+		"adr":                 req.ADR,
+		"dr":                  req.DR,
+		"tx_power_index":      req.TxPowerIndex,
+		"nb_trans":            req.NbTrans,
+		"max_tx_power_index":  req.MaxTxPowerIndex,
+		"required_snr_for_dr": req.RequiredSNRForDR,
+		"installation_margin": req.InstallationMargin,
+		"min_dr":              req.MinDR,
+		"max_dr":              req.MaxDR,
+		"uplink_history":      uplinkHistory,
+	}
+}
+
+// loadScriptHandlers loads the scripts (*.js) from the given directory.
+func loadScriptHandlers(dir string) ([]*scriptHandler, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.js"))
+	if err != nil {
+		return nil, errors.Wrap(err, "list scripts error")
+	}
+	sort.Strings(files)
+
+	var out []*scriptHandler
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "read script error")
+		}
+
+		h, err := newScriptHandler(filepath.Base(f), string(b))
+		if err != nil {
+			return nil, errors.Wrapf(err, "load script error (file: %s)", f)
+		}
+
+		if h.id == "" {
+			return nil, fmt.Errorf("script must define the id variable (file: %s)", f)
+		}
+
+		out = append(out, h)
+	}
+
+	return out, nil
+}
+
+// ValidateScript validates the given ADR script.
+func ValidateScript(script string) error {
+	_, err := newScriptHandler("device-profile.js", script)
+	return err
+}
+
+// getDeviceProfileScriptHandler returns the (cached) handler for the given
+// device-profile script.
+func getDeviceProfileScriptHandler(dpID uuid.UUID, script string) (*scriptHandler, error) {
+	hash := sha256.Sum256([]byte(script))
+
+	deviceProfileScripts.Lock()
+	defer deviceProfileScripts.Unlock()
+
+	s, ok := deviceProfileScripts.items[dpID]
+	if !ok || s.hash != hash {
+		s = deviceProfileScript{
+			hash: hash,
+		}
+		s.handler, s.err = newScriptHandler("device-profile.js", script)
+		if s.handler != nil {
+			s.handler.id = DeviceProfileScriptID
+		}
+		deviceProfileScripts.items[dpID] = s
+	}
+
+	return s.handler, s.err
+}
+
+// flushDeviceProfileScripts flushes the compiled device-profile scripts.
+func flushDeviceProfileScripts() {
+	deviceProfileScripts.Lock()
+	defer deviceProfileScripts.Unlock()
+
+	deviceProfileScripts.items = make(map[uuid.UUID]deviceProfileScript)
+}
@@ -0,0 +1,208 @@
+package adr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+const testScript = `
+var id = "script";
+var name = "Script ADR";
+
+function handle(req) {
+	var maxSNR = -999;
+	for (var i = 0; i < req.uplink_history.length; i++) {
+		maxSNR = Math.max(maxSNR, req.uplink_history[i].max_snr);
+	}
+
+	return {
+		dr: maxSNR > 0 ? req.max_dr : req.dr,
+		tx_power_index: req.tx_power_index,
+		nb_trans: req.uplink_history[0].gateways.length + 1,
+	};
+}
+`
+
+func TestScriptHandler(t *testing.T) {
+	req := adr.HandleRequest{
+		DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ADR:             true,
+		DR:              0,
+		TxPowerIndex:    1,
+		NbTrans:         1,
+		MaxTxPowerIndex: 5,
+		MaxDR:           5,
+		UplinkHistory: []adr.UplinkMetaData{
+			{
+				FCnt:   10,
+				MaxSNR: 5,
+				Gateways: []adr.UplinkGatewayMetaData{
+					{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, SNR: 5, RSSI: -60},
+				},
+			},
+		},
+	}
+
+	defaultResp, err := (&DefaultHandler{}).Handle(req)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		script   string
+		err      string
+		expected adr.HandleResponse
+	}{
+		{
+			name:   "valid",
+			script: testScript,
+			expected: adr.HandleResponse{
+				DR:           5,
+				TxPowerIndex: 1,
+				NbTrans:      2,
+			},
+		},
+		{
+			name:   "syntax error",
+			script: `function handle(req) {`,
+			err:    "compile script error",
+		},
+		{
+			name:   "no handle function",
+			script: `var id = "test";`,
+			err:    "script must define the handle function",
+		},
+		{
+			name:     "exception falls back to default",
+			script:   `function handle(req) { throw new Error("boom"); }`,
+			expected: defaultResp,
+		},
+		{
+			name:     "timeout falls back to default",
+			script:   `function handle(req) { while (true) {} }`,
+			expected: defaultResp,
+		},
+		{
+			name:     "recursion falls back to default",
+			script:   `function f() { return f(); } function handle(req) { return f(); }`,
+			expected: defaultResp,
+		},
+		{
+			name:     "invalid response falls back to default",
+			script:   `function handle(req) { return {dr: 1}; }`,
+			expected: defaultResp,
+		},
+		{
+			name:     "dr out of range falls back to default",
+			script:   `function handle(req) { return {dr: 6, tx_power_index: 1, nb_trans: 1}; }`,
+			expected: defaultResp,
+		},
+		{
+			name:     "tx_power_index out of range falls back to default",
+			script:   `function handle(req) { return {dr: 0, tx_power_index: -1, nb_trans: 1}; }`,
+			expected: defaultResp,
+		},
+		{
+			name:     "nb_trans out of range falls back to default",
+			script:   `function handle(req) { return {dr: 0, tx_power_index: 1, nb_trans: 16}; }`,
+			expected: defaultResp,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			h, err := newScriptHandler("test.js", tst.script)
+			if tst.err != "" {
+				assert.Error(err)
+				assert.Contains(err.Error(), tst.err)
+				return
+			}
+			assert.NoError(err)
+
+			resp, err := h.Handle(req)
+			assert.NoError(err)
+			assert.Equal(tst.expected, resp)
+		})
+	}
+}
+
+func TestScriptAllocationLimit(t *testing.T) {
+	assert := require.New(t)
+
+	// make sure the allocation limit is reached before the timeout
+	scriptTimeout = 10 * time.Second
+	defer func() { scriptTimeout = defaultScriptTimeout }()
+
+	h, err := newScriptHandler("test.js", `function handle(req) { var a = []; while (true) { a.push(new Array(10000).fill(0)); } }`)
+	assert.NoError(err)
+
+	_, err = h.handle(adr.HandleRequest{})
+	assert.Error(err)
+	assert.Contains(err.Error(), "allocation limit exceeded")
+}
+
+func TestScriptSetup(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "adr-scripts")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "script.js"), []byte(testScript), 0644))
+
+	var conf config.Config
+	conf.NetworkServer.NetworkSettings.ADRScriptsDir = dir
+	assert.NoError(Setup(conf))
+	defer Setup(config.Config{})
+
+	assert.Equal("Script ADR", GetADRAlgorithms()["script"])
+	id, err := GetHandler("script").ID()
+	assert.NoError(err)
+	assert.Equal("script", id)
+
+	t.Run("Duplicate ID", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ioutil.WriteFile(filepath.Join(dir, "default.js"), []byte(`var id = "default"; function handle(req) {}`), 0644))
+		assert.Error(Setup(conf))
+		assert.NoError(os.Remove(filepath.Join(dir, "default.js")))
+	})
+}
+
+func TestGetHandlerForDeviceProfile(t *testing.T) {
+	assert := require.New(t)
+
+	dp := storage.DeviceProfile{
+		ID:             uuid.Must(uuid.NewV4()),
+		ADRAlgorithmID: DeviceProfileScriptID,
+		ADRScript:      testScript,
+	}
+
+	h := GetHandlerForDeviceProfile(dp)
+	id, err := h.ID()
+	assert.NoError(err)
+	assert.Equal(DeviceProfileScriptID, id)
+	assert.Equal(h, GetHandlerForDeviceProfile(dp))
+
+	dp.ADRScript = "invalid"
+	h = GetHandlerForDeviceProfile(dp)
+	id, err = h.ID()
+	assert.NoError(err)
+	assert.Equal("default", id)
+
+	dp.ADRAlgorithmID = "default"
+	_, ok := GetHandlerForDeviceProfile(dp).(*DefaultHandler)
+	assert.True(ok)
+}
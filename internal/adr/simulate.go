@@ -0,0 +1,210 @@
+package adr
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+// SimulationConfig contains the configuration of an ADR simulation.
+type SimulationConfig struct {
+	// Request holds the initial state of the device (DR, TxPowerIndex and
+	// NbTrans) and the static request fields (e.g. MinDR, MaxDR,
+	// MaxTxPowerIndex and InstallationMargin). Its UplinkHistory is ignored.
+	Request adr.HandleRequest
+
+	// HistorySize defines the max. uplink history size (UplinkHistorySize
+	// when <= 0).
+	HistorySize int
+
+	// RequiredSNR returns the min. required SNR for the given data-rate.
+	RequiredSNR func(dr int) (float32, error)
+
+	// TXPowerOffset returns the tx-power offset (dB) for the given tx-power
+	// index. When set, the SNR of each uplink is corrected for the difference
+	// between the recorded and the simulated tx-power index.
+	TXPowerOffset func(txPowerIndex int) (float32, error)
+}
+
+// SimulationStep contains the outcome of a single simulated uplink.
+type SimulationStep struct {
+	// Uplink holds the uplink meta-data as added to the uplink history
+	// (after applying the simulated DR and tx-power).
+	Uplink adr.UplinkMetaData
+
+	// HistoryCount holds the number of uplinks in the history when calling
+	// the ADR handler.
+	HistoryCount int
+
+	// Request and Response hold the DR, tx-power index and NbTrans before
+	// and after calling the ADR handler.
+	Request  adr.HandleResponse
+	Response adr.HandleResponse
+}
+
+// Changed returns true when the ADR handler requested a change.
+func (s SimulationStep) Changed() bool {
+	return s.Request != s.Response
+}
+
+// Simulate runs the given uplinks through the ADR handler, step by step.
+// After each uplink, it is assumed that the device applies the requested
+// DR, tx-power index and NbTrans before sending the next uplink. Like the
+// uplink handling of the network-server, the uplink history is reset when
+// the data-rate changes.
+func Simulate(h adr.Handler, conf SimulationConfig, uplinks []adr.UplinkMetaData) ([]SimulationStep, error) {
+	if conf.RequiredSNR == nil {
+		return nil, errors.New("required snr function must be set")
+	}
+
+	req := conf.Request
+	req.UplinkHistory = nil
+
+	var out []SimulationStep
+
+	for _, up := range uplinks {
+		if conf.TXPowerOffset != nil {
+			recorded, err := conf.TXPowerOffset(up.TXPowerIndex)
+			if err != nil {
+				return nil, errors.Wrap(err, "get tx-power offset error")
+			}
+			simulated, err := conf.TXPowerOffset(req.TxPowerIndex)
+			if err != nil {
+				return nil, errors.Wrap(err, "get tx-power offset error")
+			}
+
+			diff := simulated - recorded
+			up.MaxSNR += diff
+			up.MaxRSSI += int32(math.Round(float64(diff)))
+
+			gateways := make([]adr.UplinkGatewayMetaData, len(up.Gateways))
+			for i, gw := range up.Gateways {
+				gw.SNR += diff
+				gw.RSSI += int32(math.Round(float64(diff)))
+				gateways[i] = gw
+			}
+			up.Gateways = gateways
+		}
+
+		up.DR = req.DR
+		up.TXPowerIndex = req.TxPowerIndex
+		req.UplinkHistory = appendUplinkMetaData(req.UplinkHistory, up, conf.HistorySize)
+
+		requiredSNR, err := conf.RequiredSNR(req.DR)
+		if err != nil {
+			return nil, errors.Wrap(err, "get required snr error")
+		}
+		req.RequiredSNRForDR = requiredSNR
+
+		resp, err := h.Handle(req)
+		if err != nil {
+			return nil, errors.Wrap(err, "handle adr error")
+		}
+
+		step := SimulationStep{
+			Uplink:       up,
+			HistoryCount: len(req.UplinkHistory),
+			Request: adr.HandleResponse{
+				DR:           req.DR,
+				TxPowerIndex: req.TxPowerIndex,
+				NbTrans:      req.NbTrans,
+			},
+			Response: resp,
+		}
+		out = append(out, step)
+
+		if resp.DR != req.DR {
+			req.UplinkHistory = nil
+		}
+
+		req.DR = resp.DR
+		req.TxPowerIndex = resp.TxPowerIndex
+		req.NbTrans = resp.NbTrans
+	}
+
+	return out, nil
+}
+
+// appendUplinkMetaData appends the uplink to the history and makes sure
+// the history does not exceed the given size.
+func appendUplinkMetaData(history []adr.UplinkMetaData, up adr.UplinkMetaData, size int) []adr.UplinkMetaData {
+	if size <= 0 {
+		size = storage.UplinkHistorySize
+	}
+
+	history = append(history, up)
+	if count := len(history); count > size {
+		history = history[count-size:]
+	}
+
+	return history
+}
+
+// SyntheticUplinkConfig defines the distribution of synthesised uplinks.
+type SyntheticUplinkConfig struct {
+	// Count holds the number of uplinks to generate.
+	Count int
+
+	// SNRMean and SNRStdDev define the (normal) SNR distribution.
+	SNRMean   float64
+	SNRStdDev float64
+
+	// RSSIMean and RSSIStdDev define the (normal) RSSI distribution.
+	RSSIMean   float64
+	RSSIStdDev float64
+
+	// GatewayCount holds the number of receiving gateways per uplink
+	// (min. 1). The SNR and RSSI are drawn per gateway.
+	GatewayCount int
+
+	// Interval holds the interval between the uplinks.
+	Interval time.Duration
+}
+
+// SynthesizeUplinks generates uplinks using the given SNR and RSSI
+// distributions. The uplinks are generated at tx-power index 0.
+func SynthesizeUplinks(conf SyntheticUplinkConfig, rnd *rand.Rand) []adr.UplinkMetaData {
+	gatewayCount := conf.GatewayCount
+	if gatewayCount < 1 {
+		gatewayCount = 1
+	}
+
+	start := time.Unix(0, 0).UTC()
+	out := make([]adr.UplinkMetaData, 0, conf.Count)
+
+	for i := 0; i < conf.Count; i++ {
+		up := adr.UplinkMetaData{
+			FCnt:         uint32(i),
+			MaxSNR:       float32(math.Inf(-1)),
+			MaxRSSI:      math.MinInt32,
+			GatewayCount: gatewayCount,
+			Time:         start.Add(time.Duration(i) * conf.Interval),
+		}
+
+		for j := 0; j < gatewayCount; j++ {
+			gw := adr.UplinkGatewayMetaData{
+				SNR:  float32(conf.SNRMean + rnd.NormFloat64()*conf.SNRStdDev),
+				RSSI: int32(math.Round(conf.RSSIMean + rnd.NormFloat64()*conf.RSSIStdDev)),
+			}
+			gw.GatewayID[7] = byte(j + 1)
+
+			if gw.SNR > up.MaxSNR {
+				up.MaxSNR = gw.SNR
+			}
+			if gw.RSSI > up.MaxRSSI {
+				up.MaxRSSI = gw.RSSI
+			}
+
+			up.Gateways = append(up.Gateways, gw)
+		}
+
+		out = append(out, up)
+	}
+
+	return out
+}
@@ -0,0 +1,153 @@
+package adr
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/adr"
+)
+
+// stepHandler increases the DR by one after three uplinks and decreases the
+// tx-power when the last uplink has a positive SNR margin.
+type stepHandler struct{}
+
+func (h stepHandler) ID() (string, error)   { return "step", nil }
+func (h stepHandler) Name() (string, error) { return "Step", nil }
+
+func (h stepHandler) Handle(req adr.HandleRequest) (adr.HandleResponse, error) {
+	resp := adr.HandleResponse{
+		DR:           req.DR,
+		TxPowerIndex: req.TxPowerIndex,
+		NbTrans:      req.NbTrans,
+	}
+
+	if len(req.UplinkHistory) >= 3 && req.DR < req.MaxDR {
+		resp.DR++
+		return resp, nil
+	}
+
+	last := req.UplinkHistory[len(req.UplinkHistory)-1]
+	if last.MaxSNR-req.RequiredSNRForDR > 0 && req.TxPowerIndex < req.MaxTxPowerIndex {
+		resp.TxPowerIndex++
+	}
+
+	return resp, nil
+}
+
+func TestSimulate(t *testing.T) {
+	requiredSNR := func(dr int) (float32, error) {
+		return float32(-20 + 2.5*float64(dr)), nil
+	}
+	txPowerOffset := func(i int) (float32, error) {
+		return float32(-2 * i), nil
+	}
+
+	uplinks := []adr.UplinkMetaData{
+		{FCnt: 1, MaxSNR: -18, TXPowerIndex: 0},
+		{FCnt: 2, MaxSNR: -18, TXPowerIndex: 0},
+		{FCnt: 3, MaxSNR: -18, TXPowerIndex: 0},
+		{FCnt: 4, MaxSNR: -20, TXPowerIndex: 1},
+		{FCnt: 5, MaxSNR: -30, TXPowerIndex: 0},
+	}
+
+	t.Run("No request function", func(t *testing.T) {
+		assert := require.New(t)
+		_, err := Simulate(stepHandler{}, SimulationConfig{}, uplinks)
+		assert.Error(err)
+	})
+
+	t.Run("Step by step", func(t *testing.T) {
+		assert := require.New(t)
+
+		steps, err := Simulate(stepHandler{}, SimulationConfig{
+			Request: adr.HandleRequest{
+				DR:              0,
+				NbTrans:         1,
+				MaxDR:           5,
+				MaxTxPowerIndex: 7,
+			},
+			HistorySize:   2,
+			RequiredSNR:   requiredSNR,
+			TXPowerOffset: txPowerOffset,
+		}, uplinks)
+		assert.NoError(err)
+		assert.Len(steps, 5)
+
+		// tx-power decreases on the first two uplinks, the history is
+		// limited to two uplinks
+		assert.Equal(1, steps[0].HistoryCount)
+		assert.Equal(adr.HandleResponse{DR: 0, TxPowerIndex: 1, NbTrans: 1}, steps[0].Response)
+		assert.True(steps[0].Changed())
+
+		assert.Equal(float32(-20), steps[1].Uplink.MaxSNR)
+		assert.Equal(1, steps[1].Uplink.TXPowerIndex)
+		assert.Equal(2, steps[1].HistoryCount)
+		assert.False(steps[1].Changed())
+
+		assert.Equal(2, steps[2].HistoryCount)
+
+		// the recorded uplink has the same tx-power as simulated
+		assert.Equal(float32(-20), steps[3].Uplink.MaxSNR)
+		assert.Equal(2, steps[4].HistoryCount)
+	})
+
+	t.Run("History reset on DR change", func(t *testing.T) {
+		assert := require.New(t)
+
+		steps, err := Simulate(stepHandler{}, SimulationConfig{
+			Request: adr.HandleRequest{
+				DR:      0,
+				NbTrans: 1,
+				MaxDR:   5,
+			},
+			RequiredSNR: requiredSNR,
+		}, uplinks)
+		assert.NoError(err)
+
+		assert.Equal(3, steps[2].HistoryCount)
+		assert.Equal(1, steps[2].Response.DR)
+		assert.Equal(1, steps[3].HistoryCount)
+		assert.Equal(1, steps[3].Uplink.DR)
+
+		// without tx-power offset function, the SNR is not corrected
+		assert.Equal(float32(-20), steps[3].Uplink.MaxSNR)
+	})
+}
+
+func TestSynthesizeUplinks(t *testing.T) {
+	assert := require.New(t)
+
+	conf := SyntheticUplinkConfig{
+		Count:        100,
+		SNRMean:      5,
+		SNRStdDev:    2,
+		RSSIMean:     -100,
+		RSSIStdDev:   5,
+		GatewayCount: 3,
+		Interval:     time.Minute,
+	}
+
+	a := SynthesizeUplinks(conf, rand.New(rand.NewSource(1)))
+	b := SynthesizeUplinks(conf, rand.New(rand.NewSource(1)))
+	assert.Equal(a, b)
+	assert.Len(a, 100)
+
+	var sum float64
+	for i, up := range a {
+		assert.EqualValues(i, up.FCnt)
+		assert.Equal(3, up.GatewayCount)
+		assert.Len(up.Gateways, 3)
+		assert.Equal(time.Duration(i)*time.Minute, up.Time.Sub(a[0].Time))
+
+		for _, gw := range up.Gateways {
+			assert.LessOrEqual(gw.SNR, up.MaxSNR)
+			assert.LessOrEqual(gw.RSSI, up.MaxRSSI)
+			sum += float64(gw.SNR)
+		}
+	}
+
+	assert.InDelta(5, sum/300, 0.5)
+}
@@ -0,0 +1,129 @@
+package asclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is returned when the circuit-breaker of the
+// application-server is open, in which case the request is not sent.
+var ErrCircuitOpen = errors.New("application-server circuit-breaker is open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker implements a circuit-breaker. After threshold consecutive
+// failures, the breaker opens and requests fail fast. After openDuration,
+// a single (probe) request is allowed. When it succeeds the breaker closes,
+// when it fails the breaker opens again.
+type breaker struct {
+	sync.Mutex
+
+	threshold    int
+	openDuration time.Duration
+
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow returns true if a request is allowed.
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success records a successful request.
+func (b *breaker) success() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// failure records a failed request.
+func (b *breaker) failure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.failures++
+	b.probing = false
+
+	if b.state != breakerClosed || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// isClosed returns true when the breaker is closed.
+func (b *breaker) isClosed() bool {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.state == breakerClosed
+}
+
+// isUnavailable returns true when the error indicates that the
+// application-server could not be reached.
+func isUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRetryable returns true when the request failed because the
+// application-server could not be reached (or was not able to handle the
+// request at that moment) and the request can be retried.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,89 @@
+package asclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+		b := breaker{}
+
+		for i := 0; i < 10; i++ {
+			b.failure()
+		}
+		assert.True(b.allow())
+		assert.True(b.isClosed())
+	})
+
+	t.Run("Open and recover", func(t *testing.T) {
+		assert := require.New(t)
+		b := breaker{
+			threshold:    2,
+			openDuration: 10 * time.Millisecond,
+		}
+
+		b.failure()
+		assert.True(b.allow())
+		assert.True(b.isClosed())
+
+		b.failure()
+		assert.False(b.allow())
+		assert.False(b.isClosed())
+
+		// only a single probe request is allowed
+		time.Sleep(10 * time.Millisecond)
+		assert.True(b.allow())
+		assert.False(b.allow())
+
+		// failed probe request opens the breaker again
+		b.failure()
+		assert.False(b.allow())
+
+		time.Sleep(10 * time.Millisecond)
+		assert.True(b.allow())
+		b.success()
+		assert.True(b.isClosed())
+		assert.True(b.allow())
+		assert.True(b.allow())
+	})
+
+	t.Run("Success resets failures", func(t *testing.T) {
+		assert := require.New(t)
+		b := breaker{
+			threshold:    2,
+			openDuration: time.Minute,
+		}
+
+		b.failure()
+		b.success()
+		b.failure()
+		assert.True(b.isClosed())
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{ErrCircuitOpen, true},
+		{status.Error(codes.Unavailable, "unavailable"), true},
+		{status.Error(codes.DeadlineExceeded, "timeout"), true},
+		{status.Error(codes.ResourceExhausted, "exhausted"), true},
+		{status.Error(codes.InvalidArgument, "invalid"), false},
+		{errors.New("unknown"), false},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.err.Error(), func(t *testing.T) {
+			require.Equal(t, tst.retryable, IsRetryable(tst.err))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
@@ -13,35 +14,115 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	"github.com/brocaar/chirpstack-api/go/v3/as"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 )
 
+const healthCheckMethod = "/grpc.health.v1.Health/Check"
+
 // Pool defines the application-server client pool.
 type Pool interface {
 	Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error)
 }
 
+// PoolConfig defines the application-server client pool configuration.
+type PoolConfig struct {
+	// IdleTimeout defines after which duration of inactivity a connection
+	// is closed. When set to 0, connections are never closed.
+	IdleTimeout time.Duration
+
+	// HealthCheckInterval defines the interval in which the connections are
+	// health-checked (and evicted when idle). When set to 0, no
+	// health-checks are performed.
+	HealthCheckInterval time.Duration
+
+	// FailureThreshold defines the number of consecutive failures after
+	// which the circuit-breaker opens. When set to 0, the circuit-breaker
+	// is disabled.
+	FailureThreshold int
+
+	// OpenDuration defines the duration after which an open circuit-breaker
+	// allows a probe request.
+	OpenDuration time.Duration
+}
+
 type client struct {
 	client     as.ApplicationServerServiceClient
 	clientConn *grpc.ClientConn
 	caCert     []byte
 	tlsCert    []byte
 	tlsKey     []byte
+
+	breaker  *breaker
+	lastUsed int64
+
+	// inFlight contains the number of pending requests. A connection is
+	// not closed on idle eviction while it has pending requests.
+	inFlight int64
+}
+
+func (c *client) touch() {
+	atomic.StoreInt64(&c.lastUsed, time.Now().UnixNano())
+}
+
+func (c *client) idleSince() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&c.lastUsed)))
+}
+
+// unaryInterceptor fails fast when the circuit-breaker is open and records
+// the result of the request in the circuit-breaker.
+func (c *client) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if method == healthCheckMethod {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	c.touch()
+	atomic.AddInt64(&c.inFlight, 1)
+	defer atomic.AddInt64(&c.inFlight, -1)
+
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if isUnavailable(err) {
+		c.breaker.failure()
+	} else {
+		c.breaker.success()
+	}
+
+	return err
 }
 
 type pool struct {
 	sync.RWMutex
-	clients map[string]client
+	config  PoolConfig
+	clients map[string]*client
 }
 
 // NewPool creates a new Pool.
-func NewPool() Pool {
-	return &pool{
-		clients: make(map[string]client),
+func NewPool(conf PoolConfig) Pool {
+	p := &pool{
+		config:  conf,
+		clients: make(map[string]*client),
+	}
+
+	if conf.HealthCheckInterval > 0 {
+		go func() {
+			for {
+				time.Sleep(conf.HealthCheckInterval)
+				p.maintain()
+			}
+		}()
 	}
+
+	return p
 }
 
 // Get Returns an ApplicationServerClient for the given server (hostname:ip).
@@ -64,33 +145,102 @@ func (p *pool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.Applicat
 	}
 
 	if connect {
-		clientConn, asClient, err := p.createClient(hostname, caCert, tlsCert, tlsKey)
+		c = &client{
+			caCert:  caCert,
+			tlsCert: tlsCert,
+			tlsKey:  tlsKey,
+			breaker: &breaker{
+				threshold:    p.config.FailureThreshold,
+				openDuration: p.config.OpenDuration,
+			},
+		}
+
+		clientConn, asClient, err := p.createClient(hostname, c)
 		if err != nil {
 			return nil, errors.Wrap(err, "create application-server api client error")
 		}
-		c = client{
-			client:     asClient,
-			clientConn: clientConn,
-			caCert:     caCert,
-			tlsCert:    tlsCert,
-			tlsKey:     tlsKey,
-		}
+		c.client = asClient
+		c.clientConn = clientConn
 		p.clients[hostname] = c
 	}
 
+	c.touch()
+
 	return c.client, nil
 }
 
-func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*grpc.ClientConn, as.ApplicationServerServiceClient, error) {
+// maintain closes the idle connections and health-checks the remaining
+// connections.
+func (p *pool) maintain() {
+	p.Lock()
+	var clients = make(map[string]*client)
+	for hostname, c := range p.clients {
+		// Connections with an open circuit-breaker are not evicted, as this
+		// would reset the circuit-breaker. Connections with pending requests
+		// are not evicted either.
+		if p.config.IdleTimeout > 0 && c.idleSince() > p.config.IdleTimeout && c.breaker.isClosed() && atomic.LoadInt64(&c.inFlight) == 0 {
+			log.WithField("server", hostname).Info("closing idle application-server client")
+			c.clientConn.Close()
+			delete(p.clients, hostname)
+			continue
+		}
+		clients[hostname] = c
+	}
+	p.Unlock()
+
+	for hostname, c := range clients {
+		p.healthCheck(hostname, c)
+	}
+}
+
+// healthCheck performs a gRPC health-check and records the result in the
+// circuit-breaker. Application-servers that do not implement the
+// health-check service are considered healthy when reachable.
+func (p *pool) healthCheck(hostname string, c *client) {
+	timeout := p.config.HealthCheckInterval
+	if timeout == 0 || timeout > time.Second {
+		timeout = time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := grpc_health_v1.NewHealthClient(c.clientConn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil && status.Code(err) != codes.Unimplemented {
+		log.WithField("server", hostname).WithError(err).Warning("application-server health-check error")
+		c.breaker.failure()
+		return
+	}
+
+	if resp != nil && resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		log.WithFields(log.Fields{
+			"server": hostname,
+			"status": resp.GetStatus(),
+		}).Warning("application-server is not serving")
+		c.breaker.failure()
+		return
+	}
+
+	if !c.breaker.isClosed() {
+		log.WithField("server", hostname).Info("application-server recovered")
+	}
+	c.breaker.success()
+}
+
+func (p *pool) createClient(hostname string, c *client) (*grpc.ClientConn, as.ApplicationServerServiceClient, error) {
 	logrusEntry := log.NewEntry(log.StandardLogger())
 	logrusOpts := []grpc_logrus.Option{
 		grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel),
 	}
 
+	// The connection is not blocking, as an unavailable application-server
+	// must not block the caller. The unavailability is handled by the
+	// circuit-breaker.
 	asOpts := []grpc.DialOption{
-		grpc.WithBlock(),
-		grpc.WithUnaryInterceptor(
+		grpc.WithChainUnaryInterceptor(
 			logging.UnaryClientCtxIDInterceptor,
+			tracing.UnaryClientInterceptor(),
+			c.unaryInterceptor,
 		),
 		grpc.WithStreamInterceptor(
 			grpc_logrus.StreamClientInterceptor(logrusEntry, logrusOpts...),
@@ -98,20 +248,20 @@ func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*g
 		grpc.WithBalancerName(roundrobin.Name),
 	}
 
-	if len(tlsCert) == 0 && len(tlsKey) == 0 && len(caCert) == 0 {
+	if len(c.tlsCert) == 0 && len(c.tlsKey) == 0 && len(c.caCert) == 0 {
 		asOpts = append(asOpts, grpc.WithInsecure())
 		log.WithField("server", hostname).Warning("creating insecure application-server client")
 	} else {
 		log.WithField("server", hostname).Info("creating application-server client")
-		cert, err := tls.X509KeyPair(tlsCert, tlsKey)
+		cert, err := tls.X509KeyPair(c.tlsCert, c.tlsKey)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "load x509 keypair error")
 		}
 
 		var caCertPool *x509.CertPool
-		if len(caCert) != 0 {
+		if len(c.caCert) != 0 {
 			caCertPool = x509.NewCertPool()
-			if !caCertPool.AppendCertsFromPEM(caCert) {
+			if !caCertPool.AppendCertsFromPEM(c.caCert) {
 				return nil, nil, errors.Wrap(err, "append ca cert to pool error")
 			}
 		}
@@ -122,10 +272,7 @@ func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*g
 		})))
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
-	defer cancel()
-
-	asClient, err := grpc.DialContext(ctx, hostname, asOpts...)
+	asClient, err := grpc.Dial(hostname, asOpts...)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "dial application-server api error")
 	}
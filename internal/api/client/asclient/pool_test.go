@@ -0,0 +1,88 @@
+package asclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/brocaar/chirpstack-api/go/v3/as"
+)
+
+type testApplicationServer struct {
+	as.UnimplementedApplicationServerServiceServer
+}
+
+func (s *testApplicationServer) HandleUplinkData(ctx context.Context, req *as.HandleUplinkDataRequest) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func TestPool(t *testing.T) {
+	assert := require.New(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+
+	healthServer := health.NewServer()
+	server := grpc.NewServer()
+	as.RegisterApplicationServerServiceServer(server, &testApplicationServer{})
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go server.Serve(ln)
+	defer server.Stop()
+
+	p := NewPool(PoolConfig{
+		IdleTimeout:      100 * time.Millisecond,
+		FailureThreshold: 1,
+		OpenDuration:     time.Minute,
+	}).(*pool)
+
+	hostname := ln.Addr().String()
+
+	asClient, err := p.Get(hostname, nil, nil, nil)
+	assert.NoError(err)
+
+	t.Run("Request", func(t *testing.T) {
+		assert := require.New(t)
+		_, err := asClient.HandleUplinkData(context.Background(), &as.HandleUplinkDataRequest{})
+		assert.NoError(err)
+	})
+
+	t.Run("Health-check not serving", func(t *testing.T) {
+		assert := require.New(t)
+		healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+		p.maintain()
+		assert.False(p.clients[hostname].breaker.isClosed())
+
+		_, err := asClient.HandleUplinkData(context.Background(), &as.HandleUplinkDataRequest{})
+		assert.Equal(ErrCircuitOpen, err)
+	})
+
+	t.Run("Health-check serving", func(t *testing.T) {
+		assert := require.New(t)
+		healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+		p.maintain()
+		assert.True(p.clients[hostname].breaker.isClosed())
+
+		_, err := asClient.HandleUplinkData(context.Background(), &as.HandleUplinkDataRequest{})
+		assert.NoError(err)
+	})
+
+	t.Run("Idle eviction", func(t *testing.T) {
+		assert := require.New(t)
+
+		p.maintain()
+		assert.Len(p.clients, 1)
+
+		time.Sleep(100 * time.Millisecond)
+		p.maintain()
+		assert.Len(p.clients, 0)
+	})
+}
@@ -0,0 +1,5 @@
+//go:generate protoc -I=/protobuf/src -I=. --go_out=plugins=grpc,paths=source_relative:. extapi.proto
+
+// Package extapi contains the API definitions of the network-server API
+// methods which are not (yet) part of the ChirpStack API.
+package extapi
@@ -0,0 +1,5911 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0-devel
+// 	protoc        v3.18.1
+// source: extapi.proto
+
+package extapi
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type DeviceModeFilter int32
+
+const (
+	// Any mode.
+	DeviceModeFilter_ANY_MODE DeviceModeFilter = 0
+	// Class-A.
+	DeviceModeFilter_MODE_A DeviceModeFilter = 1
+	// Class-B.
+	DeviceModeFilter_MODE_B DeviceModeFilter = 2
+	// Class-C.
+	DeviceModeFilter_MODE_C DeviceModeFilter = 3
+)
+
+// Enum value maps for DeviceModeFilter.
+var (
+	DeviceModeFilter_name = map[int32]string{
+		0: "ANY_MODE",
+		1: "MODE_A",
+		2: "MODE_B",
+		3: "MODE_C",
+	}
+	DeviceModeFilter_value = map[string]int32{
+		"ANY_MODE": 0,
+		"MODE_A":   1,
+		"MODE_B":   2,
+		"MODE_C":   3,
+	}
+)
+
+func (x DeviceModeFilter) Enum() *DeviceModeFilter {
+	p := new(DeviceModeFilter)
+	*p = x
+	return p
+}
+
+func (x DeviceModeFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceModeFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_extapi_proto_enumTypes[0].Descriptor()
+}
+
+func (DeviceModeFilter) Type() protoreflect.EnumType {
+	return &file_extapi_proto_enumTypes[0]
+}
+
+func (x DeviceModeFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeviceModeFilter.Descriptor instead.
+func (DeviceModeFilter) EnumDescriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{0}
+}
+
+type DisabledFilter int32
+
+const (
+	// Enabled and disabled.
+	DisabledFilter_ANY_STATE DisabledFilter = 0
+	// Only enabled.
+	DisabledFilter_ENABLED DisabledFilter = 1
+	// Only disabled.
+	DisabledFilter_DISABLED DisabledFilter = 2
+)
+
+// Enum value maps for DisabledFilter.
+var (
+	DisabledFilter_name = map[int32]string{
+		0: "ANY_STATE",
+		1: "ENABLED",
+		2: "DISABLED",
+	}
+	DisabledFilter_value = map[string]int32{
+		"ANY_STATE": 0,
+		"ENABLED":   1,
+		"DISABLED":  2,
+	}
+)
+
+func (x DisabledFilter) Enum() *DisabledFilter {
+	p := new(DisabledFilter)
+	*p = x
+	return p
+}
+
+func (x DisabledFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisabledFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_extapi_proto_enumTypes[1].Descriptor()
+}
+
+func (DisabledFilter) Type() protoreflect.EnumType {
+	return &file_extapi_proto_enumTypes[1]
+}
+
+func (x DisabledFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisabledFilter.Descriptor instead.
+func (DisabledFilter) EnumDescriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{1}
+}
+
+type MulticastGroupTypeFilter int32
+
+const (
+	// Any type.
+	MulticastGroupTypeFilter_ANY_TYPE MulticastGroupTypeFilter = 0
+	// Class-B.
+	MulticastGroupTypeFilter_CLASS_B MulticastGroupTypeFilter = 1
+	// Class-C.
+	MulticastGroupTypeFilter_CLASS_C MulticastGroupTypeFilter = 2
+)
+
+// Enum value maps for MulticastGroupTypeFilter.
+var (
+	MulticastGroupTypeFilter_name = map[int32]string{
+		0: "ANY_TYPE",
+		1: "CLASS_B",
+		2: "CLASS_C",
+	}
+	MulticastGroupTypeFilter_value = map[string]int32{
+		"ANY_TYPE": 0,
+		"CLASS_B":  1,
+		"CLASS_C":  2,
+	}
+)
+
+func (x MulticastGroupTypeFilter) Enum() *MulticastGroupTypeFilter {
+	p := new(MulticastGroupTypeFilter)
+	*p = x
+	return p
+}
+
+func (x MulticastGroupTypeFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MulticastGroupTypeFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_extapi_proto_enumTypes[2].Descriptor()
+}
+
+func (MulticastGroupTypeFilter) Type() protoreflect.EnumType {
+	return &file_extapi_proto_enumTypes[2]
+}
+
+func (x MulticastGroupTypeFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MulticastGroupTypeFilter.Descriptor instead.
+func (MulticastGroupTypeFilter) EnumDescriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{2}
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Max. number of items to return.
+	// When not set, the default page-size is used.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Page token, as returned by the previous response.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Filter on device-profile ID.
+	DeviceProfileId []byte `protobuf:"bytes,3,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
+	// Filter on service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,4,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Filter on routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,5,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Filter on device mode.
+	Mode DeviceModeFilter `protobuf:"varint,6,opt,name=mode,proto3,enum=extapi.DeviceModeFilter" json:"mode,omitempty"`
+	// Filter on disabled state.
+	Disabled DisabledFilter `protobuf:"varint,7,opt,name=disabled,proto3,enum=extapi.DisabledFilter" json:"disabled,omitempty"`
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListDevicesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDevicesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListDevicesRequest) GetDeviceProfileId() []byte {
+	if x != nil {
+		return x.DeviceProfileId
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetServiceProfileId() []byte {
+	if x != nil {
+		return x.ServiceProfileId
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetRoutingProfileId() []byte {
+	if x != nil {
+		return x.RoutingProfileId
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetMode() DeviceModeFilter {
+	if x != nil {
+		return x.Mode
+	}
+	return DeviceModeFilter_ANY_MODE
+}
+
+func (x *ListDevicesRequest) GetDisabled() DisabledFilter {
+	if x != nil {
+		return x.Disabled
+	}
+	return DisabledFilter_ANY_STATE
+}
+
+type DeviceListItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Device-profile ID.
+	DeviceProfileId []byte `protobuf:"bytes,2,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
+	// Service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,3,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,4,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Device mode (A, B or C).
+	Mode string `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Device is disabled.
+	IsDisabled bool `protobuf:"varint,6,opt,name=is_disabled,json=isDisabled,proto3" json:"is_disabled,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *DeviceListItem) Reset() {
+	*x = DeviceListItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceListItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceListItem) ProtoMessage() {}
+
+func (x *DeviceListItem) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceListItem.ProtoReflect.Descriptor instead.
+func (*DeviceListItem) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeviceListItem) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+func (x *DeviceListItem) GetDeviceProfileId() []byte {
+	if x != nil {
+		return x.DeviceProfileId
+	}
+	return nil
+}
+
+func (x *DeviceListItem) GetServiceProfileId() []byte {
+	if x != nil {
+		return x.ServiceProfileId
+	}
+	return nil
+}
+
+func (x *DeviceListItem) GetRoutingProfileId() []byte {
+	if x != nil {
+		return x.RoutingProfileId
+	}
+	return nil
+}
+
+func (x *DeviceListItem) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *DeviceListItem) GetIsDisabled() bool {
+	if x != nil {
+		return x.IsDisabled
+	}
+	return false
+}
+
+func (x *DeviceListItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeviceListItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Devices.
+	Result []*DeviceListItem `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	// Token for retrieving the next page.
+	// This is empty when there are no more items.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDevicesResponse) GetResult() []*DeviceListItem {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ListDevicesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListGatewaysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Max. number of items to return.
+	// When not set, the default page-size is used.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Page token, as returned by the previous response.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Filter on routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,3,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Filter on service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,4,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Filter on gateway-profile ID.
+	GatewayProfileId []byte `protobuf:"bytes,5,opt,name=gateway_profile_id,json=gatewayProfileId,proto3" json:"gateway_profile_id,omitempty"`
+	// Only return gateways last seen at or after the given timestamp.
+	LastSeenAfter *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen_after,json=lastSeenAfter,proto3" json:"last_seen_after,omitempty"`
+	// Only return gateways last seen before the given timestamp.
+	LastSeenBefore *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen_before,json=lastSeenBefore,proto3" json:"last_seen_before,omitempty"`
+}
+
+func (x *ListGatewaysRequest) Reset() {
+	*x = ListGatewaysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGatewaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatewaysRequest) ProtoMessage() {}
+
+func (x *ListGatewaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatewaysRequest.ProtoReflect.Descriptor instead.
+func (*ListGatewaysRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListGatewaysRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListGatewaysRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListGatewaysRequest) GetRoutingProfileId() []byte {
+	if x != nil {
+		return x.RoutingProfileId
+	}
+	return nil
+}
+
+func (x *ListGatewaysRequest) GetServiceProfileId() []byte {
+	if x != nil {
+		return x.ServiceProfileId
+	}
+	return nil
+}
+
+func (x *ListGatewaysRequest) GetGatewayProfileId() []byte {
+	if x != nil {
+		return x.GatewayProfileId
+	}
+	return nil
+}
+
+func (x *ListGatewaysRequest) GetLastSeenAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAfter
+	}
+	return nil
+}
+
+func (x *ListGatewaysRequest) GetLastSeenBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenBefore
+	}
+	return nil
+}
+
+type GatewayListItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,2,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,3,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Gateway-profile ID.
+	GatewayProfileId []byte `protobuf:"bytes,4,opt,name=gateway_profile_id,json=gatewayProfileId,proto3" json:"gateway_profile_id,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// First seen timestamp.
+	FirstSeenAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	// Last seen timestamp.
+	LastSeenAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+}
+
+func (x *GatewayListItem) Reset() {
+	*x = GatewayListItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatewayListItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewayListItem) ProtoMessage() {}
+
+func (x *GatewayListItem) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewayListItem.ProtoReflect.Descriptor instead.
+func (*GatewayListItem) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GatewayListItem) GetGatewayId() []byte {
+	if x != nil {
+		return x.GatewayId
+	}
+	return nil
+}
+
+func (x *GatewayListItem) GetRoutingProfileId() []byte {
+	if x != nil {
+		return x.RoutingProfileId
+	}
+	return nil
+}
+
+func (x *GatewayListItem) GetServiceProfileId() []byte {
+	if x != nil {
+		return x.ServiceProfileId
+	}
+	return nil
+}
+
+func (x *GatewayListItem) GetGatewayProfileId() []byte {
+	if x != nil {
+		return x.GatewayProfileId
+	}
+	return nil
+}
+
+func (x *GatewayListItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GatewayListItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GatewayListItem) GetFirstSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeenAt
+	}
+	return nil
+}
+
+func (x *GatewayListItem) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+type ListGatewaysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateways.
+	Result []*GatewayListItem `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	// Token for retrieving the next page.
+	// This is empty when there are no more items.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListGatewaysResponse) Reset() {
+	*x = ListGatewaysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGatewaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatewaysResponse) ProtoMessage() {}
+
+func (x *ListGatewaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatewaysResponse.ProtoReflect.Descriptor instead.
+func (*ListGatewaysResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListGatewaysResponse) GetResult() []*GatewayListItem {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ListGatewaysResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListMulticastGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Max. number of items to return.
+	// When not set, the default page-size is used.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Page token, as returned by the previous response.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Filter on service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,3,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Filter on routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,4,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Filter on multicast-group type.
+	GroupType MulticastGroupTypeFilter `protobuf:"varint,5,opt,name=group_type,json=groupType,proto3,enum=extapi.MulticastGroupTypeFilter" json:"group_type,omitempty"`
+}
+
+func (x *ListMulticastGroupsRequest) Reset() {
+	*x = ListMulticastGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMulticastGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMulticastGroupsRequest) ProtoMessage() {}
+
+func (x *ListMulticastGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMulticastGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListMulticastGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListMulticastGroupsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMulticastGroupsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMulticastGroupsRequest) GetServiceProfileId() []byte {
+	if x != nil {
+		return x.ServiceProfileId
+	}
+	return nil
+}
+
+func (x *ListMulticastGroupsRequest) GetRoutingProfileId() []byte {
+	if x != nil {
+		return x.RoutingProfileId
+	}
+	return nil
+}
+
+func (x *ListMulticastGroupsRequest) GetGroupType() MulticastGroupTypeFilter {
+	if x != nil {
+		return x.GroupType
+	}
+	return MulticastGroupTypeFilter_ANY_TYPE
+}
+
+type MulticastGroupListItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Multicast-group ID.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Multicast address.
+	McAddr []byte `protobuf:"bytes,2,opt,name=mc_addr,json=mcAddr,proto3" json:"mc_addr,omitempty"`
+	// Multicast-group type (B or C).
+	GroupType string `protobuf:"bytes,3,opt,name=group_type,json=groupType,proto3" json:"group_type,omitempty"`
+	// Service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,4,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,5,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *MulticastGroupListItem) Reset() {
+	*x = MulticastGroupListItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MulticastGroupListItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MulticastGroupListItem) ProtoMessage() {}
+
+func (x *MulticastGroupListItem) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MulticastGroupListItem.ProtoReflect.Descriptor instead.
+func (*MulticastGroupListItem) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MulticastGroupListItem) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *MulticastGroupListItem) GetMcAddr() []byte {
+	if x != nil {
+		return x.McAddr
+	}
+	return nil
+}
+
+func (x *MulticastGroupListItem) GetGroupType() string {
+	if x != nil {
+		return x.GroupType
+	}
+	return ""
+}
+
+func (x *MulticastGroupListItem) GetServiceProfileId() []byte {
+	if x != nil {
+		return x.ServiceProfileId
+	}
+	return nil
+}
+
+func (x *MulticastGroupListItem) GetRoutingProfileId() []byte {
+	if x != nil {
+		return x.RoutingProfileId
+	}
+	return nil
+}
+
+func (x *MulticastGroupListItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MulticastGroupListItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListMulticastGroupsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Multicast-groups.
+	Result []*MulticastGroupListItem `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	// Token for retrieving the next page.
+	// This is empty when there are no more items.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListMulticastGroupsResponse) Reset() {
+	*x = ListMulticastGroupsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMulticastGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMulticastGroupsResponse) ProtoMessage() {}
+
+func (x *ListMulticastGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMulticastGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListMulticastGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMulticastGroupsResponse) GetResult() []*MulticastGroupListItem {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ListMulticastGroupsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListProfilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Max. number of items to return.
+	// When not set, the default page-size is used.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Page token, as returned by the previous response.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListProfilesRequest) Reset() {
+	*x = ListProfilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfilesRequest) ProtoMessage() {}
+
+func (x *ListProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ListProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListProfilesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProfilesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ProfileListItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Profile ID.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *ProfileListItem) Reset() {
+	*x = ProfileListItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProfileListItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileListItem) ProtoMessage() {}
+
+func (x *ProfileListItem) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileListItem.ProtoReflect.Descriptor instead.
+func (*ProfileListItem) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProfileListItem) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ProfileListItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProfileListItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListProfilesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Profiles.
+	Result []*ProfileListItem `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	// Token for retrieving the next page.
+	// This is empty when there are no more items.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListProfilesResponse) Reset() {
+	*x = ListProfilesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfilesResponse) ProtoMessage() {}
+
+func (x *ListProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ListProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListProfilesResponse) GetResult() []*ProfileListItem {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ListProfilesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type BulkDevice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Device-profile ID.
+	DeviceProfileId []byte `protobuf:"bytes,2,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
+	// Service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,3,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,4,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Skip frame-counter checks (this is insecure, but could be helpful for
+	// debugging).
+	SkipFCntCheck bool `protobuf:"varint,5,opt,name=skip_f_cnt_check,json=skipFCntCheck,proto3" json:"skip_f_cnt_check,omitempty"`
+	// Reference altitude for geolocation.
+	ReferenceAltitude float64 `protobuf:"fixed64,6,opt,name=reference_altitude,json=referenceAltitude,proto3" json:"reference_altitude,omitempty"`
+	// Device is disabled.
+	IsDisabled bool `protobuf:"varint,7,opt,name=is_disabled,json=isDisabled,proto3" json:"is_disabled,omitempty"`
+}
+
+func (x *BulkDevice) Reset() {
+	*x = BulkDevice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDevice) ProtoMessage() {}
+
+func (x *BulkDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDevice.ProtoReflect.Descriptor instead.
+func (*BulkDevice) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BulkDevice) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+func (x *BulkDevice) GetDeviceProfileId() []byte {
+	if x != nil {
+		return x.DeviceProfileId
+	}
+	return nil
+}
+
+func (x *BulkDevice) GetServiceProfileId() []byte {
+	if x != nil {
+		return x.ServiceProfileId
+	}
+	return nil
+}
+
+func (x *BulkDevice) GetRoutingProfileId() []byte {
+	if x != nil {
+		return x.RoutingProfileId
+	}
+	return nil
+}
+
+func (x *BulkDevice) GetSkipFCntCheck() bool {
+	if x != nil {
+		return x.SkipFCntCheck
+	}
+	return false
+}
+
+func (x *BulkDevice) GetReferenceAltitude() float64 {
+	if x != nil {
+		return x.ReferenceAltitude
+	}
+	return 0
+}
+
+func (x *BulkDevice) GetIsDisabled() bool {
+	if x != nil {
+		return x.IsDisabled
+	}
+	return false
+}
+
+type CreateDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Devices to create.
+	Devices []*BulkDevice `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Number of devices to create within a single database transaction.
+	// When not set, the default batch-size is used.
+	BatchSize uint32 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Only validate the devices, without creating them.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *CreateDevicesRequest) Reset() {
+	*x = CreateDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDevicesRequest) ProtoMessage() {}
+
+func (x *CreateDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDevicesRequest.ProtoReflect.Descriptor instead.
+func (*CreateDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateDevicesRequest) GetDevices() []*BulkDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *CreateDevicesRequest) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *CreateDevicesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BulkDeviceActivation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Device address.
+	DevAddr []byte `protobuf:"bytes,2,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	// Serving network session integrity key.
+	SNwkSIntKey []byte `protobuf:"bytes,3,opt,name=s_nwk_s_int_key,json=sNwkSIntKey,proto3" json:"s_nwk_s_int_key,omitempty"`
+	// Forwarding network session integrity key.
+	FNwkSIntKey []byte `protobuf:"bytes,4,opt,name=f_nwk_s_int_key,json=fNwkSIntKey,proto3" json:"f_nwk_s_int_key,omitempty"`
+	// Network session encryption key.
+	NwkSEncKey []byte `protobuf:"bytes,5,opt,name=nwk_s_enc_key,json=nwkSEncKey,proto3" json:"nwk_s_enc_key,omitempty"`
+	// Uplink frame-counter.
+	FCntUp uint32 `protobuf:"varint,6,opt,name=f_cnt_up,json=fCntUp,proto3" json:"f_cnt_up,omitempty"`
+	// Downlink network frame-counter.
+	NFCntDown uint32 `protobuf:"varint,7,opt,name=n_f_cnt_down,json=nFCntDown,proto3" json:"n_f_cnt_down,omitempty"`
+	// Downlink application frame-counter.
+	AFCntDown uint32 `protobuf:"varint,8,opt,name=a_f_cnt_down,json=aFCntDown,proto3" json:"a_f_cnt_down,omitempty"`
+	// Skip frame-counter checks (this is insecure, but could be helpful for
+	// debugging).
+	SkipFCntCheck bool `protobuf:"varint,9,opt,name=skip_f_cnt_check,json=skipFCntCheck,proto3" json:"skip_f_cnt_check,omitempty"`
+}
+
+func (x *BulkDeviceActivation) Reset() {
+	*x = BulkDeviceActivation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkDeviceActivation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDeviceActivation) ProtoMessage() {}
+
+func (x *BulkDeviceActivation) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDeviceActivation.ProtoReflect.Descriptor instead.
+func (*BulkDeviceActivation) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BulkDeviceActivation) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+func (x *BulkDeviceActivation) GetDevAddr() []byte {
+	if x != nil {
+		return x.DevAddr
+	}
+	return nil
+}
+
+func (x *BulkDeviceActivation) GetSNwkSIntKey() []byte {
+	if x != nil {
+		return x.SNwkSIntKey
+	}
+	return nil
+}
+
+func (x *BulkDeviceActivation) GetFNwkSIntKey() []byte {
+	if x != nil {
+		return x.FNwkSIntKey
+	}
+	return nil
+}
+
+func (x *BulkDeviceActivation) GetNwkSEncKey() []byte {
+	if x != nil {
+		return x.NwkSEncKey
+	}
+	return nil
+}
+
+func (x *BulkDeviceActivation) GetFCntUp() uint32 {
+	if x != nil {
+		return x.FCntUp
+	}
+	return 0
+}
+
+func (x *BulkDeviceActivation) GetNFCntDown() uint32 {
+	if x != nil {
+		return x.NFCntDown
+	}
+	return 0
+}
+
+func (x *BulkDeviceActivation) GetAFCntDown() uint32 {
+	if x != nil {
+		return x.AFCntDown
+	}
+	return 0
+}
+
+func (x *BulkDeviceActivation) GetSkipFCntCheck() bool {
+	if x != nil {
+		return x.SkipFCntCheck
+	}
+	return false
+}
+
+type ActivateDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device activations.
+	DeviceActivations []*BulkDeviceActivation `protobuf:"bytes,1,rep,name=device_activations,json=deviceActivations,proto3" json:"device_activations,omitempty"`
+	// Number of devices to activate within a single database transaction.
+	// When not set, the default batch-size is used.
+	BatchSize uint32 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Only validate the device activations, without activating the devices.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *ActivateDevicesRequest) Reset() {
+	*x = ActivateDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateDevicesRequest) ProtoMessage() {}
+
+func (x *ActivateDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ActivateDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ActivateDevicesRequest) GetDeviceActivations() []*BulkDeviceActivation {
+	if x != nil {
+		return x.DeviceActivations
+	}
+	return nil
+}
+
+func (x *ActivateDevicesRequest) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ActivateDevicesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BulkItemResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// gRPC status code (0 = OK).
+	Code uint32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	// Error message (empty on success).
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BulkItemResult) Reset() {
+	*x = BulkItemResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkItemResult) ProtoMessage() {}
+
+func (x *BulkItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkItemResult.ProtoReflect.Descriptor instead.
+func (*BulkItemResult) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BulkItemResult) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+func (x *BulkItemResult) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BulkItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BulkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Result per item, in the order of the request.
+	Result []*BulkItemResult `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	// Number of items that were processed successfully.
+	SuccessCount uint32 `protobuf:"varint,2,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	// Number of items that failed.
+	ErrorCount uint32 `protobuf:"varint,3,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+}
+
+func (x *BulkResponse) Reset() {
+	*x = BulkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkResponse) ProtoMessage() {}
+
+func (x *BulkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkResponse.ProtoReflect.Descriptor instead.
+func (*BulkResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BulkResponse) GetResult() []*BulkItemResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BulkResponse) GetSuccessCount() uint32 {
+	if x != nil {
+		return x.SuccessCount
+	}
+	return 0
+}
+
+func (x *BulkResponse) GetErrorCount() uint32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+type GetDeviceEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Max. number of items to return.
+	// When not set, the default page-size is used.
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Page token, as returned by the previous response.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *GetDeviceEventsRequest) Reset() {
+	*x = GetDeviceEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeviceEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceEventsRequest) ProtoMessage() {}
+
+func (x *GetDeviceEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceEventsRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetDeviceEventsRequest) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+func (x *GetDeviceEventsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetDeviceEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type DeviceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Event ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Event type (e.g. join, rejoin, adr_change_request, mac_command_ack,
+	// mac_command_error, mac_command, device_disabled or mode_change).
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Event timestamp.
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Context ID of the request or uplink which triggered the event.
+	CtxId string `protobuf:"bytes,4,opt,name=ctx_id,json=ctxId,proto3" json:"ctx_id,omitempty"`
+	// Changed values before the event.
+	Before *_struct.Struct `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	// Changed values after the event.
+	After *_struct.Struct `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	// Event details.
+	Details *_struct.Struct `protobuf:"bytes,7,opt,name=details,proto3" json:"details,omitempty"`
+}
+
+func (x *DeviceEvent) Reset() {
+	*x = DeviceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceEvent) ProtoMessage() {}
+
+func (x *DeviceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceEvent.ProtoReflect.Descriptor instead.
+func (*DeviceEvent) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeviceEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeviceEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DeviceEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *DeviceEvent) GetCtxId() string {
+	if x != nil {
+		return x.CtxId
+	}
+	return ""
+}
+
+func (x *DeviceEvent) GetBefore() *_struct.Struct {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *DeviceEvent) GetAfter() *_struct.Struct {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *DeviceEvent) GetDetails() *_struct.Struct {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type GetDeviceEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device events.
+	Result []*DeviceEvent `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	// Token for retrieving the next page.
+	// This is empty when there are no more items.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *GetDeviceEventsResponse) Reset() {
+	*x = GetDeviceEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeviceEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceEventsResponse) ProtoMessage() {}
+
+func (x *GetDeviceEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceEventsResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetDeviceEventsResponse) GetResult() []*DeviceEvent {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetDeviceEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeviceProfileSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device-profile ID.
+	DeviceProfileId []byte `protobuf:"bytes,1,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
+	// Number of uplinks to keep in the uplink history (used by ADR).
+	// When set to 0, the default (20) is used. Valid values are 20 - 200.
+	UplinkHistorySize uint32 `protobuf:"varint,2,opt,name=uplink_history_size,json=uplinkHistorySize,proto3" json:"uplink_history_size,omitempty"`
+	// ADR script (JavaScript).
+	// This script is used when the ADR algorithm of the device-profile is
+	// set to 'device_profile_script'. The script must define the handle
+	// function, which receives the ADR request and must return an object
+	// containing the dr, tx_power_index and nb_trans.
+	AdrScript string `protobuf:"bytes,3,opt,name=adr_script,json=adrScript,proto3" json:"adr_script,omitempty"`
+	// Device quirks (work-arounds for device firmware issues).
+	Quirks *DeviceQuirks `protobuf:"bytes,4,opt,name=quirks,proto3" json:"quirks,omitempty"`
+	// Channel-plan ID.
+	// When set, the devices are configured with the channels of the
+	// channel-plan instead of the extra channels of the network.
+	ChannelPlanId []byte `protobuf:"bytes,5,opt,name=channel_plan_id,json=channelPlanId,proto3" json:"channel_plan_id,omitempty"`
+}
+
+func (x *DeviceProfileSettings) Reset() {
+	*x = DeviceProfileSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceProfileSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceProfileSettings) ProtoMessage() {}
+
+func (x *DeviceProfileSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceProfileSettings.ProtoReflect.Descriptor instead.
+func (*DeviceProfileSettings) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeviceProfileSettings) GetDeviceProfileId() []byte {
+	if x != nil {
+		return x.DeviceProfileId
+	}
+	return nil
+}
+
+func (x *DeviceProfileSettings) GetUplinkHistorySize() uint32 {
+	if x != nil {
+		return x.UplinkHistorySize
+	}
+	return 0
+}
+
+func (x *DeviceProfileSettings) GetAdrScript() string {
+	if x != nil {
+		return x.AdrScript
+	}
+	return ""
+}
+
+func (x *DeviceProfileSettings) GetQuirks() *DeviceQuirks {
+	if x != nil {
+		return x.Quirks
+	}
+	return nil
+}
+
+func (x *DeviceProfileSettings) GetChannelPlanId() []byte {
+	if x != nil {
+		return x.ChannelPlanId
+	}
+	return nil
+}
+
+type DeviceQuirks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The device does not support tx-power index 0 and nACKs the
+	// LinkADRReq requesting it (e.g. RN2483 firmware 1.0.3).
+	TxPower_0Unsupported bool `protobuf:"varint,1,opt,name=tx_power_0_unsupported,json=txPower0Unsupported,proto3" json:"tx_power_0_unsupported,omitempty"`
+	// Ignore the channel-mask nACK of the LinkADRAns.
+	IgnoreChannelMaskNack bool `protobuf:"varint,2,opt,name=ignore_channel_mask_nack,json=ignoreChannelMaskNack,proto3" json:"ignore_channel_mask_nack,omitempty"`
+	// Do not send the RXTimingSetupReq mac-command.
+	NoRxtimingsetup bool `protobuf:"varint,3,opt,name=no_rxtimingsetup,json=noRxtimingsetup,proto3" json:"no_rxtimingsetup,omitempty"`
+	// Max. number of mac-command bytes in the FOpts field (max. 15).
+	// When set to 0, the default (15) is used.
+	MaxFoptsLen uint32 `protobuf:"varint,4,opt,name=max_fopts_len,json=maxFoptsLen,proto3" json:"max_fopts_len,omitempty"`
+}
+
+func (x *DeviceQuirks) Reset() {
+	*x = DeviceQuirks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceQuirks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceQuirks) ProtoMessage() {}
+
+func (x *DeviceQuirks) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceQuirks.ProtoReflect.Descriptor instead.
+func (*DeviceQuirks) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeviceQuirks) GetTxPower_0Unsupported() bool {
+	if x != nil {
+		return x.TxPower_0Unsupported
+	}
+	return false
+}
+
+func (x *DeviceQuirks) GetIgnoreChannelMaskNack() bool {
+	if x != nil {
+		return x.IgnoreChannelMaskNack
+	}
+	return false
+}
+
+func (x *DeviceQuirks) GetNoRxtimingsetup() bool {
+	if x != nil {
+		return x.NoRxtimingsetup
+	}
+	return false
+}
+
+func (x *DeviceQuirks) GetMaxFoptsLen() uint32 {
+	if x != nil {
+		return x.MaxFoptsLen
+	}
+	return 0
+}
+
+type GetDeviceProfileSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device-profile ID.
+	DeviceProfileId []byte `protobuf:"bytes,1,opt,name=device_profile_id,json=deviceProfileId,proto3" json:"device_profile_id,omitempty"`
+}
+
+func (x *GetDeviceProfileSettingsRequest) Reset() {
+	*x = GetDeviceProfileSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeviceProfileSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceProfileSettingsRequest) ProtoMessage() {}
+
+func (x *GetDeviceProfileSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceProfileSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceProfileSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDeviceProfileSettingsRequest) GetDeviceProfileId() []byte {
+	if x != nil {
+		return x.DeviceProfileId
+	}
+	return nil
+}
+
+type GetDeviceProfileSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device-profile settings.
+	Settings *DeviceProfileSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *GetDeviceProfileSettingsResponse) Reset() {
+	*x = GetDeviceProfileSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeviceProfileSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceProfileSettingsResponse) ProtoMessage() {}
+
+func (x *GetDeviceProfileSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceProfileSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceProfileSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetDeviceProfileSettingsResponse) GetSettings() *DeviceProfileSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateDeviceProfileSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device-profile settings.
+	Settings *DeviceProfileSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *UpdateDeviceProfileSettingsRequest) Reset() {
+	*x = UpdateDeviceProfileSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDeviceProfileSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDeviceProfileSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateDeviceProfileSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDeviceProfileSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDeviceProfileSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateDeviceProfileSettingsRequest) GetSettings() *DeviceProfileSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type ChannelPlanChannel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Uplink frequency (Hz).
+	// This frequency must be configured as extra channel of the network.
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Min. data-rate.
+	MinDr uint32 `protobuf:"varint,2,opt,name=min_dr,json=minDr,proto3" json:"min_dr,omitempty"`
+	// Max. data-rate.
+	MaxDr uint32 `protobuf:"varint,3,opt,name=max_dr,json=maxDr,proto3" json:"max_dr,omitempty"`
+	// Downlink (RX1) frequency (Hz).
+	// When set to 0, the default RX1 frequency is used.
+	DownlinkFrequency uint32 `protobuf:"varint,4,opt,name=downlink_frequency,json=downlinkFrequency,proto3" json:"downlink_frequency,omitempty"`
+}
+
+func (x *ChannelPlanChannel) Reset() {
+	*x = ChannelPlanChannel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelPlanChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelPlanChannel) ProtoMessage() {}
+
+func (x *ChannelPlanChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelPlanChannel.ProtoReflect.Descriptor instead.
+func (*ChannelPlanChannel) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ChannelPlanChannel) GetFrequency() uint32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *ChannelPlanChannel) GetMinDr() uint32 {
+	if x != nil {
+		return x.MinDr
+	}
+	return 0
+}
+
+func (x *ChannelPlanChannel) GetMaxDr() uint32 {
+	if x != nil {
+		return x.MaxDr
+	}
+	return 0
+}
+
+func (x *ChannelPlanChannel) GetDownlinkFrequency() uint32 {
+	if x != nil {
+		return x.DownlinkFrequency
+	}
+	return 0
+}
+
+type ChannelPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Channel-plan ID.
+	// This will be automatically assigned on create.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the channel-plan.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Channels.
+	Channels []*ChannelPlanChannel `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *ChannelPlan) Reset() {
+	*x = ChannelPlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelPlan) ProtoMessage() {}
+
+func (x *ChannelPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelPlan.ProtoReflect.Descriptor instead.
+func (*ChannelPlan) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ChannelPlan) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ChannelPlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChannelPlan) GetChannels() []*ChannelPlanChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+type CreateChannelPlanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Channel-plan to create.
+	ChannelPlan *ChannelPlan `protobuf:"bytes,1,opt,name=channel_plan,json=channelPlan,proto3" json:"channel_plan,omitempty"`
+}
+
+func (x *CreateChannelPlanRequest) Reset() {
+	*x = CreateChannelPlanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateChannelPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChannelPlanRequest) ProtoMessage() {}
+
+func (x *CreateChannelPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChannelPlanRequest.ProtoReflect.Descriptor instead.
+func (*CreateChannelPlanRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateChannelPlanRequest) GetChannelPlan() *ChannelPlan {
+	if x != nil {
+		return x.ChannelPlan
+	}
+	return nil
+}
+
+type CreateChannelPlanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the created channel-plan.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CreateChannelPlanResponse) Reset() {
+	*x = CreateChannelPlanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateChannelPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChannelPlanResponse) ProtoMessage() {}
+
+func (x *CreateChannelPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChannelPlanResponse.ProtoReflect.Descriptor instead.
+func (*CreateChannelPlanResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateChannelPlanResponse) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetChannelPlanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Channel-plan ID.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetChannelPlanRequest) Reset() {
+	*x = GetChannelPlanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChannelPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChannelPlanRequest) ProtoMessage() {}
+
+func (x *GetChannelPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChannelPlanRequest.ProtoReflect.Descriptor instead.
+func (*GetChannelPlanRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetChannelPlanRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetChannelPlanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Channel-plan.
+	ChannelPlan *ChannelPlan `protobuf:"bytes,1,opt,name=channel_plan,json=channelPlan,proto3" json:"channel_plan,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *GetChannelPlanResponse) Reset() {
+	*x = GetChannelPlanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChannelPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChannelPlanResponse) ProtoMessage() {}
+
+func (x *GetChannelPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChannelPlanResponse.ProtoReflect.Descriptor instead.
+func (*GetChannelPlanResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetChannelPlanResponse) GetChannelPlan() *ChannelPlan {
+	if x != nil {
+		return x.ChannelPlan
+	}
+	return nil
+}
+
+func (x *GetChannelPlanResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetChannelPlanResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UpdateChannelPlanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Channel-plan to update.
+	ChannelPlan *ChannelPlan `protobuf:"bytes,1,opt,name=channel_plan,json=channelPlan,proto3" json:"channel_plan,omitempty"`
+}
+
+func (x *UpdateChannelPlanRequest) Reset() {
+	*x = UpdateChannelPlanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateChannelPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateChannelPlanRequest) ProtoMessage() {}
+
+func (x *UpdateChannelPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateChannelPlanRequest.ProtoReflect.Descriptor instead.
+func (*UpdateChannelPlanRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateChannelPlanRequest) GetChannelPlan() *ChannelPlan {
+	if x != nil {
+		return x.ChannelPlan
+	}
+	return nil
+}
+
+type DeleteChannelPlanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Channel-plan ID.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteChannelPlanRequest) Reset() {
+	*x = DeleteChannelPlanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteChannelPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChannelPlanRequest) ProtoMessage() {}
+
+func (x *DeleteChannelPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChannelPlanRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChannelPlanRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteChannelPlanRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetDeviceChannelPlanStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+}
+
+func (x *GetDeviceChannelPlanStatusRequest) Reset() {
+	*x = GetDeviceChannelPlanStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeviceChannelPlanStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceChannelPlanStatusRequest) ProtoMessage() {}
+
+func (x *GetDeviceChannelPlanStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceChannelPlanStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceChannelPlanStatusRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetDeviceChannelPlanStatusRequest) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+type DeviceChannelStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Uplink channel index.
+	Channel uint32 `protobuf:"varint,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Channel of the channel-plan.
+	PlanChannel *ChannelPlanChannel `protobuf:"bytes,2,opt,name=plan_channel,json=planChannel,proto3" json:"plan_channel,omitempty"`
+	// The channel has been configured on the device (NewChannelReq).
+	UplinkConfigured bool `protobuf:"varint,3,opt,name=uplink_configured,json=uplinkConfigured,proto3" json:"uplink_configured,omitempty"`
+	// The channel has been enabled on the device (LinkADRReq).
+	UplinkEnabled bool `protobuf:"varint,4,opt,name=uplink_enabled,json=uplinkEnabled,proto3" json:"uplink_enabled,omitempty"`
+	// The downlink frequency has been configured on the device
+	// (DlChannelReq).
+	DownlinkConfigured bool `protobuf:"varint,5,opt,name=downlink_configured,json=downlinkConfigured,proto3" json:"downlink_configured,omitempty"`
+}
+
+func (x *DeviceChannelStatus) Reset() {
+	*x = DeviceChannelStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceChannelStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceChannelStatus) ProtoMessage() {}
+
+func (x *DeviceChannelStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceChannelStatus.ProtoReflect.Descriptor instead.
+func (*DeviceChannelStatus) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeviceChannelStatus) GetChannel() uint32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *DeviceChannelStatus) GetPlanChannel() *ChannelPlanChannel {
+	if x != nil {
+		return x.PlanChannel
+	}
+	return nil
+}
+
+func (x *DeviceChannelStatus) GetUplinkConfigured() bool {
+	if x != nil {
+		return x.UplinkConfigured
+	}
+	return false
+}
+
+func (x *DeviceChannelStatus) GetUplinkEnabled() bool {
+	if x != nil {
+		return x.UplinkEnabled
+	}
+	return false
+}
+
+func (x *DeviceChannelStatus) GetDownlinkConfigured() bool {
+	if x != nil {
+		return x.DownlinkConfigured
+	}
+	return false
+}
+
+type GetDeviceChannelPlanStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Channel-plan ID.
+	ChannelPlanId []byte `protobuf:"bytes,1,opt,name=channel_plan_id,json=channelPlanId,proto3" json:"channel_plan_id,omitempty"`
+	// Status of the channels of the channel-plan.
+	Channels []*DeviceChannelStatus `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+	// Channels enabled on the device which are not part of the
+	// channel-plan and which are pending removal.
+	PendingRemovalChannels []uint32 `protobuf:"varint,3,rep,packed,name=pending_removal_channels,json=pendingRemovalChannels,proto3" json:"pending_removal_channels,omitempty"`
+	// The device is configured according to the channel-plan.
+	InSync bool `protobuf:"varint,4,opt,name=in_sync,json=inSync,proto3" json:"in_sync,omitempty"`
+}
+
+func (x *GetDeviceChannelPlanStatusResponse) Reset() {
+	*x = GetDeviceChannelPlanStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeviceChannelPlanStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceChannelPlanStatusResponse) ProtoMessage() {}
+
+func (x *GetDeviceChannelPlanStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceChannelPlanStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceChannelPlanStatusResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetDeviceChannelPlanStatusResponse) GetChannelPlanId() []byte {
+	if x != nil {
+		return x.ChannelPlanId
+	}
+	return nil
+}
+
+func (x *GetDeviceChannelPlanStatusResponse) GetChannels() []*DeviceChannelStatus {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *GetDeviceChannelPlanStatusResponse) GetPendingRemovalChannels() []uint32 {
+	if x != nil {
+		return x.PendingRemovalChannels
+	}
+	return nil
+}
+
+func (x *GetDeviceChannelPlanStatusResponse) GetInSync() bool {
+	if x != nil {
+		return x.InSync
+	}
+	return false
+}
+
+type GetGatewayConfigStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+}
+
+func (x *GetGatewayConfigStateRequest) Reset() {
+	*x = GetGatewayConfigStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGatewayConfigStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGatewayConfigStateRequest) ProtoMessage() {}
+
+func (x *GetGatewayConfigStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGatewayConfigStateRequest.ProtoReflect.Descriptor instead.
+func (*GetGatewayConfigStateRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetGatewayConfigStateRequest) GetGatewayId() []byte {
+	if x != nil {
+		return x.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayConfigStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Configuration translator.
+	// When empty, only gateways using the ChirpStack Concentratord are
+	// configured.
+	Translator string `protobuf:"bytes,1,opt,name=translator,proto3" json:"translator,omitempty"`
+	// Configuration version of the gateway-profile, including the gateway
+	// overrides.
+	// This is empty when the gateway does not have a gateway-profile.
+	ProfileVersion string `protobuf:"bytes,2,opt,name=profile_version,json=profileVersion,proto3" json:"profile_version,omitempty"`
+	// Last configuration version reported by the gateway.
+	AppliedVersion string `protobuf:"bytes,3,opt,name=applied_version,json=appliedVersion,proto3" json:"applied_version,omitempty"`
+	// Timestamp of the last reported configuration version.
+	AppliedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	// Configuration version sent to the gateway, but not (yet) reported
+	// by the gateway.
+	PendingVersion string `protobuf:"bytes,5,opt,name=pending_version,json=pendingVersion,proto3" json:"pending_version,omitempty"`
+	// Timestamp of sending the pending configuration version.
+	PendingAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=pending_at,json=pendingAt,proto3" json:"pending_at,omitempty"`
+}
+
+func (x *GetGatewayConfigStateResponse) Reset() {
+	*x = GetGatewayConfigStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGatewayConfigStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGatewayConfigStateResponse) ProtoMessage() {}
+
+func (x *GetGatewayConfigStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGatewayConfigStateResponse.ProtoReflect.Descriptor instead.
+func (*GetGatewayConfigStateResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetGatewayConfigStateResponse) GetTranslator() string {
+	if x != nil {
+		return x.Translator
+	}
+	return ""
+}
+
+func (x *GetGatewayConfigStateResponse) GetProfileVersion() string {
+	if x != nil {
+		return x.ProfileVersion
+	}
+	return ""
+}
+
+func (x *GetGatewayConfigStateResponse) GetAppliedVersion() string {
+	if x != nil {
+		return x.AppliedVersion
+	}
+	return ""
+}
+
+func (x *GetGatewayConfigStateResponse) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *GetGatewayConfigStateResponse) GetPendingVersion() string {
+	if x != nil {
+		return x.PendingVersion
+	}
+	return ""
+}
+
+func (x *GetGatewayConfigStateResponse) GetPendingAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PendingAt
+	}
+	return nil
+}
+
+type UpdateGatewayConfigTranslatorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Configuration translator (concentratord, packet_forwarder or
+	// basic_station).
+	// When empty, only gateways using the ChirpStack Concentratord are
+	// configured.
+	Translator string `protobuf:"bytes,2,opt,name=translator,proto3" json:"translator,omitempty"`
+}
+
+func (x *UpdateGatewayConfigTranslatorRequest) Reset() {
+	*x = UpdateGatewayConfigTranslatorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateGatewayConfigTranslatorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGatewayConfigTranslatorRequest) ProtoMessage() {}
+
+func (x *UpdateGatewayConfigTranslatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGatewayConfigTranslatorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGatewayConfigTranslatorRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateGatewayConfigTranslatorRequest) GetGatewayId() []byte {
+	if x != nil {
+		return x.GatewayId
+	}
+	return nil
+}
+
+func (x *UpdateGatewayConfigTranslatorRequest) GetTranslator() string {
+	if x != nil {
+		return x.Translator
+	}
+	return ""
+}
+
+type GatewayOverrides struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Frequencies (Hz) of the gateway-profile channels to configure.
+	// When empty, all the gateway-profile channels are configured. This
+	// requires the gateway to have a gateway-profile.
+	Channels []uint32 `protobuf:"varint,1,rep,packed,name=channels,proto3" json:"channels,omitempty"`
+	// Overrides per gateway board.
+	Boards []*GatewayBoardOverrides `protobuf:"bytes,2,rep,name=boards,proto3" json:"boards,omitempty"`
+	// Antenna gain (dBi).
+	// The downlink TX power is reduced by this gain.
+	AntennaGain int32 `protobuf:"varint,3,opt,name=antenna_gain,json=antennaGain,proto3" json:"antenna_gain,omitempty"`
+	// Max. downlink EIRP (dBm).
+	// When not set, the network-server or band default is used.
+	MaxTxPower *wrappers.Int32Value `protobuf:"bytes,4,opt,name=max_tx_power,json=maxTxPower,proto3" json:"max_tx_power,omitempty"`
+	// Listen-before-talk configuration.
+	// When not set, LBT is disabled. This is only supported by the
+	// packet_forwarder translator.
+	Lbt *GatewayLBT `protobuf:"bytes,5,opt,name=lbt,proto3" json:"lbt,omitempty"`
+	// Disable downlink for the gateway.
+	DownlinkDisabled bool `protobuf:"varint,6,opt,name=downlink_disabled,json=downlinkDisabled,proto3" json:"downlink_disabled,omitempty"`
+}
+
+func (x *GatewayOverrides) Reset() {
+	*x = GatewayOverrides{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatewayOverrides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewayOverrides) ProtoMessage() {}
+
+func (x *GatewayOverrides) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewayOverrides.ProtoReflect.Descriptor instead.
+func (*GatewayOverrides) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GatewayOverrides) GetChannels() []uint32 {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *GatewayOverrides) GetBoards() []*GatewayBoardOverrides {
+	if x != nil {
+		return x.Boards
+	}
+	return nil
+}
+
+func (x *GatewayOverrides) GetAntennaGain() int32 {
+	if x != nil {
+		return x.AntennaGain
+	}
+	return 0
+}
+
+func (x *GatewayOverrides) GetMaxTxPower() *wrappers.Int32Value {
+	if x != nil {
+		return x.MaxTxPower
+	}
+	return nil
+}
+
+func (x *GatewayOverrides) GetLbt() *GatewayLBT {
+	if x != nil {
+		return x.Lbt
+	}
+	return nil
+}
+
+func (x *GatewayOverrides) GetDownlinkDisabled() bool {
+	if x != nil {
+		return x.DownlinkDisabled
+	}
+	return false
+}
+
+type GatewayBoardOverrides struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Center frequencies (Hz) of the board radios.
+	// When empty, these are calculated from the channels. This is only
+	// supported for the first board and not by the concentratord translator.
+	RadioFrequencies []uint32 `protobuf:"varint,1,rep,packed,name=radio_frequencies,json=radioFrequencies,proto3" json:"radio_frequencies,omitempty"`
+	// Disable downlink for the board.
+	DownlinkDisabled bool `protobuf:"varint,2,opt,name=downlink_disabled,json=downlinkDisabled,proto3" json:"downlink_disabled,omitempty"`
+}
+
+func (x *GatewayBoardOverrides) Reset() {
+	*x = GatewayBoardOverrides{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatewayBoardOverrides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewayBoardOverrides) ProtoMessage() {}
+
+func (x *GatewayBoardOverrides) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewayBoardOverrides.ProtoReflect.Descriptor instead.
+func (*GatewayBoardOverrides) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GatewayBoardOverrides) GetRadioFrequencies() []uint32 {
+	if x != nil {
+		return x.RadioFrequencies
+	}
+	return nil
+}
+
+func (x *GatewayBoardOverrides) GetDownlinkDisabled() bool {
+	if x != nil {
+		return x.DownlinkDisabled
+	}
+	return false
+}
+
+type GatewayLBT struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RSSI target (dBm).
+	RssiTarget int32 `protobuf:"varint,1,opt,name=rssi_target,json=rssiTarget,proto3" json:"rssi_target,omitempty"`
+	// Channel scan time (µs), 128 or 5000.
+	ScanTimeUs uint32 `protobuf:"varint,2,opt,name=scan_time_us,json=scanTimeUs,proto3" json:"scan_time_us,omitempty"`
+}
+
+func (x *GatewayLBT) Reset() {
+	*x = GatewayLBT{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GatewayLBT) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatewayLBT) ProtoMessage() {}
+
+func (x *GatewayLBT) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatewayLBT.ProtoReflect.Descriptor instead.
+func (*GatewayLBT) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GatewayLBT) GetRssiTarget() int32 {
+	if x != nil {
+		return x.RssiTarget
+	}
+	return 0
+}
+
+func (x *GatewayLBT) GetScanTimeUs() uint32 {
+	if x != nil {
+		return x.ScanTimeUs
+	}
+	return 0
+}
+
+type GetGatewayOverridesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+}
+
+func (x *GetGatewayOverridesRequest) Reset() {
+	*x = GetGatewayOverridesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGatewayOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGatewayOverridesRequest) ProtoMessage() {}
+
+func (x *GetGatewayOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGatewayOverridesRequest.ProtoReflect.Descriptor instead.
+func (*GetGatewayOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetGatewayOverridesRequest) GetGatewayId() []byte {
+	if x != nil {
+		return x.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayOverridesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateway overrides.
+	Overrides *GatewayOverrides `protobuf:"bytes,1,opt,name=overrides,proto3" json:"overrides,omitempty"`
+}
+
+func (x *GetGatewayOverridesResponse) Reset() {
+	*x = GetGatewayOverridesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGatewayOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGatewayOverridesResponse) ProtoMessage() {}
+
+func (x *GetGatewayOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGatewayOverridesResponse.ProtoReflect.Descriptor instead.
+func (*GetGatewayOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetGatewayOverridesResponse) GetOverrides() *GatewayOverrides {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+type UpdateGatewayOverridesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Gateway overrides.
+	Overrides *GatewayOverrides `protobuf:"bytes,2,opt,name=overrides,proto3" json:"overrides,omitempty"`
+}
+
+func (x *UpdateGatewayOverridesRequest) Reset() {
+	*x = UpdateGatewayOverridesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateGatewayOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGatewayOverridesRequest) ProtoMessage() {}
+
+func (x *UpdateGatewayOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGatewayOverridesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGatewayOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpdateGatewayOverridesRequest) GetGatewayId() []byte {
+	if x != nil {
+		return x.GatewayId
+	}
+	return nil
+}
+
+func (x *UpdateGatewayOverridesRequest) GetOverrides() *GatewayOverrides {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+type DeviceQueueItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Encrypted FRMPayload.
+	FrmPayload []byte `protobuf:"bytes,2,opt,name=frm_payload,json=frmPayload,proto3" json:"frm_payload,omitempty"`
+	// Frame-counter used for the encryption.
+	FCnt uint32 `protobuf:"varint,3,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// FPort.
+	FPort uint32 `protobuf:"varint,4,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
+	// Confirmed downlink.
+	Confirmed bool `protobuf:"varint,5,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	// Device address used for the encryption (optional).
+	DevAddr []byte `protobuf:"bytes,6,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	// Priority (-32768 - 32767, default 0).
+	// Items with a higher priority are transmitted first, items with the
+	// same priority are transmitted in the order in which these were
+	// created. The frame-counter is assigned at transmission time, in which
+	// case the item is re-encrypted by the application-server.
+	Priority int32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *DeviceQueueItem) Reset() {
+	*x = DeviceQueueItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceQueueItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceQueueItem) ProtoMessage() {}
+
+func (x *DeviceQueueItem) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceQueueItem.ProtoReflect.Descriptor instead.
+func (*DeviceQueueItem) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeviceQueueItem) GetDevEui() []byte {
+	if x != nil {
+		return x.DevEui
+	}
+	return nil
+}
+
+func (x *DeviceQueueItem) GetFrmPayload() []byte {
+	if x != nil {
+		return x.FrmPayload
+	}
+	return nil
+}
+
+func (x *DeviceQueueItem) GetFCnt() uint32 {
+	if x != nil {
+		return x.FCnt
+	}
+	return 0
+}
+
+func (x *DeviceQueueItem) GetFPort() uint32 {
+	if x != nil {
+		return x.FPort
+	}
+	return 0
+}
+
+func (x *DeviceQueueItem) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *DeviceQueueItem) GetDevAddr() []byte {
+	if x != nil {
+		return x.DevAddr
+	}
+	return nil
+}
+
+func (x *DeviceQueueItem) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type CreateDeviceQueueItemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Device-queue item.
+	Item *DeviceQueueItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *CreateDeviceQueueItemRequest) Reset() {
+	*x = CreateDeviceQueueItemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extapi_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDeviceQueueItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDeviceQueueItemRequest) ProtoMessage() {}
+
+func (x *CreateDeviceQueueItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extapi_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDeviceQueueItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
+	return file_extapi_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateDeviceQueueItemRequest) GetItem() *DeviceQueueItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+var File_extapi_proto protoreflect.FileDescriptor
+
+var file_extapi_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xb3, 0x02, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2a,
+	0x0a, 0x11, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xdc, 0x02, 0x0a, 0x0e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x65, 0x76, 0x5f, 0x65, 0x75, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x65,
+	0x76, 0x45, 0x75, 0x69, 0x12, 0x2a, 0x0a, 0x11, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64,
+	0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c,
+	0x0a, 0x12, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6d, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xde, 0x02, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x47,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x10, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49,
+	0x64, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12,
+	0x2c, 0x0a, 0x12, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x42, 0x0a,
+	0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x41, 0x66, 0x74, 0x65,
+	0x72, 0x12, 0x44, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x62,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65,
+	0x6e, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0xae, 0x03, 0x0a, 0x0f, 0x47, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x10, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3e, 0x0a, 0x0d, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x22, 0x6f, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2f, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xee, 0x01, 0x0a, 0x1a, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2c, 0x0a,
+	0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x3f, 0x0a, 0x0a, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e,
+	0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x22, 0xb2, 0x02, 0x0a, 0x16, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x63, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6d, 0x63, 0x41, 0x64, 0x64, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a,
+	0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x7d, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73,
+	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x4a,
+	0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x97, 0x01, 0x0a, 0x0f, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x6f, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65,
+	0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a,
+	0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xa6, 0x02, 0x0a, 0x0a, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x5f, 0x65, 0x75, 0x69, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x65, 0x76, 0x45, 0x75, 0x69, 0x12, 0x2a, 0x0a,
+	0x11, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x5f,
+	0x63, 0x6e, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x73, 0x6b, 0x69, 0x70, 0x46, 0x43, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x2d,
+	0x0a, 0x12, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x61, 0x6c, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x69, 0x73, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x7c,
+	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69,
+	0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0xbe, 0x02, 0x0a,
+	0x14, 0x42, 0x75, 0x6c, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x5f, 0x65, 0x75, 0x69,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x65, 0x76, 0x45, 0x75, 0x69, 0x12, 0x19,
+	0x0a, 0x08, 0x64, 0x65, 0x76, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x64, 0x65, 0x76, 0x41, 0x64, 0x64, 0x72, 0x12, 0x24, 0x0a, 0x0f, 0x73, 0x5f, 0x6e,
+	0x77, 0x6b, 0x5f, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x73, 0x4e, 0x77, 0x6b, 0x53, 0x49, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x12,
+	0x24, 0x0a, 0x0f, 0x66, 0x5f, 0x6e, 0x77, 0x6b, 0x5f, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x66, 0x4e, 0x77, 0x6b, 0x53, 0x49,
+	0x6e, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0d, 0x6e, 0x77, 0x6b, 0x5f, 0x73, 0x5f, 0x65,
+	0x6e, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6e, 0x77,
+	0x6b, 0x53, 0x45, 0x6e, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x08, 0x66, 0x5f, 0x63, 0x6e,
+	0x74, 0x5f, 0x75, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x66, 0x43, 0x6e, 0x74,
+	0x55, 0x70, 0x12, 0x1f, 0x0a, 0x0c, 0x6e, 0x5f, 0x66, 0x5f, 0x63, 0x6e, 0x74, 0x5f, 0x64, 0x6f,
+	0x77, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6e, 0x46, 0x43, 0x6e, 0x74, 0x44,
+	0x6f, 0x77, 0x6e, 0x12, 0x1f, 0x0a, 0x0c, 0x61, 0x5f, 0x66, 0x5f, 0x63, 0x6e, 0x74, 0x5f, 0x64,
+	0x6f, 0x77, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61, 0x46, 0x43, 0x6e, 0x74,
+	0x44, 0x6f, 0x77, 0x6e, 0x12, 0x27, 0x0a, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x66, 0x5f, 0x63,
+	0x6e, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x73, 0x6b, 0x69, 0x70, 0x46, 0x43, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0x9d, 0x01,
+	0x0a, 0x16, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x12, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x75,
+	0x6c, 0x6b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x11, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x53, 0x0a,
+	0x0e, 0x42, 0x75, 0x6c, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x5f, 0x65, 0x75, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x64, 0x65, 0x76, 0x45, 0x75, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x84, 0x01, 0x0a, 0x0c, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x75, 0x6c,
+	0x6b, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x66, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x5f, 0x65, 0x75, 0x69, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x65, 0x76, 0x45, 0x75, 0x69, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x8b, 0x02, 0x0a, 0x0b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x63, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x74, 0x78, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x06,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x2d, 0x0a,
+	0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x07,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22,
+	0x6e, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x78, 0x74,
+	0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0xe8, 0x01, 0x0a, 0x15, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x5f,
+	0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x11, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x64, 0x72, 0x5f, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x72, 0x53, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x71, 0x75, 0x69, 0x72, 0x6b, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x51, 0x75, 0x69, 0x72, 0x6b, 0x73, 0x52, 0x06, 0x71, 0x75, 0x69, 0x72,
+	0x6b, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x70, 0x6c,
+	0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x22, 0xcb, 0x01, 0x0a, 0x0c, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x51, 0x75, 0x69, 0x72, 0x6b, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x74,
+	0x78, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x30, 0x5f, 0x75, 0x6e, 0x73, 0x75, 0x70, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x74, 0x78, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x30, 0x55, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x12, 0x37, 0x0a, 0x18, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x6e, 0x61, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x15, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x4d, 0x61, 0x73, 0x6b, 0x4e, 0x61, 0x63, 0x6b, 0x12, 0x29, 0x0a, 0x10, 0x6e, 0x6f, 0x5f,
+	0x72, 0x78, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x65, 0x74, 0x75, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x6e, 0x6f, 0x52, 0x78, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73,
+	0x65, 0x74, 0x75, 0x70, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x6f, 0x70, 0x74,
+	0x73, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78,
+	0x46, 0x6f, 0x70, 0x74, 0x73, 0x4c, 0x65, 0x6e, 0x22, 0x4d, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x5f, 0x0a, 0x22, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x08,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1c,
+	0x0a, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x15, 0x0a, 0x06,
+	0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x69,
+	0x6e, 0x44, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x61, 0x78, 0x44, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x6f,
+	0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x69, 0x0a, 0x0b, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x08,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50,
+	0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x22, 0x52, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x36, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x0b, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x22, 0x2b, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x27, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0xc6,
+	0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0c, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x50, 0x6c, 0x61, 0x6e, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61,
+	0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x52, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x70,
+	0x6c, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x78, 0x74, 0x61,
+	0x70, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x0b,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x22, 0x2a, 0x0a, 0x18, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3c, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x64, 0x65, 0x76, 0x5f, 0x65, 0x75, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64,
+	0x65, 0x76, 0x45, 0x75, 0x69, 0x22, 0xf3, 0x01, 0x0a, 0x13, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x3d, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x6e, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c,
+	0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x6e, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x2b, 0x0a, 0x11, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x10, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x70, 0x6c,
+	0x69, 0x6e, 0x6b, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x6f,
+	0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x22, 0xd8, 0x01, 0x0a, 0x22,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x70, 0x6c,
+	0x61, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x08, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65,
+	0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x16, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x17, 0x0a,
+	0x07, 0x69, 0x6e, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x69, 0x6e, 0x53, 0x79, 0x6e, 0x63, 0x22, 0x3d, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x47, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x49, 0x64, 0x22, 0xb0, 0x02, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x47, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x27, 0x0a, 0x0f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a,
+	0x0a, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x22, 0x65, 0x0a, 0x24, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x49, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x22,
+	0x9a, 0x02, 0x0a, 0x10, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x12, 0x35, 0x0a, 0x06, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52,
+	0x06, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6e, 0x74, 0x65, 0x6e,
+	0x6e, 0x61, 0x5f, 0x67, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61,
+	0x6e, 0x74, 0x65, 0x6e, 0x6e, 0x61, 0x47, 0x61, 0x69, 0x6e, 0x12, 0x3d, 0x0a, 0x0c, 0x6d, 0x61,
+	0x78, 0x5f, 0x74, 0x78, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x54, 0x78, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x03, 0x6c, 0x62, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4c, 0x42, 0x54, 0x52, 0x03, 0x6c, 0x62, 0x74, 0x12,
+	0x2b, 0x0a, 0x11, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x64, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x71, 0x0a, 0x15,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x61, 0x64, 0x69, 0x6f, 0x5f, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x10, 0x72, 0x61, 0x64, 0x69, 0x6f, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x69,
+	0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64,
+	0x6f, 0x77, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22,
+	0x4f, 0x0a, 0x0a, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4c, 0x42, 0x54, 0x12, 0x1f, 0x0a,
+	0x0b, 0x72, 0x73, 0x73, 0x69, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x72, 0x73, 0x73, 0x69, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x20,
+	0x0a, 0x0c, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x63, 0x61, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x73,
+	0x22, 0x3b, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x49, 0x64, 0x22, 0x55, 0x0a,
+	0x1b, 0x47, 0x65, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x09,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x73, 0x22, 0x76, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69,
+	0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x73, 0x52, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x22, 0xcc, 0x01, 0x0a,
+	0x0f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65, 0x49, 0x74, 0x65, 0x6d,
+	0x12, 0x17, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x5f, 0x65, 0x75, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x64, 0x65, 0x76, 0x45, 0x75, 0x69, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6d,
+	0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x66, 0x72, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x13, 0x0a, 0x05, 0x66, 0x5f,
+	0x63, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x66, 0x43, 0x6e, 0x74, 0x12,
+	0x15, 0x0a, 0x06, 0x66, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x66, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x76, 0x41, 0x64, 0x64, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x4b, 0x0a, 0x1c, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x69,
+	0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x78, 0x74, 0x61,
+	0x70, 0x69, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65, 0x49, 0x74,
+	0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x2a, 0x44, 0x0a, 0x10, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x0c, 0x0a, 0x08,
+	0x41, 0x4e, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x41, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x42,
+	0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x10, 0x03, 0x2a, 0x3a,
+	0x0a, 0x0e, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x0d, 0x0a, 0x09, 0x41, 0x4e, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x42, 0x0a, 0x18, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x79, 0x70, 0x65,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x42, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x43, 0x10, 0x02, 0x32, 0xd0,
+	0x0f, 0x0a, 0x1c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x48, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1a,
+	0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x65, 0x78, 0x74,
+	0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x4c, 0x69, 0x73,
+	0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x12, 0x1b, 0x2e, 0x65, 0x78, 0x74, 0x61,
+	0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x22, 0x2e,
+	0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x63, 0x61, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x23, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x63, 0x61, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1b,
+	0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78,
+	0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x12, 0x1b, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x52, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0f, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1e, 0x2e,
+	0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70,
+	0x69, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70,
+	0x69, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6f, 0x0a, 0x18, 0x47,
+	0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69,
+	0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x1b,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2a, 0x2e, 0x65, 0x78,
+	0x74, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x5a, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x20, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70,
+	0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50,
+	0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x12,
+	0x1d, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x4f, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x20, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x4f, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x20, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x00, 0x12, 0x4f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x73, 0x12, 0x1b, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x75, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x29, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x65,
+	0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a, 0x15, 0x47, 0x65,
+	0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x24, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x65, 0x78, 0x74, 0x61,
+	0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x67, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x2c, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x73, 0x12, 0x22, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x47,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x47, 0x65, 0x74, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
+	0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a,
+	0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x4f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x12, 0x24, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6c, 0x69, 0x75, 0x68, 0x77, 0x30, 0x2f, 0x63, 0x68, 0x69, 0x72, 0x70, 0x73, 0x74, 0x61, 0x63,
+	0x6b, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2f, 0x76, 0x33, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x65, 0x78, 0x74, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_extapi_proto_rawDescOnce sync.Once
+	file_extapi_proto_rawDescData = file_extapi_proto_rawDesc
+)
+
+func file_extapi_proto_rawDescGZIP() []byte {
+	file_extapi_proto_rawDescOnce.Do(func() {
+		file_extapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_extapi_proto_rawDescData)
+	})
+	return file_extapi_proto_rawDescData
+}
+
+var file_extapi_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_extapi_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
+var file_extapi_proto_goTypes = []interface{}{
+	(DeviceModeFilter)(0),                        // 0: extapi.DeviceModeFilter
+	(DisabledFilter)(0),                          // 1: extapi.DisabledFilter
+	(MulticastGroupTypeFilter)(0),                // 2: extapi.MulticastGroupTypeFilter
+	(*ListDevicesRequest)(nil),                   // 3: extapi.ListDevicesRequest
+	(*DeviceListItem)(nil),                       // 4: extapi.DeviceListItem
+	(*ListDevicesResponse)(nil),                  // 5: extapi.ListDevicesResponse
+	(*ListGatewaysRequest)(nil),                  // 6: extapi.ListGatewaysRequest
+	(*GatewayListItem)(nil),                      // 7: extapi.GatewayListItem
+	(*ListGatewaysResponse)(nil),                 // 8: extapi.ListGatewaysResponse
+	(*ListMulticastGroupsRequest)(nil),           // 9: extapi.ListMulticastGroupsRequest
+	(*MulticastGroupListItem)(nil),               // 10: extapi.MulticastGroupListItem
+	(*ListMulticastGroupsResponse)(nil),          // 11: extapi.ListMulticastGroupsResponse
+	(*ListProfilesRequest)(nil),                  // 12: extapi.ListProfilesRequest
+	(*ProfileListItem)(nil),                      // 13: extapi.ProfileListItem
+	(*ListProfilesResponse)(nil),                 // 14: extapi.ListProfilesResponse
+	(*BulkDevice)(nil),                           // 15: extapi.BulkDevice
+	(*CreateDevicesRequest)(nil),                 // 16: extapi.CreateDevicesRequest
+	(*BulkDeviceActivation)(nil),                 // 17: extapi.BulkDeviceActivation
+	(*ActivateDevicesRequest)(nil),               // 18: extapi.ActivateDevicesRequest
+	(*BulkItemResult)(nil),                       // 19: extapi.BulkItemResult
+	(*BulkResponse)(nil),                         // 20: extapi.BulkResponse
+	(*GetDeviceEventsRequest)(nil),               // 21: extapi.GetDeviceEventsRequest
+	(*DeviceEvent)(nil),                          // 22: extapi.DeviceEvent
+	(*GetDeviceEventsResponse)(nil),              // 23: extapi.GetDeviceEventsResponse
+	(*DeviceProfileSettings)(nil),                // 24: extapi.DeviceProfileSettings
+	(*DeviceQuirks)(nil),                         // 25: extapi.DeviceQuirks
+	(*GetDeviceProfileSettingsRequest)(nil),      // 26: extapi.GetDeviceProfileSettingsRequest
+	(*GetDeviceProfileSettingsResponse)(nil),     // 27: extapi.GetDeviceProfileSettingsResponse
+	(*UpdateDeviceProfileSettingsRequest)(nil),   // 28: extapi.UpdateDeviceProfileSettingsRequest
+	(*ChannelPlanChannel)(nil),                   // 29: extapi.ChannelPlanChannel
+	(*ChannelPlan)(nil),                          // 30: extapi.ChannelPlan
+	(*CreateChannelPlanRequest)(nil),             // 31: extapi.CreateChannelPlanRequest
+	(*CreateChannelPlanResponse)(nil),            // 32: extapi.CreateChannelPlanResponse
+	(*GetChannelPlanRequest)(nil),                // 33: extapi.GetChannelPlanRequest
+	(*GetChannelPlanResponse)(nil),               // 34: extapi.GetChannelPlanResponse
+	(*UpdateChannelPlanRequest)(nil),             // 35: extapi.UpdateChannelPlanRequest
+	(*DeleteChannelPlanRequest)(nil),             // 36: extapi.DeleteChannelPlanRequest
+	(*GetDeviceChannelPlanStatusRequest)(nil),    // 37: extapi.GetDeviceChannelPlanStatusRequest
+	(*DeviceChannelStatus)(nil),                  // 38: extapi.DeviceChannelStatus
+	(*GetDeviceChannelPlanStatusResponse)(nil),   // 39: extapi.GetDeviceChannelPlanStatusResponse
+	(*GetGatewayConfigStateRequest)(nil),         // 40: extapi.GetGatewayConfigStateRequest
+	(*GetGatewayConfigStateResponse)(nil),        // 41: extapi.GetGatewayConfigStateResponse
+	(*UpdateGatewayConfigTranslatorRequest)(nil), // 42: extapi.UpdateGatewayConfigTranslatorRequest
+	(*GatewayOverrides)(nil),                     // 43: extapi.GatewayOverrides
+	(*GatewayBoardOverrides)(nil),                // 44: extapi.GatewayBoardOverrides
+	(*GatewayLBT)(nil),                           // 45: extapi.GatewayLBT
+	(*GetGatewayOverridesRequest)(nil),           // 46: extapi.GetGatewayOverridesRequest
+	(*GetGatewayOverridesResponse)(nil),          // 47: extapi.GetGatewayOverridesResponse
+	(*UpdateGatewayOverridesRequest)(nil),        // 48: extapi.UpdateGatewayOverridesRequest
+	(*DeviceQueueItem)(nil),                      // 49: extapi.DeviceQueueItem
+	(*CreateDeviceQueueItemRequest)(nil),         // 50: extapi.CreateDeviceQueueItemRequest
+	(*timestamppb.Timestamp)(nil),                // 51: google.protobuf.Timestamp
+	(*_struct.Struct)(nil),                       // 52: google.protobuf.Struct
+	(*wrappers.Int32Value)(nil),                  // 53: google.protobuf.Int32Value
+	(*empty.Empty)(nil),                          // 54: google.protobuf.Empty
+}
+var file_extapi_proto_depIdxs = []int32{
+	0,  // 0: extapi.ListDevicesRequest.mode:type_name -> extapi.DeviceModeFilter
+	1,  // 1: extapi.ListDevicesRequest.disabled:type_name -> extapi.DisabledFilter
+	51, // 2: extapi.DeviceListItem.created_at:type_name -> google.protobuf.Timestamp
+	51, // 3: extapi.DeviceListItem.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 4: extapi.ListDevicesResponse.result:type_name -> extapi.DeviceListItem
+	51, // 5: extapi.ListGatewaysRequest.last_seen_after:type_name -> google.protobuf.Timestamp
+	51, // 6: extapi.ListGatewaysRequest.last_seen_before:type_name -> google.protobuf.Timestamp
+	51, // 7: extapi.GatewayListItem.created_at:type_name -> google.protobuf.Timestamp
+	51, // 8: extapi.GatewayListItem.updated_at:type_name -> google.protobuf.Timestamp
+	51, // 9: extapi.GatewayListItem.first_seen_at:type_name -> google.protobuf.Timestamp
+	51, // 10: extapi.GatewayListItem.last_seen_at:type_name -> google.protobuf.Timestamp
+	7,  // 11: extapi.ListGatewaysResponse.result:type_name -> extapi.GatewayListItem
+	2,  // 12: extapi.ListMulticastGroupsRequest.group_type:type_name -> extapi.MulticastGroupTypeFilter
+	51, // 13: extapi.MulticastGroupListItem.created_at:type_name -> google.protobuf.Timestamp
+	51, // 14: extapi.MulticastGroupListItem.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 15: extapi.ListMulticastGroupsResponse.result:type_name -> extapi.MulticastGroupListItem
+	51, // 16: extapi.ProfileListItem.created_at:type_name -> google.protobuf.Timestamp
+	51, // 17: extapi.ProfileListItem.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 18: extapi.ListProfilesResponse.result:type_name -> extapi.ProfileListItem
+	15, // 19: extapi.CreateDevicesRequest.devices:type_name -> extapi.BulkDevice
+	17, // 20: extapi.ActivateDevicesRequest.device_activations:type_name -> extapi.BulkDeviceActivation
+	19, // 21: extapi.BulkResponse.result:type_name -> extapi.BulkItemResult
+	51, // 22: extapi.DeviceEvent.time:type_name -> google.protobuf.Timestamp
+	52, // 23: extapi.DeviceEvent.before:type_name -> google.protobuf.Struct
+	52, // 24: extapi.DeviceEvent.after:type_name -> google.protobuf.Struct
+	52, // 25: extapi.DeviceEvent.details:type_name -> google.protobuf.Struct
+	22, // 26: extapi.GetDeviceEventsResponse.result:type_name -> extapi.DeviceEvent
+	25, // 27: extapi.DeviceProfileSettings.quirks:type_name -> extapi.DeviceQuirks
+	24, // 28: extapi.GetDeviceProfileSettingsResponse.settings:type_name -> extapi.DeviceProfileSettings
+	24, // 29: extapi.UpdateDeviceProfileSettingsRequest.settings:type_name -> extapi.DeviceProfileSettings
+	29, // 30: extapi.ChannelPlan.channels:type_name -> extapi.ChannelPlanChannel
+	30, // 31: extapi.CreateChannelPlanRequest.channel_plan:type_name -> extapi.ChannelPlan
+	30, // 32: extapi.GetChannelPlanResponse.channel_plan:type_name -> extapi.ChannelPlan
+	51, // 33: extapi.GetChannelPlanResponse.created_at:type_name -> google.protobuf.Timestamp
+	51, // 34: extapi.GetChannelPlanResponse.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 35: extapi.UpdateChannelPlanRequest.channel_plan:type_name -> extapi.ChannelPlan
+	29, // 36: extapi.DeviceChannelStatus.plan_channel:type_name -> extapi.ChannelPlanChannel
+	38, // 37: extapi.GetDeviceChannelPlanStatusResponse.channels:type_name -> extapi.DeviceChannelStatus
+	51, // 38: extapi.GetGatewayConfigStateResponse.applied_at:type_name -> google.protobuf.Timestamp
+	51, // 39: extapi.GetGatewayConfigStateResponse.pending_at:type_name -> google.protobuf.Timestamp
+	44, // 40: extapi.GatewayOverrides.boards:type_name -> extapi.GatewayBoardOverrides
+	53, // 41: extapi.GatewayOverrides.max_tx_power:type_name -> google.protobuf.Int32Value
+	45, // 42: extapi.GatewayOverrides.lbt:type_name -> extapi.GatewayLBT
+	43, // 43: extapi.GetGatewayOverridesResponse.overrides:type_name -> extapi.GatewayOverrides
+	43, // 44: extapi.UpdateGatewayOverridesRequest.overrides:type_name -> extapi.GatewayOverrides
+	49, // 45: extapi.CreateDeviceQueueItemRequest.item:type_name -> extapi.DeviceQueueItem
+	3,  // 46: extapi.ExtendedNetworkServerService.ListDevices:input_type -> extapi.ListDevicesRequest
+	6,  // 47: extapi.ExtendedNetworkServerService.ListGateways:input_type -> extapi.ListGatewaysRequest
+	9,  // 48: extapi.ExtendedNetworkServerService.ListMulticastGroups:input_type -> extapi.ListMulticastGroupsRequest
+	12, // 49: extapi.ExtendedNetworkServerService.ListDeviceProfiles:input_type -> extapi.ListProfilesRequest
+	12, // 50: extapi.ExtendedNetworkServerService.ListServiceProfiles:input_type -> extapi.ListProfilesRequest
+	12, // 51: extapi.ExtendedNetworkServerService.ListRoutingProfiles:input_type -> extapi.ListProfilesRequest
+	16, // 52: extapi.ExtendedNetworkServerService.CreateDevices:input_type -> extapi.CreateDevicesRequest
+	18, // 53: extapi.ExtendedNetworkServerService.ActivateDevices:input_type -> extapi.ActivateDevicesRequest
+	21, // 54: extapi.ExtendedNetworkServerService.GetDeviceEvents:input_type -> extapi.GetDeviceEventsRequest
+	26, // 55: extapi.ExtendedNetworkServerService.GetDeviceProfileSettings:input_type -> extapi.GetDeviceProfileSettingsRequest
+	28, // 56: extapi.ExtendedNetworkServerService.UpdateDeviceProfileSettings:input_type -> extapi.UpdateDeviceProfileSettingsRequest
+	31, // 57: extapi.ExtendedNetworkServerService.CreateChannelPlan:input_type -> extapi.CreateChannelPlanRequest
+	33, // 58: extapi.ExtendedNetworkServerService.GetChannelPlan:input_type -> extapi.GetChannelPlanRequest
+	35, // 59: extapi.ExtendedNetworkServerService.UpdateChannelPlan:input_type -> extapi.UpdateChannelPlanRequest
+	36, // 60: extapi.ExtendedNetworkServerService.DeleteChannelPlan:input_type -> extapi.DeleteChannelPlanRequest
+	12, // 61: extapi.ExtendedNetworkServerService.ListChannelPlans:input_type -> extapi.ListProfilesRequest
+	37, // 62: extapi.ExtendedNetworkServerService.GetDeviceChannelPlanStatus:input_type -> extapi.GetDeviceChannelPlanStatusRequest
+	40, // 63: extapi.ExtendedNetworkServerService.GetGatewayConfigState:input_type -> extapi.GetGatewayConfigStateRequest
+	42, // 64: extapi.ExtendedNetworkServerService.UpdateGatewayConfigTranslator:input_type -> extapi.UpdateGatewayConfigTranslatorRequest
+	46, // 65: extapi.ExtendedNetworkServerService.GetGatewayOverrides:input_type -> extapi.GetGatewayOverridesRequest
+	48, // 66: extapi.ExtendedNetworkServerService.UpdateGatewayOverrides:input_type -> extapi.UpdateGatewayOverridesRequest
+	50, // 67: extapi.ExtendedNetworkServerService.CreateDeviceQueueItem:input_type -> extapi.CreateDeviceQueueItemRequest
+	5,  // 68: extapi.ExtendedNetworkServerService.ListDevices:output_type -> extapi.ListDevicesResponse
+	8,  // 69: extapi.ExtendedNetworkServerService.ListGateways:output_type -> extapi.ListGatewaysResponse
+	11, // 70: extapi.ExtendedNetworkServerService.ListMulticastGroups:output_type -> extapi.ListMulticastGroupsResponse
+	14, // 71: extapi.ExtendedNetworkServerService.ListDeviceProfiles:output_type -> extapi.ListProfilesResponse
+	14, // 72: extapi.ExtendedNetworkServerService.ListServiceProfiles:output_type -> extapi.ListProfilesResponse
+	14, // 73: extapi.ExtendedNetworkServerService.ListRoutingProfiles:output_type -> extapi.ListProfilesResponse
+	20, // 74: extapi.ExtendedNetworkServerService.CreateDevices:output_type -> extapi.BulkResponse
+	20, // 75: extapi.ExtendedNetworkServerService.ActivateDevices:output_type -> extapi.BulkResponse
+	23, // 76: extapi.ExtendedNetworkServerService.GetDeviceEvents:output_type -> extapi.GetDeviceEventsResponse
+	27, // 77: extapi.ExtendedNetworkServerService.GetDeviceProfileSettings:output_type -> extapi.GetDeviceProfileSettingsResponse
+	54, // 78: extapi.ExtendedNetworkServerService.UpdateDeviceProfileSettings:output_type -> google.protobuf.Empty
+	32, // 79: extapi.ExtendedNetworkServerService.CreateChannelPlan:output_type -> extapi.CreateChannelPlanResponse
+	34, // 80: extapi.ExtendedNetworkServerService.GetChannelPlan:output_type -> extapi.GetChannelPlanResponse
+	54, // 81: extapi.ExtendedNetworkServerService.UpdateChannelPlan:output_type -> google.protobuf.Empty
+	54, // 82: extapi.ExtendedNetworkServerService.DeleteChannelPlan:output_type -> google.protobuf.Empty
+	14, // 83: extapi.ExtendedNetworkServerService.ListChannelPlans:output_type -> extapi.ListProfilesResponse
+	39, // 84: extapi.ExtendedNetworkServerService.GetDeviceChannelPlanStatus:output_type -> extapi.GetDeviceChannelPlanStatusResponse
+	41, // 85: extapi.ExtendedNetworkServerService.GetGatewayConfigState:output_type -> extapi.GetGatewayConfigStateResponse
+	54, // 86: extapi.ExtendedNetworkServerService.UpdateGatewayConfigTranslator:output_type -> google.protobuf.Empty
+	47, // 87: extapi.ExtendedNetworkServerService.GetGatewayOverrides:output_type -> extapi.GetGatewayOverridesResponse
+	54, // 88: extapi.ExtendedNetworkServerService.UpdateGatewayOverrides:output_type -> google.protobuf.Empty
+	54, // 89: extapi.ExtendedNetworkServerService.CreateDeviceQueueItem:output_type -> google.protobuf.Empty
+	68, // [68:90] is the sub-list for method output_type
+	46, // [46:68] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
+}
+
+func init() { file_extapi_proto_init() }
+func file_extapi_proto_init() {
+	if File_extapi_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_extapi_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceListItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDevicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGatewaysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatewayListItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGatewaysResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMulticastGroupsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MulticastGroupListItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMulticastGroupsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListProfilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileListItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListProfilesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkDeviceActivation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActivateDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkItemResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeviceEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeviceEventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceProfileSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceQuirks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeviceProfileSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeviceProfileSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDeviceProfileSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelPlanChannel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelPlan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateChannelPlanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateChannelPlanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChannelPlanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChannelPlanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateChannelPlanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteChannelPlanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeviceChannelPlanStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceChannelStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeviceChannelPlanStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGatewayConfigStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGatewayConfigStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateGatewayConfigTranslatorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatewayOverrides); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatewayBoardOverrides); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GatewayLBT); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGatewayOverridesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGatewayOverridesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateGatewayOverridesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceQueueItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extapi_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDeviceQueueItemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_extapi_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   48,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_extapi_proto_goTypes,
+		DependencyIndexes: file_extapi_proto_depIdxs,
+		EnumInfos:         file_extapi_proto_enumTypes,
+		MessageInfos:      file_extapi_proto_msgTypes,
+	}.Build()
+	File_extapi_proto = out.File
+	file_extapi_proto_rawDesc = nil
+	file_extapi_proto_goTypes = nil
+	file_extapi_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ExtendedNetworkServerServiceClient is the client API for ExtendedNetworkServerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ExtendedNetworkServerServiceClient interface {
+	// ListDevices returns the devices matching the given filters.
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// ListGateways returns the gateways matching the given filters.
+	ListGateways(ctx context.Context, in *ListGatewaysRequest, opts ...grpc.CallOption) (*ListGatewaysResponse, error)
+	// ListMulticastGroups returns the multicast-groups matching the given filters.
+	ListMulticastGroups(ctx context.Context, in *ListMulticastGroupsRequest, opts ...grpc.CallOption) (*ListMulticastGroupsResponse, error)
+	// ListDeviceProfiles returns the device-profiles.
+	ListDeviceProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error)
+	// ListServiceProfiles returns the service-profiles.
+	ListServiceProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error)
+	// ListRoutingProfiles returns the routing-profiles.
+	ListRoutingProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error)
+	// CreateDevices creates the given devices (max. 10000 per request).
+	// The result is returned per device.
+	CreateDevices(ctx context.Context, in *CreateDevicesRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	// ActivateDevices activates the given devices (ABP, max. 10000 per
+	// request). The result is returned per device.
+	// The device-sessions are stored after the database transaction of each
+	// batch has been committed. When storing a device-session fails, the
+	// item is reported as failed while the device (mode and flushed
+	// device-queue) has already been updated. As an activation replaces the
+	// previous one, the failed items can safely be re-submitted.
+	ActivateDevices(ctx context.Context, in *ActivateDevicesRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	// GetDeviceEvents returns the audit events of the given device,
+	// most recent first.
+	GetDeviceEvents(ctx context.Context, in *GetDeviceEventsRequest, opts ...grpc.CallOption) (*GetDeviceEventsResponse, error)
+	// GetDeviceProfileSettings returns the network-server specific settings
+	// of the given device-profile.
+	GetDeviceProfileSettings(ctx context.Context, in *GetDeviceProfileSettingsRequest, opts ...grpc.CallOption) (*GetDeviceProfileSettingsResponse, error)
+	// UpdateDeviceProfileSettings updates the network-server specific settings
+	// of the given device-profile.
+	UpdateDeviceProfileSettings(ctx context.Context, in *UpdateDeviceProfileSettingsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateChannelPlan creates the given channel-plan.
+	CreateChannelPlan(ctx context.Context, in *CreateChannelPlanRequest, opts ...grpc.CallOption) (*CreateChannelPlanResponse, error)
+	// GetChannelPlan returns the channel-plan matching the given id.
+	GetChannelPlan(ctx context.Context, in *GetChannelPlanRequest, opts ...grpc.CallOption) (*GetChannelPlanResponse, error)
+	// UpdateChannelPlan updates the given channel-plan.
+	UpdateChannelPlan(ctx context.Context, in *UpdateChannelPlanRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteChannelPlan deletes the channel-plan matching the given id.
+	DeleteChannelPlan(ctx context.Context, in *DeleteChannelPlanRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ListChannelPlans returns the channel-plans.
+	ListChannelPlans(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error)
+	// GetDeviceChannelPlanStatus returns the progress of configuring the
+	// channel-plan of the device-profile on the given device.
+	GetDeviceChannelPlanStatus(ctx context.Context, in *GetDeviceChannelPlanStatusRequest, opts ...grpc.CallOption) (*GetDeviceChannelPlanStatusResponse, error)
+	// GetGatewayConfigState returns the last applied and pending configuration
+	// versions of the given gateway.
+	GetGatewayConfigState(ctx context.Context, in *GetGatewayConfigStateRequest, opts ...grpc.CallOption) (*GetGatewayConfigStateResponse, error)
+	// UpdateGatewayConfigTranslator sets the translator used for pushing the
+	// gateway-profile configuration to the given gateway.
+	UpdateGatewayConfigTranslator(ctx context.Context, in *UpdateGatewayConfigTranslatorRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetGatewayOverrides returns the configuration overrides of the given
+	// gateway.
+	GetGatewayOverrides(ctx context.Context, in *GetGatewayOverridesRequest, opts ...grpc.CallOption) (*GetGatewayOverridesResponse, error)
+	// UpdateGatewayOverrides updates the configuration overrides of the given
+	// gateway.
+	UpdateGatewayOverrides(ctx context.Context, in *UpdateGatewayOverridesRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateDeviceQueueItem creates the given device-queue item with the
+	// given priority. Items with a higher priority are transmitted first.
+	CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type extendedNetworkServerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtendedNetworkServerServiceClient(cc grpc.ClientConnInterface) ExtendedNetworkServerServiceClient {
+	return &extendedNetworkServerServiceClient{cc}
+}
+
+func (c *extendedNetworkServerServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ListDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) ListGateways(ctx context.Context, in *ListGatewaysRequest, opts ...grpc.CallOption) (*ListGatewaysResponse, error) {
+	out := new(ListGatewaysResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ListGateways", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) ListMulticastGroups(ctx context.Context, in *ListMulticastGroupsRequest, opts ...grpc.CallOption) (*ListMulticastGroupsResponse, error) {
+	out := new(ListMulticastGroupsResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ListMulticastGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) ListDeviceProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error) {
+	out := new(ListProfilesResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ListDeviceProfiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) ListServiceProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error) {
+	out := new(ListProfilesResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ListServiceProfiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) ListRoutingProfiles(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error) {
+	out := new(ListProfilesResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ListRoutingProfiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) CreateDevices(ctx context.Context, in *CreateDevicesRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/CreateDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) ActivateDevices(ctx context.Context, in *ActivateDevicesRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ActivateDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) GetDeviceEvents(ctx context.Context, in *GetDeviceEventsRequest, opts ...grpc.CallOption) (*GetDeviceEventsResponse, error) {
+	out := new(GetDeviceEventsResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/GetDeviceEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) GetDeviceProfileSettings(ctx context.Context, in *GetDeviceProfileSettingsRequest, opts ...grpc.CallOption) (*GetDeviceProfileSettingsResponse, error) {
+	out := new(GetDeviceProfileSettingsResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/GetDeviceProfileSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) UpdateDeviceProfileSettings(ctx context.Context, in *UpdateDeviceProfileSettingsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/UpdateDeviceProfileSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) CreateChannelPlan(ctx context.Context, in *CreateChannelPlanRequest, opts ...grpc.CallOption) (*CreateChannelPlanResponse, error) {
+	out := new(CreateChannelPlanResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/CreateChannelPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) GetChannelPlan(ctx context.Context, in *GetChannelPlanRequest, opts ...grpc.CallOption) (*GetChannelPlanResponse, error) {
+	out := new(GetChannelPlanResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/GetChannelPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) UpdateChannelPlan(ctx context.Context, in *UpdateChannelPlanRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/UpdateChannelPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) DeleteChannelPlan(ctx context.Context, in *DeleteChannelPlanRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/DeleteChannelPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) ListChannelPlans(ctx context.Context, in *ListProfilesRequest, opts ...grpc.CallOption) (*ListProfilesResponse, error) {
+	out := new(ListProfilesResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/ListChannelPlans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) GetDeviceChannelPlanStatus(ctx context.Context, in *GetDeviceChannelPlanStatusRequest, opts ...grpc.CallOption) (*GetDeviceChannelPlanStatusResponse, error) {
+	out := new(GetDeviceChannelPlanStatusResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/GetDeviceChannelPlanStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) GetGatewayConfigState(ctx context.Context, in *GetGatewayConfigStateRequest, opts ...grpc.CallOption) (*GetGatewayConfigStateResponse, error) {
+	out := new(GetGatewayConfigStateResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/GetGatewayConfigState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) UpdateGatewayConfigTranslator(ctx context.Context, in *UpdateGatewayConfigTranslatorRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/UpdateGatewayConfigTranslator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) GetGatewayOverrides(ctx context.Context, in *GetGatewayOverridesRequest, opts ...grpc.CallOption) (*GetGatewayOverridesResponse, error) {
+	out := new(GetGatewayOverridesResponse)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/GetGatewayOverrides", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) UpdateGatewayOverrides(ctx context.Context, in *UpdateGatewayOverridesRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/UpdateGatewayOverrides", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedNetworkServerServiceClient) CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/extapi.ExtendedNetworkServerService/CreateDeviceQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtendedNetworkServerServiceServer is the server API for ExtendedNetworkServerService service.
+type ExtendedNetworkServerServiceServer interface {
+	// ListDevices returns the devices matching the given filters.
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// ListGateways returns the gateways matching the given filters.
+	ListGateways(context.Context, *ListGatewaysRequest) (*ListGatewaysResponse, error)
+	// ListMulticastGroups returns the multicast-groups matching the given filters.
+	ListMulticastGroups(context.Context, *ListMulticastGroupsRequest) (*ListMulticastGroupsResponse, error)
+	// ListDeviceProfiles returns the device-profiles.
+	ListDeviceProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error)
+	// ListServiceProfiles returns the service-profiles.
+	ListServiceProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error)
+	// ListRoutingProfiles returns the routing-profiles.
+	ListRoutingProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error)
+	// CreateDevices creates the given devices (max. 10000 per request).
+	// The result is returned per device.
+	CreateDevices(context.Context, *CreateDevicesRequest) (*BulkResponse, error)
+	// ActivateDevices activates the given devices (ABP, max. 10000 per
+	// request). The result is returned per device.
+	// The device-sessions are stored after the database transaction of each
+	// batch has been committed. When storing a device-session fails, the
+	// item is reported as failed while the device (mode and flushed
+	// device-queue) has already been updated. As an activation replaces the
+	// previous one, the failed items can safely be re-submitted.
+	ActivateDevices(context.Context, *ActivateDevicesRequest) (*BulkResponse, error)
+	// GetDeviceEvents returns the audit events of the given device,
+	// most recent first.
+	GetDeviceEvents(context.Context, *GetDeviceEventsRequest) (*GetDeviceEventsResponse, error)
+	// GetDeviceProfileSettings returns the network-server specific settings
+	// of the given device-profile.
+	GetDeviceProfileSettings(context.Context, *GetDeviceProfileSettingsRequest) (*GetDeviceProfileSettingsResponse, error)
+	// UpdateDeviceProfileSettings updates the network-server specific settings
+	// of the given device-profile.
+	UpdateDeviceProfileSettings(context.Context, *UpdateDeviceProfileSettingsRequest) (*empty.Empty, error)
+	// CreateChannelPlan creates the given channel-plan.
+	CreateChannelPlan(context.Context, *CreateChannelPlanRequest) (*CreateChannelPlanResponse, error)
+	// GetChannelPlan returns the channel-plan matching the given id.
+	GetChannelPlan(context.Context, *GetChannelPlanRequest) (*GetChannelPlanResponse, error)
+	// UpdateChannelPlan updates the given channel-plan.
+	UpdateChannelPlan(context.Context, *UpdateChannelPlanRequest) (*empty.Empty, error)
+	// DeleteChannelPlan deletes the channel-plan matching the given id.
+	DeleteChannelPlan(context.Context, *DeleteChannelPlanRequest) (*empty.Empty, error)
+	// ListChannelPlans returns the channel-plans.
+	ListChannelPlans(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error)
+	// GetDeviceChannelPlanStatus returns the progress of configuring the
+	// channel-plan of the device-profile on the given device.
+	GetDeviceChannelPlanStatus(context.Context, *GetDeviceChannelPlanStatusRequest) (*GetDeviceChannelPlanStatusResponse, error)
+	// GetGatewayConfigState returns the last applied and pending configuration
+	// versions of the given gateway.
+	GetGatewayConfigState(context.Context, *GetGatewayConfigStateRequest) (*GetGatewayConfigStateResponse, error)
+	// UpdateGatewayConfigTranslator sets the translator used for pushing the
+	// gateway-profile configuration to the given gateway.
+	UpdateGatewayConfigTranslator(context.Context, *UpdateGatewayConfigTranslatorRequest) (*empty.Empty, error)
+	// GetGatewayOverrides returns the configuration overrides of the given
+	// gateway.
+	GetGatewayOverrides(context.Context, *GetGatewayOverridesRequest) (*GetGatewayOverridesResponse, error)
+	// UpdateGatewayOverrides updates the configuration overrides of the given
+	// gateway.
+	UpdateGatewayOverrides(context.Context, *UpdateGatewayOverridesRequest) (*empty.Empty, error)
+	// CreateDeviceQueueItem creates the given device-queue item with the
+	// given priority. Items with a higher priority are transmitted first.
+	CreateDeviceQueueItem(context.Context, *CreateDeviceQueueItemRequest) (*empty.Empty, error)
+}
+
+// UnimplementedExtendedNetworkServerServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedExtendedNetworkServerServiceServer struct {
+}
+
+func (*UnimplementedExtendedNetworkServerServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) ListGateways(context.Context, *ListGatewaysRequest) (*ListGatewaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGateways not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) ListMulticastGroups(context.Context, *ListMulticastGroupsRequest) (*ListMulticastGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMulticastGroups not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) ListDeviceProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceProfiles not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) ListServiceProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceProfiles not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) ListRoutingProfiles(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoutingProfiles not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) CreateDevices(context.Context, *CreateDevicesRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDevices not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) ActivateDevices(context.Context, *ActivateDevicesRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateDevices not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) GetDeviceEvents(context.Context, *GetDeviceEventsRequest) (*GetDeviceEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceEvents not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) GetDeviceProfileSettings(context.Context, *GetDeviceProfileSettingsRequest) (*GetDeviceProfileSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceProfileSettings not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) UpdateDeviceProfileSettings(context.Context, *UpdateDeviceProfileSettingsRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceProfileSettings not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) CreateChannelPlan(context.Context, *CreateChannelPlanRequest) (*CreateChannelPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChannelPlan not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) GetChannelPlan(context.Context, *GetChannelPlanRequest) (*GetChannelPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelPlan not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) UpdateChannelPlan(context.Context, *UpdateChannelPlanRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChannelPlan not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) DeleteChannelPlan(context.Context, *DeleteChannelPlanRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChannelPlan not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) ListChannelPlans(context.Context, *ListProfilesRequest) (*ListProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChannelPlans not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) GetDeviceChannelPlanStatus(context.Context, *GetDeviceChannelPlanStatusRequest) (*GetDeviceChannelPlanStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceChannelPlanStatus not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) GetGatewayConfigState(context.Context, *GetGatewayConfigStateRequest) (*GetGatewayConfigStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayConfigState not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) UpdateGatewayConfigTranslator(context.Context, *UpdateGatewayConfigTranslatorRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGatewayConfigTranslator not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) GetGatewayOverrides(context.Context, *GetGatewayOverridesRequest) (*GetGatewayOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayOverrides not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) UpdateGatewayOverrides(context.Context, *UpdateGatewayOverridesRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGatewayOverrides not implemented")
+}
+func (*UnimplementedExtendedNetworkServerServiceServer) CreateDeviceQueueItem(context.Context, *CreateDeviceQueueItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceQueueItem not implemented")
+}
+
+func RegisterExtendedNetworkServerServiceServer(s *grpc.Server, srv ExtendedNetworkServerServiceServer) {
+	s.RegisterService(&_ExtendedNetworkServerService_serviceDesc, srv)
+}
+
+func _ExtendedNetworkServerService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ListGateways(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ListGateways",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ListGateways(ctx, req.(*ListGatewaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_ListMulticastGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMulticastGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ListMulticastGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ListMulticastGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ListMulticastGroups(ctx, req.(*ListMulticastGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_ListDeviceProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ListDeviceProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ListDeviceProfiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ListDeviceProfiles(ctx, req.(*ListProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_ListServiceProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ListServiceProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ListServiceProfiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ListServiceProfiles(ctx, req.(*ListProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_ListRoutingProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ListRoutingProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ListRoutingProfiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ListRoutingProfiles(ctx, req.(*ListProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_CreateDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).CreateDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/CreateDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).CreateDevices(ctx, req.(*CreateDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_ActivateDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ActivateDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ActivateDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ActivateDevices(ctx, req.(*ActivateDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_GetDeviceEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).GetDeviceEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/GetDeviceEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).GetDeviceEvents(ctx, req.(*GetDeviceEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_GetDeviceProfileSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceProfileSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).GetDeviceProfileSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/GetDeviceProfileSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).GetDeviceProfileSettings(ctx, req.(*GetDeviceProfileSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_UpdateDeviceProfileSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceProfileSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateDeviceProfileSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/UpdateDeviceProfileSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateDeviceProfileSettings(ctx, req.(*UpdateDeviceProfileSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_CreateChannelPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChannelPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).CreateChannelPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/CreateChannelPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).CreateChannelPlan(ctx, req.(*CreateChannelPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_GetChannelPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).GetChannelPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/GetChannelPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).GetChannelPlan(ctx, req.(*GetChannelPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_UpdateChannelPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChannelPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateChannelPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/UpdateChannelPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateChannelPlan(ctx, req.(*UpdateChannelPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_DeleteChannelPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChannelPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).DeleteChannelPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/DeleteChannelPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).DeleteChannelPlan(ctx, req.(*DeleteChannelPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_ListChannelPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).ListChannelPlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/ListChannelPlans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).ListChannelPlans(ctx, req.(*ListProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_GetDeviceChannelPlanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceChannelPlanStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).GetDeviceChannelPlanStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/GetDeviceChannelPlanStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).GetDeviceChannelPlanStatus(ctx, req.(*GetDeviceChannelPlanStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_GetGatewayConfigState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayConfigStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).GetGatewayConfigState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/GetGatewayConfigState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).GetGatewayConfigState(ctx, req.(*GetGatewayConfigStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_UpdateGatewayConfigTranslator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGatewayConfigTranslatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateGatewayConfigTranslator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/UpdateGatewayConfigTranslator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateGatewayConfigTranslator(ctx, req.(*UpdateGatewayConfigTranslatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_GetGatewayOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).GetGatewayOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/GetGatewayOverrides",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).GetGatewayOverrides(ctx, req.(*GetGatewayOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_UpdateGatewayOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGatewayOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateGatewayOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/UpdateGatewayOverrides",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).UpdateGatewayOverrides(ctx, req.(*UpdateGatewayOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtendedNetworkServerService_CreateDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedNetworkServerServiceServer).CreateDeviceQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extapi.ExtendedNetworkServerService/CreateDeviceQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedNetworkServerServiceServer).CreateDeviceQueueItem(ctx, req.(*CreateDeviceQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ExtendedNetworkServerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "extapi.ExtendedNetworkServerService",
+	HandlerType: (*ExtendedNetworkServerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDevices",
+			Handler:    _ExtendedNetworkServerService_ListDevices_Handler,
+		},
+		{
+			MethodName: "ListGateways",
+			Handler:    _ExtendedNetworkServerService_ListGateways_Handler,
+		},
+		{
+			MethodName: "ListMulticastGroups",
+			Handler:    _ExtendedNetworkServerService_ListMulticastGroups_Handler,
+		},
+		{
+			MethodName: "ListDeviceProfiles",
+			Handler:    _ExtendedNetworkServerService_ListDeviceProfiles_Handler,
+		},
+		{
+			MethodName: "ListServiceProfiles",
+			Handler:    _ExtendedNetworkServerService_ListServiceProfiles_Handler,
+		},
+		{
+			MethodName: "ListRoutingProfiles",
+			Handler:    _ExtendedNetworkServerService_ListRoutingProfiles_Handler,
+		},
+		{
+			MethodName: "CreateDevices",
+			Handler:    _ExtendedNetworkServerService_CreateDevices_Handler,
+		},
+		{
+			MethodName: "ActivateDevices",
+			Handler:    _ExtendedNetworkServerService_ActivateDevices_Handler,
+		},
+		{
+			MethodName: "GetDeviceEvents",
+			Handler:    _ExtendedNetworkServerService_GetDeviceEvents_Handler,
+		},
+		{
+			MethodName: "GetDeviceProfileSettings",
+			Handler:    _ExtendedNetworkServerService_GetDeviceProfileSettings_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceProfileSettings",
+			Handler:    _ExtendedNetworkServerService_UpdateDeviceProfileSettings_Handler,
+		},
+		{
+			MethodName: "CreateChannelPlan",
+			Handler:    _ExtendedNetworkServerService_CreateChannelPlan_Handler,
+		},
+		{
+			MethodName: "GetChannelPlan",
+			Handler:    _ExtendedNetworkServerService_GetChannelPlan_Handler,
+		},
+		{
+			MethodName: "UpdateChannelPlan",
+			Handler:    _ExtendedNetworkServerService_UpdateChannelPlan_Handler,
+		},
+		{
+			MethodName: "DeleteChannelPlan",
+			Handler:    _ExtendedNetworkServerService_DeleteChannelPlan_Handler,
+		},
+		{
+			MethodName: "ListChannelPlans",
+			Handler:    _ExtendedNetworkServerService_ListChannelPlans_Handler,
+		},
+		{
+			MethodName: "GetDeviceChannelPlanStatus",
+			Handler:    _ExtendedNetworkServerService_GetDeviceChannelPlanStatus_Handler,
+		},
+		{
+			MethodName: "GetGatewayConfigState",
+			Handler:    _ExtendedNetworkServerService_GetGatewayConfigState_Handler,
+		},
+		{
+			MethodName: "UpdateGatewayConfigTranslator",
+			Handler:    _ExtendedNetworkServerService_UpdateGatewayConfigTranslator_Handler,
+		},
+		{
+			MethodName: "GetGatewayOverrides",
+			Handler:    _ExtendedNetworkServerService_GetGatewayOverrides_Handler,
+		},
+		{
+			MethodName: "UpdateGatewayOverrides",
+			Handler:    _ExtendedNetworkServerService_UpdateGatewayOverrides_Handler,
+		},
+		{
+			MethodName: "CreateDeviceQueueItem",
+			Handler:    _ExtendedNetworkServerService_CreateDeviceQueueItem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "extapi.proto",
+}
@@ -1,6 +1,7 @@
 package ns
 
 import (
+	"context"
 	"net"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/brocaar/chirpstack-api/go/v3/ns"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/tls"
@@ -41,6 +43,7 @@ func Setup(c config.Config) error {
 	gs := grpc.NewServer(opts...)
 	nsAPI := NewNetworkServerAPI()
 	ns.RegisterNetworkServerServiceServer(gs, nsAPI)
+	extapi.RegisterExtendedNetworkServerServiceServer(gs, NewExtendedNetworkServerAPI())
 
 	ln, err := net.Listen("tcp", apiConfig.Bind)
 	if err != nil {
@@ -63,6 +66,7 @@ func serverOptions() []grpc.ServerOption {
 			grpc_logrus.UnaryServerInterceptor(logrusEntry, logrusOpts...),
 			logging.UnaryServerCtxIDInterceptor,
 			grpc_prometheus.UnaryServerInterceptor,
+			configRLockInterceptor,
 		),
 		grpc_middleware.WithStreamServerChain(
 			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
@@ -71,3 +75,13 @@ func serverOptions() []grpc.ServerOption {
 		),
 	}
 }
+
+// configRLockInterceptor holds the configuration read-lock during the
+// handling of the request, so that the packages are not re-configured
+// while handling the request.
+func configRLockInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	config.RLock()
+	defer config.RUnlock()
+
+	return handler(ctx, req)
+}
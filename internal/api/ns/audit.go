@@ -0,0 +1,89 @@
+package ns
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
+	"github.com/liuhw0/lorawan"
+)
+
+var streamIDRegexp = regexp.MustCompile(`^\d+-\d+$`)
+
+// GetDeviceEvents returns the audit events of the given device, most recent
+// first.
+func (n *ExtendedNetworkServerAPI) GetDeviceEvents(ctx context.Context, req *extapi.GetDeviceEventsRequest) (*extapi.GetDeviceEventsResponse, error) {
+	var devEUI lorawan.EUI64
+	if len(req.DevEui) != len(devEUI) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid dev_eui")
+	}
+	copy(devEUI[:], req.DevEui)
+
+	if req.PageToken != "" && !streamIDRegexp.MatchString(req.PageToken) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid page_token")
+	}
+
+	limit := pageSize(req.Limit)
+	events, err := audit.GetDeviceEvents(ctx, devEUI, limit+1, req.PageToken)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp extapi.GetDeviceEventsResponse
+	if len(events) == limit+1 {
+		events = events[:limit]
+		resp.NextPageToken = events[limit-1].ID
+	}
+
+	for _, e := range events {
+		item := extapi.DeviceEvent{
+			Id:    e.ID,
+			Type:  string(e.Type),
+			CtxId: e.CtxID,
+		}
+
+		if item.Time, err = ptypes.TimestampProto(e.Time); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if item.Before, err = toStruct(e.Before); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if item.After, err = toStruct(e.After); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if item.Details, err = toStruct(e.Details); err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// toStruct converts the given map into a Struct. The map is JSON encoded
+// first, so that it only contains JSON compatible values.
+func toStruct(m map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+
+	return structpb.NewStruct(out)
+}
@@ -0,0 +1,411 @@
+package ns
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/brocaar/chirpstack-api/go/v3/ns"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+const (
+	// defaultBulkBatchSize defines the number of items handled within a
+	// single database transaction, when the request does not set a
+	// batch-size.
+	defaultBulkBatchSize = 100
+
+	// maxBulkBatchSize defines the max. batch-size.
+	maxBulkBatchSize = 1000
+
+	// maxBulkItems defines the max. number of items of a single bulk
+	// request. This keeps the request and response well within the
+	// default gRPC max. message-size (4MB).
+	maxBulkItems = 10000
+)
+
+// bulkSavepoint defines the name of the savepoint created for each item
+// within a batch transaction, so that a failing item does not fail the
+// other items of the batch.
+const bulkSavepoint = "bulk_item"
+
+// CreateDevices creates the given devices.
+func (n *ExtendedNetworkServerAPI) CreateDevices(ctx context.Context, req *extapi.CreateDevicesRequest) (*extapi.BulkResponse, error) {
+	if len(req.Devices) > maxBulkItems {
+		return nil, grpc.Errorf(codes.InvalidArgument, "max. number of devices is %d", maxBulkItems)
+	}
+
+	results := newBulkResults(len(req.Devices))
+	devices := make([]storage.Device, len(req.Devices))
+	devEUIs := make([]lorawan.EUI64, 0, len(req.Devices))
+	seen := make(map[lorawan.EUI64]bool)
+
+	for i, item := range req.Devices {
+		results.items[i].DevEui = item.DevEui
+
+		d, err := getDeviceForBulkDevice(item)
+		if err != nil {
+			results.setError(i, err)
+			continue
+		}
+
+		if seen[d.DevEUI] {
+			results.setError(i, grpc.Errorf(codes.InvalidArgument, "duplicate dev_eui"))
+			continue
+		}
+		seen[d.DevEUI] = true
+
+		devices[i] = d
+		devEUIs = append(devEUIs, d.DevEUI)
+	}
+
+	if req.DryRun {
+		existing, err := storage.GetDevicesForDevEUIs(ctx, storage.DB(), devEUIs)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		pv := newProfileValidator()
+		for i := range devices {
+			if results.failed(i) {
+				continue
+			}
+
+			if _, ok := existing[devices[i].DevEUI]; ok {
+				results.setError(i, errToRPCError(storage.ErrAlreadyExists))
+				continue
+			}
+
+			if err := pv.validate(ctx, devices[i]); err != nil {
+				results.setError(i, err)
+			}
+		}
+
+		return results.response(), nil
+	}
+
+	forEachBatch(len(devices), bulkBatchSize(req.BatchSize), func(start, end int) {
+		err := storage.Transaction(func(tx sqlx.Ext) error {
+			for i := start; i < end; i++ {
+				if results.failed(i) {
+					continue
+				}
+
+				err := storage.Savepoint(tx, bulkSavepoint, func() error {
+					return storage.CreateDevice(ctx, tx, &devices[i])
+				})
+				if err != nil {
+					results.setError(i, errToRPCError(err))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			results.setBatchError(start, end, errToRPCError(err))
+		}
+	})
+
+	return results.response(), nil
+}
+
+// ActivateDevices activates the given devices (ABP).
+func (n *ExtendedNetworkServerAPI) ActivateDevices(ctx context.Context, req *extapi.ActivateDevicesRequest) (*extapi.BulkResponse, error) {
+	if len(req.DeviceActivations) > maxBulkItems {
+		return nil, grpc.Errorf(codes.InvalidArgument, "max. number of device activations is %d", maxBulkItems)
+	}
+
+	results := newBulkResults(len(req.DeviceActivations))
+	devEUIs := make([]lorawan.EUI64, len(req.DeviceActivations))
+	seen := make(map[lorawan.EUI64]bool)
+
+	for i, item := range req.DeviceActivations {
+		results.items[i].DevEui = item.DevEui
+
+		if err := validateBulkDeviceActivation(item); err != nil {
+			results.setError(i, err)
+			continue
+		}
+
+		copy(devEUIs[i][:], item.DevEui)
+		if seen[devEUIs[i]] {
+			results.setError(i, grpc.Errorf(codes.InvalidArgument, "duplicate dev_eui"))
+			continue
+		}
+		seen[devEUIs[i]] = true
+	}
+
+	devices, err := storage.GetDevicesForDevEUIs(ctx, storage.DB(), devEUIs)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	deviceProfiles := make(map[uuid.UUID]storage.DeviceProfile)
+	activated := make([]storage.Device, len(req.DeviceActivations))
+	sessions := make([]storage.DeviceSession, len(req.DeviceActivations))
+
+	for i, item := range req.DeviceActivations {
+		if results.failed(i) {
+			continue
+		}
+
+		d, ok := devices[devEUIs[i]]
+		if !ok {
+			results.setError(i, errToRPCError(storage.ErrDoesNotExist))
+			continue
+		}
+
+		dp, ok := deviceProfiles[d.DeviceProfileID]
+		if !ok {
+			dp, err = storage.GetDeviceProfile(ctx, storage.DB(), d.DeviceProfileID)
+			if err != nil {
+				results.setError(i, errToRPCError(err))
+				continue
+			}
+			deviceProfiles[d.DeviceProfileID] = dp
+		}
+
+		sessions[i] = getDeviceSessionForActivation(d, dp, &ns.DeviceActivation{
+			DevEui:        item.DevEui,
+			DevAddr:       item.DevAddr,
+			SNwkSIntKey:   item.SNwkSIntKey,
+			FNwkSIntKey:   item.FNwkSIntKey,
+			NwkSEncKey:    item.NwkSEncKey,
+			FCntUp:        item.FCntUp,
+			NFCntDown:     item.NFCntDown,
+			AFCntDown:     item.AFCntDown,
+			SkipFCntCheck: item.SkipFCntCheck,
+		})
+
+		d.Mode = getDeviceModeForActivation(dp)
+		activated[i] = d
+	}
+
+	if req.DryRun {
+		return results.response(), nil
+	}
+
+	forEachBatch(len(activated), bulkBatchSize(req.BatchSize), func(start, end int) {
+		err := storage.Transaction(func(tx sqlx.Ext) error {
+			for i := start; i < end; i++ {
+				if results.failed(i) {
+					continue
+				}
+
+				err := storage.Savepoint(tx, bulkSavepoint, func() error {
+					if err := storage.UpdateDevice(ctx, tx, &activated[i]); err != nil {
+						return err
+					}
+					return storage.FlushDeviceQueueForDevEUI(ctx, tx, activated[i].DevEUI)
+				})
+				if err != nil {
+					results.setError(i, errToRPCError(err))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			results.setBatchError(start, end, errToRPCError(err))
+			return
+		}
+
+		// The device-sessions are stored after the database transaction
+		// has been committed. Redis is not part of this transaction, thus
+		// a failing item keeps its (committed) device changes. This is
+		// reported as an item error, and as the activation is idempotent,
+		// the item can be re-submitted.
+		for i := start; i < end; i++ {
+			if results.failed(i) {
+				continue
+			}
+
+			if err := storage.SaveDeviceSession(ctx, sessions[i]); err != nil {
+				results.setError(i, errToRPCError(err))
+				continue
+			}
+
+			if err := storage.FlushMACCommandQueue(ctx, sessions[i].DevEUI); err != nil {
+				results.setError(i, errToRPCError(err))
+			}
+		}
+	})
+
+	return results.response(), nil
+}
+
+// bulkResults holds the per item results of a bulk request.
+type bulkResults struct {
+	items []extapi.BulkItemResult
+}
+
+func newBulkResults(n int) *bulkResults {
+	return &bulkResults{
+		items: make([]extapi.BulkItemResult, n),
+	}
+}
+
+// setError sets the error of the given item.
+func (b *bulkResults) setError(i int, err error) {
+	s := status.Convert(err)
+	b.items[i].Code = uint32(s.Code())
+	b.items[i].Error = s.Message()
+}
+
+// setBatchError sets the error of all items of the given batch which did
+// not already fail.
+func (b *bulkResults) setBatchError(start, end int, err error) {
+	for i := start; i < end; i++ {
+		if !b.failed(i) {
+			b.setError(i, err)
+		}
+	}
+}
+
+// failed returns true when the given item failed.
+func (b *bulkResults) failed(i int) bool {
+	return b.items[i].Code != uint32(codes.OK)
+}
+
+// response returns the BulkResponse.
+func (b *bulkResults) response() *extapi.BulkResponse {
+	var resp extapi.BulkResponse
+	for i := range b.items {
+		resp.Result = append(resp.Result, &b.items[i])
+		if b.failed(i) {
+			resp.ErrorCount++
+		} else {
+			resp.SuccessCount++
+		}
+	}
+	return &resp
+}
+
+// profileValidator validates that the profiles of a device exist. The
+// result is cached, as typically many devices share the same profiles.
+type profileValidator struct {
+	deviceProfiles  map[uuid.UUID]error
+	serviceProfiles map[uuid.UUID]error
+	routingProfiles map[uuid.UUID]error
+}
+
+func newProfileValidator() *profileValidator {
+	return &profileValidator{
+		deviceProfiles:  make(map[uuid.UUID]error),
+		serviceProfiles: make(map[uuid.UUID]error),
+		routingProfiles: make(map[uuid.UUID]error),
+	}
+}
+
+// validate validates that the profiles of the given device exist.
+func (p *profileValidator) validate(ctx context.Context, d storage.Device) error {
+	err, ok := p.deviceProfiles[d.DeviceProfileID]
+	if !ok {
+		_, err = storage.GetDeviceProfile(ctx, storage.DB(), d.DeviceProfileID)
+		p.deviceProfiles[d.DeviceProfileID] = err
+	}
+	if err != nil {
+		return errToRPCError(err)
+	}
+
+	err, ok = p.serviceProfiles[d.ServiceProfileID]
+	if !ok {
+		_, err = storage.GetServiceProfile(ctx, storage.DB(), d.ServiceProfileID)
+		p.serviceProfiles[d.ServiceProfileID] = err
+	}
+	if err != nil {
+		return errToRPCError(err)
+	}
+
+	err, ok = p.routingProfiles[d.RoutingProfileID]
+	if !ok {
+		_, err = storage.GetRoutingProfile(ctx, storage.DB(), d.RoutingProfileID)
+		p.routingProfiles[d.RoutingProfileID] = err
+	}
+	if err != nil {
+		return errToRPCError(err)
+	}
+
+	return nil
+}
+
+// getDeviceForBulkDevice validates and returns the device for the given
+// bulk device.
+func getDeviceForBulkDevice(item *extapi.BulkDevice) (storage.Device, error) {
+	var d storage.Device
+
+	if len(item.DevEui) != len(d.DevEUI) {
+		return d, grpc.Errorf(codes.InvalidArgument, "dev_eui must be exactly %d bytes", len(d.DevEUI))
+	}
+	copy(d.DevEUI[:], item.DevEui)
+
+	for _, id := range []struct {
+		name  string
+		value []byte
+		out   *uuid.UUID
+	}{
+		{"device_profile_id", item.DeviceProfileId, &d.DeviceProfileID},
+		{"service_profile_id", item.ServiceProfileId, &d.ServiceProfileID},
+		{"routing_profile_id", item.RoutingProfileId, &d.RoutingProfileID},
+	} {
+		u, err := uuid.FromBytes(id.value)
+		if err != nil {
+			return d, grpc.Errorf(codes.InvalidArgument, "invalid %s: %s", id.name, err)
+		}
+		*id.out = u
+	}
+
+	d.SkipFCntCheck = item.SkipFCntCheck
+	d.ReferenceAltitude = item.ReferenceAltitude
+	d.IsDisabled = item.IsDisabled
+
+	return d, nil
+}
+
+// validateBulkDeviceActivation validates the field lengths of the given
+// device activation.
+func validateBulkDeviceActivation(item *extapi.BulkDeviceActivation) error {
+	for _, f := range []struct {
+		name  string
+		value []byte
+		size  int
+	}{
+		{"dev_eui", item.DevEui, len(lorawan.EUI64{})},
+		{"dev_addr", item.DevAddr, len(lorawan.DevAddr{})},
+		{"s_nwk_s_int_key", item.SNwkSIntKey, len(lorawan.AES128Key{})},
+		{"f_nwk_s_int_key", item.FNwkSIntKey, len(lorawan.AES128Key{})},
+		{"nwk_s_enc_key", item.NwkSEncKey, len(lorawan.AES128Key{})},
+	} {
+		if len(f.value) != f.size {
+			return grpc.Errorf(codes.InvalidArgument, "%s must be exactly %d bytes", f.name, f.size)
+		}
+	}
+
+	return nil
+}
+
+// bulkBatchSize returns the batch-size for the given requested batch-size.
+func bulkBatchSize(size uint32) int {
+	if size == 0 {
+		return defaultBulkBatchSize
+	}
+	if size > maxBulkBatchSize {
+		return maxBulkBatchSize
+	}
+	return int(size)
+}
+
+// forEachBatch calls f for each batch of the given size, with the start
+// (inclusive) and end (exclusive) index of the batch.
+func forEachBatch(n, size int, f func(start, end int)) {
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		f(start, end)
+	}
+}
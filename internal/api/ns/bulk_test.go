@@ -0,0 +1,183 @@
+package ns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+func TestBulkResults(t *testing.T) {
+	assert := require.New(t)
+
+	r := newBulkResults(4)
+	r.setError(1, grpc.Errorf(codes.InvalidArgument, "invalid"))
+	r.setBatchError(1, 3, errors.New("boom"))
+
+	resp := r.response()
+	assert.EqualValues(2, resp.SuccessCount)
+	assert.EqualValues(2, resp.ErrorCount)
+	assert.EqualValues(codes.OK, resp.Result[0].Code)
+	assert.EqualValues(codes.InvalidArgument, resp.Result[1].Code)
+	assert.Equal("invalid", resp.Result[1].Error)
+	assert.EqualValues(codes.Unknown, resp.Result[2].Code)
+	assert.Equal("boom", resp.Result[2].Error)
+	assert.EqualValues(codes.OK, resp.Result[3].Code)
+}
+
+func TestForEachBatch(t *testing.T) {
+	assert := require.New(t)
+
+	var batches [][2]int
+	forEachBatch(5, 2, func(start, end int) {
+		batches = append(batches, [2]int{start, end})
+	})
+	assert.Equal([][2]int{{0, 2}, {2, 4}, {4, 5}}, batches)
+
+	assert.Equal(defaultBulkBatchSize, bulkBatchSize(0))
+	assert.Equal(10, bulkBatchSize(10))
+	assert.Equal(maxBulkBatchSize, bulkBatchSize(maxBulkBatchSize+1))
+}
+
+func TestGetDeviceForBulkDevice(t *testing.T) {
+	assert := require.New(t)
+	id := make([]byte, 16)
+
+	_, err := getDeviceForBulkDevice(&extapi.BulkDevice{DevEui: []byte{1, 2, 3}})
+	assert.Equal(codes.InvalidArgument, grpc.Code(err))
+
+	_, err = getDeviceForBulkDevice(&extapi.BulkDevice{DevEui: make([]byte, 8), DeviceProfileId: id, ServiceProfileId: id})
+	assert.Equal(codes.InvalidArgument, grpc.Code(err))
+
+	d, err := getDeviceForBulkDevice(&extapi.BulkDevice{
+		DevEui:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		DeviceProfileId:  id,
+		ServiceProfileId: id,
+		RoutingProfileId: id,
+		IsDisabled:       true,
+	})
+	assert.NoError(err)
+	assert.Equal(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, d.DevEUI)
+	assert.True(d.IsDisabled)
+}
+
+func TestValidateBulkDeviceActivation(t *testing.T) {
+	assert := require.New(t)
+
+	da := extapi.BulkDeviceActivation{
+		DevEui:      make([]byte, 8),
+		DevAddr:     make([]byte, 4),
+		SNwkSIntKey: make([]byte, 16),
+		FNwkSIntKey: make([]byte, 16),
+		NwkSEncKey:  make([]byte, 16),
+	}
+	assert.NoError(validateBulkDeviceActivation(&da))
+
+	da.NwkSEncKey = make([]byte, 15)
+	assert.Equal(codes.InvalidArgument, grpc.Code(validateBulkDeviceActivation(&da)))
+}
+
+func (ts *NetworkServerAPITestSuite) TestBulk() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+	api := NewExtendedNetworkServerAPI()
+
+	var sp storage.ServiceProfile
+	var dp storage.DeviceProfile
+	var rp storage.RoutingProfile
+	assert.NoError(storage.CreateServiceProfile(ctx, storage.DB(), &sp))
+	assert.NoError(storage.CreateDeviceProfile(ctx, storage.DB(), &dp))
+	assert.NoError(storage.CreateRoutingProfile(ctx, storage.DB(), &rp))
+
+	existing := storage.Device{
+		DevEUI:           lorawan.EUI64{2, 1, 1, 1, 1, 1, 1, 1},
+		ServiceProfileID: sp.ID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateDevice(ctx, storage.DB(), &existing))
+
+	devices := []*extapi.BulkDevice{
+		{DevEui: []byte{2, 1, 1, 1, 1, 1, 1, 2}, ServiceProfileId: sp.ID[:], DeviceProfileId: dp.ID[:], RoutingProfileId: rp.ID[:]},
+		{DevEui: existing.DevEUI[:], ServiceProfileId: sp.ID[:], DeviceProfileId: dp.ID[:], RoutingProfileId: rp.ID[:]},
+		{DevEui: []byte{2, 1, 1, 1, 1, 1, 1, 3}, ServiceProfileId: sp.ID[:], DeviceProfileId: make([]byte, 16), RoutingProfileId: rp.ID[:]},
+		{DevEui: []byte{2, 1, 1, 1, 1, 1, 1, 2}, ServiceProfileId: sp.ID[:], DeviceProfileId: dp.ID[:], RoutingProfileId: rp.ID[:]},
+	}
+	expectedCodes := []codes.Code{codes.OK, codes.AlreadyExists, codes.NotFound, codes.InvalidArgument}
+
+	ts.T().Run("CreateDevices dry-run", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.CreateDevices(ctx, &extapi.CreateDevicesRequest{Devices: devices, DryRun: true})
+		assert.NoError(err)
+		for i, r := range resp.Result {
+			assert.EqualValues(expectedCodes[i], r.Code, "item %d", i)
+		}
+
+		_, err = storage.GetDevice(ctx, storage.DB(), lorawan.EUI64{2, 1, 1, 1, 1, 1, 1, 2}, false)
+		assert.Equal(storage.ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("CreateDevices", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.CreateDevices(ctx, &extapi.CreateDevicesRequest{Devices: devices, BatchSize: 2})
+		assert.NoError(err)
+		assert.EqualValues(1, resp.SuccessCount)
+		assert.EqualValues(3, resp.ErrorCount)
+		for i, r := range resp.Result {
+			assert.EqualValues(expectedCodes[i], r.Code, "item %d", i)
+		}
+
+		_, err = storage.GetDevice(ctx, storage.DB(), lorawan.EUI64{2, 1, 1, 1, 1, 1, 1, 2}, false)
+		assert.NoError(err)
+	})
+
+	ts.T().Run("ActivateDevices", func(t *testing.T) {
+		assert := require.New(t)
+
+		activation := func(devEUI lorawan.EUI64) *extapi.BulkDeviceActivation {
+			return &extapi.BulkDeviceActivation{
+				DevEui:      devEUI[:],
+				DevAddr:     []byte{1, 2, 3, 4},
+				SNwkSIntKey: make([]byte, 16),
+				FNwkSIntKey: make([]byte, 16),
+				NwkSEncKey:  make([]byte, 16),
+				FCntUp:      10,
+			}
+		}
+
+		req := extapi.ActivateDevicesRequest{
+			DeviceActivations: []*extapi.BulkDeviceActivation{
+				activation(existing.DevEUI),
+				activation(lorawan.EUI64{2, 1, 1, 1, 1, 1, 1, 9}),
+			},
+			DryRun: true,
+		}
+
+		resp, err := api.ActivateDevices(ctx, &req)
+		assert.NoError(err)
+		assert.EqualValues(codes.OK, resp.Result[0].Code)
+		assert.EqualValues(codes.NotFound, resp.Result[1].Code)
+
+		_, err = storage.GetDeviceSession(ctx, existing.DevEUI)
+		assert.Equal(storage.ErrDoesNotExist, err)
+
+		req.DryRun = false
+		resp, err = api.ActivateDevices(ctx, &req)
+		assert.NoError(err)
+		assert.EqualValues(1, resp.SuccessCount)
+
+		ds, err := storage.GetDeviceSession(ctx, existing.DevEUI)
+		assert.NoError(err)
+		assert.Equal(lorawan.DevAddr{1, 2, 3, 4}, ds.DevAddr)
+		assert.EqualValues(10, ds.FCntUp)
+	})
+}
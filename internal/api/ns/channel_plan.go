@@ -0,0 +1,237 @@
+package ns
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/channels"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+// CreateChannelPlan creates the given channel-plan.
+func (n *ExtendedNetworkServerAPI) CreateChannelPlan(ctx context.Context, req *extapi.CreateChannelPlanRequest) (*extapi.CreateChannelPlanResponse, error) {
+	if req.ChannelPlan == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "channel_plan must not be nil")
+	}
+
+	cp, err := channelPlanFromPB(req.ChannelPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.CreateChannelPlan(ctx, storage.DB(), &cp); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &extapi.CreateChannelPlanResponse{
+		Id: cp.ID.Bytes(),
+	}, nil
+}
+
+// GetChannelPlan returns the channel-plan matching the given id.
+func (n *ExtendedNetworkServerAPI) GetChannelPlan(ctx context.Context, req *extapi.GetChannelPlanRequest) (*extapi.GetChannelPlanResponse, error) {
+	id, err := channelPlanID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	cp, err := storage.GetChannelPlan(ctx, storage.DB(), id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := extapi.GetChannelPlanResponse{
+		ChannelPlan: &extapi.ChannelPlan{
+			Id:   cp.ID.Bytes(),
+			Name: cp.Name,
+		},
+	}
+
+	for _, c := range cp.Channels {
+		resp.ChannelPlan.Channels = append(resp.ChannelPlan.Channels, channelPlanChannelToPB(c))
+	}
+
+	if resp.CreatedAt, err = ptypes.TimestampProto(cp.CreatedAt); err != nil {
+		return nil, errToRPCError(err)
+	}
+	if resp.UpdatedAt, err = ptypes.TimestampProto(cp.UpdatedAt); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+// UpdateChannelPlan updates the given channel-plan.
+func (n *ExtendedNetworkServerAPI) UpdateChannelPlan(ctx context.Context, req *extapi.UpdateChannelPlanRequest) (*empty.Empty, error) {
+	if req.ChannelPlan == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "channel_plan must not be nil")
+	}
+
+	if _, err := channelPlanID(req.ChannelPlan.Id); err != nil {
+		return nil, err
+	}
+
+	cp, err := channelPlanFromPB(req.ChannelPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.UpdateChannelPlan(ctx, storage.DB(), &cp); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// flush after the update, so that the old channel-plan can not be
+	// cached again by a concurrent request
+	if err := storage.FlushChannelPlanCache(ctx, cp.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// DeleteChannelPlan deletes the channel-plan matching the given id.
+// The device-profiles referring to the channel-plan are flushed from the
+// cache, as these no longer refer to a channel-plan.
+func (n *ExtendedNetworkServerAPI) DeleteChannelPlan(ctx context.Context, req *extapi.DeleteChannelPlanRequest) (*empty.Empty, error) {
+	id, err := channelPlanID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dpIDs []uuid.UUID
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		var err error
+		dpIDs, err = storage.GetDeviceProfileIDsForChannelPlan(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return storage.DeleteChannelPlan(ctx, tx, id)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.FlushChannelPlanCache(ctx, id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	for _, dpID := range dpIDs {
+		if err := storage.FlushDeviceProfileCache(ctx, dpID); err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// ListChannelPlans returns the channel-plans.
+func (n *ExtendedNetworkServerAPI) ListChannelPlans(ctx context.Context, req *extapi.ListProfilesRequest) (*extapi.ListProfilesResponse, error) {
+	return listProfiles(ctx, req, storage.GetChannelPlans)
+}
+
+// GetDeviceChannelPlanStatus returns the progress of configuring the
+// channel-plan of the device-profile on the given device.
+func (n *ExtendedNetworkServerAPI) GetDeviceChannelPlanStatus(ctx context.Context, req *extapi.GetDeviceChannelPlanStatusRequest) (*extapi.GetDeviceChannelPlanStatusResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	d, err := storage.GetDevice(ctx, storage.DB(), devEUI, false)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	dp, err := storage.GetDeviceProfile(ctx, storage.DB(), d.DeviceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if dp.ChannelPlanID == nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "device-profile does not have a channel-plan")
+	}
+
+	cp, err := storage.GetChannelPlan(ctx, storage.DB(), *dp.ChannelPlanID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ds, err := storage.GetDeviceSession(ctx, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	status, err := channels.GetChannelPlanStatus(ds, cp)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := extapi.GetDeviceChannelPlanStatusResponse{
+		ChannelPlanId: cp.ID.Bytes(),
+		InSync:        status.InSync(),
+	}
+
+	for _, c := range status.Channels {
+		resp.Channels = append(resp.Channels, &extapi.DeviceChannelStatus{
+			Channel:            uint32(c.Channel),
+			PlanChannel:        channelPlanChannelToPB(c.PlanChannel),
+			UplinkConfigured:   c.UplinkConfigured,
+			UplinkEnabled:      c.UplinkEnabled,
+			DownlinkConfigured: c.DownlinkConfigured,
+		})
+	}
+
+	for _, c := range status.PendingRemovalChannels {
+		resp.PendingRemovalChannels = append(resp.PendingRemovalChannels, uint32(c))
+	}
+
+	return &resp, nil
+}
+
+// channelPlanID returns the channel-plan ID for the given bytes.
+func channelPlanID(b []byte) (uuid.UUID, error) {
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		return id, grpc.Errorf(codes.InvalidArgument, "invalid id: %s", err)
+	}
+	return id, nil
+}
+
+// channelPlanFromPB returns the validated channel-plan for the given
+// channel-plan message.
+func channelPlanFromPB(pb *extapi.ChannelPlan) (storage.ChannelPlan, error) {
+	cp := storage.ChannelPlan{
+		Name: pb.Name,
+	}
+	copy(cp.ID[:], pb.Id)
+
+	for _, c := range pb.Channels {
+		cp.Channels = append(cp.Channels, storage.ChannelPlanChannel{
+			Frequency:         c.Frequency,
+			MinDR:             int(c.MinDr),
+			MaxDR:             int(c.MaxDr),
+			DownlinkFrequency: c.DownlinkFrequency,
+		})
+	}
+
+	if err := cp.Validate(); err != nil {
+		return cp, grpc.Errorf(codes.InvalidArgument, "invalid channel_plan: %s", err)
+	}
+
+	return cp, nil
+}
+
+func channelPlanChannelToPB(c storage.ChannelPlanChannel) *extapi.ChannelPlanChannel {
+	return &extapi.ChannelPlanChannel{
+		Frequency:         c.Frequency,
+		MinDr:             uint32(c.MinDR),
+		MaxDr:             uint32(c.MaxDR),
+		DownlinkFrequency: c.DownlinkFrequency,
+	}
+}
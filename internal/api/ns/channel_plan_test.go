@@ -0,0 +1,178 @@
+package ns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+	"github.com/liuhw0/lorawan"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+func (ts *NetworkServerAPITestSuite) TestChannelPlan() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+	api := NewExtendedNetworkServerAPI()
+
+	conf := test.GetConfig()
+	for _, f := range []uint32{867100000, 867300000} {
+		conf.NetworkServer.NetworkSettings.ExtraChannels = append(conf.NetworkServer.NetworkSettings.ExtraChannels, struct {
+			Frequency uint32 `mapstructure:"frequency"`
+			MinDR     int    `mapstructure:"min_dr"`
+			MaxDR     int    `mapstructure:"max_dr"`
+		}{
+			Frequency: f,
+			MinDR:     0,
+			MaxDR:     5,
+		})
+	}
+	assert.NoError(band.Setup(conf))
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	var id []byte
+
+	ts.T().Run("Create invalid", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.CreateChannelPlan(ctx, &extapi.CreateChannelPlanRequest{
+			ChannelPlan: &extapi.ChannelPlan{
+				Name: "invalid",
+				Channels: []*extapi.ChannelPlanChannel{
+					{Frequency: 867900000, MaxDr: 5},
+				},
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Create", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.CreateChannelPlan(ctx, &extapi.CreateChannelPlanRequest{
+			ChannelPlan: &extapi.ChannelPlan{
+				Name: "test-plan",
+				Channels: []*extapi.ChannelPlanChannel{
+					{Frequency: 867100000, MaxDr: 5, DownlinkFrequency: 869000000},
+				},
+			},
+		})
+		assert.NoError(err)
+		id = resp.Id
+	})
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.GetChannelPlan(ctx, &extapi.GetChannelPlanRequest{Id: id})
+		assert.NoError(err)
+		assert.Equal("test-plan", resp.ChannelPlan.Name)
+		assert.Len(resp.ChannelPlan.Channels, 1)
+		assert.EqualValues(869000000, resp.ChannelPlan.Channels[0].DownlinkFrequency)
+	})
+
+	ts.T().Run("Update", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateChannelPlan(ctx, &extapi.UpdateChannelPlanRequest{
+			ChannelPlan: &extapi.ChannelPlan{
+				Id:   id,
+				Name: "test-plan-updated",
+				Channels: []*extapi.ChannelPlanChannel{
+					{Frequency: 867100000, MaxDr: 5, DownlinkFrequency: 869000000},
+					{Frequency: 867300000, MaxDr: 5},
+				},
+			},
+		})
+		assert.NoError(err)
+
+		resp, err := api.GetChannelPlan(ctx, &extapi.GetChannelPlanRequest{Id: id})
+		assert.NoError(err)
+		assert.Equal("test-plan-updated", resp.ChannelPlan.Name)
+		assert.Len(resp.ChannelPlan.Channels, 2)
+	})
+
+	ts.T().Run("List", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.ListChannelPlans(ctx, &extapi.ListProfilesRequest{})
+		assert.NoError(err)
+		assert.Len(resp.Result, 1)
+		assert.Equal(id, resp.Result[0].Id)
+	})
+
+	ts.T().Run("Device status", func(t *testing.T) {
+		assert := require.New(t)
+
+		var sp storage.ServiceProfile
+		var dp storage.DeviceProfile
+		var rp storage.RoutingProfile
+		assert.NoError(storage.CreateServiceProfile(ctx, storage.DB(), &sp))
+		assert.NoError(storage.CreateDeviceProfile(ctx, storage.DB(), &dp))
+		assert.NoError(storage.CreateRoutingProfile(ctx, storage.DB(), &rp))
+
+		d := storage.Device{
+			DevEUI:           lorawan.EUI64{3, 1, 1, 1, 1, 1, 1, 1},
+			ServiceProfileID: sp.ID,
+			DeviceProfileID:  dp.ID,
+			RoutingProfileID: rp.ID,
+		}
+		assert.NoError(storage.CreateDevice(ctx, storage.DB(), &d))
+
+		assert.NoError(storage.SaveDeviceSession(ctx, storage.DeviceSession{
+			DevEUI:                d.DevEUI,
+			DeviceProfileID:       dp.ID,
+			ServiceProfileID:      sp.ID,
+			RoutingProfileID:      rp.ID,
+			EnabledUplinkChannels: []int{0, 1, 2, 3},
+			ExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+			},
+		}))
+
+		// the device-profile does not have a channel-plan
+		_, err := api.GetDeviceChannelPlanStatus(ctx, &extapi.GetDeviceChannelPlanStatusRequest{DevEui: d.DevEUI[:]})
+		assert.Equal(codes.FailedPrecondition, grpc.Code(err))
+
+		_, err = api.UpdateDeviceProfileSettings(ctx, &extapi.UpdateDeviceProfileSettingsRequest{
+			Settings: &extapi.DeviceProfileSettings{
+				DeviceProfileId: dp.ID.Bytes(),
+				ChannelPlanId:   id,
+			},
+		})
+		assert.NoError(err)
+
+		settings, err := api.GetDeviceProfileSettings(ctx, &extapi.GetDeviceProfileSettingsRequest{DeviceProfileId: dp.ID.Bytes()})
+		assert.NoError(err)
+		assert.Equal(id, settings.Settings.ChannelPlanId)
+
+		resp, err := api.GetDeviceChannelPlanStatus(ctx, &extapi.GetDeviceChannelPlanStatusRequest{DevEui: d.DevEUI[:]})
+		assert.NoError(err)
+		assert.False(resp.InSync)
+		assert.Len(resp.Channels, 2)
+		assert.EqualValues(3, resp.Channels[0].Channel)
+		assert.True(resp.Channels[0].UplinkConfigured)
+		assert.True(resp.Channels[0].UplinkEnabled)
+		assert.False(resp.Channels[0].DownlinkConfigured)
+		assert.EqualValues(4, resp.Channels[1].Channel)
+		assert.False(resp.Channels[1].UplinkConfigured)
+	})
+
+	ts.T().Run("Delete", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.DeleteChannelPlan(ctx, &extapi.DeleteChannelPlanRequest{Id: id})
+		assert.NoError(err)
+
+		_, err = api.GetChannelPlan(ctx, &extapi.GetChannelPlanRequest{Id: id})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+}
@@ -0,0 +1,126 @@
+package ns
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+const (
+	// minUplinkHistorySize defines the min. uplink history size that can be
+	// configured, as the ADR algorithms require at least the default
+	// number of uplinks.
+	minUplinkHistorySize = storage.UplinkHistorySize
+
+	// maxUplinkHistorySize defines the max. uplink history size that can be
+	// configured, as the uplink history is stored in the device-session.
+	maxUplinkHistorySize = 200
+)
+
+// GetDeviceProfileSettings returns the network-server specific settings of
+// the given device-profile.
+func (n *ExtendedNetworkServerAPI) GetDeviceProfileSettings(ctx context.Context, req *extapi.GetDeviceProfileSettingsRequest) (*extapi.GetDeviceProfileSettingsResponse, error) {
+	var dpID uuid.UUID
+	copy(dpID[:], req.DeviceProfileId)
+
+	dp, err := storage.GetDeviceProfile(ctx, storage.DB(), dpID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := extapi.GetDeviceProfileSettingsResponse{
+		Settings: &extapi.DeviceProfileSettings{
+			DeviceProfileId:   dp.ID.Bytes(),
+			UplinkHistorySize: uint32(dp.UplinkHistorySize),
+			AdrScript:         dp.ADRScript,
+			Quirks: &extapi.DeviceQuirks{
+				TxPower_0Unsupported:  dp.Quirks.TXPower0Unsupported,
+				IgnoreChannelMaskNack: dp.Quirks.IgnoreChannelMaskNACK,
+				NoRxtimingsetup:       dp.Quirks.NoRXTimingSetup,
+				MaxFoptsLen:           uint32(dp.Quirks.MaxFOptsLen),
+			},
+		},
+	}
+
+	if dp.ChannelPlanID != nil {
+		resp.Settings.ChannelPlanId = dp.ChannelPlanID.Bytes()
+	}
+
+	return &resp, nil
+}
+
+// UpdateDeviceProfileSettings updates the network-server specific settings
+// of the given device-profile.
+func (n *ExtendedNetworkServerAPI) UpdateDeviceProfileSettings(ctx context.Context, req *extapi.UpdateDeviceProfileSettingsRequest) (*empty.Empty, error) {
+	if req.Settings == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "settings must not be nil")
+	}
+
+	if req.Settings.UplinkHistorySize > maxUplinkHistorySize {
+		return nil, grpc.Errorf(codes.InvalidArgument, "uplink_history_size must be <= %d", maxUplinkHistorySize)
+	}
+
+	// 0 = default
+	if req.Settings.UplinkHistorySize != 0 && req.Settings.UplinkHistorySize < minUplinkHistorySize {
+		return nil, grpc.Errorf(codes.InvalidArgument, "uplink_history_size must be >= %d", minUplinkHistorySize)
+	}
+
+	if req.Settings.AdrScript != "" {
+		if err := adr.ValidateScript(req.Settings.AdrScript); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "invalid adr_script: %s", err)
+		}
+	}
+
+	var quirks storage.DeviceQuirks
+	if q := req.Settings.Quirks; q != nil {
+		quirks = storage.DeviceQuirks{
+			TXPower0Unsupported:   q.TxPower_0Unsupported,
+			IgnoreChannelMaskNACK: q.IgnoreChannelMaskNack,
+			NoRXTimingSetup:       q.NoRxtimingsetup,
+			MaxFOptsLen:           int(q.MaxFoptsLen),
+		}
+	}
+	if err := quirks.Validate(); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid quirks: %s", err)
+	}
+
+	var dpID uuid.UUID
+	copy(dpID[:], req.Settings.DeviceProfileId)
+
+	dp, err := storage.GetDeviceProfile(ctx, storage.DB(), dpID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var channelPlanID *uuid.UUID
+	if len(req.Settings.ChannelPlanId) != 0 {
+		var id uuid.UUID
+		copy(id[:], req.Settings.ChannelPlanId)
+
+		if _, err := storage.GetChannelPlan(ctx, storage.DB(), id); err != nil {
+			return nil, errToRPCError(err)
+		}
+		channelPlanID = &id
+	}
+
+	dp.UplinkHistorySize = int(req.Settings.UplinkHistorySize)
+	dp.ADRScript = req.Settings.AdrScript
+	dp.Quirks = quirks
+	dp.ChannelPlanID = channelPlanID
+
+	if err := storage.FlushDeviceProfileCache(ctx, dp.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.UpdateDeviceProfile(ctx, storage.DB(), &dp); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
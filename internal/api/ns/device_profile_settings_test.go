@@ -0,0 +1,125 @@
+package ns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+func (ts *NetworkServerAPITestSuite) TestDeviceProfileSettings() {
+	assert := require.New(ts.T())
+	api := NewExtendedNetworkServerAPI()
+
+	dp := storage.DeviceProfile{}
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	ts.T().Run("Get default", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.GetDeviceProfileSettings(context.Background(), &extapi.GetDeviceProfileSettingsRequest{
+			DeviceProfileId: dp.ID.Bytes(),
+		})
+		assert.NoError(err)
+		assert.EqualValues(0, resp.Settings.UplinkHistorySize)
+	})
+
+	ts.T().Run("Update", func(t *testing.T) {
+		assert := require.New(t)
+
+		// make sure the cache is flushed
+		assert.NoError(storage.CreateDeviceProfileCache(context.Background(), dp))
+
+		_, err := api.UpdateDeviceProfileSettings(context.Background(), &extapi.UpdateDeviceProfileSettingsRequest{
+			Settings: &extapi.DeviceProfileSettings{
+				DeviceProfileId:   dp.ID.Bytes(),
+				UplinkHistorySize: 40,
+			},
+		})
+		assert.NoError(err)
+
+		dpGet, err := storage.GetAndCacheDeviceProfile(context.Background(), storage.DB(), dp.ID)
+		assert.NoError(err)
+		assert.Equal(40, dpGet.UplinkHistorySize)
+	})
+
+	ts.T().Run("Update quirks", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateDeviceProfileSettings(context.Background(), &extapi.UpdateDeviceProfileSettingsRequest{
+			Settings: &extapi.DeviceProfileSettings{
+				DeviceProfileId: dp.ID.Bytes(),
+				Quirks: &extapi.DeviceQuirks{
+					TxPower_0Unsupported: true,
+					NoRxtimingsetup:      true,
+					MaxFoptsLen:          10,
+				},
+			},
+		})
+		assert.NoError(err)
+
+		resp, err := api.GetDeviceProfileSettings(context.Background(), &extapi.GetDeviceProfileSettingsRequest{
+			DeviceProfileId: dp.ID.Bytes(),
+		})
+		assert.NoError(err)
+		assert.True(resp.Settings.Quirks.TxPower_0Unsupported)
+		assert.False(resp.Settings.Quirks.IgnoreChannelMaskNack)
+		assert.True(resp.Settings.Quirks.NoRxtimingsetup)
+		assert.EqualValues(10, resp.Settings.Quirks.MaxFoptsLen)
+	})
+
+	ts.T().Run("Update invalid quirks", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateDeviceProfileSettings(context.Background(), &extapi.UpdateDeviceProfileSettingsRequest{
+			Settings: &extapi.DeviceProfileSettings{
+				DeviceProfileId: dp.ID.Bytes(),
+				Quirks: &extapi.DeviceQuirks{
+					MaxFoptsLen: 16,
+				},
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Update exceeding max", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateDeviceProfileSettings(context.Background(), &extapi.UpdateDeviceProfileSettingsRequest{
+			Settings: &extapi.DeviceProfileSettings{
+				DeviceProfileId:   dp.ID.Bytes(),
+				UplinkHistorySize: maxUplinkHistorySize + 1,
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Update below min", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateDeviceProfileSettings(context.Background(), &extapi.UpdateDeviceProfileSettingsRequest{
+			Settings: &extapi.DeviceProfileSettings{
+				DeviceProfileId:   dp.ID.Bytes(),
+				UplinkHistorySize: minUplinkHistorySize - 1,
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Update invalid adr script", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateDeviceProfileSettings(context.Background(), &extapi.UpdateDeviceProfileSettingsRequest{
+			Settings: &extapi.DeviceProfileSettings{
+				DeviceProfileId: dp.ID.Bytes(),
+				AdrScript:       "function handle(req) {",
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+}
@@ -0,0 +1,40 @@
+package ns
+
+import (
+	"math"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+// CreateDeviceQueueItem creates the given device-queue item with the given
+// priority. Items with a higher priority are transmitted first.
+func (n *ExtendedNetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *extapi.CreateDeviceQueueItemRequest) (*empty.Empty, error) {
+	if req.Item == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
+	}
+
+	if req.Item.Priority < math.MinInt16 || req.Item.Priority > math.MaxInt16 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "priority must be between %d and %d", math.MinInt16, math.MaxInt16)
+	}
+
+	var qi storage.DeviceQueueItem
+	copy(qi.DevEUI[:], req.Item.DevEui)
+	copy(qi.DevAddr[:], req.Item.DevAddr)
+	qi.FRMPayload = req.Item.FrmPayload
+	qi.FCnt = req.Item.FCnt
+	qi.FPort = uint8(req.Item.FPort)
+	qi.Confirmed = req.Item.Confirmed
+	qi.Priority = int(req.Item.Priority)
+
+	if err := createDeviceQueueItem(ctx, qi); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,373 @@
+package ns
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+const (
+	// defaultPageSize defines the page-size used when the request does not
+	// set a limit.
+	defaultPageSize = 100
+
+	// maxPageSize defines the max. page-size.
+	maxPageSize = 1000
+)
+
+// ExtendedNetworkServerAPI implements the network-server API methods which
+// are not part of the ChirpStack NetworkServerService.
+type ExtendedNetworkServerAPI struct{}
+
+// NewExtendedNetworkServerAPI returns a new ExtendedNetworkServerAPI.
+func NewExtendedNetworkServerAPI() *ExtendedNetworkServerAPI {
+	return &ExtendedNetworkServerAPI{}
+}
+
+// ListDevices returns the devices matching the given filters.
+func (n *ExtendedNetworkServerAPI) ListDevices(ctx context.Context, req *extapi.ListDevicesRequest) (*extapi.ListDevicesResponse, error) {
+	var err error
+	filters := storage.DeviceFilters{
+		Limit: pageSize(req.Limit) + 1,
+	}
+
+	if filters.DeviceProfileID, err = uuidFilter("device_profile_id", req.DeviceProfileId); err != nil {
+		return nil, err
+	}
+	if filters.ServiceProfileID, err = uuidFilter("service_profile_id", req.ServiceProfileId); err != nil {
+		return nil, err
+	}
+	if filters.RoutingProfileID, err = uuidFilter("routing_profile_id", req.RoutingProfileId); err != nil {
+		return nil, err
+	}
+	if filters.After, err = euiPageToken(req.PageToken); err != nil {
+		return nil, err
+	}
+
+	var mode storage.DeviceMode
+	switch req.Mode {
+	case extapi.DeviceModeFilter_MODE_A:
+		mode = storage.DeviceModeA
+	case extapi.DeviceModeFilter_MODE_B:
+		mode = storage.DeviceModeB
+	case extapi.DeviceModeFilter_MODE_C:
+		mode = storage.DeviceModeC
+	}
+	if mode != "" {
+		filters.Mode = &mode
+	}
+
+	var disabled bool
+	switch req.Disabled {
+	case extapi.DisabledFilter_ENABLED:
+		filters.IsDisabled = &disabled
+	case extapi.DisabledFilter_DISABLED:
+		disabled = true
+		filters.IsDisabled = &disabled
+	}
+
+	devices, err := storage.GetDevices(ctx, storage.DB(), filters)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp extapi.ListDevicesResponse
+	if len(devices) == filters.Limit {
+		devices = devices[:len(devices)-1]
+		resp.NextPageToken = devices[len(devices)-1].DevEUI.String()
+	}
+
+	for _, d := range devices {
+		item := extapi.DeviceListItem{
+			DevEui:           d.DevEUI[:],
+			DeviceProfileId:  d.DeviceProfileID.Bytes(),
+			ServiceProfileId: d.ServiceProfileID.Bytes(),
+			RoutingProfileId: d.RoutingProfileID.Bytes(),
+			Mode:             string(d.Mode),
+			IsDisabled:       d.IsDisabled,
+		}
+
+		if item.CreatedAt, err = ptypes.TimestampProto(d.CreatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if item.UpdatedAt, err = ptypes.TimestampProto(d.UpdatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// ListGateways returns the gateways matching the given filters.
+func (n *ExtendedNetworkServerAPI) ListGateways(ctx context.Context, req *extapi.ListGatewaysRequest) (*extapi.ListGatewaysResponse, error) {
+	var err error
+	filters := storage.GatewayFilters{
+		Limit: pageSize(req.Limit) + 1,
+	}
+
+	if filters.RoutingProfileID, err = uuidFilter("routing_profile_id", req.RoutingProfileId); err != nil {
+		return nil, err
+	}
+	if filters.ServiceProfileID, err = uuidFilter("service_profile_id", req.ServiceProfileId); err != nil {
+		return nil, err
+	}
+	if filters.GatewayProfileID, err = uuidFilter("gateway_profile_id", req.GatewayProfileId); err != nil {
+		return nil, err
+	}
+	if filters.LastSeenAfter, err = timeFilter("last_seen_after", req.LastSeenAfter); err != nil {
+		return nil, err
+	}
+	if filters.LastSeenBefore, err = timeFilter("last_seen_before", req.LastSeenBefore); err != nil {
+		return nil, err
+	}
+	if filters.After, err = euiPageToken(req.PageToken); err != nil {
+		return nil, err
+	}
+
+	gws, err := storage.GetGateways(ctx, storage.DB(), filters)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp extapi.ListGatewaysResponse
+	if len(gws) == filters.Limit {
+		gws = gws[:len(gws)-1]
+		resp.NextPageToken = gws[len(gws)-1].GatewayID.String()
+	}
+
+	for _, gw := range gws {
+		item := extapi.GatewayListItem{
+			GatewayId:        gw.GatewayID[:],
+			RoutingProfileId: gw.RoutingProfileID.Bytes(),
+		}
+
+		if gw.ServiceProfileID != nil {
+			item.ServiceProfileId = gw.ServiceProfileID.Bytes()
+		}
+		if gw.GatewayProfileID != nil {
+			item.GatewayProfileId = gw.GatewayProfileID.Bytes()
+		}
+
+		if item.CreatedAt, err = ptypes.TimestampProto(gw.CreatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if item.UpdatedAt, err = ptypes.TimestampProto(gw.UpdatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if gw.FirstSeenAt != nil {
+			if item.FirstSeenAt, err = ptypes.TimestampProto(*gw.FirstSeenAt); err != nil {
+				return nil, errToRPCError(err)
+			}
+		}
+		if gw.LastSeenAt != nil {
+			if item.LastSeenAt, err = ptypes.TimestampProto(*gw.LastSeenAt); err != nil {
+				return nil, errToRPCError(err)
+			}
+		}
+
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// ListMulticastGroups returns the multicast-groups matching the given
+// filters.
+func (n *ExtendedNetworkServerAPI) ListMulticastGroups(ctx context.Context, req *extapi.ListMulticastGroupsRequest) (*extapi.ListMulticastGroupsResponse, error) {
+	var err error
+	filters := storage.MulticastGroupFilters{
+		Limit: pageSize(req.Limit) + 1,
+	}
+
+	if filters.ServiceProfileID, err = uuidFilter("service_profile_id", req.ServiceProfileId); err != nil {
+		return nil, err
+	}
+	if filters.RoutingProfileID, err = uuidFilter("routing_profile_id", req.RoutingProfileId); err != nil {
+		return nil, err
+	}
+	if filters.After, err = uuidPageToken(req.PageToken); err != nil {
+		return nil, err
+	}
+
+	var groupType storage.MulticastGroupType
+	switch req.GroupType {
+	case extapi.MulticastGroupTypeFilter_CLASS_B:
+		groupType = storage.MulticastGroupB
+	case extapi.MulticastGroupTypeFilter_CLASS_C:
+		groupType = storage.MulticastGroupC
+	}
+	if groupType != "" {
+		filters.GroupType = &groupType
+	}
+
+	mgs, err := storage.GetMulticastGroups(ctx, storage.DB(), filters)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp extapi.ListMulticastGroupsResponse
+	if len(mgs) == filters.Limit {
+		mgs = mgs[:len(mgs)-1]
+		resp.NextPageToken = mgs[len(mgs)-1].ID.String()
+	}
+
+	for _, mg := range mgs {
+		item := extapi.MulticastGroupListItem{
+			Id:               mg.ID.Bytes(),
+			McAddr:           mg.MCAddr[:],
+			GroupType:        string(mg.GroupType),
+			ServiceProfileId: mg.ServiceProfileID.Bytes(),
+			RoutingProfileId: mg.RoutingProfileID.Bytes(),
+		}
+
+		if item.CreatedAt, err = ptypes.TimestampProto(mg.CreatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if item.UpdatedAt, err = ptypes.TimestampProto(mg.UpdatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// ListDeviceProfiles returns the device-profiles.
+func (n *ExtendedNetworkServerAPI) ListDeviceProfiles(ctx context.Context, req *extapi.ListProfilesRequest) (*extapi.ListProfilesResponse, error) {
+	return listProfiles(ctx, req, storage.GetDeviceProfiles)
+}
+
+// ListServiceProfiles returns the service-profiles.
+func (n *ExtendedNetworkServerAPI) ListServiceProfiles(ctx context.Context, req *extapi.ListProfilesRequest) (*extapi.ListProfilesResponse, error) {
+	return listProfiles(ctx, req, storage.GetServiceProfiles)
+}
+
+// ListRoutingProfiles returns the routing-profiles.
+func (n *ExtendedNetworkServerAPI) ListRoutingProfiles(ctx context.Context, req *extapi.ListProfilesRequest) (*extapi.ListProfilesResponse, error) {
+	return listProfiles(ctx, req, storage.GetRoutingProfiles)
+}
+
+type getProfilesFunc func(context.Context, sqlx.Queryer, storage.ProfileFilters) ([]storage.ProfileListItem, error)
+
+func listProfiles(ctx context.Context, req *extapi.ListProfilesRequest, getProfiles getProfilesFunc) (*extapi.ListProfilesResponse, error) {
+	var err error
+	filters := storage.ProfileFilters{
+		Limit: pageSize(req.Limit) + 1,
+	}
+
+	if filters.After, err = uuidPageToken(req.PageToken); err != nil {
+		return nil, err
+	}
+
+	profiles, err := getProfiles(ctx, storage.DB(), filters)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp extapi.ListProfilesResponse
+	if len(profiles) == filters.Limit {
+		profiles = profiles[:len(profiles)-1]
+		resp.NextPageToken = profiles[len(profiles)-1].ID.String()
+	}
+
+	for _, p := range profiles {
+		item := extapi.ProfileListItem{
+			Id: p.ID.Bytes(),
+		}
+
+		if item.CreatedAt, err = ptypes.TimestampProto(p.CreatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+		if item.UpdatedAt, err = ptypes.TimestampProto(p.UpdatedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// pageSize returns the page-size for the given requested limit.
+func pageSize(limit uint32) int {
+	if limit == 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+	return int(limit)
+}
+
+// uuidFilter returns the UUID filter for the given bytes. It returns nil
+// when no filter is set.
+func uuidFilter(field string, b []byte) (*uuid.UUID, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	id, err := uuid.FromBytes(b)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid %s: %s", field, err)
+	}
+
+	return &id, nil
+}
+
+// timeFilter returns the time filter for the given timestamp. It returns nil
+// when no filter is set.
+func timeFilter(field string, ts *timestamp.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid %s: %s", field, err)
+	}
+
+	return &t, nil
+}
+
+// euiPageToken decodes the given EUI64 page token. It returns nil when the
+// token is empty.
+func euiPageToken(token string) (*lorawan.EUI64, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	var eui lorawan.EUI64
+	if err := eui.UnmarshalText([]byte(token)); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid page_token: %s", err)
+	}
+
+	return &eui, nil
+}
+
+// uuidPageToken decodes the given UUID page token. It returns nil when the
+// token is empty.
+func uuidPageToken(token string) (*uuid.UUID, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.FromString(token)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid page_token: %s", err)
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,50 @@
+package ns
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/liuhw0/lorawan"
+)
+
+func TestPageSize(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(defaultPageSize, pageSize(0))
+	assert.Equal(10, pageSize(10))
+	assert.Equal(maxPageSize, pageSize(maxPageSize+1))
+}
+
+func TestPageTokens(t *testing.T) {
+	assert := require.New(t)
+
+	eui, err := euiPageToken("")
+	assert.NoError(err)
+	assert.Nil(eui)
+
+	eui, err = euiPageToken("0102030405060708")
+	assert.NoError(err)
+	assert.Equal(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, *eui)
+
+	_, err = euiPageToken("foo")
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	id, err := uuidPageToken("")
+	assert.NoError(err)
+	assert.Nil(id)
+
+	u, _ := uuid.NewV4()
+	id, err = uuidPageToken(u.String())
+	assert.NoError(err)
+	assert.Equal(u, *id)
+
+	_, err = uuidPageToken("foo")
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = uuidFilter("device_profile_id", []byte{1, 2, 3})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,263 @@
+package ns
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway/translator"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+// GetGatewayConfigState returns the last applied and pending configuration
+// versions of the given gateway.
+func (n *ExtendedNetworkServerAPI) GetGatewayConfigState(ctx context.Context, req *extapi.GetGatewayConfigStateRequest) (*extapi.GetGatewayConfigStateResponse, error) {
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], req.GatewayId)
+
+	gw, err := storage.GetGateway(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	state, err := storage.GetGatewayConfigState(ctx, storage.DB(), gatewayID)
+	if err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return nil, errToRPCError(err)
+	}
+
+	resp := extapi.GetGatewayConfigStateResponse{
+		Translator:     state.Translator,
+		AppliedVersion: state.AppliedVersion,
+		PendingVersion: state.PendingVersion,
+	}
+
+	if gw.GatewayProfileID != nil {
+		gwProfile, err := storage.GetGatewayProfile(ctx, storage.DB(), *gw.GatewayProfileID)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		resp.ProfileVersion = gw.Overrides.GetVersion(gwProfile)
+	}
+
+	if state.AppliedAt != nil {
+		if resp.AppliedAt, err = ptypes.TimestampProto(*state.AppliedAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+	if state.PendingAt != nil {
+		if resp.PendingAt, err = ptypes.TimestampProto(*state.PendingAt); err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	return &resp, nil
+}
+
+// UpdateGatewayConfigTranslator sets the translator used for pushing the
+// gateway-profile configuration to the given gateway.
+func (n *ExtendedNetworkServerAPI) UpdateGatewayConfigTranslator(ctx context.Context, req *extapi.UpdateGatewayConfigTranslatorRequest) (*empty.Empty, error) {
+	if req.Translator != "" {
+		if _, err := translator.Get(req.Translator); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%s", err)
+		}
+	}
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], req.GatewayId)
+
+	gw, err := storage.GetGateway(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := translator.ValidateOverrides(req.Translator, gw.Overrides); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "overrides are not supported by the translator: %s", err)
+	}
+
+	state, err := storage.GetGatewayConfigState(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		if errors.Cause(err) != storage.ErrDoesNotExist {
+			return nil, errToRPCError(err)
+		}
+		state = storage.GatewayConfigState{GatewayID: gatewayID}
+	}
+
+	// Changing the translator invalidates the pending configuration, so that
+	// the configuration is re-sent using the new translator.
+	if state.Translator != req.Translator {
+		state.Translator = req.Translator
+		state.PendingVersion = ""
+		state.PendingAt = nil
+	}
+
+	if err := storage.SaveGatewayConfigState(ctx, storage.DB(), &state); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.FlushGatewayConfigStateCache(ctx, gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetGatewayOverrides returns the configuration overrides of the given
+// gateway.
+func (n *ExtendedNetworkServerAPI) GetGatewayOverrides(ctx context.Context, req *extapi.GetGatewayOverridesRequest) (*extapi.GetGatewayOverridesResponse, error) {
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], req.GatewayId)
+
+	gw, err := storage.GetGateway(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	o := gw.Overrides
+	resp := extapi.GetGatewayOverridesResponse{
+		Overrides: &extapi.GatewayOverrides{
+			Channels:         o.Channels,
+			AntennaGain:      int32(o.AntennaGain),
+			DownlinkDisabled: o.DownlinkDisabled,
+		},
+	}
+
+	for _, b := range o.Boards {
+		resp.Overrides.Boards = append(resp.Overrides.Boards, &extapi.GatewayBoardOverrides{
+			RadioFrequencies: b.RadioFrequencies,
+			DownlinkDisabled: b.DownlinkDisabled,
+		})
+	}
+
+	if o.MaxTXPower != nil {
+		resp.Overrides.MaxTxPower = &wrappers.Int32Value{Value: int32(*o.MaxTXPower)}
+	}
+
+	if o.LBT != nil {
+		resp.Overrides.Lbt = &extapi.GatewayLBT{
+			RssiTarget: int32(o.LBT.RSSITarget),
+			ScanTimeUs: uint32(o.LBT.ScanTime),
+		}
+	}
+
+	return &resp, nil
+}
+
+// UpdateGatewayOverrides updates the configuration overrides of the given
+// gateway.
+func (n *ExtendedNetworkServerAPI) UpdateGatewayOverrides(ctx context.Context, req *extapi.UpdateGatewayOverridesRequest) (*empty.Empty, error) {
+	var overrides storage.GatewayOverrides
+	if o := req.Overrides; o != nil {
+		overrides = storage.GatewayOverrides{
+			Channels:         o.Channels,
+			AntennaGain:      int(o.AntennaGain),
+			DownlinkDisabled: o.DownlinkDisabled,
+		}
+
+		for _, b := range o.Boards {
+			if b == nil {
+				return nil, grpc.Errorf(codes.InvalidArgument, "board must not be nil")
+			}
+
+			overrides.Boards = append(overrides.Boards, storage.GatewayBoardOverrides{
+				RadioFrequencies: b.RadioFrequencies,
+				DownlinkDisabled: b.DownlinkDisabled,
+			})
+		}
+
+		if o.MaxTxPower != nil {
+			maxTXPower := int(o.MaxTxPower.Value)
+			overrides.MaxTXPower = &maxTXPower
+		}
+
+		if o.Lbt != nil {
+			overrides.LBT = &storage.GatewayLBT{
+				RSSITarget: int(o.Lbt.RssiTarget),
+				ScanTime:   int(o.Lbt.ScanTimeUs),
+			}
+		}
+	}
+
+	if err := overrides.Validate(); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid overrides: %s", err)
+	}
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], req.GatewayId)
+
+	gw, err := storage.GetGateway(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := validateGatewayOverrides(ctx, gw, overrides); err != nil {
+		return nil, err
+	}
+
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		gw, err := storage.GetGateway(ctx, tx, gatewayID)
+		if err != nil {
+			return err
+		}
+
+		gw.Overrides = overrides
+		return storage.UpdateGateway(ctx, tx, &gw)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// validateGatewayOverrides validates that the channels of the given overrides
+// are channels of the gateway-profile of the gateway and that the overrides
+// can be expressed by the configuration translator of the gateway.
+func validateGatewayOverrides(ctx context.Context, gw storage.Gateway, overrides storage.GatewayOverrides) error {
+	if len(overrides.Channels) != 0 {
+		if gw.GatewayProfileID == nil {
+			return grpc.Errorf(codes.InvalidArgument, "invalid overrides: channels require a gateway-profile")
+		}
+
+		gp, err := storage.GetGatewayProfile(ctx, storage.DB(), *gw.GatewayProfileID)
+		if err != nil {
+			return errToRPCError(err)
+		}
+
+		freqs := make(map[uint32]struct{})
+		for _, i := range gp.Channels {
+			c, err := band.Band().GetUplinkChannel(int(i))
+			if err != nil {
+				return errToRPCError(err)
+			}
+			freqs[uint32(c.Frequency)] = struct{}{}
+		}
+		for _, c := range gp.ExtraChannels {
+			freqs[uint32(c.Frequency)] = struct{}{}
+		}
+
+		for _, f := range overrides.Channels {
+			if _, ok := freqs[f]; !ok {
+				return grpc.Errorf(codes.InvalidArgument, "invalid overrides: channel %d is not a gateway-profile channel", f)
+			}
+		}
+	}
+
+	state, err := storage.GetGatewayConfigState(ctx, storage.DB(), gw.GatewayID)
+	if err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return errToRPCError(err)
+	}
+
+	if err := translator.ValidateOverrides(state.Translator, overrides); err != nil {
+		return grpc.Errorf(codes.InvalidArgument, "invalid overrides: %s", err)
+	}
+
+	return nil
+}
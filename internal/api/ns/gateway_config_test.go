@@ -0,0 +1,219 @@
+package ns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway/translator"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+func (ts *NetworkServerAPITestSuite) TestGatewayConfigState() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+	api := NewExtendedNetworkServerAPI()
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(ctx, storage.DB(), &rp))
+
+	gp := storage.GatewayProfile{
+		Channels: []int64{0, 1, 2},
+	}
+	assert.NoError(storage.CreateGatewayProfile(ctx, storage.DB(), &gp))
+
+	gw := storage.Gateway{
+		GatewayID:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 1},
+		RoutingProfileID: rp.ID,
+		GatewayProfileID: &gp.ID,
+	}
+	assert.NoError(storage.CreateGateway(ctx, storage.DB(), &gw))
+
+	ts.T().Run("Get unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.GetGatewayConfigState(ctx, &extapi.GetGatewayConfigStateRequest{
+			GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2},
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+
+	ts.T().Run("Get without state", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.GetGatewayConfigState(ctx, &extapi.GetGatewayConfigStateRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.Equal(&extapi.GetGatewayConfigStateResponse{
+			ProfileVersion: gp.GetVersion(),
+		}, resp)
+	})
+
+	ts.T().Run("Update invalid translator", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateGatewayConfigTranslator(ctx, &extapi.UpdateGatewayConfigTranslatorRequest{
+			GatewayId:  gw.GatewayID[:],
+			Translator: "foo",
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Update translator", func(t *testing.T) {
+		assert := require.New(t)
+
+		state := storage.GatewayConfigState{
+			GatewayID:      gw.GatewayID,
+			PendingVersion: gp.GetVersion(),
+		}
+		assert.NoError(storage.SaveGatewayConfigState(ctx, storage.DB(), &state))
+
+		_, err := api.UpdateGatewayConfigTranslator(ctx, &extapi.UpdateGatewayConfigTranslatorRequest{
+			GatewayId:  gw.GatewayID[:],
+			Translator: translator.PacketForwarder,
+		})
+		assert.NoError(err)
+
+		resp, err := api.GetGatewayConfigState(ctx, &extapi.GetGatewayConfigStateRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.Equal(&extapi.GetGatewayConfigStateResponse{
+			Translator:     translator.PacketForwarder,
+			ProfileVersion: gp.GetVersion(),
+		}, resp)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGatewayOverrides() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+	api := NewExtendedNetworkServerAPI()
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(ctx, storage.DB(), &rp))
+
+	gp := storage.GatewayProfile{
+		Channels: []int64{0, 1, 2},
+	}
+	assert.NoError(storage.CreateGatewayProfile(ctx, storage.DB(), &gp))
+
+	gw := storage.Gateway{
+		GatewayID:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 3},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(ctx, storage.DB(), &gw))
+
+	ts.T().Run("Get empty", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := api.GetGatewayOverrides(ctx, &extapi.GetGatewayOverridesRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.Equal(&extapi.GatewayOverrides{}, resp.Overrides)
+	})
+
+	ts.T().Run("Update invalid", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateGatewayOverrides(ctx, &extapi.UpdateGatewayOverridesRequest{
+			GatewayId: gw.GatewayID[:],
+			Overrides: &extapi.GatewayOverrides{
+				Lbt: &extapi.GatewayLBT{RssiTarget: -80, ScanTimeUs: 100},
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Update channels without gateway-profile", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateGatewayOverrides(ctx, &extapi.UpdateGatewayOverridesRequest{
+			GatewayId: gw.GatewayID[:],
+			Overrides: &extapi.GatewayOverrides{
+				Channels: []uint32{868100000},
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	gw.GatewayProfileID = &gp.ID
+	assert.NoError(storage.UpdateGateway(ctx, storage.DB(), &gw))
+
+	ts.T().Run("Update unknown channel", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateGatewayOverrides(ctx, &extapi.UpdateGatewayOverridesRequest{
+			GatewayId: gw.GatewayID[:],
+			Overrides: &extapi.GatewayOverrides{
+				Channels: []uint32{868100000, 867100000},
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Update unsupported by concentratord", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateGatewayOverrides(ctx, &extapi.UpdateGatewayOverridesRequest{
+			GatewayId: gw.GatewayID[:],
+			Overrides: &extapi.GatewayOverrides{
+				Lbt: &extapi.GatewayLBT{RssiTarget: -80, ScanTimeUs: 5000},
+			},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Update", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := api.UpdateGatewayConfigTranslator(ctx, &extapi.UpdateGatewayConfigTranslatorRequest{
+			GatewayId:  gw.GatewayID[:],
+			Translator: translator.PacketForwarder,
+		})
+		assert.NoError(err)
+
+		overrides := extapi.GatewayOverrides{
+			Channels: []uint32{868100000, 868300000},
+			Boards: []*extapi.GatewayBoardOverrides{
+				{RadioFrequencies: []uint32{868300000}, DownlinkDisabled: true},
+			},
+			AntennaGain: 3,
+			MaxTxPower:  &wrappers.Int32Value{Value: 14},
+			Lbt:         &extapi.GatewayLBT{RssiTarget: -80, ScanTimeUs: 5000},
+		}
+
+		_, err = api.UpdateGatewayOverrides(ctx, &extapi.UpdateGatewayOverridesRequest{
+			GatewayId: gw.GatewayID[:],
+			Overrides: &overrides,
+		})
+		assert.NoError(err)
+
+		resp, err := api.GetGatewayOverrides(ctx, &extapi.GetGatewayOverridesRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.True(proto.Equal(&overrides, resp.Overrides))
+
+		meta, err := storage.GetAndCacheGatewayMeta(ctx, storage.DB(), gw.GatewayID)
+		assert.NoError(err)
+		assert.Equal(11, meta.Overrides.GetDownlinkTXPower(27))
+		assert.True(meta.Overrides.IsDownlinkDisabled(0))
+
+		// the lbt override can not be expressed by the basic_station translator
+		_, err = api.UpdateGatewayConfigTranslator(ctx, &extapi.UpdateGatewayConfigTranslatorRequest{
+			GatewayId:  gw.GatewayID[:],
+			Translator: translator.BasicStation,
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+}
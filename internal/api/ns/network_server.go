@@ -16,6 +16,7 @@ import (
 	"github.com/brocaar/chirpstack-api/go/v3/common"
 	"github.com/brocaar/chirpstack-api/go/v3/ns"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/multicast"
@@ -583,6 +584,7 @@ func (n *NetworkServerAPI) UpdateDevice(ctx context.Context, req *ns.UpdateDevic
 	d.RoutingProfileID = rpID
 	d.SkipFCntCheck = req.Device.SkipFCntCheck
 	d.ReferenceAltitude = req.Device.ReferenceAltitude
+	disabledBefore := d.IsDisabled
 	d.IsDisabled = req.Device.IsDisabled
 
 	err = storage.Transaction(func(tx sqlx.Ext) error {
@@ -605,6 +607,15 @@ func (n *NetworkServerAPI) UpdateDevice(ctx context.Context, req *ns.UpdateDevic
 		return nil, errToRPCError(err)
 	}
 
+	if disabledBefore != d.IsDisabled {
+		audit.Log(ctx, audit.Event{
+			DevEUI: devEUI,
+			Type:   audit.EventDeviceDisabled,
+			Before: audit.State{"is_disabled": disabledBefore},
+			After:  audit.State{"is_disabled": d.IsDisabled},
+		})
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -638,14 +649,7 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 	}
 
 	var devEUI lorawan.EUI64
-	var devAddr lorawan.DevAddr
-	var sNwkSIntKey, fNwkSIntKey, nwkSEncKey lorawan.AES128Key
-
 	copy(devEUI[:], req.DeviceActivation.DevEui)
-	copy(devAddr[:], req.DeviceActivation.DevAddr)
-	copy(sNwkSIntKey[:], req.DeviceActivation.SNwkSIntKey)
-	copy(fNwkSIntKey[:], req.DeviceActivation.FNwkSIntKey)
-	copy(nwkSEncKey[:], req.DeviceActivation.NwkSEncKey)
 
 	d, err := storage.GetDevice(ctx, storage.DB(), devEUI, false)
 	if err != nil {
@@ -657,20 +661,53 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		return nil, errToRPCError(err)
 	}
 
+	ds := getDeviceSessionForActivation(d, dp, req.DeviceActivation)
+
+	d.Mode = getDeviceModeForActivation(dp)
+	if err := storage.UpdateDevice(ctx, storage.DB(), &d); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.SaveDeviceSession(ctx, ds); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.FlushDeviceQueueForDevEUI(ctx, storage.DB(), d.DevEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.FlushMACCommandQueue(ctx, ds.DevEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// getDeviceSessionForActivation returns the device-session for the
+// activation (ABP) of the given device, reset to the device boot parameters.
+func getDeviceSessionForActivation(d storage.Device, dp storage.DeviceProfile, da *ns.DeviceActivation) storage.DeviceSession {
+	var devAddr lorawan.DevAddr
+	var sNwkSIntKey, fNwkSIntKey, nwkSEncKey lorawan.AES128Key
+
+	copy(devAddr[:], da.DevAddr)
+	copy(sNwkSIntKey[:], da.SNwkSIntKey)
+	copy(fNwkSIntKey[:], da.FNwkSIntKey)
+	copy(nwkSEncKey[:], da.NwkSEncKey)
+
 	ds := storage.DeviceSession{
 		DeviceProfileID:  d.DeviceProfileID,
 		ServiceProfileID: d.ServiceProfileID,
 		RoutingProfileID: d.RoutingProfileID,
 
-		DevEUI:             devEUI,
+		DevEUI:             d.DevEUI,
 		DevAddr:            devAddr,
 		SNwkSIntKey:        sNwkSIntKey,
 		FNwkSIntKey:        fNwkSIntKey,
 		NwkSEncKey:         nwkSEncKey,
-		FCntUp:             req.DeviceActivation.FCntUp,
-		NFCntDown:          req.DeviceActivation.NFCntDown,
-		AFCntDown:          req.DeviceActivation.AFCntDown,
-		SkipFCntValidation: req.DeviceActivation.SkipFCntCheck || d.SkipFCntCheck,
+		FCntUp:             da.FCntUp,
+		NFCntDown:          da.NFCntDown,
+		AFCntDown:          da.AFCntDown,
+		SkipFCntValidation: da.SkipFCntCheck || d.SkipFCntCheck,
 
 		RXWindow: storage.RX1,
 
@@ -679,33 +716,20 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		IsDisabled: d.IsDisabled,
 	}
 
-	// The device is never set to DeviceModeB because the device first needs to
-	// aquire a Class-B beacon lock and will signal this to the network-server.
-	if dp.SupportsClassC {
-		d.Mode = storage.DeviceModeC
-	} else {
-		d.Mode = storage.DeviceModeA
-	}
-	if err := storage.UpdateDevice(ctx, storage.DB(), &d); err != nil {
-		return nil, errToRPCError(err)
-	}
-
 	// reset the device-session to the device boot parameters
 	ds.ResetToBootParameters(dp)
 
-	if err := storage.SaveDeviceSession(ctx, ds); err != nil {
-		return nil, errToRPCError(err)
-	}
-
-	if err := storage.FlushDeviceQueueForDevEUI(ctx, storage.DB(), d.DevEUI); err != nil {
-		return nil, errToRPCError(err)
-	}
+	return ds
+}
 
-	if err := storage.FlushMACCommandQueue(ctx, ds.DevEUI); err != nil {
-		return nil, errToRPCError(err)
+// getDeviceModeForActivation returns the device mode after activation.
+// The device is never set to DeviceModeB because the device first needs to
+// aquire a Class-B beacon lock and will signal this to the network-server.
+func getDeviceModeForActivation(dp storage.DeviceProfile) storage.DeviceMode {
+	if dp.SupportsClassC {
+		return storage.DeviceModeC
 	}
-
-	return &empty.Empty{}, nil
+	return storage.DeviceModeA
 }
 
 // DeactivateDevice de-activates a device.
@@ -751,7 +775,7 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 
 // GetRandomDevAddr returns a random DevAddr.
 func (n *NetworkServerAPI) GetRandomDevAddr(ctx context.Context, req *empty.Empty) (*ns.GetRandomDevAddrResponse, error) {
-	devAddr, err := storage.GetRandomDevAddr(config.C.NetworkServer.NetID)
+	devAddr, err := storage.GetRandomDevAddr(config.Get().NetworkServer.NetID)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -1368,46 +1392,48 @@ func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.Cr
 		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
 	}
 
-	var devEUI lorawan.EUI64
-	copy(devEUI[:], req.Item.DevEui)
+	var qi storage.DeviceQueueItem
+	copy(qi.DevEUI[:], req.Item.DevEui)
+	copy(qi.DevAddr[:], req.Item.DevAddr)
+	qi.FRMPayload = req.Item.FrmPayload
+	qi.FCnt = req.Item.FCnt
+	qi.FPort = uint8(req.Item.FPort)
+	qi.Confirmed = req.Item.Confirmed
 
-	d, err := storage.GetDevice(ctx, storage.DB(), devEUI, false)
+	if err := createDeviceQueueItem(ctx, qi); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// createDeviceQueueItem validates the security-context of the given
+// device-queue item and adds it to the device-queue.
+func createDeviceQueueItem(ctx context.Context, qi storage.DeviceQueueItem) error {
+	d, err := storage.GetDevice(ctx, storage.DB(), qi.DevEUI, false)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return errToRPCError(err)
 	}
 
 	dp, err := storage.GetAndCacheDeviceProfile(ctx, storage.DB(), d.DeviceProfileID)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return errToRPCError(err)
 	}
 
 	ds, err := storage.GetDeviceSession(ctx, d.DevEUI)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return errToRPCError(err)
 	}
 
-	var devAddr lorawan.DevAddr
-	copy(devAddr[:], req.Item.DevAddr)
-
-	if (devAddr != lorawan.DevAddr{0, 0, 0, 0} && ds.DevAddr != devAddr) {
-		return nil, grpc.Errorf(codes.InvalidArgument, "device security-context out of sync")
-	}
-
-	qi := storage.DeviceQueueItem{
-		DevAddr:    devAddr,
-		DevEUI:     d.DevEUI,
-		FRMPayload: req.Item.FrmPayload,
-		FCnt:       req.Item.FCnt,
-		FPort:      uint8(req.Item.FPort),
-		Confirmed:  req.Item.Confirmed,
+	if (qi.DevAddr != lorawan.DevAddr{0, 0, 0, 0} && ds.DevAddr != qi.DevAddr) {
+		return grpc.Errorf(codes.InvalidArgument, "device security-context out of sync")
 	}
 
-	err = storage.CreateDeviceQueueItem(ctx, storage.DB(), &qi, dp, ds)
-	if err != nil {
-		return nil, errToRPCError(err)
+	if err := storage.CreateDeviceQueueItem(ctx, storage.DB(), &qi, dp, ds); err != nil {
+		return errToRPCError(err)
 	}
 
-	return &empty.Empty{}, nil
+	return nil
 }
 
 // FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
@@ -1485,8 +1511,12 @@ func (n *NetworkServerAPI) GetNextDownlinkFCntForDevEUI(ctx context.Context, req
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
-	if count := len(items); count != 0 {
-		resp.FCnt = items[count-1].FCnt + 1 // we want the next usable frame-counter
+	// The items are ordered by priority, thus the last item does not
+	// necessarily have the highest frame-counter.
+	for i, item := range items {
+		if i == 0 || item.FCnt >= resp.FCnt {
+			resp.FCnt = item.FCnt + 1 // we want the next usable frame-counter
+		}
 	}
 
 	return &resp, nil
@@ -16,6 +16,7 @@ import (
 
 	"github.com/brocaar/chirpstack-api/go/v3/common"
 	"github.com/brocaar/chirpstack-api/go/v3/ns"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/extapi"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway"
@@ -282,7 +283,7 @@ func (ts *NetworkServerAPITestSuite) TestMulticastQueue() {
 					assert.NoError(err)
 					assert.Equal(emitAt, *items[i].EmitAtTimeSinceGPSEpoch, "queue item %d", i)
 
-					scheduleAt := time.Time(gps.NewFromTimeSinceGPSEpoch(emitAt)).Add(-2 * config.C.NetworkServer.Scheduler.SchedulerInterval)
+					scheduleAt := time.Time(gps.NewFromTimeSinceGPSEpoch(emitAt)).Add(-2 * config.Get().NetworkServer.Scheduler.SchedulerInterval)
 					assert.EqualValues(scheduleAt.UTC(), items[i].ScheduleAt.UTC())
 				}
 			})
@@ -363,7 +364,7 @@ func (ts *NetworkServerAPITestSuite) TestMulticastQueue() {
 					if i == 0 {
 						continue
 					}
-					lockDuration := config.C.NetworkServer.Scheduler.ClassC.DeviceDownlinkLockDuration
+					lockDuration := config.Get().NetworkServer.Scheduler.ClassC.DeviceDownlinkLockDuration
 					assert.Equal(scheduleAt, items[i].ScheduleAt.Add(-lockDuration))
 					scheduleAt = items[i].ScheduleAt
 				}
@@ -527,6 +528,39 @@ func (ts *NetworkServerAPITestSuite) TestDevice() {
 
 			})
 
+			t.Run("Enqueue with priority", func(t *testing.T) {
+				assert := require.New(t)
+				extAPI := NewExtendedNetworkServerAPI()
+
+				_, err := extAPI.CreateDeviceQueueItem(context.Background(), &extapi.CreateDeviceQueueItemRequest{
+					Item: &extapi.DeviceQueueItem{
+						DevEui:     devEUI[:],
+						FrmPayload: []byte{1, 2, 3, 4},
+						FCnt:       11,
+						FPort:      20,
+						Priority:   40000,
+					},
+				})
+				assert.Equal(codes.InvalidArgument, grpc.Code(err))
+
+				_, err = extAPI.CreateDeviceQueueItem(context.Background(), &extapi.CreateDeviceQueueItemRequest{
+					Item: &extapi.DeviceQueueItem{
+						DevEui:     devEUI[:],
+						FrmPayload: []byte{1, 2, 3, 4},
+						FCnt:       11,
+						FPort:      20,
+						Priority:   5,
+					},
+				})
+				assert.NoError(err)
+
+				items, err := storage.GetDeviceQueueItemsForDevEUI(context.Background(), storage.DB(), devEUI)
+				assert.NoError(err)
+				assert.Len(items, 2)
+				assert.Equal(5, items[0].Priority)
+				assert.EqualValues(11, items[0].FCnt)
+			})
+
 			_, err = ts.api.ActivateDevice(context.Background(), &ns.ActivateDeviceRequest{
 				DeviceActivation: &ns.DeviceActivation{
 					DevEui:        devEUI[:],
@@ -102,6 +102,9 @@ func NewAPI(netID lorawan.NetID) *API {
 
 // ServeHTTP handles a HTTP request.
 func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	config.RLock()
+	defer config.RUnlock()
+
 	// read request body
 	b, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -0,0 +1,318 @@
+// Package audit implements the device lifecycle audit event stream.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	gwbackend "github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+const (
+	globalEventStreamKey = "lora:ns:device:stream:audit"
+	deviceEventStreamKey = "lora:ns:device:%s:stream:audit"
+)
+
+// logQueueSize defines the max. number of events waiting to be written to
+// Redis.
+const logQueueSize = 1000
+
+// publishQueueSize defines the max. number of events waiting to be
+// published to the gateway backend or webhook.
+const publishQueueSize = 1000
+
+// EventType defines the audit event type.
+type EventType string
+
+// Audit event types.
+const (
+	// The device has (re)joined the network.
+	EventJoin EventType = "join"
+
+	// The device has sent a rejoin-request.
+	EventRejoin EventType = "rejoin"
+
+	// The ADR algorithm requested a data-rate, tx-power or nb-trans change.
+	EventADRChangeRequest EventType = "adr_change_request"
+
+	// The device acknowledged a mac-command.
+	EventMACCommandAck EventType = "mac_command_ack"
+
+	// The device rejected a mac-command (nACK).
+	EventMACCommandError EventType = "mac_command_error"
+
+	// A mac-command sent by the device changed the device-session.
+	EventMACCommand EventType = "mac_command"
+
+	// The device has been enabled or disabled.
+	EventDeviceDisabled EventType = "device_disabled"
+
+	// The device changed mode (Class-A, B or C).
+	EventModeChange EventType = "mode_change"
+)
+
+// Event defines an audit event.
+type Event struct {
+	// ID of the event (the Redis stream ID of the device stream).
+	ID      string                 `json:"id,omitempty"`
+	DevEUI  lorawan.EUI64          `json:"dev_eui"`
+	Type    EventType              `json:"type"`
+	Time    time.Time              `json:"time"`
+	CtxID   string                 `json:"ctx_id,omitempty"`
+	Before  map[string]interface{} `json:"before,omitempty"`
+	After   map[string]interface{} `json:"after,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+var (
+	perDeviceMaxHistory int64
+	maxHistory          int64
+	deviceStreamTTL     time.Duration
+	topicTemplate       *template.Template
+	webhookURL          string
+	httpClient          *http.Client
+
+	logChan chan Event
+	logOnce sync.Once
+
+	publishChan chan Event
+	publishOnce sync.Once
+)
+
+// Setup configures the package.
+func Setup(c config.Config) error {
+	conf := c.Monitoring.Audit
+
+	perDeviceMaxHistory = conf.PerDeviceMaxHistory
+	maxHistory = conf.MaxHistory
+	deviceStreamTTL = c.NetworkServer.DeviceSessionTTL
+	webhookURL = conf.WebhookURL
+	httpClient = &http.Client{
+		Timeout: conf.WebhookTimeout,
+	}
+
+	topicTemplate = nil
+	if conf.GatewayBackendTopicTemplate != "" {
+		var err error
+		topicTemplate, err = template.New("topic").Parse(conf.GatewayBackendTopicTemplate)
+		if err != nil {
+			return errors.Wrap(err, "parse gateway backend topic template error")
+		}
+	}
+
+	if topicTemplate != nil || webhookURL != "" {
+		publishOnce.Do(func() {
+			publishChan = make(chan Event, publishQueueSize)
+			go publishLoop()
+		})
+	}
+
+	if perDeviceMaxHistory > 0 || maxHistory > 0 || publishChan != nil {
+		logOnce.Do(func() {
+			logChan = make(chan Event, logQueueSize)
+			go logLoop()
+		})
+	}
+
+	return nil
+}
+
+// Log queues the given audit event. The event is written to Redis and
+// published asynchronously, as logging an audit event must not delay the
+// uplink or downlink handling. Events are handled in order.
+func Log(ctx context.Context, e Event) {
+	if logChan == nil {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if ctxID := ctx.Value(logging.ContextIDKey); ctxID != nil {
+		e.CtxID = fmt.Sprintf("%s", ctxID)
+	}
+
+	select {
+	case logChan <- e:
+	default:
+		log.WithFields(log.Fields{
+			"dev_eui": e.DevEUI,
+			"type":    e.Type,
+			"ctx_id":  e.CtxID,
+		}).Warning("audit: log queue is full, event not logged")
+	}
+}
+
+func logLoop() {
+	for e := range logChan {
+		if err := logEvent(context.Background(), &e); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": e.DevEUI,
+				"type":    e.Type,
+				"ctx_id":  e.CtxID,
+			}).Error("audit: log event error")
+		}
+
+		if publishChan != nil && (topicTemplate != nil || webhookURL != "") {
+			select {
+			case publishChan <- e:
+			default:
+				log.WithFields(log.Fields{
+					"dev_eui": e.DevEUI,
+					"type":    e.Type,
+				}).Warning("audit: publish queue is full, event not published")
+			}
+		}
+	}
+}
+
+func logEvent(ctx context.Context, e *Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	if perDeviceMaxHistory > 0 {
+		key := storage.GetRedisKey(deviceEventStreamKey, e.DevEUI)
+		pipe := storage.RedisClient().TxPipeline()
+		id := pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: key,
+			MaxLen: perDeviceMaxHistory,
+			Values: map[string]interface{}{
+				"event": b,
+			},
+		})
+		pipe.PExpire(ctx, key, deviceStreamTTL)
+
+		if _, err := pipe.Exec(ctx); err != nil {
+			return errors.Wrap(err, "redis xadd error")
+		}
+		e.ID = id.Val()
+	}
+
+	if maxHistory > 0 {
+		key := storage.GetRedisKey(globalEventStreamKey)
+		if err := storage.RedisClient().XAdd(ctx, &redis.XAddArgs{
+			Stream:       key,
+			MaxLenApprox: maxHistory,
+			Values: map[string]interface{}{
+				"event": b,
+			},
+		}).Err(); err != nil {
+			return errors.Wrap(err, "redis xadd error")
+		}
+	}
+
+	return nil
+}
+
+// GetDeviceEvents returns the audit events of the given device, most recent
+// first. When before is set, only the events before the given event ID are
+// returned.
+func GetDeviceEvents(ctx context.Context, devEUI lorawan.EUI64, limit int, before string) ([]Event, error) {
+	key := storage.GetRedisKey(deviceEventStreamKey, devEUI)
+
+	end := "+"
+	count := int64(limit)
+	if before != "" {
+		// the range is inclusive, the 'before' event is skipped below
+		end = before
+		count++
+	}
+
+	msgs, err := storage.RedisClient().XRevRangeN(ctx, key, end, "-", count).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "redis xrevrange error")
+	}
+
+	var out []Event
+	for _, msg := range msgs {
+		if msg.ID == before {
+			continue
+		}
+
+		v, ok := msg.Values["event"].(string)
+		if !ok {
+			continue
+		}
+
+		var e Event
+		if err := json.Unmarshal([]byte(v), &e); err != nil {
+			return nil, errors.Wrap(err, "unmarshal event error")
+		}
+		e.ID = msg.ID
+
+		out = append(out, e)
+		if len(out) == limit {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func publishLoop() {
+	for e := range publishChan {
+		if err := publish(e); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": e.DevEUI,
+				"type":    e.Type,
+				"ctx_id":  e.CtxID,
+			}).Error("audit: publish event error")
+		}
+	}
+}
+
+func publish(e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	if topicTemplate != nil {
+		p, ok := gwbackend.Backend().(gwbackend.Publisher)
+		if !ok {
+			return errors.New("gateway backend does not support publishing")
+		}
+
+		topic := bytes.NewBuffer(nil)
+		if err := topicTemplate.Execute(topic, struct {
+			DevEUI lorawan.EUI64
+			Type   EventType
+		}{e.DevEUI, e.Type}); err != nil {
+			return errors.Wrap(err, "execute topic template error")
+		}
+
+		if err := p.Publish(topic.String(), b); err != nil {
+			return errors.Wrap(err, "publish to gateway backend error")
+		}
+	}
+
+	if webhookURL != "" {
+		resp, err := httpClient.Post(webhookURL, "application/json", bytes.NewReader(b))
+		if err != nil {
+			return errors.Wrap(err, "webhook post error")
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook returned unexpected status: %s", resp.Status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+	"github.com/liuhw0/lorawan"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+
+	DevEUI lorawan.EUI64
+	events chan Event
+	server *httptest.Server
+}
+
+func (ts *AuditTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+
+	ts.events = make(chan Event, 10)
+	ts.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err == nil {
+			ts.events <- e
+		}
+	}))
+
+	conf := test.GetConfig()
+	conf.Monitoring.Audit.PerDeviceMaxHistory = 3
+	conf.Monitoring.Audit.MaxHistory = 10
+	conf.Monitoring.Audit.WebhookURL = ts.server.URL
+	conf.Monitoring.Audit.WebhookTimeout = time.Second
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(Setup(conf))
+
+	ts.DevEUI = lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+}
+
+func (ts *AuditTestSuite) TearDownSuite() {
+	ts.server.Close()
+}
+
+func (ts *AuditTestSuite) SetupTest() {
+	storage.RedisClient().FlushAll(context.Background())
+}
+
+func (ts *AuditTestSuite) TestLog() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		Log(ctx, Event{
+			DevEUI: ts.DevEUI,
+			Type:   EventADRChangeRequest,
+			Before: State{"dr": i},
+			After:  State{"dr": i + 1},
+		})
+
+		select {
+		case e := <-ts.events:
+			assert.Equal(EventADRChangeRequest, e.Type)
+			assert.NotEmpty(e.ID)
+		case <-time.After(time.Second):
+			ts.T().Fatal("timeout waiting for webhook")
+		}
+	}
+
+	ts.T().Run("GetDeviceEvents", func(t *testing.T) {
+		assert := require.New(t)
+
+		events, err := GetDeviceEvents(ctx, ts.DevEUI, 10, "")
+		assert.NoError(err)
+		assert.Len(events, 3)
+		assert.EqualValues(4, events[0].After["dr"])
+		assert.EqualValues(2, events[2].After["dr"])
+
+		t.Run("Before", func(t *testing.T) {
+			assert := require.New(t)
+
+			page, err := GetDeviceEvents(ctx, ts.DevEUI, 1, events[0].ID)
+			assert.NoError(err)
+			assert.Len(page, 1)
+			assert.Equal(events[1].ID, page[0].ID)
+		})
+	})
+
+	ts.T().Run("Global stream", func(t *testing.T) {
+		assert := require.New(t)
+
+		l, err := storage.RedisClient().XLen(ctx, storage.GetRedisKey(globalEventStreamKey)).Result()
+		assert.NoError(err)
+		assert.EqualValues(4, l)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
+
+func TestDiff(t *testing.T) {
+	assert := require.New(t)
+
+	ds := storage.DeviceSession{
+		DR:                    3,
+		TXPowerIndex:          1,
+		NbTrans:               1,
+		EnabledUplinkChannels: []int{0, 1, 2},
+	}
+	before := GetState(ds)
+
+	ds.DR = 5
+	ds.EnabledUplinkChannels[0] = 3
+	ds.EnabledUplinkChannels = append(ds.EnabledUplinkChannels, 4)
+	after := GetState(ds)
+
+	b, a := Diff(before, after)
+	assert.Equal(State{
+		"dr":                      3,
+		"enabled_uplink_channels": []int{0, 1, 2},
+	}, b)
+	assert.Equal(State{
+		"dr":                      5,
+		"enabled_uplink_channels": []int{3, 1, 2, 4},
+	}, a)
+
+	b, a = Diff(after, after)
+	assert.Empty(b)
+	assert.Empty(a)
+}
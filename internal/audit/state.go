@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+// State contains the audited device-session values.
+type State map[string]interface{}
+
+// GetState returns the audited values of the given device-session.
+// Slices and maps are copied, so that the returned state is not affected by
+// later device-session changes.
+func GetState(ds storage.DeviceSession) State {
+	extraChannels := make(map[int]loraband.Channel, len(ds.ExtraUplinkChannels))
+	for k, v := range ds.ExtraUplinkChannels {
+		extraChannels[k] = v
+	}
+
+	extraDownlinkFrequencies := make(map[int]uint32, len(ds.ExtraDownlinkFrequencies))
+	for k, v := range ds.ExtraDownlinkFrequencies {
+		extraDownlinkFrequencies[k] = v
+	}
+
+	return State{
+		"dev_addr":                  ds.DevAddr.String(),
+		"beacon_locked":             ds.BeaconLocked,
+		"dr":                        ds.DR,
+		"tx_power_index":            ds.TXPowerIndex,
+		"nb_trans":                  int(ds.NbTrans),
+		"enabled_uplink_channels":   append([]int{}, ds.EnabledUplinkChannels...),
+		"extra_uplink_channels":     extraChannels,
+		"extra_downlink_channels":   extraDownlinkFrequencies,
+		"rx_delay":                  int(ds.RXDelay),
+		"rx1_dr_offset":             int(ds.RX1DROffset),
+		"rx2_dr":                    int(ds.RX2DR),
+		"rx2_frequency":             ds.RX2Frequency,
+		"ping_slot_dr":              ds.PingSlotDR,
+		"ping_slot_frequency":       ds.PingSlotFrequency,
+		"uplink_dwell_time_400ms":   ds.UplinkDwellTime400ms,
+		"downlink_dwell_time_400ms": ds.DownlinkDwellTime400ms,
+		"uplink_max_eirp_index":     int(ds.UplinkMaxEIRPIndex),
+		"rejoin_request_enabled":    ds.RejoinRequestEnabled,
+	}
+}
+
+// Diff returns the values of before and after which are different.
+func Diff(before, after State) (State, State) {
+	b := make(State)
+	a := make(State)
+
+	for k, v := range before {
+		if av, ok := after[k]; !ok || !reflect.DeepEqual(v, av) {
+			b[k] = v
+		}
+	}
+	for k, v := range after {
+		if bv, ok := before[k]; !ok || !reflect.DeepEqual(v, bv) {
+			a[k] = v
+		}
+	}
+
+	return b, a
+}
+
+// LogModeChange logs a mode_change event for the given device.
+func LogModeChange(ctx context.Context, devEUI lorawan.EUI64, before, after storage.DeviceMode, reason string) {
+	if before == after {
+		return
+	}
+
+	Log(ctx, Event{
+		DevEUI: devEUI,
+		Type:   EventModeChange,
+		Before: State{"mode": string(before)},
+		After:  State{"mode": string(after)},
+		Details: map[string]interface{}{
+			"reason": reason,
+		},
+	})
+}
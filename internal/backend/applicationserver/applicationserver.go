@@ -1,6 +1,9 @@
 package applicationserver
 
-import "github.com/liuhw0/chirpstack-network-server/v3/internal/api/client/asclient"
+import (
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/client/asclient"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+)
 
 var pool asclient.Pool
 
@@ -15,7 +18,12 @@ func Pool() asclient.Pool {
 }
 
 // Setup sets up the application-server pool.
-func Setup() error {
-	pool = asclient.NewPool()
+func Setup(conf config.Config) error {
+	pool = asclient.NewPool(asclient.PoolConfig{
+		IdleTimeout:         conf.ApplicationServer.Client.IdleTimeout,
+		HealthCheckInterval: conf.ApplicationServer.Client.HealthCheckInterval,
+		FailureThreshold:    conf.ApplicationServer.Client.CircuitBreaker.FailureThreshold,
+		OpenDuration:        conf.ApplicationServer.Client.CircuitBreaker.OpenDuration,
+	})
 	return nil
 }
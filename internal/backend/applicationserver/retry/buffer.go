@@ -0,0 +1,185 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/client/asclient"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+const (
+	bufferKeyTempl     = "lora:ns:as:buffer:%s"      // contains the list of buffered events of a routing-profile
+	bufferLockKeyTempl = "lora:ns:as:buffer:%s:lock" // lock to avoid that multiple instances replay the same buffer
+	bufferSetKey       = "lora:ns:as:buffer"         // contains the set of routing-profile IDs with buffered events
+)
+
+// replayLockTTL defines the TTL of the replay lock.
+const replayLockTTL = time.Minute
+
+// bufferEvent appends the event to the buffer of the routing-profile.
+func bufferEvent(ctx context.Context, e event) error {
+	b, err := e.marshal()
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	key := storage.GetRedisKey(bufferKeyTempl, e.RoutingProfileID)
+
+	pipe := storage.RedisClient().Pipeline()
+	pipe.RPush(ctx, key, b)
+	if bufferMaxSize > 0 {
+		pipe.LTrim(ctx, key, -bufferMaxSize, -1)
+	}
+	if bufferTTL > 0 {
+		pipe.PExpire(ctx, key, bufferTTL)
+	}
+	pipe.SAdd(ctx, storage.GetRedisKey(bufferSetKey), e.RoutingProfileID.String())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(err, "buffer event error")
+	}
+
+	return nil
+}
+
+func replayLoop() {
+	for {
+		time.Sleep(replayInterval)
+
+		if err := Replay(context.Background()); err != nil {
+			log.WithError(err).Error("application-server: replay buffered events error")
+		}
+	}
+}
+
+// Replay replays the buffered events of all routing-profiles. Events are
+// replayed in order. The replay of a routing-profile stops at the first
+// event that can't be delivered because the application-server is
+// unavailable.
+func Replay(ctx context.Context) error {
+	ids, err := storage.RedisClient().SMembers(ctx, storage.GetRedisKey(bufferSetKey)).Result()
+	if err != nil {
+		return errors.Wrap(err, "get buffered routing-profile ids error")
+	}
+
+	for _, idStr := range ids {
+		id, err := uuid.FromString(idStr)
+		if err != nil {
+			log.WithError(err).WithField("routing_profile_id", idStr).Error("application-server: invalid routing-profile id in buffer set")
+			storage.RedisClient().SRem(ctx, storage.GetRedisKey(bufferSetKey), idStr)
+			continue
+		}
+
+		if err := replayRoutingProfile(ctx, id); err != nil {
+			log.WithError(err).WithField("routing_profile_id", id).Warning("application-server: replay buffered events error")
+		}
+	}
+
+	return nil
+}
+
+func replayRoutingProfile(ctx context.Context, id uuid.UUID) error {
+	lockKey := storage.GetRedisKey(bufferLockKeyTempl, id)
+	set, err := storage.RedisClient().SetNX(ctx, lockKey, "lock", replayLockTTL).Result()
+	if err != nil {
+		return errors.Wrap(err, "acquire lock error")
+	}
+	if !set {
+		return nil
+	}
+	defer storage.RedisClient().Del(ctx, lockKey)
+
+	key := storage.GetRedisKey(bufferKeyTempl, id)
+
+	rp, err := storage.GetRoutingProfile(ctx, storage.DB(), id)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			if err := storage.RedisClient().Del(ctx, key).Err(); err != nil {
+				return errors.Wrap(err, "delete buffer error")
+			}
+			return removeBuffer(ctx, id)
+		}
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := rp.GetApplicationServerClient()
+	if err != nil {
+		return err
+	}
+
+	var count int
+	for {
+		b, err := storage.RedisClient().LIndex(ctx, key, 0).Bytes()
+		if err != nil {
+			if err == redis.Nil {
+				break
+			}
+			return errors.Wrap(err, "get buffered event error")
+		}
+
+		e, err := unmarshalEvent(id, b)
+		if err != nil {
+			log.WithError(err).WithField("routing_profile_id", id).Error("application-server: unmarshal buffered event error")
+		} else {
+			ctxTimeout, cancel := context.WithTimeout(ctx, deliveryTimeout)
+			err = e.send(ctxTimeout, asClient)
+			cancel()
+
+			if err != nil {
+				if asclient.IsRetryable(err) {
+					return errors.Wrap(err, "deliver buffered event error")
+				}
+
+				log.WithError(err).WithFields(log.Fields{
+					"routing_profile_id": id,
+					"event":              e.name(),
+				}).Error("application-server: deliver buffered event error")
+			}
+		}
+
+		if err := storage.RedisClient().LPop(ctx, key).Err(); err != nil {
+			return errors.Wrap(err, "remove buffered event error")
+		}
+		count++
+	}
+
+	if count != 0 {
+		log.WithFields(log.Fields{
+			"routing_profile_id": id,
+			"count":              count,
+		}).Info("application-server: buffered events replayed")
+	}
+
+	return removeBuffer(ctx, id)
+}
+
+// removeBuffer removes the buffer of the given routing-profile. Note that an
+// event might have been buffered in the meantime, in which case the
+// routing-profile is re-added to the set.
+func removeBuffer(ctx context.Context, id uuid.UUID) error {
+	key := storage.GetRedisKey(bufferKeyTempl, id)
+	setKey := storage.GetRedisKey(bufferSetKey)
+
+	if err := storage.RedisClient().SRem(ctx, setKey, id.String()).Err(); err != nil {
+		return errors.Wrap(err, "remove routing-profile id from buffer set error")
+	}
+
+	n, err := storage.RedisClient().LLen(ctx, key).Result()
+	if err != nil {
+		return errors.Wrap(err, "get buffer length error")
+	}
+
+	if n != 0 {
+		if err := storage.RedisClient().SAdd(ctx, setKey, id.String()).Err(); err != nil {
+			return errors.Wrap(err, "add routing-profile id to buffer set error")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package retry
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/chirpstack-api/go/v3/as"
+	"github.com/liuhw0/lorawan"
+)
+
+const (
+	eventUplinkData  = "uplink_data"
+	eventDownlinkACK = "downlink_ack"
+	eventTxAck       = "tx_ack"
+)
+
+// event contains an application-server event.
+type event struct {
+	RoutingProfileID uuid.UUID
+
+	UplinkData  *as.HandleUplinkDataRequest
+	DownlinkACK *as.HandleDownlinkACKRequest
+	TxAck       *as.HandleTxAckRequest
+}
+
+// bufferedEvent defines the format in which an event is buffered.
+// The routing-profile ID is part of the buffer key.
+type bufferedEvent struct {
+	Type    string `json:"type"`
+	Payload []byte `json:"payload"`
+}
+
+func (e event) name() string {
+	switch {
+	case e.UplinkData != nil:
+		return eventUplinkData
+	case e.TxAck != nil:
+		return eventTxAck
+	default:
+		return eventDownlinkACK
+	}
+}
+
+// devEUI returns the DevEUI of the device to which the event belongs.
+func (e event) devEUI() lorawan.EUI64 {
+	var devEUI lorawan.EUI64
+
+	switch {
+	case e.UplinkData != nil:
+		copy(devEUI[:], e.UplinkData.DevEui)
+	case e.DownlinkACK != nil:
+		copy(devEUI[:], e.DownlinkACK.DevEui)
+	case e.TxAck != nil:
+		copy(devEUI[:], e.TxAck.DevEui)
+	}
+
+	return devEUI
+}
+
+func (e event) send(ctx context.Context, asClient as.ApplicationServerServiceClient) error {
+	var err error
+
+	switch {
+	case e.UplinkData != nil:
+		_, err = asClient.HandleUplinkData(ctx, e.UplinkData)
+	case e.DownlinkACK != nil:
+		_, err = asClient.HandleDownlinkACK(ctx, e.DownlinkACK)
+	case e.TxAck != nil:
+		_, err = asClient.HandleTxAck(ctx, e.TxAck)
+	default:
+		return errors.New("event is empty")
+	}
+
+	return err
+}
+
+func (e event) marshal() ([]byte, error) {
+	var msg proto.Message
+	switch {
+	case e.UplinkData != nil:
+		msg = e.UplinkData
+	case e.DownlinkACK != nil:
+		msg = e.DownlinkACK
+	case e.TxAck != nil:
+		msg = e.TxAck
+	default:
+		return nil, errors.New("event is empty")
+	}
+
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "protobuf marshal error")
+	}
+
+	return json.Marshal(bufferedEvent{
+		Type:    e.name(),
+		Payload: b,
+	})
+}
+
+func unmarshalEvent(routingProfileID uuid.UUID, b []byte) (event, error) {
+	e := event{
+		RoutingProfileID: routingProfileID,
+	}
+
+	var be bufferedEvent
+	if err := json.Unmarshal(b, &be); err != nil {
+		return e, errors.Wrap(err, "json unmarshal error")
+	}
+
+	var msg proto.Message
+	switch be.Type {
+	case eventUplinkData:
+		e.UplinkData = &as.HandleUplinkDataRequest{}
+		msg = e.UplinkData
+	case eventDownlinkACK:
+		e.DownlinkACK = &as.HandleDownlinkACKRequest{}
+		msg = e.DownlinkACK
+	case eventTxAck:
+		e.TxAck = &as.HandleTxAckRequest{}
+		msg = e.TxAck
+	default:
+		return e, errors.Errorf("unexpected event type: %s", be.Type)
+	}
+
+	if err := proto.Unmarshal(be.Payload, msg); err != nil {
+		return e, errors.Wrap(err, "protobuf unmarshal error")
+	}
+
+	return e, nil
+}
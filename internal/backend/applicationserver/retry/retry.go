@@ -0,0 +1,219 @@
+// Package retry implements the retrying and buffering of application-server
+// events which could not be delivered because the application-server is
+// unavailable. Buffered events are replayed when the application-server is
+// available again.
+package retry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-api/go/v3/as"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/api/client/asclient"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+var (
+	maxAttempts     int
+	interval        time.Duration
+	deliveryTimeout time.Duration
+	bufferMaxSize   int64
+	bufferTTL       time.Duration
+	replayInterval  time.Duration
+
+	// retrying limits the number of events pending a retry.
+	retrying chan struct{}
+
+	// queues contains the events pending delivery per device. Events of the
+	// same device are delivered one by one, in order.
+	queuesMu sync.Mutex
+	queues   map[lorawan.EUI64][]queueItem
+)
+
+// queueItem contains an event pending delivery.
+type queueItem struct {
+	ctx      context.Context
+	asClient as.ApplicationServerServiceClient
+	event    event
+}
+
+// Setup configures the package and starts the replay loop.
+func Setup(conf config.Config) error {
+	maxAttempts = conf.ApplicationServer.Retry.MaxAttempts
+	interval = conf.ApplicationServer.Retry.Interval
+	deliveryTimeout = conf.ApplicationServer.Retry.DeliveryTimeout
+	bufferMaxSize = conf.ApplicationServer.Retry.BufferMaxSize
+	bufferTTL = conf.ApplicationServer.Retry.BufferTTL
+	replayInterval = conf.ApplicationServer.Retry.ReplayInterval
+	retrying = make(chan struct{}, conf.ApplicationServer.Retry.QueueSize)
+	queues = make(map[lorawan.EUI64][]queueItem)
+
+	if deliveryTimeout == 0 {
+		deliveryTimeout = time.Second
+	}
+
+	if replayInterval > 0 {
+		go replayLoop()
+	}
+
+	return nil
+}
+
+// HandleUplinkData delivers the uplink data to the application-server.
+// This function returns immediately, the delivery happens asynchronously.
+func HandleUplinkData(ctx context.Context, routingProfileID uuid.UUID, asClient as.ApplicationServerServiceClient, req *as.HandleUplinkDataRequest) {
+	enqueue(ctx, asClient, event{
+		RoutingProfileID: routingProfileID,
+		UplinkData:       req,
+	})
+}
+
+// HandleDownlinkACK delivers the downlink (n)ack to the application-server.
+// This function returns immediately, the delivery happens asynchronously.
+func HandleDownlinkACK(ctx context.Context, routingProfileID uuid.UUID, asClient as.ApplicationServerServiceClient, req *as.HandleDownlinkACKRequest) {
+	enqueue(ctx, asClient, event{
+		RoutingProfileID: routingProfileID,
+		DownlinkACK:      req,
+	})
+}
+
+// HandleTxAck delivers the downlink tx acknowledgement to the
+// application-server. This function returns immediately, the delivery
+// happens asynchronously.
+func HandleTxAck(ctx context.Context, routingProfileID uuid.UUID, asClient as.ApplicationServerServiceClient, req *as.HandleTxAckRequest) {
+	enqueue(ctx, asClient, event{
+		RoutingProfileID: routingProfileID,
+		TxAck:            req,
+	})
+}
+
+// enqueue adds the event to the queue of the device. When there is no
+// pending event for the device, a goroutine is started which delivers the
+// queued events of the device in order.
+func enqueue(ctx context.Context, asClient as.ApplicationServerServiceClient, e event) {
+	devEUI := e.devEUI()
+	item := queueItem{
+		ctx:      ctx,
+		asClient: asClient,
+		event:    e,
+	}
+
+	queuesMu.Lock()
+	pending, ok := queues[devEUI]
+	queues[devEUI] = append(pending, item)
+	queuesMu.Unlock()
+
+	if !ok {
+		go deliverQueue(devEUI)
+	}
+}
+
+// deliverQueue delivers the queued events of the device until the queue is
+// empty.
+func deliverQueue(devEUI lorawan.EUI64) {
+	for {
+		queuesMu.Lock()
+		pending := queues[devEUI]
+		if len(pending) == 0 {
+			delete(queues, devEUI)
+			queuesMu.Unlock()
+			return
+		}
+		item := pending[0]
+		queues[devEUI] = pending[1:]
+		queuesMu.Unlock()
+
+		deliver(item.ctx, item.asClient, item.event)
+	}
+}
+
+// deliver delivers the event. When there are buffered events for the
+// routing-profile, the event is buffered behind these so that the events
+// are delivered in order. When the application-server is unavailable, the
+// delivery is retried until the max. number of attempts has been reached,
+// after which the event is buffered.
+func deliver(ctx context.Context, asClient as.ApplicationServerServiceClient, e event) {
+	logFields := log.Fields{
+		"routing_profile_id": e.RoutingProfileID,
+		"event":              e.name(),
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}
+
+	n, err := storage.RedisClient().LLen(ctx, storage.GetRedisKey(bufferKeyTempl, e.RoutingProfileID)).Result()
+	if err != nil {
+		log.WithFields(logFields).WithError(err).Error("application-server: get buffer length error")
+	}
+	if n != 0 {
+		if err := bufferEvent(ctx, e); err != nil {
+			log.WithFields(logFields).WithError(err).Error("application-server: buffer event error")
+		}
+		return
+	}
+
+	for attempt := 1; ; attempt++ {
+		logFields["attempt"] = attempt
+
+		// The client is looked up again for the retries, as the pooled
+		// connection might have been closed in the meantime.
+		if attempt > 1 {
+			asClient, err = helpers.GetASClientForRoutingProfileID(ctx, e.RoutingProfileID)
+			if err != nil {
+				log.WithFields(logFields).WithError(err).Error("application-server: get application-server client error")
+				return
+			}
+		}
+
+		ctxTimeout, cancel := context.WithTimeout(ctx, deliveryTimeout)
+		err = e.send(ctxTimeout, asClient)
+		cancel()
+		if err == nil {
+			return
+		}
+
+		if !asclient.IsRetryable(err) {
+			log.WithFields(logFields).WithError(err).Error("application-server: deliver event error")
+			return
+		}
+
+		// There is no point in retrying when the circuit-breaker is open, as
+		// the retry would fail fast.
+		if attempt >= maxAttempts || errors.Is(err, asclient.ErrCircuitOpen) {
+			break
+		}
+
+		log.WithFields(logFields).WithError(err).Warning("application-server: deliver event error, retrying")
+
+		if !wait(attempt) {
+			break
+		}
+	}
+
+	log.WithFields(logFields).WithError(err).Warning("application-server: deliver event error, buffering event")
+
+	if err := bufferEvent(ctx, e); err != nil {
+		log.WithFields(logFields).WithError(err).Error("application-server: buffer event error")
+	}
+}
+
+// wait waits for the retry interval of the given attempt. It returns false
+// when the max. number of events pending a retry has been reached.
+func wait(attempt int) bool {
+	select {
+	case retrying <- struct{}{}:
+		time.Sleep(interval * time.Duration(attempt))
+		<-retrying
+		return true
+	default:
+		log.Warning("application-server: retry queue is full")
+		return false
+	}
+}
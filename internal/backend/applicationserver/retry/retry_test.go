@@ -0,0 +1,246 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/brocaar/chirpstack-api/go/v3/as"
+	"github.com/brocaar/chirpstack-api/go/v3/common"
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+)
+
+func TestEventMarshal(t *testing.T) {
+	rpID := uuid.Must(uuid.NewV4())
+
+	tests := []event{
+		{
+			RoutingProfileID: rpID,
+			UplinkData: &as.HandleUplinkDataRequest{
+				DevEui: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				FCnt:   10,
+				FPort:  2,
+				Data:   []byte{1, 2, 3},
+				TxInfo: &gw.UplinkTXInfo{
+					Frequency:  868100000,
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							Bandwidth:       125,
+							SpreadingFactor: 7,
+						},
+					},
+				},
+			},
+		},
+		{
+			RoutingProfileID: rpID,
+			DownlinkACK: &as.HandleDownlinkACKRequest{
+				DevEui:       []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				FCnt:         11,
+				Acknowledged: true,
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name(), func(t *testing.T) {
+			assert := require.New(t)
+
+			b, err := tst.marshal()
+			assert.NoError(err)
+
+			e, err := unmarshalEvent(rpID, b)
+			assert.NoError(err)
+			assert.Equal(tst.name(), e.name())
+			assert.Equal(rpID, e.RoutingProfileID)
+
+			if tst.UplinkData != nil {
+				assert.Equal(tst.UplinkData.String(), e.UplinkData.String())
+			} else {
+				assert.Equal(tst.DownlinkACK.String(), e.DownlinkACK.String())
+			}
+		})
+	}
+}
+
+type RetryTestSuite struct {
+	suite.Suite
+
+	asClient       *test.ApplicationClient
+	routingProfile storage.RoutingProfile
+}
+
+func (ts *RetryTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(storage.MigrateDown(storage.DB().DB))
+	assert.NoError(storage.MigrateUp(storage.DB().DB))
+	assert.NoError(Setup(conf))
+
+	ts.routingProfile = storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &ts.routingProfile))
+}
+
+func (ts *RetryTestSuite) SetupTest() {
+	storage.RedisClient().FlushAll(context.Background())
+
+	ts.asClient = test.NewApplicationClient()
+	applicationserver.SetPool(test.NewApplicationServerPool(ts.asClient))
+}
+
+func (ts *RetryTestSuite) bufferLen() int64 {
+	n, err := storage.RedisClient().LLen(context.Background(), storage.GetRedisKey(bufferKeyTempl, ts.routingProfile.ID)).Result()
+	ts.Require().NoError(err)
+	return n
+}
+
+func (ts *RetryTestSuite) TestDelivered() {
+	assert := require.New(ts.T())
+
+	HandleUplinkData(context.Background(), ts.routingProfile.ID, ts.asClient, &as.HandleUplinkDataRequest{FCnt: 10})
+	HandleDownlinkACK(context.Background(), ts.routingProfile.ID, ts.asClient, &as.HandleDownlinkACKRequest{FCnt: 11})
+
+	up := <-ts.asClient.HandleDataUpChan
+	assert.EqualValues(10, up.FCnt)
+
+	ack := <-ts.asClient.HandleDownlinkACKChan
+	assert.EqualValues(11, ack.FCnt)
+
+	assert.EqualValues(0, ts.bufferLen())
+}
+
+func (ts *RetryTestSuite) TestNotRetryable() {
+	assert := require.New(ts.T())
+	ts.asClient.HandleDataUpErr = status.Error(codes.InvalidArgument, "invalid argument")
+
+	deliver(context.Background(), ts.asClient, event{
+		RoutingProfileID: ts.routingProfile.ID,
+		UplinkData:       &as.HandleUplinkDataRequest{FCnt: 10},
+	})
+
+	assert.EqualValues(0, ts.bufferLen())
+}
+
+func (ts *RetryTestSuite) TestRetryBufferAndReplay() {
+	assert := require.New(ts.T())
+	ts.asClient.HandleDataUpErr = status.Error(codes.Unavailable, "unavailable")
+	ts.asClient.HandleDownlinkACKErr = status.Error(codes.Unavailable, "unavailable")
+
+	HandleUplinkData(context.Background(), ts.routingProfile.ID, ts.asClient, &as.HandleUplinkDataRequest{FCnt: 10})
+	assert.Eventually(func() bool {
+		return ts.bufferLen() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	HandleDownlinkACK(context.Background(), ts.routingProfile.ID, ts.asClient, &as.HandleDownlinkACKRequest{FCnt: 11})
+	assert.Eventually(func() bool {
+		return ts.bufferLen() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	ts.T().Run("Replay while unavailable", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(Replay(context.Background()))
+		assert.EqualValues(2, ts.bufferLen())
+	})
+
+	ts.T().Run("Replay after recovery", func(t *testing.T) {
+		assert := require.New(t)
+		ts.asClient.HandleDataUpErr = nil
+		ts.asClient.HandleDownlinkACKErr = nil
+
+		assert.NoError(Replay(context.Background()))
+		assert.EqualValues(0, ts.bufferLen())
+
+		up := <-ts.asClient.HandleDataUpChan
+		assert.EqualValues(10, up.FCnt)
+
+		ack := <-ts.asClient.HandleDownlinkACKChan
+		assert.EqualValues(11, ack.FCnt)
+
+		ids, err := storage.RedisClient().SMembers(context.Background(), storage.GetRedisKey(bufferSetKey)).Result()
+		assert.NoError(err)
+		assert.Len(ids, 0)
+	})
+}
+
+func (ts *RetryTestSuite) TestTxAck() {
+	assert := require.New(ts.T())
+
+	HandleTxAck(context.Background(), ts.routingProfile.ID, ts.asClient, &as.HandleTxAckRequest{FCnt: 12})
+
+	txAck := <-ts.asClient.HandleTxAckChan
+	assert.EqualValues(12, txAck.FCnt)
+}
+
+func (ts *RetryTestSuite) TestDeliverBehindBuffered() {
+	assert := require.New(ts.T())
+
+	assert.NoError(bufferEvent(context.Background(), event{
+		RoutingProfileID: ts.routingProfile.ID,
+		UplinkData:       &as.HandleUplinkDataRequest{FCnt: 10},
+	}))
+
+	// the live event is buffered behind the buffered event
+	deliver(context.Background(), ts.asClient, event{
+		RoutingProfileID: ts.routingProfile.ID,
+		UplinkData:       &as.HandleUplinkDataRequest{FCnt: 11},
+	})
+	assert.EqualValues(2, ts.bufferLen())
+
+	assert.NoError(Replay(context.Background()))
+	assert.EqualValues(0, ts.bufferLen())
+
+	up := <-ts.asClient.HandleDataUpChan
+	assert.EqualValues(10, up.FCnt)
+	up = <-ts.asClient.HandleDataUpChan
+	assert.EqualValues(11, up.FCnt)
+}
+
+func (ts *RetryTestSuite) TestDeviceQueueOrder() {
+	assert := require.New(ts.T())
+	devEUI := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	for i := 0; i < 5; i++ {
+		HandleUplinkData(context.Background(), ts.routingProfile.ID, ts.asClient, &as.HandleUplinkDataRequest{DevEui: devEUI, FCnt: uint32(i)})
+	}
+
+	for i := 0; i < 5; i++ {
+		up := <-ts.asClient.HandleDataUpChan
+		assert.EqualValues(i, up.FCnt)
+	}
+}
+
+func (ts *RetryTestSuite) TestBufferMaxSize() {
+	assert := require.New(ts.T())
+
+	for i := 0; i < int(bufferMaxSize)+5; i++ {
+		assert.NoError(bufferEvent(context.Background(), event{
+			RoutingProfileID: ts.routingProfile.ID,
+			DownlinkACK:      &as.HandleDownlinkACKRequest{FCnt: uint32(i)},
+		}))
+	}
+
+	assert.Equal(bufferMaxSize, ts.bufferLen())
+
+	// the oldest events have been dropped
+	b, err := storage.RedisClient().LIndex(context.Background(), storage.GetRedisKey(bufferKeyTempl, ts.routingProfile.ID), 0).Bytes()
+	assert.NoError(err)
+	e, err := unmarshalEvent(ts.routingProfile.ID, b)
+	assert.NoError(err)
+	assert.EqualValues(5, e.DownlinkACK.FCnt)
+}
+
+func TestRetry(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
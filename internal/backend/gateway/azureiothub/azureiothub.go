@@ -182,6 +182,34 @@ func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
 	return b.publishCommand(log.Fields{}, gatewayID, "config", bb)
 }
 
+// SendGatewayCommandExecRequest sends the given command execution request
+// to the gateway.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalCommand(t, &pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal command execution request error")
+	}
+
+	return b.publishCommand(log.Fields{}, gatewayID, "exec", bb)
+}
+
+// SendRawPacketForwarderCommand sends the given raw packet-forwarder command
+// to the gateway.
+func (b *Backend) SendRawPacketForwarderCommand(pl gw.RawPacketForwarderCommand) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalCommand(t, &pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal raw packet-forwarder command error")
+	}
+
+	return b.publishCommand(log.Fields{}, gatewayID, "raw", bb)
+}
+
 func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
 	return b.uplinkFrameChan
 }
@@ -31,6 +31,27 @@ type Gateway interface {
 	Close() error                                          // close the gateway backend.
 }
 
+// CommandSender is implemented by the gateway backends which are able to
+// send the (ChirpStack Gateway Bridge) command execution requests and raw
+// packet-forwarder commands to the gateway.
+type CommandSender interface {
+	SendGatewayCommandExecRequest(gw.GatewayCommandExecRequest) error // send the given command execution request to the gateway
+	SendRawPacketForwarderCommand(gw.RawPacketForwarderCommand) error // send the given raw packet-forwarder command to the gateway
+}
+
+// CommandExecResponseReceiver is implemented by the gateway backends which
+// are able to receive the (ChirpStack Gateway Bridge) command execution
+// responses. Other gateway backends ignore these responses.
+type CommandExecResponseReceiver interface {
+	CommandExecResponseChan() chan gw.GatewayCommandExecResponse // channel containing the received command execution responses
+}
+
+// Publisher is implemented by the gateway backends which are able to publish
+// arbitrary payloads to the given topic (e.g. MQTT).
+type Publisher interface {
+	Publish(topic string, payload []byte) error // publish the given payload to the given topic
+}
+
 // UpdateDownlinkFrame updates the downlink frame for backward compatibility.
 func UpdateDownlinkFrame(mode string, df *gw.DownlinkFrame) error {
 	if len(df.Items) == 0 {
@@ -0,0 +1,34 @@
+package marshaler
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
+)
+
+// UnmarshalGatewayCommandExecResponse unmarshals a GatewayCommandExecResponse.
+func UnmarshalGatewayCommandExecResponse(b []byte, resp *gw.GatewayCommandExecResponse) (Type, error) {
+	var t Type
+
+	if strings.Contains(string(b), `"gatewayID"`) {
+		t = JSON
+	} else {
+		t = Protobuf
+	}
+
+	switch t {
+	case Protobuf:
+		return t, proto.Unmarshal(b, resp)
+	case JSON:
+		m := jsonpb.Unmarshaler{
+			AllowUnknownFields: true,
+		}
+		return t, m.Unmarshal(bytes.NewReader(b), resp)
+	}
+
+	return t, nil
+}
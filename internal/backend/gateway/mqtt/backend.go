@@ -14,6 +14,7 @@ import (
 	"time"
 
 	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -43,6 +44,7 @@ type Backend struct {
 	rxPacketChan      chan gw.UplinkFrame
 	statsPacketChan   chan gw.GatewayStats
 	downlinkTXAckChan chan gw.DownlinkTXAck
+	execResponseChan  chan gw.GatewayCommandExecResponse
 
 	conn                 paho.Client
 	eventTopic           string
@@ -62,6 +64,7 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 		rxPacketChan:      make(chan gw.UplinkFrame),
 		statsPacketChan:   make(chan gw.GatewayStats),
 		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+		execResponseChan:  make(chan gw.GatewayCommandExecResponse),
 		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
 		eventTopic:        conf.EventTopic,
 		qos:               conf.QOS,
@@ -126,6 +129,7 @@ func (b *Backend) Close() error {
 	close(b.rxPacketChan)
 	close(b.statsPacketChan)
 	close(b.downlinkTXAckChan)
+	close(b.execResponseChan)
 	return nil
 }
 
@@ -144,6 +148,11 @@ func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
 	return b.downlinkTXAckChan
 }
 
+// CommandExecResponseChan returns the command execution response channel.
+func (b *Backend) CommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.execResponseChan
+}
+
 // SendTXPacket sends the given downlink-frame to the gateway.
 func (b *Backend) SendTXPacket(txPacket gw.DownlinkFrame) error {
 	gatewayID := helpers.GetGatewayID(&txPacket)
@@ -165,6 +174,31 @@ func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfiguration)
 	return b.publishCommand(log.Fields{}, gatewayID, "config", &configPacket)
 }
 
+// SendGatewayCommandExecRequest sends the given command execution request
+// to the gateway.
+func (b *Backend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+
+	return b.publishCommand(log.Fields{}, gatewayID, "exec", &pl)
+}
+
+// SendRawPacketForwarderCommand sends the given raw packet-forwarder command
+// to the gateway.
+func (b *Backend) SendRawPacketForwarderCommand(pl gw.RawPacketForwarderCommand) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+
+	return b.publishCommand(log.Fields{}, gatewayID, "raw", &pl)
+}
+
+// Publish publishes the given payload to the given topic.
+func (b *Backend) Publish(topic string, payload []byte) error {
+	if token := b.conn.Publish(topic, b.qos, false, payload); token.Wait() && token.Error() != nil {
+		return errors.Wrap(token.Error(), "gateway/mqtt: publish error")
+	}
+
+	return nil
+}
+
 func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, msg proto.Message) error {
 	t := b.getGatewayMarshaler(gatewayID)
 	bb, err := marshaler.MarshalCommand(t, msg)
@@ -210,6 +244,9 @@ func (b *Backend) eventHandler(c paho.Client, msg paho.Message) {
 	} else if strings.HasSuffix(msg.Topic(), "stats") {
 		mqttEventCounter("stats").Inc()
 		go b.statsPacketHandler(c, msg)
+	} else if strings.HasSuffix(msg.Topic(), "exec") {
+		mqttEventCounter("exec").Inc()
+		go b.execResponseHandler(c, msg)
 	}
 }
 
@@ -331,6 +368,46 @@ func (b *Backend) ackPacketHandler(c paho.Client, msg paho.Message) {
 	b.downlinkTXAckChan <- ack
 }
 
+func (b *Backend) execResponseHandler(c paho.Client, msg paho.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	var resp gw.GatewayCommandExecResponse
+	t, err := marshaler.UnmarshalGatewayCommandExecResponse(msg.Payload(), &resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).WithError(err).Error("gateway/mqtt: unmarshal command execution response error")
+		return
+	}
+
+	gatewayID := helpers.GetGatewayID(&resp)
+	var execID uuid.UUID
+	copy(execID[:], resp.ExecId)
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// Since with MQTT all subscribers will receive the execution responses
+	// sent by all the gateways, the first instance receiving the message must
+	// lock it, so that other instances can ignore the same message.
+	key := storage.GetRedisKey("lora:ns:exec:lock:%s:%s", gatewayID, execID)
+	if locked, err := b.isLocked(key); err != nil || locked {
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"key":     key,
+				"exec_id": execID,
+			}).Error("gateway/mqtt: acquire lock error")
+		}
+
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"exec_id":    execID,
+	}).Info("gateway/mqtt: command execution response received")
+	b.execResponseChan <- resp
+}
+
 func (b *Backend) onConnected(c paho.Client) {
 	log.Info("backend/gateway: connected to mqtt server")
 
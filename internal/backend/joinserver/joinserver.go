@@ -42,6 +42,7 @@ var (
 func Setup(c config.Config) error {
 	conf := c.JoinServer
 	keks = make(map[string][]byte)
+	servers = nil
 
 	netID = c.NetworkServer.NetID
 	defaultServer = c.JoinServer.Default.Server
@@ -103,6 +104,41 @@ func Setup(c config.Config) error {
 	return nil
 }
 
+// Validate validates the join-server configuration, without setting up the
+// join-server clients.
+func Validate(c config.Config) error {
+	for _, s := range c.JoinServer.Servers {
+		var joinEUI lorawan.EUI64
+		if err := joinEUI.UnmarshalText([]byte(s.JoinEUI)); err != nil {
+			return errors.Wrap(err, "decode joineui error")
+		}
+
+		if s.Server == "" {
+			s.Server = joinEUIToServer(joinEUI, c.JoinServer.ResolveDomainSuffix)
+		}
+
+		if _, err := backend.NewClient(backend.ClientConfig{
+			Logger:     log.StandardLogger(),
+			SenderID:   c.NetworkServer.NetID.String(),
+			ReceiverID: joinEUI.String(),
+			Server:     s.Server,
+			CACert:     s.CACert,
+			TLSCert:    s.TLSCert,
+			TLSKey:     s.TLSKey,
+		}); err != nil {
+			return errors.Wrap(err, "new backend client error")
+		}
+	}
+
+	for _, k := range c.JoinServer.KEK.Set {
+		if _, err := hex.DecodeString(k.KEK); err != nil {
+			return errors.Wrap(err, "decode kek error")
+		}
+	}
+
+	return nil
+}
+
 // GetClientForJoinEUI returns the backend client for the given JoinEUI.
 func GetClientForJoinEUI(joinEUI lorawan.EUI64) (backend.Client, error) {
 	// Pre-configured join-servers.
@@ -2,6 +2,7 @@ package band
 
 import (
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	"github.com/liuhw0/lorawan"
@@ -12,21 +13,57 @@ var band loraband.Band
 
 // Setup sets up the band with the given configuration.
 func Setup(c config.Config) error {
+	bandConfig, err := newBand(c)
+	if err != nil {
+		return err
+	}
+
+	if len(c.NetworkServer.NetworkSettings.EnabledUplinkChannels) != 0 {
+		log.WithField("channels", c.NetworkServer.NetworkSettings.EnabledUplinkChannels).Info("enabled uplink channels")
+	}
+
+	// the band is only replaced once it is fully configured, so that Setup
+	// can also be used for re-configuring the band at runtime.
+	band = bandConfig
+	return nil
+}
+
+// Validate validates the band configuration, without setting up the band.
+func Validate(c config.Config) error {
+	_, err := newBand(c)
+	return err
+}
+
+func newBand(c config.Config) (loraband.Band, error) {
 	dwellTime := lorawan.DwellTimeNoLimit
 	if c.NetworkServer.Band.DownlinkDwellTime400ms {
 		dwellTime = lorawan.DwellTime400ms
 	}
 	bandConfig, err := loraband.GetConfig(c.NetworkServer.Band.Name, c.NetworkServer.Band.RepeaterCompatible, dwellTime)
 	if err != nil {
-		return errors.Wrap(err, "get band config error")
+		return nil, errors.Wrap(err, "get band config error")
 	}
 	for _, ec := range c.NetworkServer.NetworkSettings.ExtraChannels {
 		if err := bandConfig.AddChannel(ec.Frequency, ec.MinDR, ec.MaxDR); err != nil {
-			return errors.Wrap(err, "add channel error")
+			return nil, errors.Wrap(err, "add channel error")
 		}
 	}
-	band = bandConfig
-	return nil
+
+	if len(c.NetworkServer.NetworkSettings.EnabledUplinkChannels) != 0 {
+		for _, i := range bandConfig.GetEnabledUplinkChannelIndices() {
+			if err := bandConfig.DisableUplinkChannelIndex(i); err != nil {
+				return nil, errors.Wrap(err, "disable uplink channel error")
+			}
+		}
+
+		for _, i := range c.NetworkServer.NetworkSettings.EnabledUplinkChannels {
+			if err := bandConfig.EnableUplinkChannelIndex(i); err != nil {
+				return nil, errors.Wrap(err, "enable uplink channel error")
+			}
+		}
+	}
+
+	return bandConfig, nil
 }
 
 // Band returns the configured band.
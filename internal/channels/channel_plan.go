@@ -0,0 +1,133 @@
+package channels
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+// ChannelPlanChannelStatus contains the status of a channel-plan channel
+// on the device.
+type ChannelPlanChannelStatus struct {
+	// Channel contains the uplink channel index.
+	Channel int
+
+	// PlanChannel contains the channel as defined by the channel-plan.
+	PlanChannel storage.ChannelPlanChannel
+
+	// UplinkConfigured indicates the channel has been created on the
+	// device with the frequency and data-rate range of the channel-plan.
+	UplinkConfigured bool
+
+	// UplinkEnabled indicates the channel is enabled on the device.
+	UplinkEnabled bool
+
+	// DownlinkConfigured indicates the device uses the downlink frequency
+	// of the channel-plan.
+	DownlinkConfigured bool
+}
+
+// ChannelPlanStatus contains the progress of configuring a channel-plan
+// on a device.
+type ChannelPlanStatus struct {
+	// Channels contains the status of the channel-plan channels, ordered
+	// by channel index.
+	Channels []ChannelPlanChannelStatus
+
+	// PendingRemovalChannels contains the custom channels of the device
+	// which are not part of the channel-plan.
+	PendingRemovalChannels []int
+}
+
+// InSync returns true when the device is configured according to the
+// channel-plan.
+func (s ChannelPlanStatus) InSync() bool {
+	if len(s.PendingRemovalChannels) != 0 {
+		return false
+	}
+
+	for _, c := range s.Channels {
+		if !c.UplinkConfigured || !c.UplinkEnabled || !c.DownlinkConfigured {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DLChannelReqSupported returns true when the DlChannelReq mac-command can
+// be sent to devices implementing the given LoRaWAN version. This
+// mac-command was introduced by LoRaWAN 1.0.2 and is not defined for the
+// bands using a fixed channel-plan (US915, AU915 and CN470).
+func DLChannelReqSupported(macVersion string) bool {
+	switch macVersion {
+	case "1.0.0", "1.0.1":
+		return false
+	}
+
+	switch loraband.Name(band.Band().Name()) {
+	case loraband.US915, loraband.AU915, loraband.CN470:
+		return false
+	}
+
+	return true
+}
+
+// GetChannelPlanStatus returns the status of the given channel-plan on the
+// device.
+func GetChannelPlanStatus(ds storage.DeviceSession, cp storage.ChannelPlan) (ChannelPlanStatus, error) {
+	var out ChannelPlanStatus
+
+	planChannels, err := cp.GetUplinkChannels()
+	if err != nil {
+		return out, errors.Wrap(err, "get channel-plan uplink channels error")
+	}
+
+	enabled := make(map[int]bool)
+	for _, c := range ds.EnabledUplinkChannels {
+		enabled[c] = true
+	}
+
+	for i, pc := range planChannels {
+		c, ok := ds.ExtraUplinkChannels[i]
+		status := ChannelPlanChannelStatus{
+			Channel:          i,
+			PlanChannel:      pc,
+			UplinkConfigured: ok && c == pc.GetBandChannel(),
+			UplinkEnabled:    enabled[i],
+		}
+
+		dlFreq, ok := ds.ExtraDownlinkFrequencies[i]
+		if pc.DownlinkFrequency == 0 {
+			defaultFreq, err := band.Band().GetRX1FrequencyForUplinkFrequency(pc.Frequency)
+			if err != nil {
+				return out, errors.Wrap(err, "get rx1 frequency error")
+			}
+			status.DownlinkConfigured = !ok || dlFreq == defaultFreq
+		} else {
+			status.DownlinkConfigured = ok && dlFreq == pc.DownlinkFrequency
+		}
+
+		out.Channels = append(out.Channels, status)
+	}
+
+	sort.Slice(out.Channels, func(i, j int) bool {
+		return out.Channels[i].Channel < out.Channels[j].Channel
+	})
+
+	for _, i := range band.Band().GetCustomUplinkChannelIndices() {
+		if _, ok := planChannels[i]; ok {
+			continue
+		}
+
+		if _, ok := ds.ExtraUplinkChannels[i]; ok || enabled[i] {
+			out.PendingRemovalChannels = append(out.PendingRemovalChannels, i)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package channels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+func TestGetChannelPlanStatus(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	for _, f := range []uint32{867100000, 867300000, 867500000} {
+		conf.NetworkServer.NetworkSettings.ExtraChannels = append(conf.NetworkServer.NetworkSettings.ExtraChannels, struct {
+			Frequency uint32 `mapstructure:"frequency"`
+			MinDR     int    `mapstructure:"min_dr"`
+			MaxDR     int    `mapstructure:"max_dr"`
+		}{
+			Frequency: f,
+			MinDR:     0,
+			MaxDR:     5,
+		})
+	}
+	assert.NoError(band.Setup(conf))
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	cp := storage.ChannelPlan{
+		Channels: storage.ChannelPlanChannels{
+			{Frequency: 867500000, MinDR: 0, MaxDR: 5},
+			{Frequency: 867100000, MinDR: 0, MaxDR: 5, DownlinkFrequency: 869000000},
+		},
+	}
+
+	t.Run("Not configured", func(t *testing.T) {
+		assert := require.New(t)
+
+		status, err := GetChannelPlanStatus(storage.DeviceSession{
+			EnabledUplinkChannels: []int{0, 1, 2, 4},
+			ExtraUplinkChannels: map[int]loraband.Channel{
+				4: {Frequency: 867300000, MinDR: 0, MaxDR: 5},
+			},
+		}, cp)
+		assert.NoError(err)
+		assert.Equal(ChannelPlanStatus{
+			Channels: []ChannelPlanChannelStatus{
+				{Channel: 3, PlanChannel: cp.Channels[1]},
+				{Channel: 5, PlanChannel: cp.Channels[0], DownlinkConfigured: true},
+			},
+			PendingRemovalChannels: []int{4},
+		}, status)
+		assert.False(status.InSync())
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		assert := require.New(t)
+
+		status, err := GetChannelPlanStatus(storage.DeviceSession{
+			EnabledUplinkChannels: []int{0, 1, 2, 3, 5},
+			ExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+			},
+			ExtraDownlinkFrequencies: map[int]uint32{
+				3: 869000000,
+			},
+		}, cp)
+		assert.NoError(err)
+		assert.Len(status.Channels, 2)
+		assert.Len(status.PendingRemovalChannels, 0)
+		assert.True(status.InSync())
+	})
+}
+
+func TestDLChannelReqSupported(t *testing.T) {
+	assert := require.New(t)
+
+	assert.False(DLChannelReqSupported("1.0.0"))
+	assert.False(DLChannelReqSupported("1.0.1"))
+	assert.True(DLChannelReqSupported("1.0.2"))
+	assert.True(DLChannelReqSupported("1.1.0"))
+
+	conf := test.GetConfig()
+	conf.NetworkServer.Band.Name = loraband.US915
+	assert.NoError(band.Setup(conf))
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	assert.False(DLChannelReqSupported("1.0.3"))
+}
@@ -1,17 +1,49 @@
 package channels
 
 import (
+	"sort"
+
+	"github.com/pkg/errors"
+
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 	"github.com/liuhw0/lorawan"
+	loraband "github.com/liuhw0/lorawan/band"
 )
 
+// ErrNoEnabledChannels is returned when the service-profile channel-mask
+// does not contain any of the enabled uplink channels.
+var ErrNoEnabledChannels = errors.New("service-profile channel-mask does not contain any enabled uplink channel")
+
 // HandleChannelReconfigure handles the reconfiguration of active channels
 // on the node. This is needed in case only a sub-set of channels is used
-// (e.g. for the US band) or when a reconfiguration of active channels
-// happens.
-func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
-	payloads := band.Band().GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
+// (e.g. for the US band), when a reconfiguration of active channels
+// happens or when the service-profile channel-mask restricts the channels
+// of the node. When a channel-plan is given, the custom channels are
+// restricted to the channels of the channel-plan.
+func HandleChannelReconfigure(ds storage.DeviceSession, sp storage.ServiceProfile, cp *storage.ChannelPlan) ([]storage.MACCommandBlock, error) {
+	var payloads []lorawan.LinkADRReqPayload
+
+	if len(sp.ChannelMask) == 0 && cp == nil {
+		payloads = band.Band().GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
+	} else {
+		enabledChannels := GetEnabledUplinkChannelIndices(sp)
+
+		if cp != nil {
+			var err error
+			enabledChannels, err = filterChannelPlanChannels(enabledChannels, *cp)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(enabledChannels) == 0 {
+			return nil, ErrNoEnabledChannels
+		}
+
+		payloads = getLinkADRReqPayloads(ds.EnabledUplinkChannels, enabledChannels)
+	}
+
 	if len(payloads) == 0 {
 		return nil, nil
 	}
@@ -32,3 +64,155 @@ func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlo
 
 	return []storage.MACCommandBlock{block}, nil
 }
+
+// GetEnabledUplinkChannelIndices returns the uplink channel indices enabled
+// by the network, restricted to the channel-mask of the given
+// service-profile.
+func GetEnabledUplinkChannelIndices(sp storage.ServiceProfile) []int {
+	var out []int
+	for _, c := range band.Band().GetEnabledUplinkChannelIndices() {
+		if ChannelMaskAllows(sp.ChannelMask, c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// filterChannelPlanChannels removes the custom channels which are not part
+// of the given channel-plan from the given channels.
+func filterChannelPlanChannels(channels []int, cp storage.ChannelPlan) ([]int, error) {
+	planChannels, err := cp.GetUplinkChannels()
+	if err != nil {
+		return nil, errors.Wrap(err, "get channel-plan uplink channels error")
+	}
+
+	custom := make(map[int]bool)
+	for _, c := range band.Band().GetCustomUplinkChannelIndices() {
+		custom[c] = true
+	}
+
+	var out []int
+	for _, c := range channels {
+		if _, ok := planChannels[c]; custom[c] && !ok {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// ChannelMaskAllows returns true when the given channel index is allowed
+// by the given (service-profile) channel-mask. The channel-mask is a
+// bit-mask in which the least significant bit of the first byte represents
+// channel 0, the most significant bit of the first byte channel 7, the least
+// significant bit of the second byte channel 8, etc. An empty channel-mask
+// allows all channels.
+func ChannelMaskAllows(mask []byte, channel int) bool {
+	if len(mask) == 0 {
+		return true
+	}
+
+	if channel < 0 || channel/8 >= len(mask) {
+		return false
+	}
+
+	return mask[channel/8]&(1<<uint(channel%8)) != 0
+}
+
+// getLinkADRReqPayloads returns the LinkADRReq payloads for changing the
+// enabled uplink channels of the device to the given enabled channels.
+// Like the band implementation, custom (CFList) channels are only enabled
+// when the device already has them enabled, as we have no knowledge if the
+// device has been provisioned with these frequencies.
+func getLinkADRReqPayloads(deviceEnabledChannels, enabledChannels []int) []lorawan.LinkADRReqPayload {
+	custom := make(map[int]bool)
+	for _, c := range band.Band().GetCustomUplinkChannelIndices() {
+		custom[c] = true
+	}
+
+	deviceEnabled := make(map[int]bool)
+	for _, c := range deviceEnabledChannels {
+		deviceEnabled[c] = true
+	}
+
+	wanted := make(map[int]bool)
+	for _, c := range enabledChannels {
+		if !custom[c] || deviceEnabled[c] {
+			wanted[c] = true
+		}
+	}
+
+	// the blocks of 16 channels containing a difference
+	diffBlocks := make(map[int]bool)
+	for c := range deviceEnabled {
+		if !wanted[c] {
+			diffBlocks[c/16] = true
+		}
+	}
+	for c := range wanted {
+		if !deviceEnabled[c] {
+			diffBlocks[c/16] = true
+		}
+	}
+
+	// nothing to do
+	if len(diffBlocks) == 0 {
+		return nil
+	}
+
+	payloads := getLinkADRReqPayloadsForBlocks(wanted, diffBlocks)
+
+	// For the US915 and AU915 bands, all 125 kHz channels can be turned off
+	// using ChMaskCntl 7, after which only the blocks containing wanted
+	// channels must be turned on. Use this when it results in less payloads.
+	name := band.Band().Name()
+	if name == string(loraband.US915) || name == string(loraband.AU915) {
+		out := []lorawan.LinkADRReqPayload{
+			{Redundancy: lorawan.Redundancy{ChMaskCntl: 7}}, // all 125 kHz off, ChMask applies to channels 64 - 71
+		}
+
+		blocks := make(map[int]bool)
+		for c := range wanted {
+			if c >= 64 {
+				out[0].ChMask[c%16] = true
+			} else {
+				blocks[c/16] = true
+			}
+		}
+
+		out = append(out, getLinkADRReqPayloadsForBlocks(wanted, blocks)...)
+
+		if len(out) < len(payloads) {
+			return out
+		}
+	}
+
+	return payloads
+}
+
+// getLinkADRReqPayloadsForBlocks returns a LinkADRReq payload for each of
+// the given blocks of 16 channels, enabling the wanted channels.
+func getLinkADRReqPayloadsForBlocks(wanted map[int]bool, blocks map[int]bool) []lorawan.LinkADRReqPayload {
+	var sorted []int
+	for b := range blocks {
+		sorted = append(sorted, b)
+	}
+	sort.Ints(sorted)
+
+	var out []lorawan.LinkADRReqPayload
+	for _, b := range sorted {
+		pl := lorawan.LinkADRReqPayload{
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: uint8(b),
+			},
+		}
+
+		for i := 0; i < 16; i++ {
+			pl.ChMask[i] = wanted[b*16+i]
+		}
+
+		out = append(out, pl)
+	}
+
+	return out
+}
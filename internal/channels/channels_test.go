@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
 	"github.com/liuhw0/lorawan"
+	loraband "github.com/liuhw0/lorawan/band"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -15,9 +19,12 @@ func TestHandleChannelReconfigure(t *testing.T) {
 
 	Convey("Given a set of tests", t, func() {
 		tests := []struct {
-			Name          string
-			DeviceSession storage.DeviceSession
-			Expected      []storage.MACCommandBlock
+			Name           string
+			DeviceSession  storage.DeviceSession
+			ServiceProfile storage.ServiceProfile
+			ChannelPlan    *storage.ChannelPlan
+			Expected       []storage.MACCommandBlock
+			ExpectedError  error
 		}{
 			{
 				Name: "no channels to reconfigure",
@@ -54,14 +61,211 @@ func TestHandleChannelReconfigure(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "service-profile channel-mask matches",
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:          1,
+					NbTrans:               2,
+					EnabledUplinkChannels: []int{0, 1},
+				},
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x03},
+				},
+			},
+			{
+				Name: "service-profile channel-mask restricts channels",
+				DeviceSession: storage.DeviceSession{
+					TXPowerIndex:          1,
+					NbTrans:               2,
+					EnabledUplinkChannels: []int{0, 1, 2},
+					DR:                    3,
+				},
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x05},
+				},
+				Expected: []storage.MACCommandBlock{
+					{
+						CID: lorawan.LinkADRReq,
+						MACCommands: storage.MACCommands{
+							lorawan.MACCommand{
+								CID: lorawan.LinkADRReq,
+								Payload: &lorawan.LinkADRReqPayload{
+									DataRate: 3,
+									TXPower:  1,
+									ChMask:   lorawan.ChMask{true, false, true},
+									Redundancy: lorawan.Redundancy{
+										NbRep: 2,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "service-profile channel-mask without enabled channels",
+				DeviceSession: storage.DeviceSession{
+					EnabledUplinkChannels: []int{0, 1, 2},
+				},
+				ServiceProfile: storage.ServiceProfile{
+					ChannelMask: []byte{0x00, 0x01},
+				},
+				ExpectedError: ErrNoEnabledChannels,
+			},
 		}
 
 		for i, test := range tests {
 			Convey(fmt.Sprintf("test: %s [%d]", test.Name, i), func() {
-				blocks, err := HandleChannelReconfigure(test.DeviceSession)
-				So(err, ShouldBeNil)
+				blocks, err := HandleChannelReconfigure(test.DeviceSession, test.ServiceProfile, test.ChannelPlan)
+				So(err, ShouldEqual, test.ExpectedError)
 				So(blocks, ShouldResemble, test.Expected)
 			})
 		}
 	})
 }
+
+func TestHandleChannelReconfigureUS915(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	conf.NetworkServer.Band.Name = loraband.US915
+	assert.NoError(band.Setup(conf))
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	// all 72 channels enabled on the device
+	var deviceChannels []int
+	for i := 0; i < 72; i++ {
+		deviceChannels = append(deviceChannels, i)
+	}
+
+	// sub-band 2 (channels 8 - 15 and 65)
+	sp := storage.ServiceProfile{
+		ChannelMask: []byte{0x00, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02},
+	}
+
+	assert.Equal([]int{8, 9, 10, 11, 12, 13, 14, 15, 65}, GetEnabledUplinkChannelIndices(sp))
+
+	blocks, err := HandleChannelReconfigure(storage.DeviceSession{
+		EnabledUplinkChannels: deviceChannels,
+		DR:                    3,
+	}, sp, nil)
+	assert.NoError(err)
+	assert.Len(blocks, 1)
+	assert.Equal(storage.MACCommands{
+		{
+			CID: lorawan.LinkADRReq,
+			Payload: &lorawan.LinkADRReqPayload{
+				ChMask:     lorawan.ChMask{false, true},
+				Redundancy: lorawan.Redundancy{ChMaskCntl: 7},
+			},
+		},
+		{
+			CID: lorawan.LinkADRReq,
+			Payload: &lorawan.LinkADRReqPayload{
+				DataRate:   3,
+				ChMask:     lorawan.ChMask{8: true, 9: true, 10: true, 11: true, 12: true, 13: true, 14: true, 15: true},
+				Redundancy: lorawan.Redundancy{ChMaskCntl: 0},
+			},
+		},
+	}, blocks[0].MACCommands)
+
+	// the device has been reconfigured
+	blocks, err = HandleChannelReconfigure(storage.DeviceSession{
+		EnabledUplinkChannels: []int{8, 9, 10, 11, 12, 13, 14, 15, 65},
+	}, sp, nil)
+	assert.NoError(err)
+	assert.Len(blocks, 0)
+}
+
+func TestHandleChannelReconfigureChannelPlan(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	for _, f := range []uint32{867100000, 867300000, 867500000} {
+		conf.NetworkServer.NetworkSettings.ExtraChannels = append(conf.NetworkServer.NetworkSettings.ExtraChannels, struct {
+			Frequency uint32 `mapstructure:"frequency"`
+			MinDR     int    `mapstructure:"min_dr"`
+			MaxDR     int    `mapstructure:"max_dr"`
+		}{
+			Frequency: f,
+			MinDR:     0,
+			MaxDR:     5,
+		})
+	}
+	assert.NoError(band.Setup(conf))
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	cp := storage.ChannelPlan{
+		Channels: storage.ChannelPlanChannels{
+			{Frequency: 867100000, MinDR: 0, MaxDR: 5},
+			{Frequency: 867500000, MinDR: 0, MaxDR: 5},
+		},
+	}
+
+	// the channel 867300000 (index 4) is not part of the channel-plan
+	blocks, err := HandleChannelReconfigure(storage.DeviceSession{
+		EnabledUplinkChannels: []int{0, 1, 2, 3, 4, 5},
+		DR:                    3,
+		NbTrans:               1,
+	}, storage.ServiceProfile{}, &cp)
+	assert.NoError(err)
+	assert.Equal([]storage.MACCommandBlock{
+		{
+			CID: lorawan.LinkADRReq,
+			MACCommands: storage.MACCommands{
+				{
+					CID: lorawan.LinkADRReq,
+					Payload: &lorawan.LinkADRReqPayload{
+						DataRate:   3,
+						ChMask:     lorawan.ChMask{true, true, true, true, false, true},
+						Redundancy: lorawan.Redundancy{NbRep: 1},
+					},
+				},
+			},
+		},
+	}, blocks)
+
+	// the device has been reconfigured, channel 5 has not yet been created
+	// on the device
+	blocks, err = HandleChannelReconfigure(storage.DeviceSession{
+		EnabledUplinkChannels: []int{0, 1, 2, 3},
+	}, storage.ServiceProfile{}, &cp)
+	assert.NoError(err)
+	assert.Len(blocks, 0)
+
+	// the channel-plan frequency is not configured in the band
+	_, err = HandleChannelReconfigure(storage.DeviceSession{
+		EnabledUplinkChannels: []int{0, 1, 2},
+	}, storage.ServiceProfile{}, &storage.ChannelPlan{
+		Channels: storage.ChannelPlanChannels{
+			{Frequency: 867900000, MinDR: 0, MaxDR: 5},
+		},
+	})
+	assert.Error(err)
+}
+
+func TestChannelMaskAllows(t *testing.T) {
+	tests := []struct {
+		Mask    []byte
+		Channel int
+		Allowed bool
+	}{
+		{nil, 0, true},
+		{nil, 71, true},
+		{[]byte{0x01}, 0, true},
+		{[]byte{0x01}, 1, false},
+		{[]byte{0x80}, 7, true},
+		{[]byte{0x00, 0x01}, 8, true},
+		{[]byte{0x00, 0x01}, 16, false},
+	}
+
+	for _, tst := range tests {
+		t.Run(fmt.Sprintf("%x %d", tst.Mask, tst.Channel), func(t *testing.T) {
+			require.Equal(t, tst.Allowed, ChannelMaskAllows(tst.Mask, tst.Channel))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/brocaar/chirpstack-api/go/v3/nc"
@@ -39,11 +41,30 @@ type Config struct {
 	} `mapstructure:"redis"`
 
 	NetworkServer struct {
-		NetID                lorawan.NetID
-		NetIDString          string        `mapstructure:"net_id"`
-		DeduplicationDelay   time.Duration `mapstructure:"deduplication_delay"`
-		DeviceSessionTTL     time.Duration `mapstructure:"device_session_ttl"`
-		GetDownlinkDataDelay time.Duration `mapstructure:"get_downlink_data_delay"`
+		NetID                         lorawan.NetID
+		NetIDString                   string        `mapstructure:"net_id"`
+		DeduplicationDelay            time.Duration `mapstructure:"deduplication_delay"`
+		AdaptiveDeduplication         bool          `mapstructure:"adaptive_deduplication"`
+		AdaptiveDeduplicationMinDelay time.Duration `mapstructure:"adaptive_deduplication_min_delay"`
+		DeviceSessionTTL              time.Duration `mapstructure:"device_session_ttl"`
+		GetDownlinkDataDelay          time.Duration `mapstructure:"get_downlink_data_delay"`
+		ProprietaryPlugins            []string      `mapstructure:"proprietary_plugins"`
+
+		DeviceSessionKEK struct {
+			Label string `mapstructure:"label"`
+			Set   []KEK  `mapstructure:"set"`
+		} `mapstructure:"device_session_kek"`
+
+		DeviceSessionPersistence struct {
+			Enabled       bool          `mapstructure:"enabled"`
+			FlushInterval time.Duration `mapstructure:"flush_interval"`
+			FCntGap       uint32        `mapstructure:"f_cnt_gap"`
+		} `mapstructure:"device_session_persistence"`
+
+		UplinkWorkerPool struct {
+			Workers   int `mapstructure:"workers"`
+			QueueSize int `mapstructure:"queue_size"`
+		} `mapstructure:"uplink_worker_pool"`
 
 		Band struct {
 			Name                   band.Name `mapstructure:"name"`
@@ -70,6 +91,17 @@ type Config struct {
 			MaxMACCommandErrorCount int      `mapstructure:"max_mac_command_error_count"`
 			ADRPlugins              []string `mapstructure:"adr_plugins"`
 
+			ADRScriptsDir    string        `mapstructure:"adr_scripts_dir"`
+			ADRScriptTimeout time.Duration `mapstructure:"adr_script_timeout"`
+
+			ADRRemoteHandlers []struct {
+				Server  string        `mapstructure:"server"`
+				CACert  string        `mapstructure:"ca_cert"`
+				TLSCert string        `mapstructure:"tls_cert"`
+				TLSKey  string        `mapstructure:"tls_key"`
+				Timeout time.Duration `mapstructure:"timeout"`
+			} `mapstructure:"adr_remote_handlers"`
+
 			ExtraChannels []struct {
 				Frequency uint32 `mapstructure:"frequency"`
 				MinDR     int    `mapstructure:"min_dr"`
@@ -89,13 +121,18 @@ type Config struct {
 		} `mapstructure:"network_settings"`
 
 		Scheduler struct {
-			SchedulerInterval time.Duration `mapstructure:"scheduler_interval"`
+			SchedulerInterval    time.Duration `mapstructure:"scheduler_interval"`
+			MulticastMaxAttempts int           `mapstructure:"multicast_max_attempts"`
 
 			ClassC struct {
 				GatewayDownlinkLockDuration time.Duration `mapstructure:"gateway_downlink_lock_duration"`
 				DeviceDownlinkLockDuration  time.Duration `mapstructure:"device_downlink_lock_duration"`
 				MulticastGatewayDelay       time.Duration `mapstructure:"multicast_gateway_delay"`
 			} `mapstructure:"class_c"`
+
+			ClassB struct {
+				MulticastGatewayTimeSyncRequired bool `mapstructure:"multicast_gateway_time_sync_required"`
+			} `mapstructure:"class_b"`
 		} `mapstructure:"scheduler"`
 
 		API struct {
@@ -118,6 +155,9 @@ type Config struct {
 
 			ForceGwsPrivate bool `mapstructure:"force_gws_private"`
 
+			PacketForwarderConfigCommand string        `mapstructure:"packet_forwarder_config_command"`
+			ConfigPendingTimeout         time.Duration `mapstructure:"config_pending_timeout"`
+
 			Backend struct {
 				Type                 string `mapstructure:"type"`
 				MultiDownlinkFeature string `mapstructure:"multi_downlink_feature"`
@@ -161,6 +201,28 @@ type Config struct {
 		} `mapstructure:"gateway"`
 	} `mapstructure:"network_server"`
 
+	ApplicationServer struct {
+		Client struct {
+			IdleTimeout         time.Duration `mapstructure:"idle_timeout"`
+			HealthCheckInterval time.Duration `mapstructure:"health_check_interval"`
+
+			CircuitBreaker struct {
+				FailureThreshold int           `mapstructure:"failure_threshold"`
+				OpenDuration     time.Duration `mapstructure:"open_duration"`
+			} `mapstructure:"circuit_breaker"`
+		} `mapstructure:"client"`
+
+		Retry struct {
+			MaxAttempts     int           `mapstructure:"max_attempts"`
+			Interval        time.Duration `mapstructure:"interval"`
+			DeliveryTimeout time.Duration `mapstructure:"delivery_timeout"`
+			QueueSize       int           `mapstructure:"queue_size"`
+			BufferMaxSize   int64         `mapstructure:"buffer_max_size"`
+			BufferTTL       time.Duration `mapstructure:"buffer_ttl"`
+			ReplayInterval  time.Duration `mapstructure:"replay_interval"`
+		} `mapstructure:"retry"`
+	} `mapstructure:"application_server"`
+
 	JoinServer struct {
 		ResolveJoinEUI      bool   `mapstructure:"resolve_join_eui"`
 		ResolveDomainSuffix string `mapstructure:"resolve_domain_suffix"`
@@ -231,10 +293,27 @@ type Config struct {
 		PrometheusEndpoint           bool   `mapstructure:"prometheus_endpoint"`
 		PrometheusAPITimingHistogram bool   `mapstructure:"prometheus_api_timing_histogram"`
 		HealthcheckEndpoint          bool   `mapstructure:"healthcheck_endpoint"`
+		ConfigReloadEndpoint         bool   `mapstructure:"config_reload_endpoint"`
 		DeviceFrameLogMaxHistory     int64  `mapstructure:"device_frame_log_max_history"`
 		GatewayFrameLogMaxHistory    int64  `mapstructure:"gateway_frame_log_max_history"`
 		PerDeviceFrameLogMaxHistory  int64  `mapstructure:"per_device_frame_log_max_history"`
 		PerGatewayFrameLogMaxHistory int64  `mapstructure:"per_gateway_frame_log_max_history"`
+
+		Tracing struct {
+			Enabled       bool    `mapstructure:"enabled"`
+			ServiceName   string  `mapstructure:"service_name"`
+			OTLPEndpoint  string  `mapstructure:"otlp_endpoint"`
+			OTLPInsecure  bool    `mapstructure:"otlp_insecure"`
+			SamplingRatio float64 `mapstructure:"sampling_ratio"`
+		} `mapstructure:"tracing"`
+
+		Audit struct {
+			PerDeviceMaxHistory         int64         `mapstructure:"per_device_max_history"`
+			MaxHistory                  int64         `mapstructure:"max_history"`
+			GatewayBackendTopicTemplate string        `mapstructure:"gateway_backend_topic_template"`
+			WebhookURL                  string        `mapstructure:"webhook_url"`
+			WebhookTimeout              time.Duration `mapstructure:"webhook_timeout"`
+		} `mapstructure:"audit"`
 	} `mapstructure:"monitoring"`
 }
 
@@ -296,12 +375,47 @@ var ClassBEnqueueMargin = time.Second * 5
 // for Class-C multicast.
 var MulticastClassCInterval = time.Second
 
-// Get returns the configuration.
+var (
+	current  atomic.Value
+	reloadMu sync.RWMutex
+)
+
+// Get returns the configuration. Until Set has been called, this returns
+// the global configuration (C). The returned configuration must not be
+// modified.
 func Get() *Config {
+	if c, ok := current.Load().(*Config); ok {
+		return c
+	}
 	return &C
 }
 
-// Set sets the configuration.
+// Set sets the configuration. The configuration is replaced atomically,
+// so that it can be safely replaced at runtime.
 func Set(c Config) {
-	C = c
+	current.Store(&c)
+}
+
+// RLock must be held while handling an uplink, downlink or API request, as
+// the packages copy their configuration into package variables. It prevents
+// the packages from being re-configured during the handling of the request.
+func RLock() {
+	reloadMu.RLock()
+}
+
+// RUnlock releases the lock acquired by RLock.
+func RUnlock() {
+	reloadMu.RUnlock()
+}
+
+// Lock must be held while re-configuring the packages at runtime. It waits
+// for the pending requests to complete and blocks new requests until Unlock
+// has been called.
+func Lock() {
+	reloadMu.Lock()
+}
+
+// Unlock releases the lock acquired by Lock.
+func Unlock() {
+	reloadMu.Unlock()
 }
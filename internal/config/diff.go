@@ -0,0 +1,50 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Diff returns the configuration keys (e.g. join_server.default.server) of
+// the values that are different between the two given configurations.
+// Slices and maps are compared as a whole.
+func Diff(a, b Config) []string {
+	var out []string
+	diffValue(reflect.ValueOf(a), reflect.ValueOf(b), nil, &out)
+	return out
+}
+
+func diffValue(a, b reflect.Value, path []string, out *[]string) {
+	if a.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*out = append(*out, strings.Join(path, "."))
+		}
+		return
+	}
+
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		// skip unexported fields
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, ok := f.Tag.Lookup("mapstructure")
+		if !ok {
+			name = strings.ToLower(f.Name)
+		}
+		if name == "-" {
+			continue
+		}
+
+		diffValue(a.Field(i), b.Field(i), append(path[:len(path):len(path)], name), out)
+	}
+}
+
+// HasKeyPrefix returns true when the given configuration key equals the
+// given prefix, or is nested under it.
+func HasKeyPrefix(key, prefix string) bool {
+	return key == prefix || strings.HasPrefix(key, prefix+".")
+}
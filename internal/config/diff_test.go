@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	assert := require.New(t)
+
+	var a Config
+	a.General.LogLevel = 4
+	a.NetworkServer.NetworkSettings.EnabledUplinkChannels = []int{0, 1, 2}
+	a.JoinServer.Default.Server = "http://localhost:8003"
+
+	b := a
+	assert.Len(Diff(a, b), 0)
+
+	b.General.LogLevel = 5
+	b.NetworkServer.NetworkSettings.EnabledUplinkChannels = []int{0, 1}
+	b.NetworkServer.DeduplicationDelay = time.Second
+	b.JoinServer.Servers = append(b.JoinServer.Servers, a.JoinServer.Servers...)
+	b.JoinServer.KEK.Set = []KEK{{Label: "kek", KEK: "01020304050607080102030405060708"}}
+
+	assert.Equal([]string{
+		"general.log_level",
+		"network_server.deduplication_delay",
+		"network_server.network_settings.enabled_uplink_channels",
+		"join_server.kek.set",
+	}, Diff(a, b))
+}
+
+func TestHasKeyPrefix(t *testing.T) {
+	assert := require.New(t)
+
+	assert.True(HasKeyPrefix("roaming", "roaming"))
+	assert.True(HasKeyPrefix("roaming.api.bind", "roaming.api"))
+	assert.False(HasKeyPrefix("roaming_foo", "roaming"))
+	assert.False(HasKeyPrefix("roaming", "roaming.api"))
+}
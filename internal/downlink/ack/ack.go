@@ -17,12 +17,15 @@ import (
 	"github.com/brocaar/chirpstack-api/go/v3/gw"
 	"github.com/brocaar/chirpstack-api/go/v3/nc"
 	"github.com/brocaar/chirpstack-api/go/v3/ns"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver/retry"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/controller"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/multicast"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/framelog"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 )
 
 var (
@@ -41,12 +44,18 @@ var handleDownlinkTXAckTasks = []func(*ackContext) error{
 			sendErrorToApplicationServerOnLastFrame,
 		),
 		forMulticastPayload(
-			// TODO: For now we delete the multicast queue-item. What would be the best
-			// way to retry? Multicast is a bit more complicated as the downlinks are
-			// tied to a gateway as one downlink can be emitted through multiple
-			// gateways. Would time-shifting all items for a Gateway ID + Multicast
-			// group ID be enough?
-			deleteMulticastQueueItem,
+			// As one multicast downlink can be emitted through multiple gateways,
+			// only the queue-item of the failed gateway is re-scheduled.
+			transaction(
+				getMulticastQueueItem,
+				retryMulticastQueueItem,
+				forMulticastLastAttempt(
+					deleteMulticastQueueItem,
+				),
+			),
+			forMulticastLastAttempt(
+				sendMulticastErrorToApplicationServer,
+			),
 		),
 
 		// Backwards compatibility.
@@ -102,12 +111,21 @@ type ackContext struct {
 	DeviceSession       storage.DeviceSession
 	DeviceProfile       storage.DeviceProfile
 	DeviceQueueItem     storage.DeviceQueueItem
+	MulticastQueueItem  storage.MulticastQueueItem
+	MulticastRetry      bool
 	MHDR                lorawan.MHDR
 	MACPayload          *lorawan.MACPayload
 }
 
 // HandleDownlinkTXAck handles the given downlink TX acknowledgement.
 func HandleDownlinkTXAck(ctx context.Context, downlinkTXAck *gw.DownlinkTXAck) error {
+	ctx, span := tracing.Start(ctx, "downlink.ack.HandleDownlinkTXAck")
+	err := handleDownlinkTXAck(ctx, downlinkTXAck)
+	tracing.End(span, err)
+	return err
+}
+
+func handleDownlinkTXAck(ctx context.Context, downlinkTXAck *gw.DownlinkTXAck) error {
 	var ackStatus gw.TxAckStatus
 
 	if len(downlinkTXAck.Items) == 0 {
@@ -138,7 +156,11 @@ func HandleDownlinkTXAck(ctx context.Context, downlinkTXAck *gw.DownlinkTXAck) e
 	}
 
 	for _, t := range handleDownlinkTXAckTasks {
-		if err := t(&actx); err != nil {
+		t := t
+		if err := tracing.RunTask(ctx, t, func(ctx context.Context) error {
+			actx.ctx = ctx
+			return t(&actx)
+		}, errAbort); err != nil {
 			if err == errAbort {
 				return nil
 			}
@@ -262,6 +284,22 @@ func forMulticastPayload(funcs ...func(*ackContext) error) func(*ackContext) err
 	}
 }
 
+func forMulticastLastAttempt(funcs ...func(*ackContext) error) func(*ackContext) error {
+	return func(ctx *ackContext) error {
+		if ctx.MulticastRetry {
+			return nil
+		}
+
+		for _, f := range funcs {
+			if err := f(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
 func getToken(ctx *ackContext) error {
 	if ctx.DownlinkTXAck.Token != 0 {
 		ctx.Token = uint16(ctx.DownlinkTXAck.Token)
@@ -403,6 +441,52 @@ func deleteMulticastQueueItem(ctx *ackContext) error {
 	return nil
 }
 
+func getMulticastQueueItem(ctx *ackContext) error {
+	var err error
+	ctx.MulticastQueueItem, err = storage.GetMulticastQueueItem(ctx.ctx, ctx.DB, ctx.DownlinkFrame.MulticastQueueItemId)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-queue item error")
+	}
+	return nil
+}
+
+// retryMulticastQueueItem re-schedules the multicast-queue item in case the
+// gateway reported a transient error and the max. number of attempts has not
+// yet been reached.
+func retryMulticastQueueItem(ctx *ackContext) error {
+	switch ctx.DownlinkTXAckStatus {
+	case gw.TxAckStatus_TOO_LATE,
+		gw.TxAckStatus_TOO_EARLY,
+		gw.TxAckStatus_COLLISION_PACKET,
+		gw.TxAckStatus_COLLISION_BEACON,
+		gw.TxAckStatus_GPS_UNLOCKED,
+		gw.TxAckStatus_QUEUE_FULL:
+	default:
+		return nil
+	}
+
+	err := multicast.RetryQueueItem(ctx.ctx, ctx.DB, ctx.MulticastQueueItem)
+	if err != nil {
+		if err == multicast.ErrMaxAttempts {
+			return nil
+		}
+		return errors.Wrap(err, "retry multicast-queue item error")
+	}
+
+	ctx.MulticastRetry = true
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": ctx.MulticastQueueItem.MulticastGroupID,
+		"gateway_id":         ctx.MulticastQueueItem.GatewayID,
+		"id":                 ctx.MulticastQueueItem.ID,
+		"retry_count":        ctx.MulticastQueueItem.RetryCount + 1,
+		"tx_ack_status":      ctx.DownlinkTXAckStatus,
+		"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("multicast-queue item re-scheduled")
+
+	return nil
+}
+
 func getDeviceProfile(ctx *ackContext) error {
 	var err error
 	ctx.DeviceProfile, err = storage.GetAndCacheDeviceProfile(ctx.ctx, ctx.DB, ctx.DeviceSession.DeviceProfileID)
@@ -476,35 +560,54 @@ func sendTxAckToApplicationServer(ctx *ackContext) error {
 		return errors.Wrap(err, "get application-server client for routing-profile id error")
 	}
 
+	retry.HandleTxAck(ctx.ctx, rpID, asClient, &as.HandleTxAckRequest{
+		DevEui:    ctx.DownlinkFrame.DevEui,
+		FCnt:      ctx.DeviceQueueItem.FCnt,
+		GatewayId: ctx.DownlinkFrame.DownlinkFrame.GatewayId,
+		TxInfo:    ctx.DownlinkFrameItem.TxInfo,
+	})
+
+	return nil
+}
+
+func sendErrorToApplicationServerOnLastFrame(ctx *ackContext) error {
+	// Only send an error to the AS on the last possible attempt.
+	if (len(ctx.DownlinkTXAck.Items) == 0 && len(ctx.DownlinkFrame.DownlinkFrame.Items) >= 2) || ctx.MACPayload == nil {
+		return nil
+	}
+
+	var rpID uuid.UUID
+	copy(rpID[:], ctx.DownlinkFrame.RoutingProfileId)
+
+	asClient, err := helpers.GetASClientForRoutingProfileID(ctx.ctx, rpID)
+	if err != nil {
+		return errors.Wrap(err, "get application-server client for routing-profile id error")
+	}
+
 	// send async to as
 	go func(ctx *ackContext, asClient as.ApplicationServerServiceClient) {
-		_, err := asClient.HandleTxAck(ctx.ctx, &as.HandleTxAckRequest{
-			DevEui:    ctx.DownlinkFrame.DevEui,
-			FCnt:      ctx.DeviceQueueItem.FCnt,
-			GatewayId: ctx.DownlinkFrame.DownlinkFrame.GatewayId,
-			TxInfo:    ctx.DownlinkFrameItem.TxInfo,
+		_, err := asClient.HandleError(ctx.ctx, &as.HandleErrorRequest{
+			DevEui: ctx.DownlinkFrame.DevEui,
+			FCnt:   ctx.MACPayload.FHDR.FCnt,
+			Type:   as.ErrorType_DATA_DOWN_GATEWAY,
+			Error:  ctx.DownlinkTXAckStatus.String(),
 		})
 		if err != nil {
 			log.WithError(err).WithFields(log.Fields{
 				"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
-			}).Error("send tx ack to application-server error")
+			}).Error("send error to application-server error")
 			return
 		}
 
 		log.WithFields(log.Fields{
 			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
-		}).Info("sent tx ack to application-server")
+		}).Info("sent error to application-server")
 	}(ctx, asClient)
 
 	return nil
 }
 
-func sendErrorToApplicationServerOnLastFrame(ctx *ackContext) error {
-	// Only send an error to the AS on the last possible attempt.
-	if (len(ctx.DownlinkTXAck.Items) == 0 && len(ctx.DownlinkFrame.DownlinkFrame.Items) >= 2) || ctx.MACPayload == nil {
-		return nil
-	}
-
+func sendMulticastErrorToApplicationServer(ctx *ackContext) error {
 	var rpID uuid.UUID
 	copy(rpID[:], ctx.DownlinkFrame.RoutingProfileId)
 
@@ -516,10 +619,9 @@ func sendErrorToApplicationServerOnLastFrame(ctx *ackContext) error {
 	// send async to as
 	go func(ctx *ackContext, asClient as.ApplicationServerServiceClient) {
 		_, err := asClient.HandleError(ctx.ctx, &as.HandleErrorRequest{
-			DevEui: ctx.DownlinkFrame.DevEui,
-			FCnt:   ctx.MACPayload.FHDR.FCnt,
-			Type:   as.ErrorType_DATA_DOWN_GATEWAY,
-			Error:  ctx.DownlinkTXAckStatus.String(),
+			FCnt:  ctx.MulticastQueueItem.FCnt,
+			Type:  as.ErrorType_DATA_DOWN_GATEWAY,
+			Error: fmt.Sprintf("multicast-group %s: %s", ctx.MulticastQueueItem.MulticastGroupID, ctx.DownlinkTXAckStatus),
 		})
 		if err != nil {
 			log.WithError(err).WithFields(log.Fields{
@@ -17,7 +17,9 @@ import (
 	"github.com/brocaar/chirpstack-api/go/v3/gw"
 	adrr "github.com/liuhw0/chirpstack-network-server/v3/adr"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver/retry"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/channels"
@@ -31,6 +33,7 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/roaming"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 	"github.com/liuhw0/lorawan"
 	"github.com/liuhw0/lorawan/backend"
 	loraband "github.com/liuhw0/lorawan/band"
@@ -116,6 +119,7 @@ var setMACCommandsSet = setMACCommands(
 var responseTasks = []func(*dataContext) error{
 	getDeviceProfile,
 	getServiceProfile,
+	getChannelPlan,
 	setDeviceGatewayRXInfo,
 	selectDownlinkGateway,
 	setDataTXInfo,
@@ -124,6 +128,7 @@ var responseTasks = []func(*dataContext) error{
 	setMACCommandsSet,
 	stopOnNothingToSend,
 	setPHYPayloads,
+	setMACCommandsPending,
 	isRoaming(false,
 		sendDownlinkFrame,
 	),
@@ -224,6 +229,9 @@ type dataContext struct {
 	// DeviceProfile of the device.
 	DeviceProfile storage.DeviceProfile
 
+	// ChannelPlan of the device-profile (if any).
+	ChannelPlan *storage.ChannelPlan
+
 	// DeviceSession holds the device-session of the device for which to send
 	// the downlink data.
 	DeviceSession storage.DeviceSession
@@ -278,6 +286,10 @@ type downlinkFrameItem struct {
 	// The remaining payload size which can be used for mac-commands and / or
 	// FRMPayload.
 	RemainingPayloadSize int
+
+	// The indices of the mac-command blocks (dataContext.MACCommands)
+	// included in this item.
+	macCommandIndices []int
 }
 
 func forClass(mode storage.DeviceMode, tasks ...func(*dataContext) error) func(*dataContext) error {
@@ -312,6 +324,13 @@ func isRoaming(r bool, tasks ...func(*dataContext) error) func(*dataContext) err
 
 // HandleResponse handles a downlink response.
 func HandleResponse(ctx context.Context, rxPacket models.RXPacket, sp storage.ServiceProfile, ds storage.DeviceSession, adr, mustSend, ack bool, macCommands []storage.MACCommandBlock) error {
+	ctx, span := tracing.Start(ctx, "downlink.data.HandleResponse")
+	err := handleResponse(ctx, rxPacket, sp, ds, adr, mustSend, ack, macCommands)
+	tracing.End(span, err)
+	return err
+}
+
+func handleResponse(ctx context.Context, rxPacket models.RXPacket, sp storage.ServiceProfile, ds storage.DeviceSession, adr, mustSend, ack bool, macCommands []storage.MACCommandBlock) error {
 	rctx := dataContext{
 		ctx:             ctx,
 		DB:              storage.DB(),
@@ -325,7 +344,11 @@ func HandleResponse(ctx context.Context, rxPacket models.RXPacket, sp storage.Se
 	}
 
 	for _, t := range responseTasks {
-		if err := t(&rctx); err != nil {
+		t := t
+		if err := tracing.RunTask(ctx, t, func(ctx context.Context) error {
+			rctx.ctx = ctx
+			return t(&rctx)
+		}, ErrAbort); err != nil {
 			if err == ErrAbort {
 				return nil
 			}
@@ -339,6 +362,13 @@ func HandleResponse(ctx context.Context, rxPacket models.RXPacket, sp storage.Se
 
 // HandleScheduleNextQueueItem handles scheduling the next device-queue item.
 func HandleScheduleNextQueueItem(ctx context.Context, db sqlx.Ext, ds storage.DeviceSession, mode storage.DeviceMode) error {
+	ctx, span := tracing.Start(ctx, "downlink.data.HandleScheduleNextQueueItem")
+	err := handleScheduleNextQueueItem(ctx, db, ds, mode)
+	tracing.End(span, err)
+	return err
+}
+
+func handleScheduleNextQueueItem(ctx context.Context, db sqlx.Ext, ds storage.DeviceSession, mode storage.DeviceMode) error {
 	nqctx := dataContext{
 		ctx:             ctx,
 		DB:              db,
@@ -348,7 +378,11 @@ func HandleScheduleNextQueueItem(ctx context.Context, db sqlx.Ext, ds storage.De
 	}
 
 	for _, t := range scheduleNextQueueItemTasks {
-		if err := t(&nqctx); err != nil {
+		t := t
+		if err := tracing.RunTask(ctx, t, func(ctx context.Context) error {
+			nqctx.ctx = ctx
+			return t(&nqctx)
+		}, ErrAbort); err != nil {
 			if err == ErrAbort {
 				return nil
 			}
@@ -426,7 +460,7 @@ func setRXParameters(ctx *dataContext) error {
 		ctx.MACCommands = append(ctx.MACCommands, block)
 	}
 
-	if ctx.DeviceSession.RXDelay != uint8(rx1Delay) {
+	if ctx.DeviceSession.RXDelay != uint8(rx1Delay) && !ctx.DeviceProfile.Quirks.NoRXTimingSetup {
 		block := maccommand.RequestRXTimingSetup(rx1Delay)
 		ctx.MACCommands = append(ctx.MACCommands, block)
 	}
@@ -513,20 +547,20 @@ func preferRX2LinkBudget(ctx *dataContext) (b bool, err error) {
 	}
 
 	// get RX1 and RX2 freq
-	rx1Freq, err := band.Band().GetRX1FrequencyForUplinkFrequency(ctx.RXPacket.TXInfo.GetFrequency())
+	rx1Freq, err := getRX1Frequency(ctx)
 	if err != nil {
 		return false, errors.Wrap(err, "get rx1 frequency for uplink frequency error")
 	}
 	rx2Freq := rx2Frequency
 
 	// get RX1 and RX2 TX Power
-	var txPowerRX1, txPowerRX2 int
-	if downlinkTXPower != -1 {
-		txPowerRX1 = downlinkTXPower
-		txPowerRX2 = downlinkTXPower
-	} else {
-		txPowerRX1 = band.Band().GetDownlinkTXPower(rx1Freq)
-		txPowerRX2 = band.Band().GetDownlinkTXPower(rx2Freq)
+	txPowerRX1, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.DownlinkGateway.GatewayID, downlinkTXPower, rx1Freq)
+	if err != nil {
+		return false, errors.Wrap(err, "get downlink tx-power error")
+	}
+	txPowerRX2, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.DownlinkGateway.GatewayID, downlinkTXPower, rx2Freq)
+	if err != nil {
+		return false, errors.Wrap(err, "get downlink tx-power error")
 	}
 
 	linkBudgetRX1 := sensitivity.CalculateLinkBudget(drRX1.Bandwidth*1000, 6, float32(config.SpreadFactorToRequiredSNRTable[drRX1.SpreadFactor]), float32(txPowerRX1))
@@ -536,8 +570,12 @@ func preferRX2LinkBudget(ctx *dataContext) (b bool, err error) {
 }
 
 func selectDownlinkGateway(ctx *dataContext) error {
-	var err error
-	ctx.DownlinkGateway, err = dwngateway.SelectDownlinkGateway(gatewayPreferMinMargin, ctx.DeviceSession.DR, ctx.DeviceGatewayRXInfo)
+	rxInfo, err := dwngateway.FilterDownlinkDisabled(ctx.ctx, ctx.DeviceGatewayRXInfo)
+	if err != nil {
+		return errors.Wrap(err, "filter downlink disabled gateways error")
+	}
+
+	ctx.DownlinkGateway, err = dwngateway.SelectDownlinkGateway(gatewayPreferMinMargin, ctx.DeviceSession.DR, rxInfo)
 	if err != nil {
 		return err
 	}
@@ -591,6 +629,19 @@ func setDataTXInfo(ctx *dataContext) error {
 	return nil
 }
 
+// getRX1Frequency returns the RX1 frequency for the uplink frequency. This
+// returns the downlink frequency configured using the DlChannelReq
+// mac-command when set for the uplink channel, else the band default.
+func getRX1Frequency(ctx *dataContext) (uint32, error) {
+	if i, err := band.Band().GetUplinkChannelIndex(ctx.RXPacket.TXInfo.GetFrequency(), false); err == nil {
+		if freq, ok := ctx.DeviceSession.ExtraDownlinkFrequencies[i]; ok {
+			return freq, nil
+		}
+	}
+
+	return band.Band().GetRX1FrequencyForUplinkFrequency(ctx.RXPacket.TXInfo.GetFrequency())
+}
+
 func setTXInfoForRX1(ctx *dataContext) error {
 	txInfo := gw.DownlinkTXInfo{
 		Board:   ctx.DownlinkGateway.Board,
@@ -609,7 +660,7 @@ func setTXInfoForRX1(ctx *dataContext) error {
 	}
 
 	// get rx1 frequency
-	freq, err := band.Band().GetRX1FrequencyForUplinkFrequency(ctx.RXPacket.TXInfo.Frequency)
+	freq, err := getRX1Frequency(ctx)
 	if err != nil {
 		return errors.Wrap(err, "get rx1 frequency error")
 	}
@@ -628,11 +679,11 @@ func setTXInfoForRX1(ctx *dataContext) error {
 	}
 
 	// get tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(txInfo.Frequency))
+	txPower, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.DownlinkGateway.GatewayID, downlinkTXPower, txInfo.Frequency)
+	if err != nil {
+		return errors.Wrap(err, "get downlink tx-power error")
 	}
+	txInfo.Power = int32(txPower)
 
 	// get remaining payload size
 	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, rx1DR)
@@ -670,11 +721,11 @@ func setTXInfoForRX2(ctx *dataContext) error {
 	}
 
 	// get tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(txInfo.Frequency))
+	txPower, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.DownlinkGateway.GatewayID, downlinkTXPower, txInfo.Frequency)
+	if err != nil {
+		return errors.Wrap(err, "get downlink tx-power error")
 	}
+	txInfo.Power = int32(txPower)
 
 	// get timestamp (when not tx immediately)
 	if !ctx.Immediately {
@@ -728,11 +779,11 @@ func setTXInfoForClassB(ctx *dataContext) error {
 	}
 
 	// get tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(txInfo.Frequency))
+	txPower, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.DownlinkGateway.GatewayID, downlinkTXPower, txInfo.Frequency)
+	if err != nil {
+		return errors.Wrap(err, "get downlink tx-power error")
 	}
+	txInfo.Power = int32(txPower)
 
 	// get remaining payload size
 	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.PingSlotDR))
@@ -844,14 +895,11 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 				return errors.Wrap(err, "delete device-queue item error")
 			}
 
-			_, err = asClient.HandleDownlinkACK(ctx.ctx, &as.HandleDownlinkACKRequest{
+			retry.HandleDownlinkACK(ctx.ctx, rp.ID, asClient, &as.HandleDownlinkACKRequest{
 				DevEui:       ctx.DeviceSession.DevEUI[:],
 				FCnt:         qi.FCnt,
 				Acknowledged: false,
 			})
-			if err != nil {
-				return errors.Wrap(err, "application-server client error")
-			}
 
 			log.WithFields(log.Fields{
 				"dev_eui":                ctx.DeviceSession.DevEUI,
@@ -894,66 +942,56 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 
 		// Handle frame-counter sync error.
 		// In this case, the queue-item does not have the expected frame-counter.
-		// We will ask the AS to re-encrypt the device-queue to re-sync with the
-		// expected frame-counters.
+		// This happens when a mac-command only downlink used a frame-counter,
+		// or when an item with a higher priority has been enqueued after other
+		// items. The frame-counter is assigned at transmission time: we will
+		// ask the AS to re-encrypt the queue-item using the expected
+		// frame-counter. The other queue-items are re-encrypted once these
+		// are transmitted.
 		// Note: we can't do this ourself, as the FCnt is used in the encryption
 		// scheme, and the encryption is within the domain of the AS.
 		if qi.FCnt != fCnt {
-			err := transactionWrapper(func(tx sqlx.Ext) error {
-				// Read the queue.
-				items, err := storage.GetDeviceQueueItemsForDevEUI(ctx.ctx, tx, ctx.DeviceSession.DevEUI)
-				if err != nil {
-					return errors.Wrap(err, "get device-queue items error")
-				}
-
-				// Create the re-encrypt request.
-				req := as.ReEncryptDeviceQueueItemsRequest{
-					DevEui:    ctx.DeviceSession.DevEUI[:],
-					DevAddr:   ctx.DeviceSession.DevAddr[:],
-					FCntStart: fCnt,
-				}
-				for i := range items {
-					req.Items = append(req.Items, &as.ReEncryptDeviceQueueItem{
-						FrmPayload: items[i].FRMPayload,
-						FCnt:       items[i].FCnt,
-						FPort:      uint32(items[i].FPort),
-						Confirmed:  items[i].Confirmed,
-					})
-
-				}
-
-				// Request the AS to re-encrypt the queue items.
-				resp, err := asClient.ReEncryptDeviceQueueItems(ctx.ctx, &req)
-				if err != nil {
-					return errors.Wrap(err, "application-server client error")
-				}
-
-				// Flush the device-queue.
-				err = storage.FlushDeviceQueueForDevEUI(ctx.ctx, tx, ctx.DeviceSession.DevEUI)
-				if err != nil {
-					return errors.Wrap(err, "flush device-queue error")
-				}
+			resp, err := asClient.ReEncryptDeviceQueueItems(ctx.ctx, &as.ReEncryptDeviceQueueItemsRequest{
+				DevEui:    ctx.DeviceSession.DevEUI[:],
+				DevAddr:   ctx.DeviceSession.DevAddr[:],
+				FCntStart: fCnt,
+				Items: []*as.ReEncryptDeviceQueueItem{
+					{
+						FrmPayload: qi.FRMPayload,
+						FCnt:       qi.FCnt,
+						FPort:      uint32(qi.FPort),
+						Confirmed:  qi.Confirmed,
+					},
+				},
+			})
+			if err != nil {
+				return errors.Wrap(err, "application-server client error")
+			}
 
-				// Enqueue re-encrypted payloads
-				for i := range resp.Items {
-					qi := storage.DeviceQueueItem{
-						DevAddr:    ctx.DeviceSession.DevAddr,
-						DevEUI:     ctx.DeviceSession.DevEUI,
-						FRMPayload: resp.Items[i].FrmPayload,
-						FCnt:       resp.Items[i].FCnt,
-						FPort:      uint8(resp.Items[i].FPort),
-						Confirmed:  resp.Items[i].Confirmed,
-					}
+			if len(resp.Items) != 1 {
+				return fmt.Errorf("expected 1 re-encrypted device-queue item, got: %d", len(resp.Items))
+			}
 
-					if err := storage.CreateDeviceQueueItem(ctx.ctx, tx, &qi, ctx.DeviceProfile, ctx.DeviceSession); err != nil {
-						return errors.Wrap(err, "create device-queue item error")
-					}
-				}
+			// This avoids an endless loop in case the AS does not use the
+			// expected frame-counter.
+			if resp.Items[0].FCnt != fCnt {
+				return fmt.Errorf("expected re-encrypted device-queue item frame-counter %d, got: %d", fCnt, resp.Items[0].FCnt)
+			}
 
-				return nil
-			})
-			if err != nil {
-				return err
+			log.WithFields(log.Fields{
+				"dev_eui":  ctx.DeviceSession.DevEUI,
+				"f_cnt":    qi.FCnt,
+				"new_fcnt": resp.Items[0].FCnt,
+				"ctx_id":   ctx.ctx.Value(logging.ContextIDKey),
+			}).Info("downlink/data: device-queue item re-encrypted")
+
+			qi.FRMPayload = resp.Items[0].FrmPayload
+			qi.FCnt = resp.Items[0].FCnt
+			qi.FPort = uint8(resp.Items[0].FPort)
+			qi.Confirmed = resp.Items[0].Confirmed
+
+			if err := storage.UpdateDeviceQueueItem(ctx.ctx, ctx.DB, &qi); err != nil {
+				return errors.Wrap(err, "update device-queue item error")
 			}
 
 			// Re-run the loop again, to fetch the next queue item.
@@ -1060,19 +1098,10 @@ func setMACCommands(funcs ...func(*dataContext) error) func(*dataContext) error
 		ctx.MACCommands = filteredMACCommands
 		ctx.MACCommands = filterIncompatibleMACCommands(ctx.MACCommands)
 
-		for _, block := range ctx.MACCommands {
-			// set mac-command pending
-			if err := storage.SetPendingMACCommand(ctx.ctx, ctx.DeviceSession.DevEUI, block); err != nil {
-				return errors.Wrap(err, "set mac-command pending error")
-			}
-
-			// delete from queue, if external
-			if block.External {
-				if err := storage.DeleteMACCommandQueueItem(ctx.ctx, ctx.DeviceSession.DevEUI, block); err != nil {
-					return errors.Wrap(err, "delete mac-command block from queue error")
-				}
-			}
-		}
+		// Rank the mac-commands by priority and error count. Note that the
+		// mac-commands are set to pending by setMACCommandsPending, after
+		// packing them within the available payload size.
+		ctx.MACCommands = rankMACCommands(ctx.DeviceSession, ctx.MACCommands)
 
 		return nil
 	}
@@ -1080,12 +1109,36 @@ func setMACCommands(funcs ...func(*dataContext) error) func(*dataContext) error
 
 func requestCustomChannelReconfiguration(ctx *dataContext) error {
 	wantedChannels := make(map[int]loraband.Channel)
-	for _, i := range band.Band().GetCustomUplinkChannelIndices() {
-		c, err := band.Band().GetUplinkChannel(i)
+	wantedDownlinkFrequencies := make(map[int]uint32)
+
+	if ctx.ChannelPlan != nil {
+		// the channels of the channel-plan
+		// the channel-plan might be out of sync with the band (e.g. after a
+		// change of the extra channels), in which case the reconfiguration
+		// is skipped so that the downlink is not aborted
+		planChannels, err := ctx.ChannelPlan.GetUplinkChannels()
 		if err != nil {
-			return errors.Wrap(err, "get uplink channel error")
+			log.WithFields(log.Fields{
+				"dev_eui": ctx.DeviceSession.DevEUI,
+				"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+			}).Warningf("get channel-plan uplink channels error: %s", err)
+			return nil
+		}
+
+		for i, c := range planChannels {
+			wantedChannels[i] = c.GetBandChannel()
+			if c.DownlinkFrequency != 0 {
+				wantedDownlinkFrequencies[i] = c.DownlinkFrequency
+			}
+		}
+	} else {
+		for _, i := range band.Band().GetCustomUplinkChannelIndices() {
+			c, err := band.Band().GetUplinkChannel(i)
+			if err != nil {
+				return errors.Wrap(err, "get uplink channel error")
+			}
+			wantedChannels[i] = c
 		}
-		wantedChannels[i] = c
 	}
 
 	// cleanup channels that do not exist anydmore
@@ -1093,10 +1146,40 @@ func requestCustomChannelReconfiguration(ctx *dataContext) error {
 	for k := range ctx.DeviceSession.ExtraUplinkChannels {
 		if _, ok := wantedChannels[k]; !ok {
 			delete(ctx.DeviceSession.ExtraUplinkChannels, k)
+			delete(ctx.DeviceSession.ExtraDownlinkFrequencies, k)
 		}
 	}
 
 	block := maccommand.RequestNewChannels(ctx.DeviceSession.DevEUI, 3, ctx.DeviceSession.ExtraUplinkChannels, wantedChannels)
+	if block != nil {
+		ctx.MACCommands = append(ctx.MACCommands, *block)
+		return nil
+	}
+
+	if !channels.DLChannelReqSupported(ctx.DeviceSession.MACVersion) {
+		return nil
+	}
+
+	// reset the downlink frequency of the channels which have a downlink
+	// frequency, but no longer should
+	for k := range ctx.DeviceSession.ExtraDownlinkFrequencies {
+		if _, ok := wantedDownlinkFrequencies[k]; ok {
+			continue
+		}
+
+		c, ok := ctx.DeviceSession.ExtraUplinkChannels[k]
+		if !ok {
+			continue
+		}
+
+		freq, err := band.Band().GetRX1FrequencyForUplinkFrequency(c.Frequency)
+		if err != nil {
+			return errors.Wrap(err, "get rx1 frequency error")
+		}
+		wantedDownlinkFrequencies[k] = freq
+	}
+
+	block = maccommand.RequestDLChannels(ctx.DeviceSession.DevEUI, 3, ctx.DeviceSession.ExtraDownlinkFrequencies, wantedDownlinkFrequencies)
 	if block != nil {
 		ctx.MACCommands = append(ctx.MACCommands, *block)
 	}
@@ -1107,7 +1190,7 @@ func requestCustomChannelReconfiguration(ctx *dataContext) error {
 func requestChannelMaskReconfiguration(ctx *dataContext) error {
 	// handle channel configuration
 	// note that this must come before ADR!
-	blocks, err := channels.HandleChannelReconfigure(ctx.DeviceSession)
+	blocks, err := channels.HandleChannelReconfigure(ctx.DeviceSession, ctx.ServiceProfile, ctx.ChannelPlan)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -1128,7 +1211,6 @@ func requestADRChange(ctx *dataContext) error {
 
 	var maxTxPowerIndex int
 	var requiredSNRforDR float32
-	var uplinkHistory []adrr.UplinkMetaData
 
 	// maxTxPowerIndex
 	if ctx.DeviceSession.MaxSupportedTXPowerIndex != 0 {
@@ -1153,14 +1235,7 @@ func requestADRChange(ctx *dataContext) error {
 	requiredSNRforDR = float32(config.SpreadFactorToRequiredSNRTable[dr.SpreadFactor])
 
 	// uplink history
-	for _, uh := range ctx.DeviceSession.UplinkHistory {
-		uplinkHistory = append(uplinkHistory, adrr.UplinkMetaData{
-			FCnt:         uh.FCnt,
-			MaxSNR:       float32(uh.MaxSNR),
-			TXPowerIndex: uh.TXPowerIndex,
-			GatewayCount: uh.GatewayCount,
-		})
-	}
+	uplinkHistory := adr.UplinkHistoryToMetaData(ctx.DeviceSession.UplinkHistory)
 
 	handleReq := adrr.HandleRequest{
 		Region:             band.Band().Name(),
@@ -1179,15 +1254,39 @@ func requestADRChange(ctx *dataContext) error {
 		UplinkHistory:      uplinkHistory,
 	}
 
-	handler := adr.GetHandler(ctx.DeviceProfile.ADRAlgorithmID)
+	handler := adr.GetHandlerForDeviceProfile(ctx.DeviceProfile)
 	handleResp, err := handler.Handle(handleReq)
 	if err != nil {
 		return errors.Wrap(err, "handle adr error")
 	}
 
+	// The device does not support tx-power index 0 and would nACK the
+	// LinkADRReq.
+	if ctx.DeviceProfile.Quirks.TXPower0Unsupported && handleResp.TxPowerIndex == 0 {
+		handleResp.TxPowerIndex = 1
+	}
+
 	// The response values are different than the request values, thus we must
 	// send a LinkADRReq to the device.
 	if handleResp.DR != handleReq.DR || handleResp.TxPowerIndex != handleReq.TxPowerIndex || handleResp.NbTrans != handleReq.NbTrans {
+		audit.Log(ctx.ctx, audit.Event{
+			DevEUI: ctx.DeviceSession.DevEUI,
+			Type:   audit.EventADRChangeRequest,
+			Before: audit.State{
+				"dr":             handleReq.DR,
+				"tx_power_index": handleReq.TxPowerIndex,
+				"nb_trans":       handleReq.NbTrans,
+			},
+			After: audit.State{
+				"dr":             handleResp.DR,
+				"tx_power_index": handleResp.TxPowerIndex,
+				"nb_trans":       handleResp.NbTrans,
+			},
+			Details: map[string]interface{}{
+				"adr_algorithm_id": ctx.DeviceProfile.ADRAlgorithmID,
+			},
+		})
+
 		var linkADRReq *storage.MACCommandBlock
 		for i := range ctx.MACCommands {
 			if ctx.MACCommands[i].CID == lorawan.LinkADRReq {
@@ -1287,30 +1386,48 @@ func stopOnNothingToSend(ctx *dataContext) error {
 // as only then we know which of the frame-counters to increment
 // (AFCntDown vs NFCntDown).
 func setPHYPayloads(ctx *dataContext) error {
+	// mac-commands exceeding this size are sent as FRMPayload
+	maxFOptsLen := ctx.DeviceProfile.Quirks.GetMaxFOptsLen()
+
 	for i := range ctx.DownlinkFrameItems {
+		item := &ctx.DownlinkFrameItems[i]
+
+		// In case the device-queue item fits, the mac-commands are packed
+		// within the remaining FOpts size. Failing that, or when the highest
+		// ranked mac-command does not fit within the FOpts, the mac-commands
+		// are packed within the remaining payload size (mac-commands have
+		// priority over application payloads).
+		var indices []int
 		var macCommandSize int
-		var macCommands []lorawan.Payload
+		var err error
+
+		if ctx.DeviceQueueItem != nil && len(ctx.DeviceQueueItem.FRMPayload) <= item.RemainingPayloadSize {
+			budget := item.RemainingPayloadSize - len(ctx.DeviceQueueItem.FRMPayload)
+			if budget > maxFOptsLen {
+				budget = maxFOptsLen
+			}
 
-		// collect all mac-commands up to RemainingPayloadSize bytes.
-		for j := range ctx.MACCommands {
-			// get size of mac-command block
-			s, err := ctx.MACCommands[j].Size()
+			indices, macCommandSize, err = packMACCommands(ctx.MACCommands, budget)
 			if err != nil {
-				return errors.Wrap(err, "get mac-command block size error")
+				return err
 			}
+		}
 
-			// break if it does not fit within the RemainingPayloadSize
-			if ctx.DownlinkFrameItems[i].RemainingPayloadSize-s < 0 {
-				break
+		if len(ctx.MACCommands) != 0 && (len(indices) == 0 || indices[0] != 0) {
+			indices, macCommandSize, err = packMACCommands(ctx.MACCommands, item.RemainingPayloadSize)
+			if err != nil {
+				return err
 			}
+		}
 
-			ctx.DownlinkFrameItems[i].RemainingPayloadSize = ctx.DownlinkFrameItems[i].RemainingPayloadSize - s
-			macCommandSize += s
+		item.RemainingPayloadSize = item.RemainingPayloadSize - macCommandSize
+		item.macCommandIndices = indices
 
+		var macCommands []lorawan.Payload
+		for _, j := range indices {
 			for k := range ctx.MACCommands[j].MACCommands {
 				macCommands = append(macCommands, &ctx.MACCommands[j].MACCommands[k])
 			}
-
 		}
 
 		// LoRaWAN MHDR
@@ -1334,7 +1451,7 @@ func setPHYPayloads(ctx *dataContext) error {
 
 		// In this case mac-commands are sent as FRMPayload. We will not be able to
 		// send a device-queue item in this case.
-		if macCommandSize > 15 {
+		if macCommandSize > maxFOptsLen {
 			// Set the FPending to true if we were planning to send a downlink
 			// device-queue item.
 			macPL.FHDR.FCtrl.FPending = (ctx.DeviceQueueItem != nil)
@@ -1353,7 +1470,7 @@ func setPHYPayloads(ctx *dataContext) error {
 		// In this case mac-commands are sent using the FOpts field. In case there
 		// is a device-queue item, we will validate if it still fits within the
 		// RemainingPayloadSize.
-		if macCommandSize <= 15 {
+		if macCommandSize <= maxFOptsLen {
 			// Set the mac-commands as FOpts.
 			macPL.FHDR.FOpts = macCommands
 
@@ -1451,7 +1568,7 @@ func sendDownlinkFramePassiveRoaming(ctx *dataContext) error {
 	}
 
 	// DLFreq1
-	dlFreq1, err := band.Band().GetRX1FrequencyForUplinkFrequency(ctx.RXPacket.TXInfo.Frequency)
+	dlFreq1, err := getRX1Frequency(ctx)
 	if err != nil {
 		return errors.Wrap(err, "get rx1 frequency error")
 	}
@@ -1524,6 +1641,28 @@ func getDeviceProfile(ctx *dataContext) error {
 	return nil
 }
 
+func getChannelPlan(ctx *dataContext) error {
+	if ctx.DeviceProfile.ChannelPlanID == nil {
+		return nil
+	}
+
+	cp, err := storage.GetAndCacheChannelPlan(ctx.ctx, ctx.DB, *ctx.DeviceProfile.ChannelPlanID)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			log.WithFields(log.Fields{
+				"dev_eui":         ctx.DeviceSession.DevEUI,
+				"channel_plan_id": ctx.DeviceProfile.ChannelPlanID,
+				"ctx_id":          ctx.ctx.Value(logging.ContextIDKey),
+			}).Warning("channel-plan of device-profile does not exist")
+			return nil
+		}
+		return errors.Wrap(err, "get channel-plan error")
+	}
+	ctx.ChannelPlan = &cp
+
+	return nil
+}
+
 func getServiceProfile(ctx *dataContext) error {
 	var err error
 	ctx.ServiceProfile, err = storage.GetAndCacheServiceProfile(ctx.ctx, ctx.DB, ctx.DeviceSession.ServiceProfileID)
@@ -232,11 +232,6 @@ func (ts *GetNextDeviceQueueItemTestSuite) TestGetNextDeviceQueueItem() {
 						FCnt:       11,
 						FPort:      3,
 					},
-					{
-						FrmPayload: []byte{6, 5, 4},
-						FCnt:       12,
-						FPort:      3,
-					},
 				},
 			},
 			expectedReEncryptDeviceQueueItemsRequest: &as.ReEncryptDeviceQueueItemsRequest{
@@ -249,6 +244,60 @@ func (ts *GetNextDeviceQueueItemTestSuite) TestGetNextDeviceQueueItem() {
 						FCnt:       10,
 						FPort:      3,
 					},
+				},
+			},
+			expectedMoreDeviceQueueItems: true,
+			expectedDeviceQueueItem: &storage.DeviceQueueItem{
+				DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+				DevEUI:     ts.device.DevEUI,
+				FRMPayload: []byte{3, 2, 1},
+				FCnt:       11,
+				FPort:      3,
+			},
+		},
+		{
+			name:           "Item with higher priority, frame-counter assigned at transmission",
+			maxPayloadSize: 100,
+			deviceSession: storage.DeviceSession{
+				DevAddr:          lorawan.DevAddr{1, 2, 3, 4},
+				DevEUI:           ts.device.DevEUI,
+				ServiceProfileID: ts.serviceProfile.ID,
+				DeviceProfileID:  ts.deviceProfile.ID,
+				RoutingProfileID: ts.routingProfile.ID,
+				MACVersion:       "1.0.3",
+				NFCntDown:        10,
+			},
+			deviceQueueItems: []storage.DeviceQueueItem{
+				{
+					DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+					DevEUI:     ts.device.DevEUI,
+					FRMPayload: []byte{1, 2, 3},
+					FCnt:       10,
+					FPort:      3,
+				},
+				{
+					DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+					DevEUI:     ts.device.DevEUI,
+					FRMPayload: []byte{4, 5, 6},
+					FCnt:       11,
+					FPort:      3,
+					Priority:   1,
+				},
+			},
+			reEncryptDeviceQueueItemsResponse: as.ReEncryptDeviceQueueItemsResponse{
+				Items: []*as.ReEncryptedDeviceQueueItem{
+					{
+						FrmPayload: []byte{6, 5, 4},
+						FCnt:       10,
+						FPort:      3,
+					},
+				},
+			},
+			expectedReEncryptDeviceQueueItemsRequest: &as.ReEncryptDeviceQueueItemsRequest{
+				DevEui:    ts.device.DevEUI[:],
+				DevAddr:   []byte{1, 2, 3, 4},
+				FCntStart: 10,
+				Items: []*as.ReEncryptDeviceQueueItem{
 					{
 						FrmPayload: []byte{4, 5, 6},
 						FCnt:       11,
@@ -260,9 +309,10 @@ func (ts *GetNextDeviceQueueItemTestSuite) TestGetNextDeviceQueueItem() {
 			expectedDeviceQueueItem: &storage.DeviceQueueItem{
 				DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
 				DevEUI:     ts.device.DevEUI,
-				FRMPayload: []byte{3, 2, 1},
-				FCnt:       11,
+				FRMPayload: []byte{6, 5, 4},
+				FCnt:       10,
 				FPort:      3,
+				Priority:   1,
 			},
 		},
 		{
@@ -396,6 +446,7 @@ func (ts *GetNextDeviceQueueItemTestSuite) TestGetNextDeviceQueueItem() {
 				assert.Equal(tst.expectedDeviceQueueItem.FCnt, ctx.DeviceQueueItem.FCnt)
 				assert.Equal(tst.expectedDeviceQueueItem.FPort, ctx.DeviceQueueItem.FPort)
 				assert.Equal(tst.expectedDeviceQueueItem.FRMPayload, ctx.DeviceQueueItem.FRMPayload)
+				assert.Equal(tst.expectedDeviceQueueItem.Priority, ctx.DeviceQueueItem.Priority)
 
 				if tst.expectedDeviceQueueItem.EmitAtTimeSinceGPSEpoch != nil {
 					assert.NotNil(ctx.DeviceQueueItem.EmitAtTimeSinceGPSEpoch)
@@ -880,6 +931,34 @@ func (ts *SetMACCommandsSetTestSuite) TestSetMACCommandsSet() {
 				},
 			},
 		},
+		{
+			BeforeFunc: func() error {
+				conf := test.GetConfig()
+				conf.NetworkServer.NetworkSettings.RX1Delay = 14
+				return Setup(conf)
+			},
+			Name: "rx timing setup not triggered (no_rxtimingsetup quirk)",
+			DataContext: dataContext{
+				ServiceProfile: storage.ServiceProfile{
+					DRMax: 5,
+				},
+				DeviceProfile: storage.DeviceProfile{
+					Quirks: storage.DeviceQuirks{
+						NoRXTimingSetup: true,
+					},
+				},
+				DeviceSession: storage.DeviceSession{
+					EnabledUplinkChannels: []int{0, 1, 2},
+					RX2Frequency:          869525000,
+					RXDelay:               1,
+				},
+				DownlinkFrameItems: []downlinkFrameItem{
+					{
+						RemainingPayloadSize: 200,
+					},
+				},
+			},
+		},
 		{
 			// This tests that in case a LinkADRReq -and- a NewChannelReq
 			// is requested, the LinkADRReq is dropped.
@@ -1256,6 +1335,242 @@ func TestSetTXParameters(t *testing.T) {
 	}
 }
 
+// setupBandWithExtraChannels sets up the band with extra channels
+// 867100000 (3), 867300000 (4) and 867500000 (5).
+func setupBandWithExtraChannels(assert *require.Assertions) {
+	conf := test.GetConfig()
+	for _, f := range []uint32{867100000, 867300000, 867500000} {
+		conf.NetworkServer.NetworkSettings.ExtraChannels = append(conf.NetworkServer.NetworkSettings.ExtraChannels, struct {
+			Frequency uint32 `mapstructure:"frequency"`
+			MinDR     int    `mapstructure:"min_dr"`
+			MaxDR     int    `mapstructure:"max_dr"`
+		}{
+			Frequency: f,
+			MinDR:     0,
+			MaxDR:     5,
+		})
+	}
+	assert.NoError(band.Setup(conf))
+}
+
+func TestRequestCustomChannelReconfiguration(t *testing.T) {
+	assert := require.New(t)
+	setupBandWithExtraChannels(assert)
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	bandChannels := map[int]loraband.Channel{
+		3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+		4: {Frequency: 867300000, MinDR: 0, MaxDR: 5},
+		5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+	}
+
+	plan := storage.ChannelPlan{
+		Channels: storage.ChannelPlanChannels{
+			{Frequency: 867100000, MinDR: 0, MaxDR: 5, DownlinkFrequency: 869000000},
+			{Frequency: 867500000, MinDR: 0, MaxDR: 5},
+		},
+	}
+
+	tests := []struct {
+		Name          string
+		ChannelPlan   *storage.ChannelPlan
+		DeviceSession storage.DeviceSession
+
+		ExpectedExtraUplinkChannels      map[int]loraband.Channel
+		ExpectedExtraDownlinkFrequencies map[int]uint32
+		ExpectedMACCommands              []storage.MACCommandBlock
+	}{
+		{
+			Name: "no channel-plan, channels of the band are created",
+			DeviceSession: storage.DeviceSession{
+				MACVersion:               "1.0.3",
+				ExtraUplinkChannels:      map[int]loraband.Channel{},
+				ExtraDownlinkFrequencies: map[int]uint32{},
+			},
+			ExpectedExtraUplinkChannels:      map[int]loraband.Channel{},
+			ExpectedExtraDownlinkFrequencies: map[int]uint32{},
+			ExpectedMACCommands: []storage.MACCommandBlock{
+				{
+					CID: lorawan.NewChannelReq,
+					MACCommands: storage.MACCommands{
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MaxDR: 5}},
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000, MaxDR: 5}},
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 5, Freq: 867500000, MaxDR: 5}},
+					},
+				},
+			},
+		},
+		{
+			Name: "no channel-plan, downlink frequency is reset",
+			DeviceSession: storage.DeviceSession{
+				MACVersion:               "1.0.3",
+				ExtraUplinkChannels:      bandChannels,
+				ExtraDownlinkFrequencies: map[int]uint32{3: 869000000},
+			},
+			ExpectedExtraUplinkChannels:      bandChannels,
+			ExpectedExtraDownlinkFrequencies: map[int]uint32{3: 869000000},
+			ExpectedMACCommands: []storage.MACCommandBlock{
+				{
+					CID: lorawan.DLChannelReq,
+					MACCommands: storage.MACCommands{
+						{CID: lorawan.DLChannelReq, Payload: &lorawan.DLChannelReqPayload{ChIndex: 3, Freq: 867100000}},
+					},
+				},
+			},
+		},
+		{
+			Name:        "channel-plan, channel is removed and downlink frequency is set",
+			ChannelPlan: &plan,
+			DeviceSession: storage.DeviceSession{
+				MACVersion: "1.0.3",
+				ExtraUplinkChannels: map[int]loraband.Channel{
+					3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+					4: {Frequency: 867300000, MinDR: 0, MaxDR: 5},
+					5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+				},
+				ExtraDownlinkFrequencies: map[int]uint32{4: 869100000},
+			},
+			ExpectedExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+			},
+			ExpectedExtraDownlinkFrequencies: map[int]uint32{},
+			ExpectedMACCommands: []storage.MACCommandBlock{
+				{
+					CID: lorawan.DLChannelReq,
+					MACCommands: storage.MACCommands{
+						{CID: lorawan.DLChannelReq, Payload: &lorawan.DLChannelReqPayload{ChIndex: 3, Freq: 869000000}},
+					},
+				},
+			},
+		},
+		{
+			Name:        "channel-plan, LoRaWAN 1.0.0 does not support DlChannelReq",
+			ChannelPlan: &plan,
+			DeviceSession: storage.DeviceSession{
+				MACVersion: "1.0.0",
+				ExtraUplinkChannels: map[int]loraband.Channel{
+					3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+					5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+				},
+				ExtraDownlinkFrequencies: map[int]uint32{},
+			},
+			ExpectedExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+			},
+			ExpectedExtraDownlinkFrequencies: map[int]uint32{},
+		},
+		{
+			Name:        "channel-plan, LoRaWAN 1.0.1 does not support DlChannelReq",
+			ChannelPlan: &plan,
+			DeviceSession: storage.DeviceSession{
+				MACVersion: "1.0.1",
+				ExtraUplinkChannels: map[int]loraband.Channel{
+					3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+					5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+				},
+				ExtraDownlinkFrequencies: map[int]uint32{},
+			},
+			ExpectedExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				5: {Frequency: 867500000, MinDR: 0, MaxDR: 5},
+			},
+			ExpectedExtraDownlinkFrequencies: map[int]uint32{},
+		},
+		{
+			Name: "channel-plan, channels are created before setting the downlink frequency",
+			ChannelPlan: &storage.ChannelPlan{
+				Channels: storage.ChannelPlanChannels{
+					{Frequency: 867100000, MinDR: 2, MaxDR: 5, DownlinkFrequency: 869000000},
+				},
+			},
+			DeviceSession: storage.DeviceSession{
+				MACVersion: "1.0.3",
+				ExtraUplinkChannels: map[int]loraband.Channel{
+					3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				},
+				ExtraDownlinkFrequencies: map[int]uint32{},
+			},
+			ExpectedExtraUplinkChannels: map[int]loraband.Channel{
+				3: {Frequency: 867100000, MinDR: 0, MaxDR: 5},
+			},
+			ExpectedExtraDownlinkFrequencies: map[int]uint32{},
+			ExpectedMACCommands: []storage.MACCommandBlock{
+				{
+					CID: lorawan.NewChannelReq,
+					MACCommands: storage.MACCommands{
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MinDR: 2, MaxDR: 5}},
+					},
+				},
+			},
+		},
+		{
+			Name: "channel-plan out of sync with the band, reconfiguration is skipped",
+			ChannelPlan: &storage.ChannelPlan{
+				Channels: storage.ChannelPlanChannels{
+					{Frequency: 867100000, MinDR: 0, MaxDR: 5},
+					{Frequency: 867900000, MinDR: 0, MaxDR: 5},
+				},
+			},
+			DeviceSession: storage.DeviceSession{
+				MACVersion:               "1.0.3",
+				ExtraUplinkChannels:      bandChannels,
+				ExtraDownlinkFrequencies: map[int]uint32{},
+			},
+			ExpectedExtraUplinkChannels:      bandChannels,
+			ExpectedExtraDownlinkFrequencies: map[int]uint32{},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ctx := dataContext{
+				ctx:           context.Background(),
+				ChannelPlan:   tst.ChannelPlan,
+				DeviceSession: tst.DeviceSession,
+			}
+
+			assert.NoError(requestCustomChannelReconfiguration(&ctx))
+			assert.Equal(tst.ExpectedMACCommands, ctx.MACCommands)
+			assert.Equal(tst.ExpectedExtraUplinkChannels, ctx.DeviceSession.ExtraUplinkChannels)
+			assert.Equal(tst.ExpectedExtraDownlinkFrequencies, ctx.DeviceSession.ExtraDownlinkFrequencies)
+		})
+	}
+}
+
+func TestGetRX1Frequency(t *testing.T) {
+	assert := require.New(t)
+	setupBandWithExtraChannels(assert)
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	ctx := dataContext{
+		DeviceSession: storage.DeviceSession{
+			ExtraDownlinkFrequencies: map[int]uint32{3: 869000000},
+		},
+		RXPacket: &models.RXPacket{
+			TXInfo: &gw.UplinkTXInfo{
+				Frequency: 867100000,
+			},
+		},
+	}
+
+	freq, err := getRX1Frequency(&ctx)
+	assert.NoError(err)
+	assert.EqualValues(869000000, freq)
+
+	ctx.RXPacket.TXInfo.Frequency = 867300000
+	freq, err = getRX1Frequency(&ctx)
+	assert.NoError(err)
+	assert.EqualValues(867300000, freq)
+}
+
 func TestPreferRX2DR(t *testing.T) {
 	assert := require.New(t)
 	conf := test.GetConfig()
@@ -1734,9 +2049,11 @@ func TestSetPHYPayloads(t *testing.T) {
 		name                       string
 		downlinkFrameItems         []downlinkFrameItem
 		deviceSession              storage.DeviceSession
+		deviceProfile              storage.DeviceProfile
 		deviceQueueItem            *storage.DeviceQueueItem
 		macCommandBlocks           []storage.MACCommandBlock
 		expectedDownlinkFrameItems []*gw.DownlinkFrameItem
+		expectedMACCommandIndices  [][]int
 	}{
 		{
 			name: "frmpayload for rx1 and rx2",
@@ -1903,6 +2220,131 @@ func TestSetPHYPayloads(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "mac-commands exceeding fopts deferred, frmpayload sent",
+			downlinkFrameItems: []downlinkFrameItem{
+				{
+					RemainingPayloadSize: 50,
+				},
+			},
+			deviceSession: storage.DeviceSession{
+				MACVersion: "1.0.3",
+				NFCntDown:  10,
+			},
+			deviceQueueItem: &storage.DeviceQueueItem{
+				FRMPayload: []byte{1, 2, 3},
+				FCnt:       10,
+				FPort:      20,
+			},
+			macCommandBlocks: []storage.MACCommandBlock{
+				{
+					CID: lorawan.DevStatusReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.DevStatusReq,
+						},
+					},
+				},
+				{
+					CID: lorawan.NewChannelReq,
+					MACCommands: []lorawan.MACCommand{
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MaxDR: 5}},
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000, MaxDR: 5}},
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 5, Freq: 867500000, MaxDR: 5}},
+					},
+				},
+			},
+			expectedDownlinkFrameItems: []*gw.DownlinkFrameItem{
+				{
+					PhyPayload: []byte{0x60, 0x0, 0x0, 0x0, 0x0, 0x81, 0xa, 0x0, 0x6, 0x14, 0x1, 0x2, 0x3, 0xa8, 0xd1, 0xb4, 0x58},
+				},
+			},
+			expectedMACCommandIndices: [][]int{{0}},
+		},
+		{
+			name: "highest ranked mac-command exceeding fopts as frmpayload",
+			downlinkFrameItems: []downlinkFrameItem{
+				{
+					RemainingPayloadSize: 50,
+				},
+			},
+			deviceSession: storage.DeviceSession{
+				MACVersion: "1.0.3",
+				NFCntDown:  10,
+			},
+			deviceQueueItem: &storage.DeviceQueueItem{
+				FRMPayload: []byte{1, 2, 3},
+				FCnt:       10,
+				FPort:      20,
+			},
+			macCommandBlocks: []storage.MACCommandBlock{
+				{
+					CID: lorawan.NewChannelReq,
+					MACCommands: []lorawan.MACCommand{
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MaxDR: 5}},
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000, MaxDR: 5}},
+						{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 5, Freq: 867500000, MaxDR: 5}},
+					},
+				},
+				{
+					CID: lorawan.DevStatusReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.DevStatusReq,
+						},
+					},
+				},
+			},
+			expectedDownlinkFrameItems: []*gw.DownlinkFrameItem{
+				{
+					PhyPayload: []byte{0x60, 0x0, 0x0, 0x0, 0x0, 0x90, 0xa, 0x0, 0x0, 0xec, 0x23, 0xf7, 0xf9, 0xce, 0xd4, 0x5d, 0xda, 0x72, 0x3, 0x35, 0x1, 0xe3, 0x9b, 0x7d, 0x3b, 0x2d, 0x1f, 0xb5, 0x5f, 0x4d, 0x6c, 0x68},
+				},
+			},
+			expectedMACCommandIndices: [][]int{{0, 1}},
+		},
+		{
+			name: "mac-commands exceeding max fopts len quirk as frmpayload",
+			downlinkFrameItems: []downlinkFrameItem{
+				{
+					RemainingPayloadSize: 50,
+				},
+			},
+			deviceSession: storage.DeviceSession{
+				MACVersion: "1.0.3",
+				NFCntDown:  10,
+			},
+			deviceProfile: storage.DeviceProfile{
+				Quirks: storage.DeviceQuirks{
+					MaxFOptsLen: 1,
+				},
+			},
+			macCommandBlocks: []storage.MACCommandBlock{
+				{
+					CID: lorawan.DevStatusReq,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.DevStatusReq,
+						},
+					},
+				},
+				{
+					CID: lorawan.DeviceTimeAns,
+					MACCommands: []lorawan.MACCommand{
+						{
+							CID: lorawan.DeviceTimeAns,
+							Payload: &lorawan.DeviceTimeAnsPayload{
+								TimeSinceGPSEpoch: time.Second,
+							},
+						},
+					},
+				},
+			},
+			expectedDownlinkFrameItems: []*gw.DownlinkFrameItem{
+				{
+					PhyPayload: []byte{0x60, 0x0, 0x0, 0x0, 0x0, 0x80, 0xa, 0x0, 0x0, 0xed, 0x2d, 0xee, 0xb6, 0x4a, 0x84, 0x5a, 0x96, 0x7a, 0xa7, 0xd2},
+				},
+			},
+		},
 	}
 
 	for _, tst := range tests {
@@ -1912,6 +2354,7 @@ func TestSetPHYPayloads(t *testing.T) {
 			ctx := dataContext{
 				DownlinkFrameItems: tst.downlinkFrameItems,
 				DeviceSession:      tst.deviceSession,
+				DeviceProfile:      tst.deviceProfile,
 				DeviceQueueItem:    tst.deviceQueueItem,
 				MACCommands:        tst.macCommandBlocks,
 			}
@@ -1922,6 +2365,10 @@ func TestSetPHYPayloads(t *testing.T) {
 			for i := range tst.expectedDownlinkFrameItems {
 				assert.Equal(tst.expectedDownlinkFrameItems[i], ctx.DownlinkFrame.Items[i])
 			}
+
+			for i := range tst.expectedMACCommandIndices {
+				assert.Equal(tst.expectedMACCommandIndices[i], ctx.DownlinkFrameItems[i].macCommandIndices)
+			}
 		})
 	}
 }
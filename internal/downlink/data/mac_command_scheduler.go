@@ -0,0 +1,148 @@
+package data
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+// maxMACCommandBackoff defines the max. number of uplinks between the last
+// error and the next attempt of sending a mac-command that was not
+// acknowledged by the device.
+const maxMACCommandBackoff = 16
+
+// mac-command priorities, higher priorities are scheduled first.
+const (
+	macCommandPriorityNormal = iota
+	macCommandPriorityHigh
+	macCommandPriorityAnswer
+)
+
+// macCommandPriorities contains the priority by (downlink) CID. CIDs that are
+// not in this map have the normal priority.
+var macCommandPriorities = map[lorawan.CID]int{
+	// Answers to mac-commands sent by the device.
+	lorawan.ResetConf:       macCommandPriorityAnswer,
+	lorawan.LinkCheckAns:    macCommandPriorityAnswer,
+	lorawan.RekeyConf:       macCommandPriorityAnswer,
+	lorawan.DeviceTimeAns:   macCommandPriorityAnswer,
+	lorawan.PingSlotInfoAns: macCommandPriorityAnswer,
+	lorawan.DeviceModeConf:  macCommandPriorityAnswer,
+
+	// Channel and data-rate (re)configuration.
+	lorawan.NewChannelReq: macCommandPriorityHigh,
+	lorawan.DLChannelReq:  macCommandPriorityHigh,
+	lorawan.LinkADRReq:    macCommandPriorityHigh,
+}
+
+// getMACCommandBackoff returns the number of uplinks between the last error
+// and the next attempt of sending a mac-command, given its error count. This
+// doubles for each error, up to maxMACCommandBackoff.
+func getMACCommandBackoff(errorCount int) int {
+	backoff := 1
+	for i := 0; i < errorCount && backoff < maxMACCommandBackoff; i++ {
+		backoff = backoff * 2
+	}
+	return backoff
+}
+
+// rankMACCommands filters out the mac-commands which are in backoff and
+// sorts the remaining mac-commands by priority and error count. The order is
+// maintained for mac-commands having the same priority and error count.
+// External (e.g. API queued) mac-commands are never in backoff.
+func rankMACCommands(ds storage.DeviceSession, blocks []storage.MACCommandBlock) []storage.MACCommandBlock {
+	var out []storage.MACCommandBlock
+	for _, block := range blocks {
+		if !block.External && inMACCommandBackoff(ds, block.CID) {
+			continue
+		}
+		out = append(out, block)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		pi, pj := macCommandPriorities[out[i].CID], macCommandPriorities[out[j].CID]
+		if pi != pj {
+			return pi > pj
+		}
+		return ds.MACCommandErrorCount[out[i].CID] < ds.MACCommandErrorCount[out[j].CID]
+	})
+
+	return out
+}
+
+// inMACCommandBackoff returns true when the number of uplinks since the last
+// error of the given mac-command is less than its backoff.
+func inMACCommandBackoff(ds storage.DeviceSession, cid lorawan.CID) bool {
+	errorCount := ds.MACCommandErrorCount[cid]
+	if errorCount == 0 {
+		return false
+	}
+
+	errorFCntUp, ok := ds.MACCommandErrorFCntUp[cid]
+	if !ok {
+		return false
+	}
+
+	return ds.FCntUp-errorFCntUp < uint32(getMACCommandBackoff(errorCount))
+}
+
+// packMACCommands returns the indices of the (ranked) mac-command blocks
+// fitting within the given budget (bytes) and their total size. Blocks that
+// do not fit are skipped, so that smaller lower ranked blocks can still be
+// packed.
+func packMACCommands(blocks []storage.MACCommandBlock, budget int) ([]int, int, error) {
+	var indices []int
+	var size int
+
+	for i := range blocks {
+		s, err := blocks[i].Size()
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "get mac-command block size error")
+		}
+
+		if size+s > budget {
+			continue
+		}
+
+		indices = append(indices, i)
+		size += s
+	}
+
+	return indices, size, nil
+}
+
+// setMACCommandsPending sets the mac-commands that were scheduled within
+// one of the downlink frame items to pending and removes the scheduled
+// external mac-commands from the queue. The mac-commands that were not
+// scheduled are deferred to a next downlink.
+func setMACCommandsPending(ctx *dataContext) error {
+	scheduled := make(map[int]struct{})
+	for _, item := range ctx.DownlinkFrameItems {
+		for _, i := range item.macCommandIndices {
+			scheduled[i] = struct{}{}
+		}
+	}
+
+	for i, block := range ctx.MACCommands {
+		if _, ok := scheduled[i]; !ok {
+			continue
+		}
+
+		// set mac-command pending
+		if err := storage.SetPendingMACCommand(ctx.ctx, ctx.DeviceSession.DevEUI, block); err != nil {
+			return errors.Wrap(err, "set mac-command pending error")
+		}
+
+		// delete from queue, if external
+		if block.External {
+			if err := storage.DeleteMACCommandQueueItem(ctx.ctx, ctx.DeviceSession.DevEUI, block); err != nil {
+				return errors.Wrap(err, "delete mac-command block from queue error")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+func TestGetMACCommandBackoff(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(1, getMACCommandBackoff(0))
+	assert.Equal(2, getMACCommandBackoff(1))
+	assert.Equal(4, getMACCommandBackoff(2))
+	assert.Equal(16, getMACCommandBackoff(4))
+	assert.Equal(maxMACCommandBackoff, getMACCommandBackoff(10))
+}
+
+func TestRankMACCommands(t *testing.T) {
+	devStatusReq := storage.MACCommandBlock{CID: lorawan.DevStatusReq}
+	rxParamSetupReq := storage.MACCommandBlock{CID: lorawan.RXParamSetupReq}
+	linkADRReq := storage.MACCommandBlock{CID: lorawan.LinkADRReq}
+	deviceTimeAns := storage.MACCommandBlock{CID: lorawan.DeviceTimeAns}
+	externalLinkADRReq := storage.MACCommandBlock{CID: lorawan.LinkADRReq, External: true}
+
+	tests := []struct {
+		name          string
+		deviceSession storage.DeviceSession
+		blocks        []storage.MACCommandBlock
+		expected      []storage.MACCommandBlock
+	}{
+		{
+			name: "sorted by priority",
+			deviceSession: storage.DeviceSession{
+				FCntUp: 1,
+			},
+			blocks:   []storage.MACCommandBlock{devStatusReq, linkADRReq, rxParamSetupReq, deviceTimeAns},
+			expected: []storage.MACCommandBlock{deviceTimeAns, linkADRReq, devStatusReq, rxParamSetupReq},
+		},
+		{
+			name: "sorted by error count",
+			deviceSession: storage.DeviceSession{
+				FCntUp: 2,
+				MACCommandErrorCount: map[lorawan.CID]int{
+					lorawan.DevStatusReq: 1,
+				},
+			},
+			blocks:   []storage.MACCommandBlock{devStatusReq, rxParamSetupReq},
+			expected: []storage.MACCommandBlock{rxParamSetupReq, devStatusReq},
+		},
+		{
+			name: "in backoff",
+			deviceSession: storage.DeviceSession{
+				FCntUp: 3,
+				MACCommandErrorCount: map[lorawan.CID]int{
+					lorawan.LinkADRReq: 2,
+				},
+				MACCommandErrorFCntUp: map[lorawan.CID]uint32{
+					lorawan.LinkADRReq: 1,
+				},
+			},
+			blocks:   []storage.MACCommandBlock{linkADRReq, rxParamSetupReq},
+			expected: []storage.MACCommandBlock{rxParamSetupReq},
+		},
+		{
+			name: "backoff expired",
+			deviceSession: storage.DeviceSession{
+				FCntUp: 5,
+				MACCommandErrorCount: map[lorawan.CID]int{
+					lorawan.LinkADRReq: 2,
+				},
+				MACCommandErrorFCntUp: map[lorawan.CID]uint32{
+					lorawan.LinkADRReq: 1,
+				},
+			},
+			blocks:   []storage.MACCommandBlock{linkADRReq, rxParamSetupReq},
+			expected: []storage.MACCommandBlock{linkADRReq, rxParamSetupReq},
+		},
+		{
+			name: "external mac-command in backoff",
+			deviceSession: storage.DeviceSession{
+				FCntUp: 3,
+				MACCommandErrorCount: map[lorawan.CID]int{
+					lorawan.LinkADRReq: 2,
+				},
+				MACCommandErrorFCntUp: map[lorawan.CID]uint32{
+					lorawan.LinkADRReq: 1,
+				},
+			},
+			blocks:   []storage.MACCommandBlock{externalLinkADRReq, rxParamSetupReq},
+			expected: []storage.MACCommandBlock{externalLinkADRReq, rxParamSetupReq},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.expected, rankMACCommands(tst.deviceSession, tst.blocks))
+		})
+	}
+}
+
+func TestPackMACCommands(t *testing.T) {
+	assert := require.New(t)
+
+	blocks := []storage.MACCommandBlock{
+		{
+			CID: lorawan.NewChannelReq,
+			MACCommands: storage.MACCommands{
+				{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MaxDR: 5}},
+				{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000, MaxDR: 5}},
+				{CID: lorawan.NewChannelReq, Payload: &lorawan.NewChannelReqPayload{ChIndex: 5, Freq: 867500000, MaxDR: 5}},
+			},
+		},
+		{
+			CID: lorawan.DevStatusReq,
+			MACCommands: storage.MACCommands{
+				{CID: lorawan.DevStatusReq},
+			},
+		},
+		{
+			CID: lorawan.RXTimingSetupReq,
+			MACCommands: storage.MACCommands{
+				{CID: lorawan.RXTimingSetupReq, Payload: &lorawan.RXTimingSetupReqPayload{Delay: 1}},
+			},
+		},
+	}
+
+	indices, size, err := packMACCommands(blocks, 15)
+	assert.NoError(err)
+	assert.Equal([]int{1, 2}, indices)
+	assert.Equal(3, size)
+
+	indices, size, err = packMACCommands(blocks, 20)
+	assert.NoError(err)
+	assert.Equal([]int{0, 1}, indices)
+	assert.Equal(19, size)
+
+	indices, size, err = packMACCommands(blocks, 0)
+	assert.NoError(err)
+	assert.Len(indices, 0)
+	assert.Equal(0, size)
+}
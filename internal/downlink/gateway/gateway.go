@@ -1,15 +1,19 @@
 package gateway
 
 import (
+	"context"
 	"math/rand"
 	"sort"
 	"time"
 
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
 	loraband "github.com/liuhw0/lorawan/band"
 )
 
@@ -71,3 +75,69 @@ func SelectDownlinkGateway(minSNRMargin float64, rxDR int, rxInfo []storage.Devi
 	rand.Seed(time.Now().UnixNano())
 	return newRxInfo[rand.Intn(len(newRxInfo))], nil
 }
+
+// FilterDownlinkDisabled returns the given DeviceGatewayRXInfo elements,
+// excluding the gateways (or gateway boards) for which downlink has been
+// disabled. Unknown (e.g. roaming) gateways are not filtered.
+func FilterDownlinkDisabled(ctx context.Context, rxInfo []storage.DeviceGatewayRXInfo) ([]storage.DeviceGatewayRXInfo, error) {
+	out := make([]storage.DeviceGatewayRXInfo, 0, len(rxInfo))
+
+	for i := range rxInfo {
+		disabled, err := IsDownlinkDisabled(ctx, rxInfo[i].GatewayID, rxInfo[i].Board)
+		if err != nil {
+			return nil, err
+		}
+
+		if disabled {
+			continue
+		}
+
+		out = append(out, rxInfo[i])
+	}
+
+	return out, nil
+}
+
+// IsDownlinkDisabled returns true when downlink has been disabled for the
+// given gateway (or gateway board). For unknown (e.g. roaming) gateways,
+// false is returned.
+func IsDownlinkDisabled(ctx context.Context, gatewayID lorawan.EUI64, board uint32) (bool, error) {
+	gw, err := storage.GetAndCacheGatewayMeta(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get gateway meta error")
+	}
+
+	if gw.Overrides.IsDownlinkDisabled(board) {
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"board":      board,
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+		}).Debug("downlink is disabled for gateway, skipping gateway")
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// GetDownlinkTXPower returns the TX power to use for the given gateway and
+// frequency. The given TX power is used as downlink EIRP, or the band default
+// in case it is set to -1. This EIRP is then adjusted to the max. TX power
+// and antenna gain of the gateway.
+func GetDownlinkTXPower(ctx context.Context, gatewayID lorawan.EUI64, txPower int, freq uint32) (int, error) {
+	if txPower == -1 {
+		txPower = band.Band().GetDownlinkTXPower(freq)
+	}
+
+	gw, err := storage.GetAndCacheGatewayMeta(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return txPower, nil
+		}
+		return 0, errors.Wrap(err, "get gateway meta error")
+	}
+
+	return gw.Overrides.GetDownlinkTXPower(txPower), nil
+}
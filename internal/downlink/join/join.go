@@ -98,8 +98,12 @@ func setDeviceGatewayRXInfo(ctx *joinContext) error {
 }
 
 func selectDownlinkGateway(ctx *joinContext) error {
-	var err error
-	ctx.DownlinkGateway, err = dwngateway.SelectDownlinkGateway(gatewayPreferMinMargin, ctx.RXPacket.DR, ctx.DeviceGatewayRXInfo)
+	rxInfo, err := dwngateway.FilterDownlinkDisabled(ctx.ctx, ctx.DeviceGatewayRXInfo)
+	if err != nil {
+		return errors.Wrap(err, "filter downlink disabled gateways error")
+	}
+
+	ctx.DownlinkGateway, err = dwngateway.SelectDownlinkGateway(gatewayPreferMinMargin, ctx.RXPacket.DR, rxInfo)
 	if err != nil {
 		return err
 	}
@@ -152,11 +156,11 @@ func setTXInfoForRX1(ctx *joinContext) error {
 	txInfo.Frequency = uint32(freq)
 
 	// set tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(txInfo.Frequency))
+	txPower, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.DownlinkGateway.GatewayID, downlinkTXPower, txInfo.Frequency)
+	if err != nil {
+		return errors.Wrap(err, "get downlink tx-power error")
 	}
+	txInfo.Power = int32(txPower)
 
 	// set timestamp
 	txInfo.Timing = gw.DownlinkTiming_DELAY
@@ -189,11 +193,11 @@ func setTXInfoForRX2(ctx *joinContext) error {
 	}
 
 	// set tx power
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(txInfo.Frequency))
+	txPower, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.DownlinkGateway.GatewayID, downlinkTXPower, txInfo.Frequency)
+	if err != nil {
+		return errors.Wrap(err, "get downlink tx-power error")
 	}
+	txInfo.Power = int32(txPower)
 
 	// set timestamp
 	txInfo.Timing = gw.DownlinkTiming_DELAY
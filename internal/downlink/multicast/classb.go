@@ -0,0 +1,160 @@
+package multicast
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gps"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers/classb"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+	"github.com/liuhw0/lorawan/airtime"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+// phyPayloadOverhead contains the number of PHYPayload bytes besides the
+// FRMPayload (MHDR, DevAddr, FCtrl, FCnt, FPort and MIC).
+const phyPayloadOverhead = 13
+
+// getTXDuration returns the time-on-air of a multicast downlink with the
+// given FRMPayload size.
+func getTXDuration(mg storage.MulticastGroup, frmPayloadSize int) (time.Duration, error) {
+	dr, err := band.Band().GetDataRate(mg.DR)
+	if err != nil {
+		return 0, errors.Wrap(err, "get data-rate error")
+	}
+
+	size := frmPayloadSize + phyPayloadOverhead
+
+	switch dr.Modulation {
+	case loraband.LoRaModulation:
+		ldro := dr.SpreadFactor >= 11 && dr.Bandwidth == 125
+		d, err := airtime.CalculateLoRaAirtime(size, dr.SpreadFactor, dr.Bandwidth, 8, airtime.CodingRate45, true, ldro)
+		if err != nil {
+			return 0, errors.Wrap(err, "calculate lora airtime error")
+		}
+		return d, nil
+	case loraband.FSKModulation:
+		if dr.BitRate == 0 {
+			return 0, fmt.Errorf("invalid bitrate for data-rate %d", mg.DR)
+		}
+		// preamble (5), sync-word (3), length (1) and crc (2) bytes
+		return time.Duration(size+11) * 8 * time.Second / time.Duration(dr.BitRate), nil
+	default:
+		return 0, fmt.Errorf("unsupported modulation for data-rate %d", mg.DR)
+	}
+}
+
+// getNextPingSlotAfter returns the next ping-slot of the multicast-group
+// after the given gps epoch timestamp, skipping the ping-slots for which the
+// transmission would overlap with the beacon-guard interval.
+func getNextPingSlotAfter(mg storage.MulticastGroup, afterGPSEpochTS time.Duration, frmPayloadSize int) (time.Duration, error) {
+	var pingSlotNb int
+	if mg.PingSlotPeriod != 0 {
+		pingSlotNb = (1 << 12) / mg.PingSlotPeriod
+	}
+
+	txDuration, err := getTXDuration(mg, frmPayloadSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "get tx duration error")
+	}
+
+	ts, err := classb.GetNextPingSlotAfterForDuration(afterGPSEpochTS, mg.MCAddr, pingSlotNb, txDuration)
+	if err != nil {
+		return 0, errors.Wrap(err, "get next ping-slot after error")
+	}
+
+	return ts, nil
+}
+
+// rescheduleQueue re-schedules the pending queue-items of the
+// multicast-group at the next available ping-slots, keeping their original
+// order so that the frame-counters are emitted in sequence. The queue-item
+// matching the given id is always re-scheduled, other queue-items are left
+// as-is when waiting for their tx acknowledgement.
+func rescheduleQueue(ctx context.Context, db sqlx.Ext, mg storage.MulticastGroup, id int64) error {
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-queue items error")
+	}
+
+	now := time.Now()
+	scheduleTS := gps.Time(now.Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
+
+	for i := range items {
+		item := items[i]
+		if item.ID != id && isInFlight(item, now) {
+			continue
+		}
+
+		scheduleTS, err = getNextPingSlotAfter(mg, scheduleTS, len(item.FRMPayload))
+		if err != nil {
+			return err
+		}
+
+		emitAt := scheduleTS
+		item.EmitAtTimeSinceGPSEpoch = &emitAt
+		item.ScheduleAt = time.Time(gps.NewFromTimeSinceGPSEpoch(scheduleTS)).Add(-2 * schedulerInterval)
+		item.RetryAfter = nil
+
+		if err := storage.UpdateMulticastQueueItem(ctx, db, &item); err != nil {
+			return errors.Wrap(err, "update multicast-queue item error")
+		}
+	}
+
+	return nil
+}
+
+// filterTimeSyncedGateways removes the gateways from the given rx-info sets
+// which are not able to emit downlinks at a GPS epoch timestamp, based on
+// the time-sync state reported by the gateway stats. Gateways for which the
+// time-sync state is unknown are only removed when gateway time-sync is
+// required.
+func filterTimeSyncedGateways(ctx context.Context, rxInfoSets []storage.DeviceGatewayRXInfoSet) ([]storage.DeviceGatewayRXInfoSet, error) {
+	var gatewayIDs []lorawan.EUI64
+	seen := make(map[lorawan.EUI64]struct{})
+	for _, set := range rxInfoSets {
+		for _, item := range set.Items {
+			if _, ok := seen[item.GatewayID]; !ok {
+				seen[item.GatewayID] = struct{}{}
+				gatewayIDs = append(gatewayIDs, item.GatewayID)
+			}
+		}
+	}
+
+	timeSync, err := storage.GetGatewayTimeSyncForGatewayIDs(ctx, gatewayIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "get gateway time-sync error")
+	}
+
+	out := make([]storage.DeviceGatewayRXInfoSet, 0, len(rxInfoSets))
+	for _, set := range rxInfoSets {
+		items := make([]storage.DeviceGatewayRXInfo, 0, len(set.Items))
+
+		for _, item := range set.Items {
+			synced, ok := timeSync[item.GatewayID]
+			if synced || (!ok && !classBGatewayTimeSyncRequired) {
+				items = append(items, item)
+				continue
+			}
+
+			log.WithFields(log.Fields{
+				"dev_eui":    set.DevEUI,
+				"gateway_id": item.GatewayID,
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+			}).Debug("multicast: gateway is not time-synchronized, skipping gateway")
+		}
+
+		set.Items = items
+		out = append(out, set)
+	}
+
+	return out, nil
+}
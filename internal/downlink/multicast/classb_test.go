@@ -0,0 +1,37 @@
+package multicast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+)
+
+func TestGetTXDuration(t *testing.T) {
+	assert := require.New(t)
+	conf := test.GetConfig()
+	assert.NoError(band.Setup(conf))
+
+	tests := []struct {
+		DR       int
+		Size     int
+		Duration time.Duration
+	}{
+		// SF12 / 125 kHz, 13 + 10 bytes
+		{0, 10, 1482752 * time.Microsecond},
+		// SF7 / 125 kHz, 13 + 10 bytes
+		{5, 10, 61696 * time.Microsecond},
+		// FSK 50 kbps
+		{7, 10, 5440 * time.Microsecond},
+	}
+
+	for _, tst := range tests {
+		d, err := getTXDuration(storage.MulticastGroup{DR: tst.DR}, tst.Size)
+		assert.NoError(err)
+		assert.Equal(tst.Duration, d, "DR%d", tst.DR)
+	}
+}
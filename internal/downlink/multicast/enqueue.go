@@ -7,8 +7,8 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
+	dwngateway "github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/gps"
-	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers/classb"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 )
 
@@ -43,6 +43,22 @@ func EnqueueQueueItem(ctx context.Context, db sqlx.Ext, qi storage.MulticastQueu
 		return errors.Wrap(err, "get device gateway rx-info set for deveuis errors")
 	}
 
+	for i := range rxInfoSets {
+		rxInfoSets[i].Items, err = dwngateway.FilterDownlinkDisabled(ctx, rxInfoSets[i].Items)
+		if err != nil {
+			return errors.Wrap(err, "filter downlink disabled gateways error")
+		}
+	}
+
+	// for class-b, only gateways which are able to emit at a GPS epoch
+	// timestamp can be used
+	if mg.GroupType == storage.MulticastGroupB {
+		rxInfoSets, err = filterTimeSyncedGateways(ctx, rxInfoSets)
+		if err != nil {
+			return errors.Wrap(err, "filter time-synchronized gateways error")
+		}
+	}
+
 	gatewayIDs, err := GetMinimumGatewaySet(rxInfoSets)
 	if err != nil {
 		return errors.Wrap(err, "get minimum gateway set error")
@@ -77,24 +93,20 @@ func EnqueueQueueItem(ctx context.Context, db sqlx.Ext, qi storage.MulticastQueu
 
 	// for each gateway the use the next ping-slot
 	if mg.GroupType == storage.MulticastGroupB {
-		var pingSlotNb int
-		if mg.PingSlotPeriod != 0 {
-			pingSlotNb = (1 << 12) / mg.PingSlotPeriod
-		}
-
 		scheduleTS, err := storage.GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(ctx, db, mg.ID)
 		if err != nil {
 			return errors.Wrap(err, "get maximum emit at time since gps epoch error")
 		}
 
-		if scheduleTS == 0 {
-			scheduleTS = gps.Time(time.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
+		// the last queue-item might have been emitted (or missed) already
+		if minTS := gps.Time(time.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch(); scheduleTS < minTS {
+			scheduleTS = minTS
 		}
 
 		for _, gatewayID := range gatewayIDs {
-			scheduleTS, err = classb.GetNextPingSlotAfter(scheduleTS, mg.MCAddr, pingSlotNb)
+			scheduleTS, err = getNextPingSlotAfter(mg, scheduleTS, len(qi.FRMPayload))
 			if err != nil {
-				return errors.Wrap(err, "get next ping-slot after error")
+				return err
 			}
 
 			qi.EmitAtTimeSinceGPSEpoch = &scheduleTS
@@ -10,7 +10,9 @@ import (
 
 	"github.com/liuhw0/lorawan"
 
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers/classb"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
 )
@@ -29,6 +31,7 @@ func (ts *EnqueueQueueItemTestCase) SetupSuite() {
 	assert := require.New(ts.T())
 	conf := test.GetConfig()
 	assert.NoError(storage.Setup(conf))
+	assert.NoError(band.Setup(conf))
 
 	assert.NoError(storage.MigrateDown(storage.DB().DB))
 	assert.NoError(storage.MigrateUp(storage.DB().DB))
@@ -145,7 +148,7 @@ func (ts *EnqueueQueueItemTestCase) TestClassC() {
 	assert.Nil(items[0].EmitAtTimeSinceGPSEpoch)
 	assert.Nil(items[1].EmitAtTimeSinceGPSEpoch)
 
-	lockDuration := config.C.NetworkServer.Scheduler.ClassC.DeviceDownlinkLockDuration
+	lockDuration := config.Get().NetworkServer.Scheduler.ClassC.DeviceDownlinkLockDuration
 	assert.EqualValues(math.Abs(float64(items[0].ScheduleAt.Sub(items[1].ScheduleAt))), lockDuration)
 
 	mg, err := storage.GetMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID, false)
@@ -180,6 +183,31 @@ func (ts *EnqueueQueueItemTestCase) TestClassB() {
 	assert.Equal(qi.FCnt+1, mg.FCnt)
 }
 
+func (ts *EnqueueQueueItemTestCase) TestClassBGatewayTimeSync() {
+	assert := require.New(ts.T())
+
+	ts.MulticastGroup.PingSlotPeriod = 16
+	ts.MulticastGroup.GroupType = storage.MulticastGroupB
+	assert.NoError(storage.UpdateMulticastGroup(context.Background(), ts.tx, &ts.MulticastGroup))
+
+	// the first gateway reports that it is not time-synchronized
+	assert.NoError(storage.SetGatewayTimeSync(context.Background(), ts.Gateways[0].GatewayID, false))
+
+	qi := storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       []byte{1, 2, 3, 4},
+	}
+	assert.NoError(EnqueueQueueItem(context.Background(), ts.tx, qi))
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+	assert.Len(items, 1)
+	assert.Equal(ts.Gateways[1].GatewayID, items[0].GatewayID)
+	assert.False(classb.OverlapsBeaconGuard(*items[0].EmitAtTimeSinceGPSEpoch, 0))
+}
+
 func TestEnqueueQueueItem(t *testing.T) {
 	suite.Run(t, new(EnqueueQueueItemTestCase))
 }
@@ -5,4 +5,5 @@ import "github.com/pkg/errors"
 // Errors
 var (
 	ErrInvalidFCnt = errors.New("invalid frame-counter value")
+	ErrMaxAttempts = errors.New("max. number of attempts reached")
 )
@@ -16,6 +16,8 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	dwngateway "github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/gateway"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gps"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
@@ -36,11 +38,13 @@ type multicastContext struct {
 
 var multicastTasks = []func(*multicastContext) error{
 	getMulticastGroup,
+	rescheduleMissedPingSlot,
 	setToken,
 	validatePayloadSize,
 	setTXInfo,
 	setPHYPayload,
 	sendDownlinkData,
+	setMulticastQueueItemRetryAfter,
 	saveDownlinkFrame,
 }
 
@@ -49,6 +53,10 @@ var (
 	schedulerInterval     time.Duration
 	installationMargin    float64
 	downlinkTXPower       int
+	downlinkTimeout       time.Duration
+	maxAttempts           int
+
+	classBGatewayTimeSyncRequired bool
 
 	// TODO: make configurable
 	classBEnqueueMargin = time.Second * 5
@@ -60,6 +68,14 @@ func Setup(conf config.Config) error {
 	schedulerInterval = conf.NetworkServer.Scheduler.SchedulerInterval
 	installationMargin = conf.NetworkServer.NetworkSettings.InstallationMargin
 	downlinkTXPower = conf.NetworkServer.NetworkSettings.DownlinkTXPower
+	downlinkTimeout = conf.NetworkServer.Gateway.DownlinkTimeout
+
+	classBGatewayTimeSyncRequired = conf.NetworkServer.Scheduler.ClassB.MulticastGatewayTimeSyncRequired
+
+	maxAttempts = conf.NetworkServer.Scheduler.MulticastMaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
 
 	return nil
 }
@@ -97,6 +113,43 @@ func getMulticastGroup(ctx *multicastContext) error {
 	return nil
 }
 
+// rescheduleMissedPingSlot re-schedules the Class-B queue-item, together
+// with the queue-items following it, at the next available ping-slots in case
+// it was not scheduled in time for its ping-slot (e.g. because of scheduler
+// delays).
+func rescheduleMissedPingSlot(ctx *multicastContext) error {
+	if ctx.MulticastQueueItem.EmitAtTimeSinceGPSEpoch == nil {
+		return nil
+	}
+
+	minTS := gps.Time(time.Now().Add(schedulerInterval)).TimeSinceGPSEpoch()
+	if *ctx.MulticastQueueItem.EmitAtTimeSinceGPSEpoch >= minTS {
+		return nil
+	}
+
+	missedTS := *ctx.MulticastQueueItem.EmitAtTimeSinceGPSEpoch
+
+	if err := rescheduleQueue(ctx.ctx, ctx.DB, ctx.MulticastGroup, ctx.MulticastQueueItem.ID); err != nil {
+		return errors.Wrap(err, "re-schedule multicast-queue error")
+	}
+
+	qi, err := storage.GetMulticastQueueItem(ctx.ctx, ctx.DB, ctx.MulticastQueueItem.ID)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-queue item error")
+	}
+	ctx.MulticastQueueItem = qi
+
+	log.WithFields(log.Fields{
+		"multicast_group_id":           ctx.MulticastGroup.ID,
+		"id":                           ctx.MulticastQueueItem.ID,
+		"missed_time_since_gps_epoch":  missedTS,
+		"emit_at_time_since_gps_epoch": *ctx.MulticastQueueItem.EmitAtTimeSinceGPSEpoch,
+		"ctx_id":                       ctx.ctx.Value(logging.ContextIDKey),
+	}).Warning("multicast: ping-slot missed, queue-item re-scheduled")
+
+	return errAbort
+}
+
 func setToken(ctx *multicastContext) error {
 	b := make([]byte, 2)
 	_, err := rand.Read(b)
@@ -167,11 +220,11 @@ func setTXInfo(ctx *multicastContext) error {
 		return errors.Wrap(err, "set data-rate error")
 	}
 
-	if downlinkTXPower != -1 {
-		txInfo.Power = int32(downlinkTXPower)
-	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(ctx.MulticastGroup.Frequency))
+	txPower, err := dwngateway.GetDownlinkTXPower(ctx.ctx, ctx.MulticastQueueItem.GatewayID, downlinkTXPower, ctx.MulticastGroup.Frequency)
+	if err != nil {
+		return errors.Wrap(err, "get downlink tx-power error")
 	}
+	txInfo.Power = int32(txPower)
 
 	ctx.DownlinkFrame.Items[0] = &gw.DownlinkFrameItem{
 		TxInfo: &txInfo,
@@ -219,10 +272,25 @@ func sendDownlinkData(ctx *multicastContext) error {
 	return nil
 }
 
+// setMulticastQueueItemRetryAfter sets the retry_after field of the
+// multicast-queue item. This avoids that multiple scheduler loops schedule
+// the same queue-item while waiting for the gateway tx acknowledgement.
+func setMulticastQueueItemRetryAfter(ctx *multicastContext) error {
+	retryAfter := time.Now().Add(downlinkTimeout)
+	ctx.MulticastQueueItem.RetryAfter = &retryAfter
+
+	if err := storage.UpdateMulticastQueueItem(ctx.ctx, ctx.DB, &ctx.MulticastQueueItem); err != nil {
+		return errors.Wrap(err, "update multicast-queue item error")
+	}
+
+	return nil
+}
+
 func saveDownlinkFrame(ctx *multicastContext) error {
 	df := storage.DownlinkFrame{
 		MulticastGroupId:     ctx.MulticastGroup.ID[:],
 		MulticastQueueItemId: ctx.MulticastQueueItem.ID,
+		RoutingProfileId:     ctx.MulticastGroup.RoutingProfileID[:],
 		Token:                uint32(ctx.DownlinkFrame.Token),
 		DownlinkFrame:        &ctx.DownlinkFrame,
 	}
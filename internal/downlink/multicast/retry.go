@@ -0,0 +1,95 @@
+package multicast
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+// RetryQueueItem re-schedules the given (failed) queue-item. The queue-item
+// keeps its position within the queue of the multicast-group: the queue-items
+// following it are pushed back, so that these are not emitted before the
+// retry (the devices would reject the retry as it has a lower frame-counter).
+// It returns ErrMaxAttempts when the max. number of attempts has been reached,
+// in which case the queue-item is left untouched.
+func RetryQueueItem(ctx context.Context, db sqlx.Ext, qi storage.MulticastQueueItem) error {
+	if qi.RetryCount+1 >= maxAttempts {
+		return ErrMaxAttempts
+	}
+
+	mg, err := storage.GetMulticastGroup(ctx, db, qi.MulticastGroupID, false)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	qi.RetryCount++
+
+	switch mg.GroupType {
+	case storage.MulticastGroupC:
+		qi.ScheduleAt = time.Now().Add(multicastGatewayDelay)
+		qi.RetryAfter = nil
+
+		if err := storage.UpdateMulticastQueueItem(ctx, db, &qi); err != nil {
+			return errors.Wrap(err, "update multicast-queue item error")
+		}
+
+		if err := pushBackClassCQueueItems(ctx, db, qi); err != nil {
+			return errors.Wrap(err, "push back multicast-queue items error")
+		}
+	case storage.MulticastGroupB:
+		if err := storage.UpdateMulticastQueueItem(ctx, db, &qi); err != nil {
+			return errors.Wrap(err, "update multicast-queue item error")
+		}
+
+		if err := rescheduleQueue(ctx, db, mg, qi.ID); err != nil {
+			return errors.Wrap(err, "re-schedule multicast-queue error")
+		}
+	}
+
+	return nil
+}
+
+// pushBackClassCQueueItems pushes back the queue-items of the same
+// multicast-group and gateway following the given (re-scheduled) queue-item,
+// keeping their original order and the multicast gateway delay in between.
+// Queue-items which are waiting for their tx acknowledgement are left as-is.
+func pushBackClassCQueueItems(ctx context.Context, db sqlx.Ext, qi storage.MulticastQueueItem) error {
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, db, qi.MulticastGroupID)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-queue items error")
+	}
+
+	now := time.Now()
+	ts := qi.ScheduleAt
+
+	for i := range items {
+		item := items[i]
+		if item.GatewayID != qi.GatewayID || item.FCnt <= qi.FCnt || isInFlight(item, now) {
+			continue
+		}
+
+		ts = ts.Add(multicastGatewayDelay)
+		if !item.ScheduleAt.Before(ts) {
+			ts = item.ScheduleAt
+			continue
+		}
+
+		item.ScheduleAt = ts
+		item.RetryAfter = nil
+		if err := storage.UpdateMulticastQueueItem(ctx, db, &item); err != nil {
+			return errors.Wrap(err, "update multicast-queue item error")
+		}
+	}
+
+	return nil
+}
+
+// isInFlight returns true when the queue-item has been sent to the gateway
+// and is waiting for its tx acknowledgement.
+func isInFlight(qi storage.MulticastQueueItem, now time.Time) bool {
+	return qi.RetryAfter != nil && qi.RetryAfter.After(now)
+}
@@ -0,0 +1,104 @@
+package multicast
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+)
+
+func (ts *EnqueueQueueItemTestCase) TestRetryQueueItemClassC() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	conf.NetworkServer.Scheduler.MulticastMaxAttempts = 2
+	assert.NoError(Setup(conf))
+
+	qi := storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       []byte{1, 2, 3, 4},
+	}
+	assert.NoError(EnqueueQueueItem(context.Background(), ts.tx, qi))
+	qi.FCnt = 12
+	assert.NoError(EnqueueQueueItem(context.Background(), ts.tx, qi))
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+	assert.Len(items, 4)
+
+	var next, other storage.MulticastQueueItem
+	for _, item := range items[1:] {
+		if item.GatewayID == items[0].GatewayID {
+			next = item
+		} else if item.FCnt == 12 {
+			other = item
+		}
+	}
+	assert.Equal(uint32(12), next.FCnt)
+
+	assert.NoError(RetryQueueItem(context.Background(), ts.tx, items[0]))
+
+	qi, err = storage.GetMulticastQueueItem(context.Background(), ts.tx, items[0].ID)
+	assert.NoError(err)
+	assert.Equal(1, qi.RetryCount)
+	assert.Nil(qi.RetryAfter)
+	assert.True(qi.ScheduleAt.After(time.Now()))
+
+	// the next queue-item for the same gateway is pushed back
+	nextUpdated, err := storage.GetMulticastQueueItem(context.Background(), ts.tx, next.ID)
+	assert.NoError(err)
+	assert.True(nextUpdated.ScheduleAt.After(qi.ScheduleAt))
+
+	// the queue-items for the other gateway are not affected
+	otherUpdated, err := storage.GetMulticastQueueItem(context.Background(), ts.tx, other.ID)
+	assert.NoError(err)
+	assert.True(otherUpdated.ScheduleAt.Equal(other.ScheduleAt))
+
+	// the max. number of attempts has been reached
+	assert.Equal(ErrMaxAttempts, RetryQueueItem(context.Background(), ts.tx, qi))
+}
+
+func (ts *EnqueueQueueItemTestCase) TestRetryQueueItemClassB() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(Setup(conf))
+
+	ts.MulticastGroup.PingSlotPeriod = 16
+	ts.MulticastGroup.GroupType = storage.MulticastGroupB
+	assert.NoError(storage.UpdateMulticastGroup(context.Background(), ts.tx, &ts.MulticastGroup))
+
+	qi := storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       []byte{1, 2, 3, 4},
+	}
+	assert.NoError(EnqueueQueueItem(context.Background(), ts.tx, qi))
+	qi.FCnt = 12
+	assert.NoError(EnqueueQueueItem(context.Background(), ts.tx, qi))
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+	assert.Len(items, 4)
+
+	assert.NoError(RetryQueueItem(context.Background(), ts.tx, items[0]))
+
+	qi, err = storage.GetMulticastQueueItem(context.Background(), ts.tx, items[0].ID)
+	assert.NoError(err)
+	assert.Equal(1, qi.RetryCount)
+	assert.Nil(qi.RetryAfter)
+
+	// the queue-items keep their order, with the retried queue-item first
+	updated, err := storage.GetMulticastQueueItemsForMulticastGroup(context.Background(), ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+	assert.Len(updated, 4)
+	assert.Equal(qi.ID, updated[0].ID)
+	for i := 1; i < len(updated); i++ {
+		assert.True(*updated[i].EmitAtTimeSinceGPSEpoch > *updated[i-1].EmitAtTimeSinceGPSEpoch)
+		assert.True(updated[i].ScheduleAt.After(updated[i-1].ScheduleAt))
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	dwngateway "github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 	"github.com/liuhw0/lorawan"
@@ -69,13 +70,6 @@ func Handle(ctx context.Context, macPayload []byte, mic lorawan.MIC, gwMACs []lo
 }
 
 func sendProprietaryDown(ctx *proprietaryContext) error {
-	var txPower int
-	if downlinkTXPower != -1 {
-		txPower = downlinkTXPower
-	} else {
-		txPower = band.Band().GetDownlinkTXPower(ctx.Frequency)
-	}
-
 	phy := lorawan.PHYPayload{
 		MHDR: lorawan.MHDR{
 			Major: lorawan.LoRaWANR1,
@@ -90,6 +84,21 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 	}
 
 	for _, mac := range ctx.GatewayMACs {
+		// the downlink frame does not set the board, which defaults to the
+		// first board of the gateway
+		disabled, err := dwngateway.IsDownlinkDisabled(ctx.ctx, mac, 0)
+		if err != nil {
+			return errors.Wrap(err, "get downlink disabled error")
+		}
+		if disabled {
+			continue
+		}
+
+		txPower, err := dwngateway.GetDownlinkTXPower(ctx.ctx, mac, downlinkTXPower, ctx.Frequency)
+		if err != nil {
+			return errors.Wrap(err, "get downlink tx-power error")
+		}
+
 		downID, err := uuid.NewV4()
 		if err != nil {
 			return errors.Wrap(err, "new uuid error")
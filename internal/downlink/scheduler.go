@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/data"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/multicast"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
@@ -30,12 +31,17 @@ func DeviceQueueSchedulerLoop() {
 			"ctx_id": ctxID,
 		}).Debug("running class-b / class-c scheduler batch")
 
-		if err := ScheduleDeviceQueueBatch(ctx, schedulerBatchSize); err != nil {
+		config.RLock()
+		err = ScheduleDeviceQueueBatch(ctx, schedulerBatchSize)
+		interval := schedulerInterval
+		config.RUnlock()
+
+		if err != nil {
 			log.WithFields(log.Fields{
 				"ctx_id": ctxID,
 			}).WithError(err).Error("class-b / class-c scheduler error")
 		}
-		time.Sleep(schedulerInterval)
+		time.Sleep(interval)
 	}
 }
 
@@ -54,12 +60,17 @@ func MulticastQueueSchedulerLoop() {
 			"ctx_id": ctxID,
 		}).Debug("running multicast scheduler batch")
 
-		if err := ScheduleMulticastQueueBatch(ctx, schedulerBatchSize); err != nil {
+		config.RLock()
+		err = ScheduleMulticastQueueBatch(ctx, schedulerBatchSize)
+		interval := schedulerInterval
+		config.RUnlock()
+
+		if err != nil {
 			log.WithFields(log.Fields{
 				"ctx_id": ctxID,
 			}).WithError(err).Error("multicast scheduler error")
 		}
-		time.Sleep(schedulerInterval)
+		time.Sleep(interval)
 	}
 }
 
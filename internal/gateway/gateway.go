@@ -6,6 +6,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway/stats"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway/translator"
 )
 
 var (
@@ -19,6 +21,14 @@ var (
 func Setup(c config.Config) error {
 	conf := c.NetworkServer.Gateway
 
+	if err := translator.Setup(c); err != nil {
+		return errors.Wrap(err, "setup gateway config translator error")
+	}
+
+	if err := stats.Setup(c); err != nil {
+		return errors.Wrap(err, "setup gateway stats error")
+	}
+
 	statsHandler = &StatsHandler{}
 	if err := statsHandler.Start(); err != nil {
 		return errors.Wrap(err, "start stats handler error")
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+)
+
+// HandleCommandExecResponse handles the command execution response of the
+// packet-forwarder configuration command. When the command did not return
+// an error, the configuration version which was sent using the command is
+// marked as applied. Responses of other commands are ignored.
+func HandleCommandExecResponse(ctx context.Context, resp gw.GatewayCommandExecResponse) error {
+	gatewayID := helpers.GetGatewayID(&resp)
+
+	var execID uuid.UUID
+	copy(execID[:], resp.ExecId)
+
+	version, err := storage.GetGatewayConfigExec(ctx, execID)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get gateway config exec error")
+	}
+
+	if resp.Error != "" {
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"exec_id":    execID,
+			"version":    version,
+			"error":      resp.Error,
+			"stderr":     string(resp.Stderr),
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+		}).Error("gateway configuration command returned an error")
+		return nil
+	}
+
+	state, err := storage.GetAndCacheGatewayConfigState(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return errors.Wrap(err, "get gateway config state error")
+	}
+
+	if !updateAppliedConfigVersion(&state, version, time.Now()) {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"version":    version,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Info("gateway configuration applied")
+
+	return saveAndCacheGatewayConfigState(ctx, &state)
+}
@@ -2,6 +2,8 @@ package stats
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
@@ -12,6 +14,8 @@ import (
 	"github.com/brocaar/chirpstack-api/go/v3/gw"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway/translator"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/helpers"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
@@ -21,6 +25,17 @@ import (
 
 var ErrAbort = errors.New("abort")
 
+// configPendingTimeout defines the duration after which a pending (not yet
+// applied) gateway configuration is sent again.
+var configPendingTimeout time.Duration
+
+// timeSyncMetaDataKeys contains the gateway stats meta-data keys which are
+// used to report if the gateway is time-synchronized (e.g. GPS locked).
+var timeSyncMetaDataKeys = []string{
+	"gps_locked",
+	"time_synced",
+}
+
 type statsContext struct {
 	ctx          context.Context
 	gatewayID    lorawan.EUI64
@@ -30,11 +45,18 @@ type statsContext struct {
 
 var tasks = []func(*statsContext) error{
 	updateGatewayState,
+	updateGatewayTimeSync,
 	getGatewayMeta,
 	handleGatewayConfigurationUpdate,
 	forwardGatewayStats,
 }
 
+// Setup configures the stats package.
+func Setup(conf config.Config) error {
+	configPendingTimeout = conf.NetworkServer.Gateway.ConfigPendingTimeout
+	return nil
+}
+
 // Handle handles the gateway stats
 func Handle(ctx context.Context, stats gw.GatewayStats) error {
 	gatewayID := helpers.GetGatewayID(&stats)
@@ -72,6 +94,35 @@ func updateGatewayState(ctx *statsContext) error {
 	return nil
 }
 
+// updateGatewayTimeSync stores the time-synchronization state of the gateway
+// in case it is reported by the gateway stats meta-data.
+func updateGatewayTimeSync(ctx *statsContext) error {
+	for _, k := range timeSyncMetaDataKeys {
+		v, ok := ctx.gatewayStats.GetMetaData()[k]
+		if !ok {
+			continue
+		}
+
+		synced, err := strconv.ParseBool(v)
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": ctx.gatewayID,
+				"key":        k,
+				"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+			}).Warning("parse gateway time-sync meta-data error")
+			return nil
+		}
+
+		if err := storage.SetGatewayTimeSync(ctx.ctx, ctx.gatewayID, synced); err != nil {
+			return errors.Wrap(err, "set gateway time-sync error")
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
 func getGatewayMeta(ctx *statsContext) error {
 
 	gw, err := storage.GetAndCacheGatewayMeta(ctx.ctx, storage.DB(), ctx.gatewayID)
@@ -93,40 +144,166 @@ func handleGatewayConfigurationUpdate(ctx *statsContext) error {
 		return nil
 	}
 
-	// not using concentratord
-	if ctx.gatewayStats.GetMetaData()["concentratord_version"] == "" {
+	state, err := storage.GetAndCacheGatewayConfigState(ctx.ctx, storage.DB(), ctx.gatewayID)
+	if err != nil {
+		if err != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "get gateway config state error")
+		}
+		state = storage.GatewayConfigState{
+			GatewayID: ctx.gatewayID,
+		}
+	}
+
+	translatorName := getTranslatorName(state, ctx.gatewayStats)
+	if translatorName == "" {
 		log.WithFields(log.Fields{
 			"gateway_id": ctx.gatewayMeta.GatewayID,
-		}).Debug("gatway does not support configuration updates")
+		}).Debug("gateway does not support configuration updates")
 		return nil
 	}
 
+	t, err := translator.Get(translatorName)
+	if err != nil {
+		return errors.Wrap(err, "get translator error")
+	}
+
 	// get gateway-profile
 	gwProfile, err := storage.GetGatewayProfile(ctx.ctx, storage.DB(), *ctx.gatewayMeta.GatewayProfileID)
 	if err != nil {
 		return errors.Wrap(err, "get gateway-profile error")
 	}
 
-	// compare gateway-profile config version with stats config version
-	if gwProfile.GetVersion() == ctx.gatewayStats.ConfigVersion || gwProfile.GetVersion() == ctx.gatewayStats.GetMetaData()["config_version"] {
+	overrides := ctx.gatewayMeta.Overrides
+	version := overrides.GetVersion(gwProfile)
+
+	reportedVersion := ctx.gatewayStats.ConfigVersion
+	if reportedVersion == "" {
+		reportedVersion = ctx.gatewayStats.GetMetaData()["config_version"]
+	}
+
+	now := time.Now()
+	stateChanged := updateAppliedConfigVersion(&state, reportedVersion, now)
+
+	// compare gateway config version with the applied config version, which
+	// is the reported version or (in case the gateway does not report its
+	// version) the version marked as applied by the command execution response
+	if version == state.AppliedVersion {
 		log.WithFields(log.Fields{
 			"gateway_id": ctx.gatewayMeta.GatewayID,
-			"version":    ctx.gatewayStats.ConfigVersion,
+			"version":    state.AppliedVersion,
 			"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
 		}).Debug("gateway configuration is up-to-date")
+		return saveGatewayConfigState(ctx, &state, stateChanged)
+	}
+
+	// do not send the same configuration again, unless it has not been
+	// applied within the pending timeout
+	if state.PendingVersion == version && state.PendingAt != nil && now.Sub(*state.PendingAt) < configPendingTimeout {
+		log.WithFields(log.Fields{
+			"gateway_id": ctx.gatewayMeta.GatewayID,
+			"version":    state.PendingVersion,
+			"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+		}).Debug("gateway configuration has already been sent")
+		return saveGatewayConfigState(ctx, &state, stateChanged)
+	}
+
+	configPacket, err := getGatewayConfiguration(ctx.gatewayMeta.GatewayID, gwProfile, overrides)
+	if err != nil {
+		return err
+	}
+
+	if err := t.Send(ctx.ctx, gateway.Backend(), configPacket, overrides); err != nil {
+		return errors.Wrapf(err, "send gateway configuration error (translator: %s)", translatorName)
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": ctx.gatewayMeta.GatewayID,
+		"version":    configPacket.Version,
+		"translator": translatorName,
+		"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("gateway configuration sent")
+
+	state.PendingVersion = configPacket.Version
+	state.PendingAt = &now
+
+	// the gateway does not confirm the configuration, mark it as applied
+	if a, ok := t.(translator.AppliedOnSend); ok && a.AppliedOnSend() {
+		updateAppliedConfigVersion(&state, configPacket.Version, now)
+	}
+
+	return saveGatewayConfigState(ctx, &state, true)
+}
+
+// getTranslatorName returns the name of the configuration translator for the
+// gateway. When not set, the ChirpStack Concentratord is detected from the
+// gateway stats meta-data.
+func getTranslatorName(state storage.GatewayConfigState, stats gw.GatewayStats) string {
+	if state.Translator != "" {
+		return state.Translator
+	}
+
+	if stats.GetMetaData()["concentratord_version"] != "" {
+		return translator.Concentratord
+	}
+
+	return ""
+}
+
+// updateAppliedConfigVersion updates the applied (and pending) configuration
+// version given the version reported by the gateway. It returns true when
+// the state has been changed.
+func updateAppliedConfigVersion(state *storage.GatewayConfigState, reportedVersion string, now time.Time) bool {
+	if reportedVersion == "" || reportedVersion == state.AppliedVersion {
+		return false
+	}
+
+	state.AppliedVersion = reportedVersion
+	state.AppliedAt = &now
+
+	if state.PendingVersion == reportedVersion {
+		state.PendingVersion = ""
+		state.PendingAt = nil
+	}
+
+	return true
+}
+
+func saveGatewayConfigState(ctx *statsContext, state *storage.GatewayConfigState, changed bool) error {
+	if !changed {
 		return nil
 	}
 
+	return saveAndCacheGatewayConfigState(ctx.ctx, state)
+}
+
+// saveAndCacheGatewayConfigState saves the gateway configuration state and
+// updates the cached state, so that the state does not have to be read from
+// the database on every gateway stats message.
+func saveAndCacheGatewayConfigState(ctx context.Context, state *storage.GatewayConfigState) error {
+	if err := storage.SaveGatewayConfigState(ctx, storage.DB(), state); err != nil {
+		return errors.Wrap(err, "save gateway config state error")
+	}
+
+	if err := storage.CreateGatewayConfigStateCache(ctx, *state); err != nil {
+		return errors.Wrap(err, "create gateway config state cache error")
+	}
+
+	return nil
+}
+
+// getGatewayConfiguration returns the gateway configuration for the given
+// gateway-profile.
+func getGatewayConfiguration(gatewayID lorawan.EUI64, gwProfile storage.GatewayProfile, overrides storage.GatewayOverrides) (gw.GatewayConfiguration, error) {
 	configPacket := gw.GatewayConfiguration{
-		GatewayId:     ctx.gatewayMeta.GatewayID[:],
+		GatewayId:     gatewayID[:],
 		StatsInterval: ptypes.DurationProto(gwProfile.StatsInterval),
-		Version:       gwProfile.GetVersion(),
+		Version:       overrides.GetVersion(gwProfile),
 	}
 
 	for _, i := range gwProfile.Channels {
 		c, err := band.Band().GetUplinkChannel(int(i))
 		if err != nil {
-			return errors.Wrap(err, "get channel error")
+			return configPacket, errors.Wrap(err, "get channel error")
 		}
 
 		gwC := gw.ChannelConfiguration{
@@ -139,7 +316,7 @@ func handleGatewayConfigurationUpdate(ctx *statsContext) error {
 		for drI := c.MaxDR; drI >= c.MinDR; drI-- {
 			dr, err := band.Band().GetDataRate(drI)
 			if err != nil {
-				return errors.Wrap(err, "get data-rate error")
+				return configPacket, errors.Wrap(err, "get data-rate error")
 			}
 
 			// skip non-LoRa modulations (e.g. LR-FHSS) and non 125 kHz data-rates
@@ -192,11 +369,55 @@ func handleGatewayConfigurationUpdate(ctx *statsContext) error {
 		configPacket.Channels = append(configPacket.Channels, &gwC)
 	}
 
-	if err := gateway.Backend().SendGatewayConfigPacket(configPacket); err != nil {
-		return errors.Wrap(err, "send gateway-configuration packet error")
+	var ignored []uint32
+	configPacket.Channels, ignored = filterChannels(configPacket.Channels, overrides.Channels)
+	if len(ignored) != 0 {
+		log.WithFields(log.Fields{
+			"gateway_id":  gatewayID,
+			"frequencies": ignored,
+			"fallback":    len(ignored) == len(overrides.Channels),
+		}).Warning("gateway/stats: ignoring override channels which are not in the gateway-profile")
 	}
 
-	return nil
+	return configPacket, nil
+}
+
+// filterChannels returns the channels matching the given frequencies and the
+// frequencies which do not match any of the channels. When no frequencies are
+// given, or none of them match (e.g. the gateway-profile has changed since the
+// overrides were set), all channels are returned.
+func filterChannels(channels []*gw.ChannelConfiguration, frequencies []uint32) ([]*gw.ChannelConfiguration, []uint32) {
+	if len(frequencies) == 0 {
+		return channels, nil
+	}
+
+	freqs := make(map[uint32]struct{}, len(channels))
+	for _, c := range channels {
+		freqs[c.Frequency] = struct{}{}
+	}
+
+	var ignored []uint32
+	wanted := make(map[uint32]struct{}, len(frequencies))
+	for _, f := range frequencies {
+		if _, ok := freqs[f]; !ok {
+			ignored = append(ignored, f)
+			continue
+		}
+		wanted[f] = struct{}{}
+	}
+
+	if len(wanted) == 0 {
+		return channels, ignored
+	}
+
+	var out []*gw.ChannelConfiguration
+	for _, c := range channels {
+		if _, ok := wanted[c.Frequency]; ok {
+			out = append(out, c)
+		}
+	}
+
+	return out, ignored
 }
 
 func forwardGatewayStats(ctx *statsContext) error {
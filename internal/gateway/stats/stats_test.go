@@ -15,6 +15,7 @@ import (
 	"github.com/brocaar/chirpstack-api/go/v3/gw"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway/translator"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
 	"github.com/liuhw0/lorawan"
@@ -34,6 +35,8 @@ func (ts *GatewayConfigurationTestSuite) SetupSuite() {
 	assert := require.New(ts.T())
 	conf := test.GetConfig()
 	assert.NoError(storage.Setup(conf))
+	assert.NoError(translator.Setup(conf))
+	assert.NoError(Setup(conf))
 
 	assert.NoError(storage.MigrateDown(storage.DB().DB))
 	assert.NoError(storage.MigrateUp(storage.DB().DB))
@@ -180,8 +183,181 @@ func (ts *GatewayConfigurationTestSuite) TestUpdate() {
 					},
 				},
 			}, gwConfig)
+
+			state, err := storage.GetGatewayConfigState(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal("1.2.3", state.AppliedVersion)
+			assert.Equal(gp.GetVersion(), state.PendingVersion)
+		})
+
+		t.Run("Concentratord applied", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId:     ts.gateway.GatewayID[:],
+				ConfigVersion: gp.GetVersion(),
+				MetaData: map[string]string{
+					"concentratord_version": "3.3.0",
+				},
+			}))
+
+			assert.Len(ts.backend.GatewayConfigPacketChan, 0)
+
+			state, err := storage.GetGatewayConfigState(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal(gp.GetVersion(), state.AppliedVersion)
+			assert.Equal("", state.PendingVersion)
+		})
+
+		t.Run("Packet-forwarder", func(t *testing.T) {
+			assert := require.New(t)
+
+			state := storage.GatewayConfigState{
+				GatewayID:  ts.gateway.GatewayID,
+				Translator: translator.PacketForwarder,
+			}
+			assert.NoError(storage.SaveGatewayConfigState(context.Background(), storage.DB(), &state))
+			assert.NoError(storage.FlushGatewayConfigStateCache(context.Background(), ts.gateway.GatewayID))
+
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId: ts.gateway.GatewayID[:],
+			}))
+
+			req := <-ts.backend.CommandExecRequestChan
+			assert.Equal(ts.gateway.GatewayID[:], req.GatewayId)
+			assert.Equal("set_packet_forwarder_config", req.Command)
+			assert.Equal(map[string]string{"CONFIG_VERSION": gp.GetVersion()}, req.Environment)
+			assert.Contains(string(req.Stdin), `"SX1301_conf"`)
+
+			// the gateway does not report its version and the configuration
+			// is pending, it must not be sent again
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId: ts.gateway.GatewayID[:],
+			}))
+			assert.Len(ts.backend.CommandExecRequestChan, 0)
+
+			// the pending timeout has expired, it must be sent again
+			configPendingTimeout = 0
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId: ts.gateway.GatewayID[:],
+			}))
+			configPendingTimeout = time.Hour
+			req = <-ts.backend.CommandExecRequestChan
+
+			// the command returned an error, the configuration is still pending
+			assert.NoError(HandleCommandExecResponse(context.Background(), gw.GatewayCommandExecResponse{
+				GatewayId: ts.gateway.GatewayID[:],
+				ExecId:    req.ExecId,
+				Error:     "exit status 1",
+			}))
+			state, err := storage.GetGatewayConfigState(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal(gp.GetVersion(), state.PendingVersion)
+
+			// the command has been executed, the configuration is applied
+			assert.NoError(HandleCommandExecResponse(context.Background(), gw.GatewayCommandExecResponse{
+				GatewayId: ts.gateway.GatewayID[:],
+				ExecId:    req.ExecId,
+			}))
+			state, err = storage.GetGatewayConfigState(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal(gp.GetVersion(), state.AppliedVersion)
+			assert.Equal("", state.PendingVersion)
+
+			// the configuration is up-to-date
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId: ts.gateway.GatewayID[:],
+			}))
+			assert.Len(ts.backend.CommandExecRequestChan, 0)
+		})
+
+		t.Run("Basics Station", func(t *testing.T) {
+			assert := require.New(t)
+
+			state := storage.GatewayConfigState{
+				GatewayID:  ts.gateway.GatewayID,
+				Translator: translator.BasicStation,
+			}
+			assert.NoError(storage.SaveGatewayConfigState(context.Background(), storage.DB(), &state))
+			assert.NoError(storage.FlushGatewayConfigStateCache(context.Background(), ts.gateway.GatewayID))
+
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId: ts.gateway.GatewayID[:],
+			}))
+
+			cmd := <-ts.backend.RawCommandChan
+			assert.Equal(ts.gateway.GatewayID[:], cmd.GatewayId)
+			assert.Contains(string(cmd.Payload), `"msgtype":"router_config"`)
+
+			// the configuration is marked as applied once sent, as the
+			// gateway does not report its version
+			state, err := storage.GetGatewayConfigState(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal(gp.GetVersion(), state.AppliedVersion)
+			assert.Equal("", state.PendingVersion)
+
+			// it must not be sent again
+			configPendingTimeout = 0
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId: ts.gateway.GatewayID[:],
+			}))
+			configPendingTimeout = time.Hour
+			assert.Len(ts.backend.RawCommandChan, 0)
 		})
 
+		t.Run("Overrides with channels not in the gateway-profile", func(t *testing.T) {
+			assert := require.New(t)
+
+			state := storage.GatewayConfigState{
+				GatewayID:  ts.gateway.GatewayID,
+				Translator: translator.Concentratord,
+			}
+			assert.NoError(storage.SaveGatewayConfigState(context.Background(), storage.DB(), &state))
+			assert.NoError(storage.FlushGatewayConfigStateCache(context.Background(), ts.gateway.GatewayID))
+
+			tests := []struct {
+				name        string
+				channels    []uint32
+				frequencies []uint32
+			}{
+				{
+					name:        "unknown channels are ignored",
+					channels:    []uint32{868100000, 869525000},
+					frequencies: []uint32{868100000},
+				},
+				{
+					name:        "no known channels falls back to all gateway-profile channels",
+					channels:    []uint32{869525000},
+					frequencies: []uint32{868100000, 868300000, 868500000, 867100000, 868800000},
+				},
+			}
+
+			for _, tst := range tests {
+				t.Run(tst.name, func(t *testing.T) {
+					assert := require.New(t)
+
+					ts.gateway.Overrides = storage.GatewayOverrides{Channels: tst.channels}
+					assert.NoError(storage.UpdateGateway(context.Background(), storage.DB(), &ts.gateway))
+
+					assert.NoError(Handle(context.Background(), gw.GatewayStats{
+						GatewayId: ts.gateway.GatewayID[:],
+						MetaData: map[string]string{
+							"concentratord_version": "3.3.0",
+						},
+					}))
+
+					gwConfig := <-ts.backend.GatewayConfigPacketChan
+					var frequencies []uint32
+					for _, c := range gwConfig.Channels {
+						frequencies = append(frequencies, c.Frequency)
+					}
+					assert.Equal(tst.frequencies, frequencies)
+				})
+			}
+
+			ts.gateway.Overrides = storage.GatewayOverrides{}
+			assert.NoError(storage.UpdateGateway(context.Background(), storage.DB(), &ts.gateway))
+		})
 	})
 }
 
@@ -314,6 +490,75 @@ func (ts *GatewayStatsTestSuite) TestStats() {
 	}, asReq)
 }
 
+func (ts *GatewayStatsTestSuite) TestTimeSync() {
+	tests := []struct {
+		Name     string
+		MetaData map[string]string
+		Expected map[lorawan.EUI64]bool
+	}{
+		{
+			Name:     "not reported",
+			MetaData: map[string]string{"foo": "bar"},
+			Expected: map[lorawan.EUI64]bool{},
+		},
+		{
+			Name:     "gps locked",
+			MetaData: map[string]string{"gps_locked": "true"},
+			Expected: map[lorawan.EUI64]bool{ts.gateway.GatewayID: true},
+		},
+		{
+			Name:     "not time synced",
+			MetaData: map[string]string{"time_synced": "false"},
+			Expected: map[lorawan.EUI64]bool{ts.gateway.GatewayID: false},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			storage.RedisClient().FlushAll(context.Background())
+
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId: ts.gateway.GatewayID[:],
+				MetaData:  tst.MetaData,
+			}))
+			<-ts.asClient.HandleGatewayStatsChan
+
+			timeSync, err := storage.GetGatewayTimeSyncForGatewayIDs(context.Background(), []lorawan.EUI64{ts.gateway.GatewayID})
+			assert.NoError(err)
+			assert.Equal(tst.Expected, timeSync)
+		})
+	}
+}
+
 func TestGatewayStats(t *testing.T) {
 	suite.Run(t, new(GatewayStatsTestSuite))
 }
+
+func TestFilterChannels(t *testing.T) {
+	assert := require.New(t)
+
+	channels := []*gw.ChannelConfiguration{
+		{Frequency: 868100000},
+		{Frequency: 868300000},
+		{Frequency: 868500000},
+	}
+
+	out, ignored := filterChannels(channels, nil)
+	assert.Equal(channels, out)
+	assert.Len(ignored, 0)
+
+	out, ignored = filterChannels(channels, []uint32{868500000, 868100000})
+	assert.Equal([]*gw.ChannelConfiguration{channels[0], channels[2]}, out)
+	assert.Len(ignored, 0)
+
+	// frequencies not in the gateway-profile are ignored
+	out, ignored = filterChannels(channels, []uint32{868300000, 867100000})
+	assert.Equal([]*gw.ChannelConfiguration{channels[1]}, out)
+	assert.Equal([]uint32{867100000}, ignored)
+
+	// none of the frequencies are in the gateway-profile, fall back to all
+	out, ignored = filterChannels(channels, []uint32{867100000})
+	assert.Equal(channels, out)
+	assert.Equal([]uint32{867100000}, ignored)
+}
@@ -9,11 +9,13 @@ import (
 
 	"github.com/brocaar/chirpstack-api/go/v3/gw"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/gateway/stats"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 )
 
-// StatsHandler represents a stat handler for incoming gateway stats.
+// StatsHandler represents a stat handler for incoming gateway stats and
+// command execution responses (used for the gateway configuration state).
 type StatsHandler struct {
 	wg sync.WaitGroup
 }
@@ -41,6 +43,9 @@ func (s *StatsHandler) Start() error {
 				ctx := context.Background()
 				ctx = context.WithValue(ctx, logging.ContextIDKey, statsID)
 
+				config.RLock()
+				defer config.RUnlock()
+
 				if err := stats.Handle(ctx, gwStats); err != nil {
 					log.WithError(err).WithFields(log.Fields{
 						"ctx_id": ctx.Value(logging.ContextIDKey),
@@ -50,6 +55,39 @@ func (s *StatsHandler) Start() error {
 			}(gwStats)
 		}
 	}()
+
+	go func() {
+		s.wg.Add(1)
+		defer s.wg.Done()
+
+		r, ok := gateway.Backend().(gateway.CommandExecResponseReceiver)
+		if !ok {
+			return
+		}
+
+		for resp := range r.CommandExecResponseChan() {
+			go func(resp gw.GatewayCommandExecResponse) {
+				s.wg.Add(1)
+				defer s.wg.Done()
+
+				var execID uuid.UUID
+				copy(execID[:], resp.ExecId)
+
+				ctx := context.Background()
+				ctx = context.WithValue(ctx, logging.ContextIDKey, execID)
+
+				config.RLock()
+				defer config.RUnlock()
+
+				if err := stats.HandleCommandExecResponse(ctx, resp); err != nil {
+					log.WithError(err).WithFields(log.Fields{
+						"ctx_id": ctx.Value(logging.ContextIDKey),
+					}).Error("gateway: handle command execution response error")
+				}
+			}(resp)
+		}
+	}()
+
 	return nil
 }
 
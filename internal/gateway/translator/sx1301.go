@@ -0,0 +1,411 @@
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/chirpstack-api/go/v3/common"
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+const (
+	// sx1301RadioBandwidth defines the (Hz) bandwidth of a SX1301 radio in
+	// which the channels must fit.
+	sx1301RadioBandwidth = 925000
+
+	sx1301Radios          = 2
+	sx1301MultiSFChannels = 8
+)
+
+// sx1301Radio defines the configuration of a SX1301 radio.
+type sx1301Radio struct {
+	Enable bool   `json:"enable"`
+	Freq   uint32 `json:"freq,omitempty"`
+}
+
+// sx1301Channel defines the configuration of a SX1301 channel.
+type sx1301Channel struct {
+	Enable       bool   `json:"enable"`
+	Radio        int    `json:"radio"`
+	IF           int    `json:"if"`
+	Bandwidth    uint32 `json:"bandwidth,omitempty"`
+	SpreadFactor uint32 `json:"spread_factor,omitempty"`
+	DataRate     uint32 `json:"datarate,omitempty"`
+}
+
+// sx1301LBTChannel defines the listen-before-talk configuration of a channel.
+type sx1301LBTChannel struct {
+	Frequency uint32 `json:"freq_hz"`
+	ScanTime  int    `json:"scan_time_us"`
+}
+
+// sx1301LBT defines the listen-before-talk configuration.
+type sx1301LBT struct {
+	Enable     bool               `json:"enable"`
+	RSSITarget int                `json:"rssi_target,omitempty"`
+	Channels   []sx1301LBTChannel `json:"chan_cfg,omitempty"`
+}
+
+// getSX1301Conf returns the SX1301 configuration (the radio and channel
+// sections) for the given gateway configuration. Unused radios and channels
+// are explicitly disabled. The radio frequencies of the first board are
+// taken from the overrides when set. Only a single board is rendered, the
+// radio frequencies of the other boards are rejected by the overrides
+// validation.
+func getSX1301Conf(conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) (map[string]interface{}, error) {
+	var multiSF, loraStd, fsk []*gw.ChannelConfiguration
+
+	for _, c := range conf.Channels {
+		switch c.Modulation {
+		case common.Modulation_LORA:
+			modConf := c.GetLoraModulationConfig()
+			if modConf == nil {
+				return nil, fmt.Errorf("channel %d: lora modulation config must not be nil", c.Frequency)
+			}
+
+			if modConf.Bandwidth == 125 {
+				multiSF = append(multiSF, c)
+			} else {
+				loraStd = append(loraStd, c)
+			}
+		case common.Modulation_FSK:
+			if c.GetFskModulationConfig() == nil {
+				return nil, fmt.Errorf("channel %d: fsk modulation config must not be nil", c.Frequency)
+			}
+			fsk = append(fsk, c)
+		default:
+			return nil, fmt.Errorf("channel %d: unsupported modulation: %s", c.Frequency, c.Modulation)
+		}
+	}
+
+	if len(multiSF) > sx1301MultiSFChannels {
+		return nil, fmt.Errorf("max. %d multi-SF LoRa channels are supported, got %d", sx1301MultiSFChannels, len(multiSF))
+	}
+	if len(loraStd) > 1 {
+		return nil, fmt.Errorf("max. 1 single-SF LoRa channel is supported, got %d", len(loraStd))
+	}
+	if len(fsk) > 1 {
+		return nil, fmt.Errorf("max. 1 FSK channel is supported, got %d", len(fsk))
+	}
+
+	var radios []uint32
+	if len(overrides.Boards) != 0 && len(overrides.Boards[0].RadioFrequencies) != 0 {
+		radios = overrides.Boards[0].RadioFrequencies
+		if len(radios) > sx1301Radios {
+			return nil, fmt.Errorf("max. %d radio frequencies are supported, got %d", sx1301Radios, len(radios))
+		}
+	} else {
+		var err error
+		radios, err = getSX1301RadioFrequencies(conf.Channels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make(map[string]interface{})
+
+	for i := 0; i < sx1301Radios; i++ {
+		r := sx1301Radio{}
+		if i < len(radios) {
+			r = sx1301Radio{
+				Enable: true,
+				Freq:   radios[i],
+			}
+		}
+		out[fmt.Sprintf("radio_%d", i)] = r
+	}
+
+	sort.Slice(multiSF, func(i, j int) bool {
+		return multiSF[i].Frequency < multiSF[j].Frequency
+	})
+
+	for i := 0; i < sx1301MultiSFChannels; i++ {
+		ch := sx1301Channel{}
+		if i < len(multiSF) {
+			var err error
+			if ch, err = getSX1301Channel(radios, multiSF[i]); err != nil {
+				return nil, err
+			}
+		}
+		out[fmt.Sprintf("chan_multiSF_%d", i)] = ch
+	}
+
+	loraStdCh := sx1301Channel{}
+	for _, c := range loraStd {
+		modConf := c.GetLoraModulationConfig()
+		if len(modConf.SpreadingFactors) != 1 {
+			return nil, fmt.Errorf("channel %d: single-SF LoRa channel must have exactly 1 spreading-factor", c.Frequency)
+		}
+
+		var err error
+		if loraStdCh, err = getSX1301Channel(radios, c); err != nil {
+			return nil, err
+		}
+		loraStdCh.Bandwidth = modConf.Bandwidth * 1000
+		loraStdCh.SpreadFactor = modConf.SpreadingFactors[0]
+	}
+	out["chan_Lora_std"] = loraStdCh
+
+	fskCh := sx1301Channel{}
+	for _, c := range fsk {
+		modConf := c.GetFskModulationConfig()
+
+		var err error
+		if fskCh, err = getSX1301Channel(radios, c); err != nil {
+			return nil, err
+		}
+		fskCh.Bandwidth = modConf.Bandwidth * 1000
+		fskCh.DataRate = modConf.Bitrate
+	}
+	out["chan_FSK"] = fskCh
+
+	return out, nil
+}
+
+// getSX1301RadioFrequencies returns the (center) frequencies of the radios
+// needed to receive the given channels.
+func getSX1301RadioFrequencies(channels []*gw.ChannelConfiguration) ([]uint32, error) {
+	type edges struct {
+		min uint32
+		max uint32
+	}
+
+	var chEdges []edges
+	for _, c := range channels {
+		bw := getChannelBandwidth(c)
+		chEdges = append(chEdges, edges{
+			min: c.Frequency - bw/2,
+			max: c.Frequency + bw/2,
+		})
+	}
+
+	sort.Slice(chEdges, func(i, j int) bool {
+		return chEdges[i].min < chEdges[j].min
+	})
+
+	var radios []edges
+	for _, e := range chEdges {
+		if len(radios) != 0 {
+			r := &radios[len(radios)-1]
+			if e.max-r.min <= sx1301RadioBandwidth {
+				if e.max > r.max {
+					r.max = e.max
+				}
+				continue
+			}
+		}
+
+		radios = append(radios, e)
+	}
+
+	if len(radios) > sx1301Radios {
+		return nil, fmt.Errorf("channels do not fit within the bandwidth of %d radios", sx1301Radios)
+	}
+
+	var out []uint32
+	for _, r := range radios {
+		out = append(out, (r.min+r.max)/2)
+	}
+
+	return out, nil
+}
+
+// getSX1301Channel returns the SX1301 channel for the given channel, using
+// the radio within which bandwidth the channel is located.
+func getSX1301Channel(radios []uint32, c *gw.ChannelConfiguration) (sx1301Channel, error) {
+	halfBW := int(getChannelBandwidth(c) / 2)
+
+	for i, r := range radios {
+		ifFreq := int(c.Frequency) - int(r)
+		if ifFreq-halfBW >= -sx1301RadioBandwidth/2 && ifFreq+halfBW <= sx1301RadioBandwidth/2 {
+			return sx1301Channel{
+				Enable: true,
+				Radio:  i,
+				IF:     ifFreq,
+			}, nil
+		}
+	}
+
+	return sx1301Channel{}, fmt.Errorf("channel %d does not fit within the bandwidth of the radios", c.Frequency)
+}
+
+func getChannelBandwidth(c *gw.ChannelConfiguration) uint32 {
+	if modConf := c.GetLoraModulationConfig(); modConf != nil {
+		return modConf.Bandwidth * 1000
+	}
+
+	if modConf := c.GetFskModulationConfig(); modConf != nil {
+		return modConf.Bandwidth * 1000
+	}
+
+	return 0
+}
+
+// RenderPacketForwarderConfig renders the SX1301_conf and gateway_conf
+// sections of the Semtech UDP packet-forwarder configuration (global_conf.json)
+// for the given gateway configuration and overrides.
+//
+// The rendered configuration is partial, as it does not contain the hardware
+// specific settings (e.g. the radio type, rssi_offset, tx_enable,
+// tx_freq_min / tx_freq_max, tx_notch_freq and the tx_lut_* objects). The
+// packet-forwarder configuration command must deep-merge it into the existing
+// global_conf.json, retaining the keys which are not rendered.
+func RenderPacketForwarderConfig(conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) ([]byte, error) {
+	sx1301Conf, err := getSX1301Conf(conf, overrides)
+	if err != nil {
+		return nil, errors.Wrap(err, "get sx1301 conf error")
+	}
+	sx1301Conf["lbt_cfg"] = getSX1301LBT(conf, overrides)
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], conf.GatewayId)
+
+	gatewayConf := map[string]interface{}{
+		"gateway_ID": gatewayID.String(),
+	}
+
+	if conf.StatsInterval != nil {
+		d, err := ptypes.Duration(conf.StatsInterval)
+		if err != nil {
+			return nil, errors.Wrap(err, "stats interval error")
+		}
+		gatewayConf["stat_interval"] = int(d.Seconds())
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"SX1301_conf":  sx1301Conf,
+		"gateway_conf": gatewayConf,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+
+	return b, nil
+}
+
+// getSX1301LBT returns the listen-before-talk configuration. LBT is
+// configured for the (uplink) channels of the gateway configuration, as
+// these are also used for RX1 downlinks in the regions requiring LBT.
+func getSX1301LBT(conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) sx1301LBT {
+	if overrides.LBT == nil {
+		return sx1301LBT{}
+	}
+
+	out := sx1301LBT{
+		Enable:     true,
+		RSSITarget: overrides.LBT.RSSITarget,
+	}
+
+	for _, c := range conf.Channels {
+		if len(out.Channels) == sx1301MultiSFChannels {
+			break
+		}
+
+		out.Channels = append(out.Channels, sx1301LBTChannel{
+			Frequency: c.Frequency,
+			ScanTime:  overrides.LBT.ScanTime,
+		})
+	}
+
+	return out
+}
+
+// stationRegions contains the Basics Station region and frequency range
+// by band name.
+var stationRegions = map[loraband.Name]struct {
+	region    string
+	freqRange [2]uint32
+}{
+	loraband.EU868:      {"EU863", [2]uint32{863000000, 870000000}},
+	loraband.EU_863_870: {"EU863", [2]uint32{863000000, 870000000}},
+	loraband.US915:      {"US902", [2]uint32{902000000, 928000000}},
+	loraband.US_902_928: {"US902", [2]uint32{902000000, 928000000}},
+	loraband.AU915:      {"AU915", [2]uint32{915000000, 928000000}},
+	loraband.AU_915_928: {"AU915", [2]uint32{915000000, 928000000}},
+	loraband.AS923:      {"AS923", [2]uint32{915000000, 928000000}},
+	loraband.AS_923:     {"AS923", [2]uint32{915000000, 928000000}},
+	loraband.CN470:      {"CN470", [2]uint32{470000000, 510000000}},
+	loraband.CN_470_510: {"CN470", [2]uint32{470000000, 510000000}},
+	loraband.IN865:      {"IN865", [2]uint32{865000000, 867000000}},
+	loraband.IN_865_867: {"IN865", [2]uint32{865000000, 867000000}},
+	loraband.KR920:      {"KR920", [2]uint32{920900000, 923300000}},
+	loraband.KR_920_923: {"KR920", [2]uint32{920900000, 923300000}},
+}
+
+// stationRouterConfig implements the Basics Station router_config message.
+type stationRouterConfig struct {
+	MsgType    string                   `json:"msgtype"`
+	Region     string                   `json:"region"`
+	HWSpec     string                   `json:"hwspec"`
+	FreqRange  [2]uint32                `json:"freq_range"`
+	DRs        [][3]int                 `json:"DRs"`
+	SX1301Conf []map[string]interface{} `json:"sx1301_conf"`
+}
+
+// RenderStationRouterConfig renders the Basics Station router_config message
+// for the given band, gateway configuration and overrides.
+func RenderStationRouterConfig(bandName loraband.Name, conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) ([]byte, error) {
+	region, ok := stationRegions[bandName]
+	if !ok {
+		return nil, fmt.Errorf("band %s is not supported by basic station", bandName)
+	}
+
+	sx1301Conf, err := getSX1301Conf(conf, overrides)
+	if err != nil {
+		return nil, errors.Wrap(err, "get sx1301 conf error")
+	}
+
+	rc := stationRouterConfig{
+		MsgType:    "router_config",
+		Region:     region.region,
+		HWSpec:     "sx1301/1",
+		FreqRange:  region.freqRange,
+		DRs:        getStationDataRates(),
+		SX1301Conf: []map[string]interface{}{sx1301Conf},
+	}
+
+	b, err := json.Marshal(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+
+	return b, nil
+}
+
+// getStationDataRates returns the data-rates of the band in the Basics
+// Station format ([SF, BW, DNONLY]). FSK is defined as SF 0 and unused (or
+// unsupported) data-rates as SF -1.
+func getStationDataRates() [][3]int {
+	var out [][3]int
+
+	for i := 0; i < 16; i++ {
+		dr, err := band.Band().GetDataRate(i)
+		if err != nil {
+			out = append(out, [3]int{-1, 0, 0})
+			continue
+		}
+
+		switch dr.Modulation {
+		case loraband.LoRaModulation:
+			var dnOnly int
+			if idx, err := band.Band().GetDataRateIndex(true, dr); err != nil || idx != i {
+				dnOnly = 1
+			}
+			out = append(out, [3]int{dr.SpreadFactor, dr.Bandwidth, dnOnly})
+		case loraband.FSKModulation:
+			out = append(out, [3]int{0, 0, 0})
+		default:
+			out = append(out, [3]int{-1, 0, 0})
+		}
+	}
+
+	return out
+}
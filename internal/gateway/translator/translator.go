@@ -0,0 +1,261 @@
+// Package translator implements the translation of the gateway configuration
+// (derived from the gateway-profile) into the configuration formats of the
+// different gateway implementations.
+package translator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/gateway"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+// Built-in translators.
+const (
+	Concentratord   = "concentratord"
+	PacketForwarder = "packet_forwarder"
+	BasicStation    = "basic_station"
+)
+
+// Translator renders the gateway configuration into the configuration
+// format of the gateway and sends it to the gateway.
+type Translator interface {
+	// Send sends the given gateway configuration to the gateway using the
+	// given gateway backend. The overrides contain the per-gateway settings
+	// which can not be expressed by the gateway configuration message.
+	Send(ctx context.Context, b gateway.Gateway, conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) error
+}
+
+// OverridesValidator is implemented by the translators which can not express
+// all the gateway overrides. Inexpressible overrides must be rejected, as the
+// gateway would otherwise report a configuration version which includes
+// these overrides, without applying them.
+type OverridesValidator interface {
+	// ValidateOverrides returns an error when the given overrides can not
+	// be expressed by the translator.
+	ValidateOverrides(overrides storage.GatewayOverrides) error
+}
+
+// AppliedOnSend is implemented by the translators for which the gateway does
+// not report the applied configuration version, nor returns a response on
+// the sent configuration. The configuration version is then marked as applied
+// once the configuration has been sent.
+type AppliedOnSend interface {
+	// AppliedOnSend returns true when the configuration must be marked as
+	// applied once sent.
+	AppliedOnSend() bool
+}
+
+var (
+	mu          sync.RWMutex
+	translators = map[string]Translator{
+		Concentratord:   &concentratordTranslator{},
+		PacketForwarder: &packetForwarderTranslator{},
+		BasicStation:    &basicStationTranslator{},
+	}
+
+	bandName               loraband.Name
+	packetForwarderCommand string
+	configPendingTimeout   time.Duration
+)
+
+// Setup configures the translator package.
+func Setup(c config.Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	bandName = c.NetworkServer.Band.Name
+	packetForwarderCommand = c.NetworkServer.Gateway.PacketForwarderConfigCommand
+	configPendingTimeout = c.NetworkServer.Gateway.ConfigPendingTimeout
+
+	return nil
+}
+
+// Register registers the given translator under the given name.
+func Register(name string, t Translator) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	translators[name] = t
+}
+
+// Get returns the translator for the given name.
+func Get(name string) (Translator, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	t, ok := translators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown translator: %s", name)
+	}
+
+	return t, nil
+}
+
+// Names returns the names of the registered translators.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var out []string
+	for name := range translators {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// ValidateOverrides validates that the given overrides can be expressed by
+// the translator with the given name. An empty name refers to the detected
+// translator, which is the Concentratord translator.
+func ValidateOverrides(name string, overrides storage.GatewayOverrides) error {
+	if name == "" {
+		name = Concentratord
+	}
+
+	t, err := Get(name)
+	if err != nil {
+		return err
+	}
+
+	if v, ok := t.(OverridesValidator); ok {
+		return v.ValidateOverrides(overrides)
+	}
+
+	return nil
+}
+
+// concentratordTranslator sends the gateway configuration as-is, as this is
+// natively supported by the ChirpStack Concentratord. The radio frequencies
+// and listen-before-talk overrides can not be expressed by the gateway
+// configuration.
+type concentratordTranslator struct{}
+
+func (t *concentratordTranslator) ValidateOverrides(overrides storage.GatewayOverrides) error {
+	for i, b := range overrides.Boards {
+		if len(b.RadioFrequencies) != 0 {
+			return fmt.Errorf("board %d: radio frequencies are not supported by the %s translator", i, Concentratord)
+		}
+	}
+
+	if overrides.LBT != nil {
+		return fmt.Errorf("lbt is not supported by the %s translator", Concentratord)
+	}
+
+	return nil
+}
+
+func (t *concentratordTranslator) Send(ctx context.Context, b gateway.Gateway, conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) error {
+	if err := b.SendGatewayConfigPacket(conf); err != nil {
+		return errors.Wrap(err, "send gateway-configuration packet error")
+	}
+
+	return nil
+}
+
+// packetForwarderTranslator renders the Semtech UDP packet-forwarder
+// configuration and sends it as stdin of the configured (ChirpStack Gateway
+// Bridge) command. The sent version is stored by execution ID, so that it can
+// be marked as applied when the command execution response is received.
+type packetForwarderTranslator struct{}
+
+func (t *packetForwarderTranslator) Send(ctx context.Context, b gateway.Gateway, conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) error {
+	cs, ok := b.(gateway.CommandSender)
+	if !ok {
+		return errors.New("gateway backend does not support sending commands")
+	}
+
+	pl, err := RenderPacketForwarderConfig(conf, overrides)
+	if err != nil {
+		return errors.Wrap(err, "render packet-forwarder config error")
+	}
+
+	execID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+
+	mu.RLock()
+	command := packetForwarderCommand
+	ttl := configPendingTimeout
+	mu.RUnlock()
+
+	if err := storage.SaveGatewayConfigExec(ctx, execID, conf.Version, ttl); err != nil {
+		return errors.Wrap(err, "save gateway config exec error")
+	}
+
+	if err := cs.SendGatewayCommandExecRequest(gw.GatewayCommandExecRequest{
+		GatewayId: conf.GatewayId,
+		Command:   command,
+		ExecId:    execID[:],
+		Stdin:     pl,
+		Environment: map[string]string{
+			"CONFIG_VERSION": conf.Version,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "send command execution request error")
+	}
+
+	return nil
+}
+
+// basicStationTranslator renders the Basics Station router_config message
+// and sends it using the raw packet-forwarder command. The listen-before-talk
+// overrides can not be expressed by the router_config message.
+type basicStationTranslator struct{}
+
+// AppliedOnSend returns true, as the Basics Station does not report its
+// configuration version and the raw packet-forwarder command has no response.
+func (t *basicStationTranslator) AppliedOnSend() bool {
+	return true
+}
+
+func (t *basicStationTranslator) ValidateOverrides(overrides storage.GatewayOverrides) error {
+	if overrides.LBT != nil {
+		return fmt.Errorf("lbt is not supported by the %s translator", BasicStation)
+	}
+
+	return nil
+}
+
+func (t *basicStationTranslator) Send(ctx context.Context, b gateway.Gateway, conf gw.GatewayConfiguration, overrides storage.GatewayOverrides) error {
+	cs, ok := b.(gateway.CommandSender)
+	if !ok {
+		return errors.New("gateway backend does not support sending commands")
+	}
+
+	mu.RLock()
+	name := bandName
+	mu.RUnlock()
+
+	pl, err := RenderStationRouterConfig(name, conf, overrides)
+	if err != nil {
+		return errors.Wrap(err, "render router_config error")
+	}
+
+	rawID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+
+	if err := cs.SendRawPacketForwarderCommand(gw.RawPacketForwarderCommand{
+		GatewayId: conf.GatewayId,
+		RawId:     rawID[:],
+		Payload:   pl,
+	}); err != nil {
+		return errors.Wrap(err, "send raw packet-forwarder command error")
+	}
+
+	return nil
+}
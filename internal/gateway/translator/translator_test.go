@@ -0,0 +1,275 @@
+package translator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-api/go/v3/common"
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+func loraChannel(freq, bw uint32, sfs ...uint32) *gw.ChannelConfiguration {
+	return &gw.ChannelConfiguration{
+		Frequency:  freq,
+		Modulation: common.Modulation_LORA,
+		ModulationConfig: &gw.ChannelConfiguration_LoraModulationConfig{
+			LoraModulationConfig: &gw.LoRaModulationConfig{
+				Bandwidth:        bw,
+				SpreadingFactors: sfs,
+			},
+		},
+	}
+}
+
+func TestGetSX1301RadioFrequencies(t *testing.T) {
+	tests := []struct {
+		name           string
+		channels       []*gw.ChannelConfiguration
+		expectedRadios []uint32
+		expectedError  bool
+	}{
+		{
+			name: "single radio",
+			channels: []*gw.ChannelConfiguration{
+				loraChannel(868100000, 125),
+				loraChannel(868300000, 125),
+				loraChannel(868500000, 125),
+			},
+			expectedRadios: []uint32{868300000},
+		},
+		{
+			name: "two radios",
+			channels: []*gw.ChannelConfiguration{
+				loraChannel(868100000, 125),
+				loraChannel(868300000, 125),
+				loraChannel(868500000, 125),
+				loraChannel(867100000, 125),
+				loraChannel(867300000, 125),
+				loraChannel(867500000, 125),
+				loraChannel(867700000, 125),
+				loraChannel(867900000, 125),
+			},
+			expectedRadios: []uint32{867500000, 868300000},
+		},
+		{
+			name: "does not fit",
+			channels: []*gw.ChannelConfiguration{
+				loraChannel(863100000, 125),
+				loraChannel(865100000, 125),
+				loraChannel(868100000, 125),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			radios, err := getSX1301RadioFrequencies(tst.channels)
+			if tst.expectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.expectedRadios, radios)
+		})
+	}
+}
+
+func TestGetSX1301Conf(t *testing.T) {
+	t.Run("EU868", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf, err := getSX1301Conf(gw.GatewayConfiguration{
+			Channels: []*gw.ChannelConfiguration{
+				loraChannel(868300000, 125, 7, 8, 9, 10, 11, 12),
+				loraChannel(868100000, 125, 7, 8, 9, 10, 11, 12),
+				loraChannel(868500000, 125, 7, 8, 9, 10, 11, 12),
+				loraChannel(868300000, 250, 7),
+			},
+		}, storage.GatewayOverrides{})
+		assert.NoError(err)
+
+		assert.Equal(sx1301Radio{Enable: true, Freq: 868300000}, conf["radio_0"])
+		assert.Equal(sx1301Radio{}, conf["radio_1"])
+		assert.Equal(sx1301Channel{Enable: true, Radio: 0, IF: -200000}, conf["chan_multiSF_0"])
+		assert.Equal(sx1301Channel{Enable: true, Radio: 0, IF: 0}, conf["chan_multiSF_1"])
+		assert.Equal(sx1301Channel{Enable: true, Radio: 0, IF: 200000}, conf["chan_multiSF_2"])
+		assert.Equal(sx1301Channel{}, conf["chan_multiSF_7"])
+		assert.Equal(sx1301Channel{Enable: true, Radio: 0, IF: 0, Bandwidth: 250000, SpreadFactor: 7}, conf["chan_Lora_std"])
+		assert.Equal(sx1301Channel{}, conf["chan_FSK"])
+	})
+
+	t.Run("Too many single-SF channels", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := getSX1301Conf(gw.GatewayConfiguration{
+			Channels: []*gw.ChannelConfiguration{
+				loraChannel(868300000, 250, 7),
+				loraChannel(868500000, 250, 7),
+			},
+		}, storage.GatewayOverrides{})
+		assert.Error(err)
+	})
+
+	t.Run("Radio frequency overrides", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf, err := getSX1301Conf(gw.GatewayConfiguration{
+			Channels: []*gw.ChannelConfiguration{
+				loraChannel(868100000, 125, 7, 8, 9, 10, 11, 12),
+			},
+		}, storage.GatewayOverrides{
+			Boards: []storage.GatewayBoardOverrides{
+				{RadioFrequencies: []uint32{868500000, 867500000}},
+			},
+		})
+		assert.NoError(err)
+
+		assert.Equal(sx1301Radio{Enable: true, Freq: 868500000}, conf["radio_0"])
+		assert.Equal(sx1301Radio{Enable: true, Freq: 867500000}, conf["radio_1"])
+		assert.Equal(sx1301Channel{Enable: true, Radio: 0, IF: -400000}, conf["chan_multiSF_0"])
+	})
+
+	t.Run("Channel outside radio overrides", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := getSX1301Conf(gw.GatewayConfiguration{
+			Channels: []*gw.ChannelConfiguration{
+				loraChannel(868100000, 125, 7, 8, 9, 10, 11, 12),
+			},
+		}, storage.GatewayOverrides{
+			Boards: []storage.GatewayBoardOverrides{
+				{RadioFrequencies: []uint32{867000000}},
+			},
+		})
+		assert.Error(err)
+	})
+}
+
+func TestRenderPacketForwarderConfigLBT(t *testing.T) {
+	assert := require.New(t)
+
+	conf := gw.GatewayConfiguration{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Channels: []*gw.ChannelConfiguration{
+			loraChannel(868100000, 125, 7, 8, 9, 10, 11, 12),
+			loraChannel(868300000, 125, 7, 8, 9, 10, 11, 12),
+		},
+	}
+
+	var out struct {
+		SX1301Conf struct {
+			LBT sx1301LBT `json:"lbt_cfg"`
+		} `json:"SX1301_conf"`
+	}
+
+	b, err := RenderPacketForwarderConfig(conf, storage.GatewayOverrides{})
+	assert.NoError(err)
+	assert.NoError(json.Unmarshal(b, &out))
+	assert.Equal(sx1301LBT{}, out.SX1301Conf.LBT)
+
+	b, err = RenderPacketForwarderConfig(conf, storage.GatewayOverrides{
+		LBT: &storage.GatewayLBT{RSSITarget: -80, ScanTime: 128},
+	})
+	assert.NoError(err)
+	assert.NoError(json.Unmarshal(b, &out))
+	assert.Equal(sx1301LBT{
+		Enable:     true,
+		RSSITarget: -80,
+		Channels: []sx1301LBTChannel{
+			{Frequency: 868100000, ScanTime: 128},
+			{Frequency: 868300000, ScanTime: 128},
+		},
+	}, out.SX1301Conf.LBT)
+}
+
+func TestRenderStationRouterConfig(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(band.Setup(test.GetConfig()))
+
+	t.Run("Unsupported band", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := RenderStationRouterConfig(loraband.ISM2400, gw.GatewayConfiguration{}, storage.GatewayOverrides{})
+		assert.Error(err)
+	})
+
+	t.Run("EU868", func(t *testing.T) {
+		assert := require.New(t)
+
+		b, err := RenderStationRouterConfig(loraband.EU868, gw.GatewayConfiguration{
+			Channels: []*gw.ChannelConfiguration{
+				loraChannel(868100000, 125, 7, 8, 9, 10, 11, 12),
+			},
+		}, storage.GatewayOverrides{})
+		assert.NoError(err)
+
+		var rc stationRouterConfig
+		assert.NoError(json.Unmarshal(b, &rc))
+		assert.Equal("router_config", rc.MsgType)
+		assert.Equal("EU863", rc.Region)
+		assert.Equal([2]uint32{863000000, 870000000}, rc.FreqRange)
+		assert.Len(rc.DRs, 16)
+		assert.Equal([3]int{12, 125, 0}, rc.DRs[0])
+		assert.Equal([3]int{7, 250, 0}, rc.DRs[6])
+		assert.Equal([3]int{0, 0, 0}, rc.DRs[7])
+		assert.Equal([3]int{-1, 0, 0}, rc.DRs[15])
+		assert.Len(rc.SX1301Conf, 1)
+	})
+}
+
+func TestValidateOverrides(t *testing.T) {
+	radios := storage.GatewayOverrides{
+		Boards: []storage.GatewayBoardOverrides{
+			{RadioFrequencies: []uint32{867500000, 868500000}},
+		},
+	}
+	lbt := storage.GatewayOverrides{
+		LBT: &storage.GatewayLBT{RSSITarget: -80, ScanTime: 128},
+	}
+	downlink := storage.GatewayOverrides{
+		AntennaGain: 3,
+		Boards: []storage.GatewayBoardOverrides{
+			{DownlinkDisabled: true},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		overrides storage.GatewayOverrides
+		expected  bool
+	}{
+		{"", downlink, true},
+		{"", radios, false},
+		{"", lbt, false},
+		{Concentratord, radios, false},
+		{PacketForwarder, radios, true},
+		{PacketForwarder, lbt, true},
+		{BasicStation, radios, true},
+		{BasicStation, lbt, false},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			err := ValidateOverrides(tst.name, tst.overrides)
+			if tst.expected {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		})
+	}
+
+	assert := require.New(t)
+	assert.Error(ValidateOverrides("unknown", storage.GatewayOverrides{}))
+}
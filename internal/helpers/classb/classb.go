@@ -101,3 +101,37 @@ func GetNextPingSlotAfter(afterGPSEpochTS time.Duration, devAddr lorawan.DevAddr
 		beaconStart += beaconPeriod
 	}
 }
+
+// GetNextPingSlotAfterForDuration returns the next ping-slot occuring after
+// the given gps epoch timestamp, for which a transmission of the given duration
+// does not overlap with the beacon-guard (and beacon-reserved) interval
+// of the next beacon.
+func GetNextPingSlotAfterForDuration(afterGPSEpochTS time.Duration, devAddr lorawan.DevAddr, pingNb int, duration time.Duration) (time.Duration, error) {
+	if duration > beaconWindow {
+		return 0, fmt.Errorf("duration must be <= %s", beaconWindow)
+	}
+
+	for {
+		gpsEpochTime, err := GetNextPingSlotAfter(afterGPSEpochTS, devAddr, pingNb)
+		if err != nil {
+			return 0, err
+		}
+
+		if !OverlapsBeaconGuard(gpsEpochTime, duration) {
+			return gpsEpochTime, nil
+		}
+
+		afterGPSEpochTS = gpsEpochTime
+	}
+}
+
+// OverlapsBeaconGuard returns true when a transmission starting at the given
+// gps epoch timestamp with the given duration overlaps with the
+// beacon-guard or beacon-reserved interval.
+func OverlapsBeaconGuard(gpsEpochTS time.Duration, duration time.Duration) bool {
+	beaconStart := gpsEpochTS - (gpsEpochTS % beaconPeriod)
+	windowStart := beaconStart + beaconReserved
+	guardStart := beaconStart + beaconPeriod - beaconGuard
+
+	return gpsEpochTS < windowStart || gpsEpochTS+duration > guardStart
+}
@@ -118,3 +118,43 @@ func TestGetNextPingSlotAfter(t *testing.T) {
 		}
 	}
 }
+
+func TestOverlapsBeaconGuard(t *testing.T) {
+	tests := []struct {
+		name     string
+		ts       time.Duration
+		duration time.Duration
+		overlaps bool
+	}{
+		{"beacon-reserved", beaconPeriod + time.Second, time.Millisecond, true},
+		{"start of beacon-window", beaconPeriod + beaconReserved, time.Second, false},
+		{"end of beacon-window", beaconPeriod + beaconReserved + beaconWindow - time.Second, time.Second, false},
+		{"overlaps beacon-guard", beaconPeriod + beaconReserved + beaconWindow - time.Second, 2 * time.Second, true},
+		{"beacon-guard", 2*beaconPeriod - time.Second, time.Millisecond, true},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.overlaps, OverlapsBeaconGuard(tst.ts, tst.duration))
+		})
+	}
+}
+
+func TestGetNextPingSlotAfterForDuration(t *testing.T) {
+	assert := require.New(t)
+	devAddr := lorawan.DevAddr{1, 2, 3, 4}
+
+	// Start right before the beacon-guard, the transmission of the
+	// next ping-slot(s) would overlap with the beacon-guard.
+	after := 10*beaconPeriod + beaconReserved + beaconWindow - 2*time.Second
+
+	ts, err := GetNextPingSlotAfterForDuration(after, devAddr, 128, 3*time.Second)
+	assert.NoError(err)
+	assert.True(ts > after)
+	assert.False(OverlapsBeaconGuard(ts, 3*time.Second))
+	assert.True(ts >= 11*beaconPeriod+beaconReserved)
+
+	_, err = GetNextPingSlotAfterForDuration(after, devAddr, 128, beaconPeriod)
+	assert.Error(err)
+}
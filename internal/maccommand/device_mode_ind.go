@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 	"github.com/liuhw0/lorawan"
 )
@@ -25,6 +26,8 @@ func handleDeviceModeInd(ctx context.Context, ds *storage.DeviceSession, block s
 		return nil, errors.Wrap(err, "get device error")
 	}
 
+	modeBefore := d.Mode
+
 	switch pl.Class {
 	case lorawan.DeviceModeClassA:
 		d.Mode = storage.DeviceModeA
@@ -38,6 +41,8 @@ func handleDeviceModeInd(ctx context.Context, ds *storage.DeviceSession, block s
 		return nil, errors.Wrap(err, "update device error")
 	}
 
+	audit.LogModeChange(ctx, d.DevEUI, modeBefore, d.Mode, "device_mode_ind")
+
 	return []storage.MACCommandBlock{
 		{
 			CID: lorawan.DeviceModeConf,
@@ -0,0 +1,114 @@
+package maccommand
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+// RequestDLChannels modifies the downlink (RX1) frequency of the channels
+// in case of changes between the current and wanted downlink frequencies
+// (by uplink channel index). To avoid generating mac-command blocks which
+// can't be sent, the max number of channels to modify must be given.
+// In case of no changes, nil is returned.
+func RequestDLChannels(devEUI lorawan.EUI64, maxChannels int, currentFrequencies, wantedFrequencies map[int]uint32) *storage.MACCommandBlock {
+	var out []lorawan.MACCommand
+
+	// sort by channel index
+	var wantedChannelNumbers []int
+	for i := range wantedFrequencies {
+		wantedChannelNumbers = append(wantedChannelNumbers, i)
+	}
+	sort.Ints(wantedChannelNumbers)
+
+	for _, i := range wantedChannelNumbers {
+		if currentFrequencies[i] == wantedFrequencies[i] {
+			continue
+		}
+
+		out = append(out, lorawan.MACCommand{
+			CID: lorawan.DLChannelReq,
+			Payload: &lorawan.DLChannelReqPayload{
+				ChIndex: uint8(i),
+				Freq:    wantedFrequencies[i],
+			},
+		})
+	}
+
+	if len(out) > maxChannels {
+		out = out[0:maxChannels]
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return &storage.MACCommandBlock{
+		CID:         lorawan.DLChannelReq,
+		MACCommands: storage.MACCommands(out),
+	}
+}
+
+func handleDLChannelAns(ctx context.Context, ds *storage.DeviceSession, block storage.MACCommandBlock, pending *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+	if len(block.MACCommands) == 0 {
+		return nil, errors.New("at least 1 mac-command expected, got none")
+	}
+
+	if pending == nil || len(pending.MACCommands) == 0 {
+		return nil, errors.New("expected pending mac-command")
+	}
+
+	if len(block.MACCommands) != len(pending.MACCommands) {
+		return nil, fmt.Errorf("received %d mac-command answers, but requested %d", len(block.MACCommands), len(pending.MACCommands))
+	}
+
+	for i := range block.MACCommands {
+		pl, ok := block.MACCommands[i].Payload.(*lorawan.DLChannelAnsPayload)
+		if !ok {
+			return nil, fmt.Errorf("expected *lorawan.DLChannelAnsPayload, got %T", block.MACCommands[i].Payload)
+		}
+
+		pendingPL, ok := pending.MACCommands[i].Payload.(*lorawan.DLChannelReqPayload)
+		if !ok {
+			return nil, fmt.Errorf("expected *lorawan.DLChannelReqPayload, got %T", pending.MACCommands[i].Payload)
+		}
+
+		if pl.UplinkFrequencyExists && pl.ChannelFrequencyOK {
+			// reset the error counter
+			delete(ds.MACCommandErrorCount, lorawan.DLChannelAns)
+
+			if ds.ExtraDownlinkFrequencies == nil {
+				ds.ExtraDownlinkFrequencies = make(map[int]uint32)
+			}
+			ds.ExtraDownlinkFrequencies[int(pendingPL.ChIndex)] = pendingPL.Freq
+
+			log.WithFields(log.Fields{
+				"frequency": pendingPL.Freq,
+				"channel":   pendingPL.ChIndex,
+				"ctx_id":    ctx.Value(logging.ContextIDKey),
+				"dev_eui":   ds.DevEUI,
+			}).Info("dl_channel request acknowledged")
+		} else {
+			// increase error counter
+			ds.MACCommandErrorCount[lorawan.DLChannelAns]++
+
+			log.WithFields(log.Fields{
+				"frequency":               pendingPL.Freq,
+				"channel":                 pendingPL.ChIndex,
+				"uplink_frequency_exists": pl.UplinkFrequencyExists,
+				"channel_frequency_ok":    pl.ChannelFrequencyOK,
+				"ctx_id":                  ctx.Value(logging.ContextIDKey),
+				"dev_eui":                 ds.DevEUI,
+			}).Warning("dl_channel request not acknowledged")
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,164 @@
+package maccommand
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/lorawan"
+)
+
+func TestDLChannel(t *testing.T) {
+	t.Run("DLChannelReq", func(t *testing.T) {
+		tests := []struct {
+			Name                    string
+			CurrentFrequencies      map[int]uint32
+			WantedFrequencies       map[int]uint32
+			ExpectedMACCommandBlock *storage.MACCommandBlock
+		}{
+			{
+				Name:               "no changes",
+				CurrentFrequencies: map[int]uint32{3: 869000000},
+				WantedFrequencies:  map[int]uint32{3: 869000000},
+			},
+			{
+				Name:               "set and modify downlink frequencies",
+				CurrentFrequencies: map[int]uint32{3: 869000000},
+				WantedFrequencies: map[int]uint32{
+					3: 869100000,
+					4: 869000000,
+					5: 869000000,
+					6: 869000000,
+				},
+				ExpectedMACCommandBlock: &storage.MACCommandBlock{
+					CID: lorawan.DLChannelReq,
+					MACCommands: storage.MACCommands{
+						{
+							CID:     lorawan.DLChannelReq,
+							Payload: &lorawan.DLChannelReqPayload{ChIndex: 3, Freq: 869100000},
+						},
+						{
+							CID:     lorawan.DLChannelReq,
+							Payload: &lorawan.DLChannelReqPayload{ChIndex: 4, Freq: 869000000},
+						},
+						{
+							CID:     lorawan.DLChannelReq,
+							Payload: &lorawan.DLChannelReqPayload{ChIndex: 5, Freq: 869000000},
+						},
+					},
+				},
+			},
+		}
+
+		for _, tst := range tests {
+			t.Run(tst.Name, func(t *testing.T) {
+				assert := require.New(t)
+				assert.Equal(tst.ExpectedMACCommandBlock, RequestDLChannels(lorawan.EUI64{}, 3, tst.CurrentFrequencies, tst.WantedFrequencies))
+			})
+		}
+	})
+
+	t.Run("handleDLChannelAns", func(t *testing.T) {
+		pending := &storage.MACCommandBlock{
+			CID: lorawan.DLChannelReq,
+			MACCommands: storage.MACCommands{
+				{
+					CID:     lorawan.DLChannelReq,
+					Payload: &lorawan.DLChannelReqPayload{ChIndex: 3, Freq: 869000000},
+				},
+			},
+		}
+
+		tests := []struct {
+			Name                    string
+			DeviceSession           storage.DeviceSession
+			ReceivedMACCommandBlock storage.MACCommandBlock
+			PendingMACCommandBlock  *storage.MACCommandBlock
+			ExpectedDeviceSession   storage.DeviceSession
+			ExpectedError           error
+		}{
+			{
+				Name: "set downlink frequency (ack)",
+				DeviceSession: storage.DeviceSession{
+					MACCommandErrorCount: map[lorawan.CID]int{
+						lorawan.DLChannelAns: 1,
+					},
+				},
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DLChannelAns,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.DLChannelAns,
+							Payload: &lorawan.DLChannelAnsPayload{
+								UplinkFrequencyExists: true,
+								ChannelFrequencyOK:    true,
+							},
+						},
+					},
+				},
+				PendingMACCommandBlock: pending,
+				ExpectedDeviceSession: storage.DeviceSession{
+					ExtraDownlinkFrequencies: map[int]uint32{3: 869000000},
+					MACCommandErrorCount:     map[lorawan.CID]int{},
+				},
+			},
+			{
+				Name: "set downlink frequency (nack)",
+				DeviceSession: storage.DeviceSession{
+					ExtraDownlinkFrequencies: map[int]uint32{},
+					MACCommandErrorCount:     map[lorawan.CID]int{},
+				},
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DLChannelAns,
+					MACCommands: storage.MACCommands{
+						{
+							CID: lorawan.DLChannelAns,
+							Payload: &lorawan.DLChannelAnsPayload{
+								UplinkFrequencyExists: false,
+								ChannelFrequencyOK:    true,
+							},
+						},
+					},
+				},
+				PendingMACCommandBlock: pending,
+				ExpectedDeviceSession: storage.DeviceSession{
+					ExtraDownlinkFrequencies: map[int]uint32{},
+					MACCommandErrorCount: map[lorawan.CID]int{
+						lorawan.DLChannelAns: 1,
+					},
+				},
+			},
+			{
+				Name: "no pending mac-command",
+				ReceivedMACCommandBlock: storage.MACCommandBlock{
+					CID: lorawan.DLChannelAns,
+					MACCommands: storage.MACCommands{
+						{
+							CID:     lorawan.DLChannelAns,
+							Payload: &lorawan.DLChannelAnsPayload{},
+						},
+					},
+				},
+				ExpectedError: errors.New("expected pending mac-command"),
+			},
+		}
+
+		for _, tst := range tests {
+			t.Run(tst.Name, func(t *testing.T) {
+				assert := require.New(t)
+
+				ans, err := handleDLChannelAns(context.Background(), &tst.DeviceSession, tst.ReceivedMACCommandBlock, tst.PendingMACCommandBlock)
+				if tst.ExpectedError != nil {
+					assert.Equal(tst.ExpectedError.Error(), err.Error())
+					return
+				}
+				assert.NoError(err)
+				assert.Nil(ans)
+				assert.Equal(tst.ExpectedDeviceSession, tst.DeviceSession)
+			})
+		}
+	})
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // handleLinkADRAns handles the ack of an ADR request
-func handleLinkADRAns(ctx context.Context, ds *storage.DeviceSession, block storage.MACCommandBlock, pendingBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
+func handleLinkADRAns(ctx context.Context, ds *storage.DeviceSession, dp storage.DeviceProfile, block storage.MACCommandBlock, pendingBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
 	if len(block.MACCommands) == 0 {
 		return nil, errors.New("at least 1 mac-command expected, got none")
 	}
@@ -32,7 +32,8 @@ func handleLinkADRAns(ctx context.Context, ds *storage.DeviceSession, block stor
 			return nil, fmt.Errorf("expected *lorawan.LinkADRAnsPayload, got %T", block.MACCommands[i].Payload)
 		}
 
-		if !pl.ChannelMaskACK {
+		// Some devices apply the channel-mask, but incorrectly nACK it.
+		if !pl.ChannelMaskACK && !dp.Quirks.IgnoreChannelMaskNACK {
 			channelMaskACK = false
 		}
 		if !pl.DataRateACK {
@@ -116,15 +117,12 @@ func handleLinkADRAns(ctx context.Context, ds *storage.DeviceSession, block stor
 		// increase the error counter
 		ds.MACCommandErrorCount[lorawan.LinkADRAns]++
 
-		// TODO: remove workaround once all RN2483 nodes have the issue below
-		// fixed.
-		//
-		// This is a workaround for the RN2483 firmware (1.0.3) which sends
-		// a nACK on TXPower 0 (this is incorrect behaviour, following the
-		// specs). It should ACK and operate at its maximum possible power
+		// This is a workaround for devices (e.g. RN2483 firmware 1.0.3)
+		// sending a nACK on TXPower 0 (this is incorrect behaviour, following
+		// the specs). It should ACK and operate at its maximum possible power
 		// when TXPower 0 is not supported. See also section 5.2 in the
 		// LoRaWAN specs.
-		if !powerACK && adrReq.TXPower == 0 {
+		if !powerACK && adrReq.TXPower == 0 && dp.Quirks.TXPower0Unsupported {
 			ds.TXPowerIndex = 1
 			ds.MinSupportedTXPowerIndex = 1
 		}
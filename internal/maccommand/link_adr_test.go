@@ -22,6 +22,7 @@ func TestLinkADR(t *testing.T) {
 		tests := []struct {
 			Name                  string
 			DeviceSession         storage.DeviceSession
+			DeviceProfile         storage.DeviceProfile
 			LinkADRReqPayload     *lorawan.LinkADRReqPayload
 			LinkADRAnsPayload     lorawan.LinkADRAnsPayload
 			ExpectedDeviceSession storage.DeviceSession
@@ -88,12 +89,17 @@ func TestLinkADR(t *testing.T) {
 				},
 			},
 			{
-				Name: "pending request and negative tx-power ack on tx-power 0 sets (min) tx-power to 1",
+				Name: "pending request and negative tx-power ack on tx-power 0 sets (min) tx-power to 1 (tx_power_0_unsupported quirk)",
 				DeviceSession: storage.DeviceSession{
 					ADR:                   true,
 					EnabledUplinkChannels: []int{0, 1},
 					MACCommandErrorCount:  map[lorawan.CID]int{},
 				},
+				DeviceProfile: storage.DeviceProfile{
+					Quirks: storage.DeviceQuirks{
+						TXPower0Unsupported: true,
+					},
+				},
 				LinkADRReqPayload: &lorawan.LinkADRReqPayload{
 					ChMask:   lorawan.ChMask{true, true, true},
 					DataRate: 5,
@@ -117,6 +123,68 @@ func TestLinkADR(t *testing.T) {
 					},
 				},
 			},
+			{
+				Name: "pending request and negative tx-power ack on tx-power 0 without quirk",
+				DeviceSession: storage.DeviceSession{
+					ADR:                   true,
+					EnabledUplinkChannels: []int{0, 1},
+					MACCommandErrorCount:  map[lorawan.CID]int{},
+				},
+				LinkADRReqPayload: &lorawan.LinkADRReqPayload{
+					ChMask:   lorawan.ChMask{true, true, true},
+					DataRate: 5,
+					TXPower:  0,
+					Redundancy: lorawan.Redundancy{
+						NbRep: 2,
+					},
+				},
+				LinkADRAnsPayload: lorawan.LinkADRAnsPayload{
+					ChannelMaskACK: true,
+					DataRateACK:    true,
+					PowerACK:       false,
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					ADR:                   true,
+					EnabledUplinkChannels: []int{0, 1},
+					MACCommandErrorCount: map[lorawan.CID]int{
+						lorawan.LinkADRAns: 1,
+					},
+				},
+			},
+			{
+				Name: "pending request and negative channel-mask ack is ignored (ignore_channel_mask_nack quirk)",
+				DeviceSession: storage.DeviceSession{
+					ADR:                   true,
+					EnabledUplinkChannels: []int{0, 1},
+					MACCommandErrorCount:  map[lorawan.CID]int{},
+				},
+				DeviceProfile: storage.DeviceProfile{
+					Quirks: storage.DeviceQuirks{
+						IgnoreChannelMaskNACK: true,
+					},
+				},
+				LinkADRReqPayload: &lorawan.LinkADRReqPayload{
+					ChMask:   lorawan.ChMask{true, true, true},
+					DataRate: 5,
+					TXPower:  3,
+					Redundancy: lorawan.Redundancy{
+						NbRep: 2,
+					},
+				},
+				LinkADRAnsPayload: lorawan.LinkADRAnsPayload{
+					ChannelMaskACK: false,
+					DataRateACK:    true,
+					PowerACK:       true,
+				},
+				ExpectedDeviceSession: storage.DeviceSession{
+					ADR:                   true,
+					EnabledUplinkChannels: []int{0, 1, 2},
+					TXPowerIndex:          3,
+					NbTrans:               2,
+					DR:                    5,
+					MACCommandErrorCount:  map[lorawan.CID]int{},
+				},
+			},
 			{
 				Name: "nothing pending and positive ACK returns an error",
 				DeviceSession: storage.DeviceSession{
@@ -196,7 +264,7 @@ func TestLinkADR(t *testing.T) {
 						},
 					},
 				}
-				resp, err := handleLinkADRAns(context.Background(), &tst.DeviceSession, answer, pending)
+				resp, err := handleLinkADRAns(context.Background(), &tst.DeviceSession, tst.DeviceProfile, answer, pending)
 				if tst.ExpectedError != nil {
 					assert.Equal(tst.ExpectedError.Error(), err.Error())
 					return
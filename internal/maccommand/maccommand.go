@@ -5,16 +5,62 @@ import (
 	"fmt"
 
 	"github.com/brocaar/chirpstack-api/go/v3/as"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
 	"github.com/liuhw0/lorawan"
 )
 
 // Handle handles a MACCommand sent by a node.
+// The device-session changes are logged as audit event.
 func Handle(ctx context.Context, ds *storage.DeviceSession, dp storage.DeviceProfile, sp storage.ServiceProfile, asClient as.ApplicationServerServiceClient, block storage.MACCommandBlock, pending *storage.MACCommandBlock, rxPacket models.RXPacket) ([]storage.MACCommandBlock, error) {
+	before := audit.GetState(*ds)
+	errCountBefore := ds.MACCommandErrorCount[block.CID]
+
+	out, err := handle(ctx, ds, dp, sp, asClient, block, pending, rxPacket)
+	if err != nil {
+		return out, err
+	}
+
+	e := audit.Event{
+		DevEUI: ds.DevEUI,
+		Details: map[string]interface{}{
+			"cid": block.CID.String(),
+		},
+	}
+	e.Before, e.After = audit.Diff(before, audit.GetState(*ds))
+
+	// keep track of the frame-counter of the last error for the backoff
+	if ds.MACCommandErrorCount[block.CID] > errCountBefore {
+		if ds.MACCommandErrorFCntUp == nil {
+			ds.MACCommandErrorFCntUp = make(map[lorawan.CID]uint32)
+		}
+		ds.MACCommandErrorFCntUp[block.CID] = ds.FCntUp
+	} else if ds.MACCommandErrorCount[block.CID] == 0 {
+		delete(ds.MACCommandErrorFCntUp, block.CID)
+	}
+
+	switch {
+	case ds.MACCommandErrorCount[block.CID] > errCountBefore:
+		e.Type = audit.EventMACCommandError
+		e.Details["error_count"] = ds.MACCommandErrorCount[block.CID]
+	case pending != nil:
+		e.Type = audit.EventMACCommandAck
+	case len(e.After) != 0:
+		e.Type = audit.EventMACCommand
+	default:
+		return out, nil
+	}
+
+	audit.Log(ctx, e)
+
+	return out, nil
+}
+
+func handle(ctx context.Context, ds *storage.DeviceSession, dp storage.DeviceProfile, sp storage.ServiceProfile, asClient as.ApplicationServerServiceClient, block storage.MACCommandBlock, pending *storage.MACCommandBlock, rxPacket models.RXPacket) ([]storage.MACCommandBlock, error) {
 	switch block.CID {
 	case lorawan.LinkADRAns:
-		return handleLinkADRAns(ctx, ds, block, pending)
+		return handleLinkADRAns(ctx, ds, dp, block, pending)
 	case lorawan.LinkCheckReq:
 		return handleLinkCheckReq(ctx, ds, rxPacket)
 	case lorawan.DevStatusAns:
@@ -27,6 +73,8 @@ func Handle(ctx context.Context, ds *storage.DeviceSession, dp storage.DevicePro
 		return handleDeviceTimeReq(ctx, ds, rxPacket)
 	case lorawan.NewChannelAns:
 		return handleNewChannelAns(ctx, ds, block, pending)
+	case lorawan.DLChannelAns:
+		return handleDLChannelAns(ctx, ds, block, pending)
 	case lorawan.RXParamSetupAns:
 		return handleRXParamSetupAns(ctx, ds, block, pending)
 	case lorawan.TXParamSetupAns:
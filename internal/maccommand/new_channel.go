@@ -93,6 +93,9 @@ func handleNewChannelAns(ctx context.Context, ds *storage.DeviceSession, block s
 				MaxDR:     int(pendingPL.MaxDR),
 			}
 
+			// the (re)defined channel uses the default downlink frequency
+			delete(ds.ExtraDownlinkFrequencies, int(pendingPL.ChIndex))
+
 			var found bool
 			for _, i := range ds.EnabledUplinkChannels {
 				if i == int(pendingPL.ChIndex) {
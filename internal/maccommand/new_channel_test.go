@@ -217,6 +217,9 @@ func TestNewChannel(t *testing.T) {
 					ExtraUplinkChannels: map[int]band.Channel{
 						3: band.Channel{Frequency: 868700000, MinDR: 3, MaxDR: 5},
 					},
+					ExtraDownlinkFrequencies: map[int]uint32{
+						3: 869000000,
+					},
 					MACCommandErrorCount: map[lorawan.CID]int{
 						lorawan.NewChannelAns: 1,
 					},
@@ -252,7 +255,8 @@ func TestNewChannel(t *testing.T) {
 					ExtraUplinkChannels: map[int]band.Channel{
 						3: band.Channel{Frequency: 868600000, MinDR: 3, MaxDR: 5},
 					},
-					MACCommandErrorCount: map[lorawan.CID]int{},
+					ExtraDownlinkFrequencies: map[int]uint32{},
+					MACCommandErrorCount:     map[lorawan.CID]int{},
 				},
 			},
 		}
@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/reload"
 )
 
 // Setup setsup the metrics server.
@@ -48,6 +49,13 @@ func setupNew(c config.Config) error {
 		mux.HandleFunc("/health", healthCheckHandlerFunc)
 	}
 
+	if c.Monitoring.ConfigReloadEndpoint {
+		log.WithFields(log.Fields{
+			"endpoint": "/config/reload",
+		}).Info("monitoring: registering configuration reload endpoint")
+		mux.HandleFunc("/config/reload", reload.HandlerFunc)
+	}
+
 	server := http.Server{
 		Handler: mux,
 		Addr:    c.Monitoring.Bind,
@@ -0,0 +1,322 @@
+// Package reload implements the reloading of the configuration at runtime.
+package reload
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/adr"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/joinserver"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/downlink"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/roaming"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/uplink"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/uplink/proprietary"
+)
+
+// Result contains the result of a configuration reload.
+type Result struct {
+	// Reloaded contains the configuration keys that have been re-applied.
+	Reloaded []string `json:"reloaded"`
+
+	// RestartRequired contains the configuration keys that have been
+	// changed, but which require a restart to be applied.
+	RestartRequired []string `json:"restart_required"`
+}
+
+// section defines a reloadable configuration section.
+type section struct {
+	// name of the section, used for logging.
+	name string
+
+	// prefix of the configuration keys that belong to this section.
+	prefix string
+
+	// excludes contains the key prefixes within this section that can't
+	// be reloaded.
+	excludes []string
+
+	// merge copies the section from src into dst.
+	merge func(dst *config.Config, src config.Config)
+
+	// validate validates the section, without re-configuring the packages.
+	validate func(c config.Config) error
+
+	// apply re-configures the packages using the given configuration.
+	apply func(c config.Config) error
+}
+
+// sections contains the reloadable configuration sections, in the order in
+// which they are applied.
+var sections = []section{
+	{
+		name:   "log level",
+		prefix: "general.log_level",
+		merge: func(dst *config.Config, src config.Config) {
+			dst.General.LogLevel = src.General.LogLevel
+		},
+		validate: func(c config.Config) error {
+			if c.General.LogLevel < int(log.PanicLevel) || c.General.LogLevel > int(log.TraceLevel) {
+				return fmt.Errorf("invalid log level: %d", c.General.LogLevel)
+			}
+			return nil
+		},
+		apply: func(c config.Config) error {
+			log.SetLevel(log.Level(uint8(c.General.LogLevel)))
+			return nil
+		},
+	},
+	{
+		name:   "network settings",
+		prefix: "network_server.network_settings",
+		merge: func(dst *config.Config, src config.Config) {
+			dst.NetworkServer.NetworkSettings = src.NetworkServer.NetworkSettings
+		},
+		validate: validateNetworkSettings,
+		apply:    applyNetworkSettings,
+	},
+	{
+		name:   "proprietary plugins",
+		prefix: "network_server.proprietary_plugins",
+		merge: func(dst *config.Config, src config.Config) {
+			dst.NetworkServer.ProprietaryPlugins = src.NetworkServer.ProprietaryPlugins
+		},
+		validate: proprietary.Validate,
+		apply:    proprietary.Setup,
+	},
+	{
+		name:   "join-server",
+		prefix: "join_server",
+		merge: func(dst *config.Config, src config.Config) {
+			dst.JoinServer = src.JoinServer
+		},
+		validate: joinserver.Validate,
+		apply:    joinserver.Setup,
+	},
+	{
+		name:     "roaming",
+		prefix:   "roaming",
+		excludes: []string{"roaming.api"},
+		merge: func(dst *config.Config, src config.Config) {
+			api := dst.Roaming.API
+			dst.Roaming = src.Roaming
+			dst.Roaming.API = api
+		},
+		validate: roaming.Validate,
+		apply:    roaming.Setup,
+	},
+}
+
+var (
+	mu       sync.Mutex
+	loadFunc func() (config.Config, error)
+)
+
+// Setup sets the function for loading the configuration (e.g. from the
+// configuration file).
+func Setup(f func() (config.Config, error)) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	loadFunc = f
+}
+
+// Reload loads the configuration, and re-applies the changed reloadable
+// sections. All the changed sections are validated before any of them is
+// applied. The sections are applied while holding the configuration lock,
+// so that the packages are not re-configured during the handling of a
+// request. When applying one of the sections fails nonetheless, all the
+// sections are reverted to the current configuration.
+func Reload() (Result, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var res Result
+
+	if loadFunc == nil {
+		return res, errors.New("configuration reload is not set up")
+	}
+
+	newConf, err := loadFunc()
+	if err != nil {
+		return res, errors.Wrap(err, "load configuration error")
+	}
+
+	oldConf := *config.Get()
+	setRXDefaults(&newConf)
+
+	conf, changed, res := merge(oldConf, newConf)
+
+	for _, s := range changed {
+		if s.validate == nil {
+			continue
+		}
+
+		if err := s.validate(conf); err != nil {
+			return Result{}, errors.Wrapf(err, "validate %s configuration error", s.name)
+		}
+	}
+
+	config.Lock()
+	defer config.Unlock()
+
+	var applied []section
+	for _, s := range changed {
+		applied = append(applied, s)
+
+		if err := s.apply(conf); err != nil {
+			revert(oldConf, applied)
+			return Result{}, errors.Wrapf(err, "apply %s configuration error", s.name)
+		}
+	}
+
+	config.Set(conf)
+
+	log.WithFields(log.Fields{
+		"reloaded":         res.Reloaded,
+		"restart_required": res.RestartRequired,
+	}).Info("reload: configuration reloaded")
+
+	return res, nil
+}
+
+// merge returns the current configuration with the reloadable sections of
+// the new configuration, the changed sections and the reload result.
+func merge(oldConf, newConf config.Config) (config.Config, []section, Result) {
+	var res Result
+	var changed []section
+	conf := oldConf
+
+	keys := config.Diff(oldConf, newConf)
+
+	for _, s := range sections {
+		var sectionChanged bool
+
+		for _, k := range keys {
+			if isReloadable(s, k) {
+				sectionChanged = true
+			}
+		}
+
+		if sectionChanged {
+			s.merge(&conf, newConf)
+			changed = append(changed, s)
+		}
+	}
+
+	for _, k := range keys {
+		reloadable := false
+		for _, s := range sections {
+			if isReloadable(s, k) {
+				reloadable = true
+			}
+		}
+
+		if reloadable {
+			res.Reloaded = append(res.Reloaded, k)
+		} else {
+			res.RestartRequired = append(res.RestartRequired, k)
+		}
+	}
+
+	return conf, changed, res
+}
+
+func isReloadable(s section, key string) bool {
+	if !config.HasKeyPrefix(key, s.prefix) {
+		return false
+	}
+
+	for _, e := range s.excludes {
+		if config.HasKeyPrefix(key, e) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// revert re-applies the given configuration to the given sections.
+func revert(c config.Config, sections []section) {
+	for _, s := range sections {
+		if err := s.apply(c); err != nil {
+			log.WithError(err).WithField("section", s.name).Error("reload: revert configuration error")
+		}
+	}
+}
+
+// validateNetworkSettings validates the band and ADR configuration.
+func validateNetworkSettings(c config.Config) error {
+	if err := band.Validate(c); err != nil {
+		return errors.Wrap(err, "validate band error")
+	}
+
+	if err := adr.Validate(c); err != nil {
+		return errors.Wrap(err, "validate adr error")
+	}
+
+	return nil
+}
+
+// applyNetworkSettings re-configures the band and the uplink and downlink
+// packages (which copy the network settings on setup).
+func applyNetworkSettings(c config.Config) error {
+	if err := band.Setup(c); err != nil {
+		return errors.Wrap(err, "setup band error")
+	}
+
+	if err := adr.Setup(c); err != nil {
+		return errors.Wrap(err, "setup adr error")
+	}
+
+	if err := uplink.Setup(c); err != nil {
+		return errors.Wrap(err, "setup uplink error")
+	}
+
+	if err := downlink.Setup(c); err != nil {
+		return errors.Wrap(err, "setup downlink error")
+	}
+
+	return nil
+}
+
+// setRXDefaults sets the RX2 parameters to the band defaults when not
+// configured, as is done on startup.
+func setRXDefaults(c *config.Config) {
+	defaults := band.Band().GetDefaults()
+
+	if c.NetworkServer.NetworkSettings.RX2DR == -1 {
+		c.NetworkServer.NetworkSettings.RX2DR = defaults.RX2DataRate
+	}
+
+	if c.NetworkServer.NetworkSettings.RX2Frequency == -1 {
+		c.NetworkServer.NetworkSettings.RX2Frequency = int64(defaults.RX2Frequency)
+	}
+}
+
+// HandlerFunc implements the configuration reload HTTP endpoint.
+func HandlerFunc(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	res, err := Reload()
+	if err != nil {
+		log.WithError(err).Error("reload: reload configuration error")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		log.WithError(err).Error("reload: encode response error")
+	}
+}
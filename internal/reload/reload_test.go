@@ -0,0 +1,142 @@
+package reload
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+)
+
+func TestMerge(t *testing.T) {
+	assert := require.New(t)
+
+	oldConf := test.GetConfig()
+	oldConf.Roaming.API.Bind = "0.0.0.0:8005"
+
+	newConf := oldConf
+	newConf.General.LogLevel = 5
+	newConf.NetworkServer.DeduplicationDelay = time.Second
+	newConf.Roaming.API.Bind = "0.0.0.0:8006"
+	newConf.Roaming.ResolveNetIDDomainSuffix = ".example.com"
+
+	conf, changed, res := merge(oldConf, newConf)
+
+	assert.Equal(Result{
+		Reloaded: []string{
+			"general.log_level",
+			"roaming.resolve_netid_domain_suffix",
+		},
+		RestartRequired: []string{
+			"network_server.deduplication_delay",
+			"roaming.api.bind",
+		},
+	}, res)
+
+	var names []string
+	for _, s := range changed {
+		names = append(names, s.name)
+	}
+	assert.Equal([]string{"log level", "roaming"}, names)
+
+	assert.Equal(5, conf.General.LogLevel)
+	assert.Equal(".example.com", conf.Roaming.ResolveNetIDDomainSuffix)
+	assert.Equal(oldConf.NetworkServer.DeduplicationDelay, conf.NetworkServer.DeduplicationDelay)
+	assert.Equal("0.0.0.0:8005", conf.Roaming.API.Bind)
+}
+
+func TestReload(t *testing.T) {
+	conf := test.GetConfig()
+	conf.General.LogLevel = int(log.InfoLevel)
+	require.NoError(t, band.Setup(conf))
+	config.Set(conf)
+
+	defer func() {
+		Setup(nil)
+		log.SetLevel(log.InfoLevel)
+	}()
+
+	t.Run("Not set up", func(t *testing.T) {
+		assert := require.New(t)
+		_, err := Reload()
+		assert.Error(err)
+	})
+
+	t.Run("Log level", func(t *testing.T) {
+		assert := require.New(t)
+
+		Setup(func() (config.Config, error) {
+			c := conf
+			c.General.LogLevel = int(log.DebugLevel)
+			return c, nil
+		})
+
+		res, err := Reload()
+		assert.NoError(err)
+		assert.Equal([]string{"general.log_level"}, res.Reloaded)
+		assert.Equal(log.DebugLevel, log.GetLevel())
+		assert.Equal(int(log.DebugLevel), config.Get().General.LogLevel)
+	})
+
+	t.Run("Apply error", func(t *testing.T) {
+		assert := require.New(t)
+		current := *config.Get()
+
+		Setup(func() (config.Config, error) {
+			c := current
+			c.General.LogLevel = int(log.WarnLevel)
+			c.NetworkServer.NetworkSettings.EnabledUplinkChannels = []int{100}
+			return c, nil
+		})
+
+		_, err := Reload()
+		assert.Error(err)
+
+		// the log level must have been reverted
+		assert.Equal(log.DebugLevel, log.GetLevel())
+		assert.Equal(current.General.LogLevel, config.Get().General.LogLevel)
+		assert.Nil(config.Get().NetworkServer.NetworkSettings.EnabledUplinkChannels)
+	})
+
+	t.Run("Validate error", func(t *testing.T) {
+		assert := require.New(t)
+		current := *config.Get()
+
+		Setup(func() (config.Config, error) {
+			c := current
+			c.General.LogLevel = 10
+			c.NetworkServer.ProprietaryPlugins = []string{"/does/not/exist"}
+			return c, nil
+		})
+
+		_, err := Reload()
+		assert.Error(err)
+		assert.Equal(log.DebugLevel, log.GetLevel())
+		assert.Equal(current.General.LogLevel, config.Get().General.LogLevel)
+		assert.Nil(config.Get().NetworkServer.ProprietaryPlugins)
+	})
+
+	t.Run("HandlerFunc", func(t *testing.T) {
+		assert := require.New(t)
+
+		Setup(func() (config.Config, error) {
+			return *config.Get(), nil
+		})
+
+		w := httptest.NewRecorder()
+		HandlerFunc(w, httptest.NewRequest(http.MethodGet, "/config/reload", nil))
+		assert.Equal(http.StatusMethodNotAllowed, w.Code)
+
+		w = httptest.NewRecorder()
+		HandlerFunc(w, httptest.NewRequest(http.MethodPost, "/config/reload", nil))
+		assert.Equal(http.StatusOK, w.Code)
+		assert.Equal(`{"reloaded":null,"restart_required":null}`, strings.TrimSpace(w.Body.String()))
+	})
+}
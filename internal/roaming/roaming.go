@@ -129,6 +129,37 @@ func Setup(c config.Config) error {
 	return nil
 }
 
+// Validate validates the roaming configuration, without setting up the
+// roaming clients.
+func Validate(c config.Config) error {
+	for _, server := range c.Roaming.Servers {
+		if server.Server == "" {
+			server.Server = fmt.Sprintf("https://%s%s", server.NetID.String(), c.Roaming.ResolveNetIDDomainSuffix)
+		}
+
+		if _, err := backend.NewClient(backend.ClientConfig{
+			Logger:        log.StandardLogger(),
+			SenderID:      c.NetworkServer.NetID.String(),
+			ReceiverID:    server.NetID.String(),
+			Server:        server.Server,
+			CACert:        server.CACert,
+			TLSCert:       server.TLSCert,
+			TLSKey:        server.TLSKey,
+			Authorization: server.Authorization,
+		}); err != nil {
+			return errors.Wrapf(err, "new roaming client error for netid: %s", server.NetID)
+		}
+	}
+
+	for _, k := range c.Roaming.KEK.Set {
+		if _, err := hex.DecodeString(k.KEK); err != nil {
+			return errors.Wrap(err, "decode kek error")
+		}
+	}
+
+	return nil
+}
+
 // IsRoamingDevAddr returns true when the DevAddr does not match the NetID of
 // the ChirpStack Network Server configuration. In case roaming is disabled,
 // this will always return false.
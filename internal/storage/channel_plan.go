@@ -0,0 +1,363 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+// Templates used for generating Redis keys
+const (
+	ChannelPlanKeyTempl = "lora:ns:cp:%s"
+)
+
+// ChannelPlanChannel defines a channel of a channel-plan.
+type ChannelPlanChannel struct {
+	// Frequency (Hz) of the uplink channel.
+	Frequency uint32 `json:"frequency"`
+
+	// MinDR and MaxDR define the data-rate range of the channel.
+	MinDR int `json:"min_dr"`
+	MaxDR int `json:"max_dr"`
+
+	// DownlinkFrequency (Hz) defines the RX1 frequency of the channel,
+	// configured using the DlChannelReq mac-command. 0 = band default.
+	DownlinkFrequency uint32 `json:"downlink_frequency,omitempty"`
+}
+
+// GetBandChannel returns the channel as band channel.
+func (c ChannelPlanChannel) GetBandChannel() loraband.Channel {
+	return loraband.Channel{
+		Frequency: c.Frequency,
+		MinDR:     c.MinDR,
+		MaxDR:     c.MaxDR,
+	}
+}
+
+// ChannelPlanChannels defines the channels of a channel-plan.
+type ChannelPlanChannels []ChannelPlanChannel
+
+// Value implements the driver.Valuer interface.
+func (c ChannelPlanChannels) Value() (driver.Value, error) {
+	if c == nil {
+		c = ChannelPlanChannels{}
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return b, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (c *ChannelPlanChannels) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	*c = nil
+	return json.Unmarshal(b, c)
+}
+
+// ChannelPlan defines a set of (extra) channels which are configured on the
+// devices using the device-profile(s) referring to the channel-plan.
+type ChannelPlan struct {
+	ID        uuid.UUID           `db:"channel_plan_id"`
+	CreatedAt time.Time           `db:"created_at"`
+	UpdatedAt time.Time           `db:"updated_at"`
+	Name      string              `db:"name"`
+	Channels  ChannelPlanChannels `db:"channels"`
+}
+
+// GetUplinkChannels returns the channels of the channel-plan by uplink
+// channel index of the band.
+func (p ChannelPlan) GetUplinkChannels() (map[int]ChannelPlanChannel, error) {
+	out := make(map[int]ChannelPlanChannel)
+	for _, c := range p.Channels {
+		i, err := band.Band().GetUplinkChannelIndex(c.Frequency, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get uplink channel index for frequency %d error", c.Frequency)
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+// Validate validates the channel-plan against the band. As uplinks are
+// matched against the channels of the band, the frequency of each channel
+// must be configured as extra channel of the band and its data-rate range
+// must be within the data-rate range of the band channel.
+func (p ChannelPlan) Validate() error {
+	custom := make(map[int]bool)
+	for _, i := range band.Band().GetCustomUplinkChannelIndices() {
+		custom[i] = true
+	}
+
+	seen := make(map[int]bool)
+	for _, c := range p.Channels {
+		i, err := band.Band().GetUplinkChannelIndex(c.Frequency, false)
+		if err != nil || !custom[i] {
+			return fmt.Errorf("frequency %d is not an extra channel of the band", c.Frequency)
+		}
+
+		if seen[i] {
+			return fmt.Errorf("frequency %d is defined more than once", c.Frequency)
+		}
+		seen[i] = true
+
+		bc, err := band.Band().GetUplinkChannel(i)
+		if err != nil {
+			return errors.Wrap(err, "get uplink channel error")
+		}
+
+		if c.MinDR > c.MaxDR || c.MinDR < bc.MinDR || c.MaxDR > bc.MaxDR {
+			return fmt.Errorf("data-rate range of frequency %d must be within %d - %d", c.Frequency, bc.MinDR, bc.MaxDR)
+		}
+	}
+
+	return nil
+}
+
+// CreateChannelPlan creates the given channel-plan.
+func CreateChannelPlan(ctx context.Context, db sqlx.Execer, p *ChannelPlan) error {
+	now := time.Now()
+
+	if p.ID == uuid.Nil {
+		var err error
+		p.ID, err = uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(err, "new uuid v4 error")
+		}
+	}
+
+	p.CreatedAt = now
+	p.UpdatedAt = now
+
+	_, err := execContext(ctx, db, `
+		insert into channel_plan (
+			channel_plan_id,
+			created_at,
+			updated_at,
+			name,
+			channels
+		) values ($1, $2, $3, $4, $5)`,
+		p.ID,
+		p.CreatedAt,
+		p.UpdatedAt,
+		p.Name,
+		p.Channels,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":     p.ID,
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Info("channel-plan created")
+
+	return nil
+}
+
+// CreateChannelPlanCache caches the given channel-plan in Redis.
+// The TTL of the channel-plan is the same as that of the device-sessions.
+func CreateChannelPlanCache(ctx context.Context, p ChannelPlan) error {
+	key := GetRedisKey(ChannelPlanKeyTempl, p.ID)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(p); err != nil {
+		return errors.Wrap(err, "gob encode channel-plan error")
+	}
+
+	err := RedisClient().Set(ctx, key, buf.Bytes(), deviceSessionTTL).Err()
+	if err != nil {
+		return errors.Wrap(err, "set channel-plan error")
+	}
+
+	return nil
+}
+
+// GetChannelPlanCache returns a cached channel-plan.
+func GetChannelPlanCache(ctx context.Context, id uuid.UUID) (ChannelPlan, error) {
+	var p ChannelPlan
+	key := GetRedisKey(ChannelPlanKeyTempl, id)
+
+	val, err := RedisClient().Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return p, ErrDoesNotExist
+		}
+		return p, errors.Wrap(err, "get error")
+	}
+
+	err = gob.NewDecoder(bytes.NewReader(val)).Decode(&p)
+	if err != nil {
+		return p, errors.Wrap(err, "gob decode error")
+	}
+
+	return p, nil
+}
+
+// FlushChannelPlanCache deletes a cached channel-plan.
+func FlushChannelPlanCache(ctx context.Context, id uuid.UUID) error {
+	key := GetRedisKey(ChannelPlanKeyTempl, id)
+
+	err := RedisClient().Del(ctx, key).Err()
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	return nil
+}
+
+// GetAndCacheChannelPlan returns the channel-plan from cache in case
+// available, else it will be retrieved from the database and then stored
+// in cache.
+func GetAndCacheChannelPlan(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (ChannelPlan, error) {
+	p, err := GetChannelPlanCache(ctx, id)
+	if err == nil {
+		return p, nil
+	}
+
+	if err != ErrDoesNotExist {
+		log.WithFields(log.Fields{
+			"channel_plan_id": id,
+		}).WithError(err).Error("get channel-plan cache error")
+		// we don't return as we can still fall-back onto db retrieval
+	}
+
+	p, err = GetChannelPlan(ctx, db, id)
+	if err != nil {
+		return ChannelPlan{}, errors.Wrap(err, "get channel-plan error")
+	}
+
+	err = CreateChannelPlanCache(ctx, p)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"ctx_id":          ctx.Value(logging.ContextIDKey),
+			"channel_plan_id": id,
+		}).WithError(err).Error("create channel-plan cache error")
+	}
+
+	return p, nil
+}
+
+// GetChannelPlan returns the channel-plan matching the given id.
+func GetChannelPlan(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (ChannelPlan, error) {
+	var p ChannelPlan
+	err := getContext(ctx, db, &p, `
+		select
+			channel_plan_id,
+			created_at,
+			updated_at,
+			name,
+			channels
+		from channel_plan
+		where
+			channel_plan_id = $1`,
+		id,
+	)
+	if err != nil {
+		return p, handlePSQLError(err, "select error")
+	}
+
+	return p, nil
+}
+
+// UpdateChannelPlan updates the given channel-plan.
+func UpdateChannelPlan(ctx context.Context, db sqlx.Execer, p *ChannelPlan) error {
+	p.UpdatedAt = time.Now()
+
+	res, err := execContext(ctx, db, `
+		update channel_plan set
+			updated_at = $2,
+			name = $3,
+			channels = $4
+		where
+			channel_plan_id = $1`,
+		p.ID,
+		p.UpdatedAt,
+		p.Name,
+		p.Channels,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":     p.ID,
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Info("channel-plan updated")
+	return nil
+}
+
+// GetChannelPlans returns the channel-plans matching the given filters,
+// ordered by ID.
+func GetChannelPlans(ctx context.Context, db sqlx.Queryer, filters ProfileFilters) ([]ProfileListItem, error) {
+	return getProfileListItems(ctx, db, "channel_plan", "channel_plan_id", filters)
+}
+
+// GetDeviceProfileIDsForChannelPlan returns the IDs of the device-profiles
+// referring to the given channel-plan.
+func GetDeviceProfileIDsForChannelPlan(ctx context.Context, db sqlx.Queryer, id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := selectContext(ctx, db, &ids, `
+		select
+			device_profile_id
+		from device_profile
+		where
+			channel_plan_id = $1`,
+		id,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return ids, nil
+}
+
+// DeleteChannelPlan deletes the channel-plan matching the given id.
+// The device-profiles referring to the channel-plan will no longer refer
+// to a channel-plan.
+func DeleteChannelPlan(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
+	res, err := execContext(ctx, db, "delete from channel_plan where channel_plan_id = $1", id)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":     id,
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Info("channel-plan deleted")
+	return nil
+}
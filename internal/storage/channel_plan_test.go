@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/test"
+)
+
+func setupBandWithExtraChannels(assert *require.Assertions) {
+	conf := test.GetConfig()
+	for _, f := range []uint32{867100000, 867300000} {
+		conf.NetworkServer.NetworkSettings.ExtraChannels = append(conf.NetworkServer.NetworkSettings.ExtraChannels, struct {
+			Frequency uint32 `mapstructure:"frequency"`
+			MinDR     int    `mapstructure:"min_dr"`
+			MaxDR     int    `mapstructure:"max_dr"`
+		}{
+			Frequency: f,
+			MinDR:     0,
+			MaxDR:     5,
+		})
+	}
+	assert.NoError(band.Setup(conf))
+}
+
+func TestChannelPlanValidate(t *testing.T) {
+	assert := require.New(t)
+	setupBandWithExtraChannels(assert)
+	defer func() {
+		assert.NoError(band.Setup(test.GetConfig()))
+	}()
+
+	tests := []struct {
+		Name     string
+		Channels ChannelPlanChannels
+		Valid    bool
+	}{
+		{
+			Name: "valid",
+			Channels: ChannelPlanChannels{
+				{Frequency: 867100000, MinDR: 0, MaxDR: 5, DownlinkFrequency: 869000000},
+				{Frequency: 867300000, MinDR: 2, MaxDR: 5},
+			},
+			Valid: true,
+		},
+		{
+			Name: "frequency is not an extra channel",
+			Channels: ChannelPlanChannels{
+				{Frequency: 867500000, MinDR: 0, MaxDR: 5},
+			},
+		},
+		{
+			Name: "frequency is a default channel",
+			Channels: ChannelPlanChannels{
+				{Frequency: 868100000, MinDR: 0, MaxDR: 5},
+			},
+		},
+		{
+			Name: "frequency is defined twice",
+			Channels: ChannelPlanChannels{
+				{Frequency: 867100000, MinDR: 0, MaxDR: 5},
+				{Frequency: 867100000, MinDR: 0, MaxDR: 3},
+			},
+		},
+		{
+			Name: "data-rate range exceeds band channel",
+			Channels: ChannelPlanChannels{
+				{Frequency: 867100000, MinDR: 0, MaxDR: 7},
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			err := ChannelPlan{Channels: tst.Channels}.Validate()
+			if tst.Valid {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		})
+	}
+
+	channels, err := ChannelPlan{Channels: tests[0].Channels}.GetUplinkChannels()
+	assert.NoError(err)
+	assert.Equal(map[int]ChannelPlanChannel{
+		3: tests[0].Channels[0],
+		4: tests[0].Channels[1],
+	}, channels)
+}
+
+func (ts *StorageTestSuite) TestChannelPlan() {
+	ts.T().Run("Create", func(t *testing.T) {
+		assert := require.New(t)
+
+		cp := ChannelPlan{
+			Name: "test-plan",
+			Channels: ChannelPlanChannels{
+				{Frequency: 867100000, MinDR: 0, MaxDR: 5, DownlinkFrequency: 869000000},
+			},
+		}
+		assert.NoError(CreateChannelPlan(context.Background(), ts.Tx(), &cp))
+		cp.CreatedAt = cp.CreatedAt.UTC().Truncate(time.Millisecond)
+		cp.UpdatedAt = cp.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+		t.Run("Get", func(t *testing.T) {
+			assert := require.New(t)
+
+			cpGet, err := GetChannelPlan(context.Background(), ts.Tx(), cp.ID)
+			assert.NoError(err)
+
+			cpGet.CreatedAt = cpGet.CreatedAt.UTC().Truncate(time.Millisecond)
+			cpGet.UpdatedAt = cpGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+			assert.Equal(cp, cpGet)
+		})
+
+		t.Run("Update", func(t *testing.T) {
+			assert := require.New(t)
+
+			cp.Name = "test-plan-updated"
+			cp.Channels = ChannelPlanChannels{
+				{Frequency: 867300000, MinDR: 0, MaxDR: 5},
+			}
+			assert.NoError(UpdateChannelPlan(context.Background(), ts.Tx(), &cp))
+			cp.UpdatedAt = cp.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+			cpGet, err := GetChannelPlan(context.Background(), ts.Tx(), cp.ID)
+			assert.NoError(err)
+
+			cpGet.CreatedAt = cpGet.CreatedAt.UTC().Truncate(time.Millisecond)
+			cpGet.UpdatedAt = cpGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+			assert.Equal(cp, cpGet)
+		})
+
+		t.Run("List", func(t *testing.T) {
+			assert := require.New(t)
+
+			items, err := GetChannelPlans(context.Background(), ts.Tx(), ProfileFilters{})
+			assert.NoError(err)
+			assert.Len(items, 1)
+			assert.Equal(cp.ID, items[0].ID)
+		})
+
+		t.Run("Cache", func(t *testing.T) {
+			assert := require.New(t)
+
+			cpGet, err := GetAndCacheChannelPlan(context.Background(), ts.Tx(), cp.ID)
+			assert.NoError(err)
+			assert.Equal(cp.ID, cpGet.ID)
+
+			cpGet, err = GetChannelPlanCache(context.Background(), cp.ID)
+			assert.NoError(err)
+			assert.Equal(cp.ID, cpGet.ID)
+
+			assert.NoError(FlushChannelPlanCache(context.Background(), cp.ID))
+			_, err = GetChannelPlanCache(context.Background(), cp.ID)
+			assert.Equal(ErrDoesNotExist, errors.Cause(err))
+		})
+
+		t.Run("Device-profile", func(t *testing.T) {
+			assert := require.New(t)
+
+			dp := DeviceProfile{
+				ChannelPlanID: &cp.ID,
+			}
+			assert.NoError(CreateDeviceProfile(context.Background(), ts.Tx(), &dp))
+
+			dpGet, err := GetDeviceProfile(context.Background(), ts.Tx(), dp.ID)
+			assert.NoError(err)
+			assert.Equal(&cp.ID, dpGet.ChannelPlanID)
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(DeleteChannelPlan(context.Background(), ts.Tx(), cp.ID))
+			assert.Equal(ErrDoesNotExist, DeleteChannelPlan(context.Background(), ts.Tx(), cp.ID))
+
+			_, err := GetChannelPlan(context.Background(), ts.Tx(), cp.ID)
+			assert.Equal(ErrDoesNotExist, err)
+		})
+	})
+}
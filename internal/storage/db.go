@@ -1,15 +1,20 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 
 	// register postgresql driver
 	_ "github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 )
 
 // redisClient holds the Redis client.
@@ -62,6 +67,42 @@ func (db *DBLogger) Exec(query string, args ...interface{}) (sql.Result, error)
 	return res, err
 }
 
+// QueryContext logs the queries executed by the QueryContext method.
+func (db *DBLogger) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	return rows, err
+}
+
+// QueryxContext logs the queries executed by the QueryxContext method.
+func (db *DBLogger) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	start := time.Now()
+	rows, err := db.DB.QueryxContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	return rows, err
+}
+
+// QueryRowxContext logs the queries executed by the QueryRowxContext
+// method.
+func (db *DBLogger) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	start := time.Now()
+	row := db.DB.QueryRowxContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	return row
+}
+
+// ExecContext logs and traces the queries executed by the ExecContext
+// method.
+func (db *DBLogger) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	start := time.Now()
+	res, err := db.DB.ExecContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	tracing.End(span, err)
+	return res, err
+}
+
 // TxLogger logs the executed sql queries and their duration.
 type TxLogger struct {
 	*sqlx.Tx
@@ -99,6 +140,136 @@ func (q *TxLogger) Exec(query string, args ...interface{}) (sql.Result, error) {
 	return res, err
 }
 
+// QueryContext logs the queries executed by the QueryContext method.
+func (q *TxLogger) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := q.Tx.QueryContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	return rows, err
+}
+
+// QueryxContext logs the queries executed by the QueryxContext method.
+func (q *TxLogger) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	start := time.Now()
+	rows, err := q.Tx.QueryxContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	return rows, err
+}
+
+// QueryRowxContext logs the queries executed by the QueryRowxContext
+// method.
+func (q *TxLogger) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	start := time.Now()
+	row := q.Tx.QueryRowxContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	return row
+}
+
+// ExecContext logs and traces the queries executed by the ExecContext
+// method.
+func (q *TxLogger) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	start := time.Now()
+	res, err := q.Tx.ExecContext(ctx, query, args...)
+	logQuery(query, time.Since(start), args...)
+	tracing.End(span, err)
+	return res, err
+}
+
+// getContext calls sqlx.GetContext when the given Queryer supports contexts
+// (which is the case for the DBLogger and TxLogger), else sqlx.Get.
+// Queries are traced by this and the functions below (and not by the
+// DBLogger and TxLogger), so that the span includes reading the result.
+func getContext(ctx context.Context, db sqlx.Queryer, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer func() { endQuerySpan(span, err) }()
+
+	if q, ok := db.(sqlx.QueryerContext); ok {
+		return sqlx.GetContext(ctx, q, dest, query, args...)
+	}
+	return sqlx.Get(db, dest, query, args...)
+}
+
+// selectContext calls sqlx.SelectContext when the given Queryer supports
+// contexts, else sqlx.Select.
+func selectContext(ctx context.Context, db sqlx.Queryer, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer func() { endQuerySpan(span, err) }()
+
+	if q, ok := db.(sqlx.QueryerContext); ok {
+		return sqlx.SelectContext(ctx, q, dest, query, args...)
+	}
+	return sqlx.Select(db, dest, query, args...)
+}
+
+// scanRowContext executes the given query and scans the resulting row into
+// dest.
+func scanRowContext(ctx context.Context, db sqlx.Queryer, dest []interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer func() { endQuerySpan(span, err) }()
+
+	if q, ok := db.(sqlx.QueryerContext); ok {
+		return q.QueryRowxContext(ctx, query, args...).Scan(dest...)
+	}
+	return db.QueryRowx(query, args...).Scan(dest...)
+}
+
+// scanRowsContext executes the given query and calls scan for each of the
+// resulting rows.
+func scanRowsContext(ctx context.Context, db sqlx.Queryer, scan func(rows *sql.Rows) error, query string, args ...interface{}) (err error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer func() { endQuerySpan(span, err) }()
+
+	var rows *sql.Rows
+	if q, ok := db.(sqlx.QueryerContext); ok {
+		rows, err = q.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = db.Query(query, args...)
+	}
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// execContext calls ExecContext when the given Execer supports contexts,
+// else Exec.
+func execContext(ctx context.Context, db sqlx.Execer, query string, args ...interface{}) (sql.Result, error) {
+	if e, ok := db.(sqlx.ExecerContext); ok {
+		return e.ExecContext(ctx, query, args...)
+	}
+	return db.Exec(query, args...)
+}
+
+// startQuerySpan starts the span for the given query, in case ctx contains
+// a recording span.
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	return tracing.StartChild(ctx, "postgresql.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatementKey.String(query),
+		),
+	)
+}
+
+// endQuerySpan ends the given query span. sql.ErrNoRows is not recorded as
+// error, as it indicates that the object does not exist.
+func endQuerySpan(span trace.Span, err error) {
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	tracing.End(span, err)
+}
+
 func logQuery(query string, duration time.Duration, args ...interface{}) {
 	log.WithFields(log.Fields{
 		"query":    query,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
@@ -36,6 +37,22 @@ type Device struct {
 	IsDisabled        bool          `db:"is_disabled"`
 }
 
+// DeviceFilters provides filters for listing devices.
+type DeviceFilters struct {
+	DeviceProfileID  *uuid.UUID
+	ServiceProfileID *uuid.UUID
+	RoutingProfileID *uuid.UUID
+	Mode             *DeviceMode
+	IsDisabled       *bool
+
+	// After returns the devices with a DevEUI greater than the given DevEUI
+	// (keyset pagination).
+	After *lorawan.EUI64
+
+	// Limit defines the max. number of devices to return (0 = no limit).
+	Limit int
+}
+
 // DeviceActivation defines the device-activation for a LoRaWAN device.
 type DeviceActivation struct {
 	ID          int64             `db:"id"`
@@ -56,7 +73,7 @@ func CreateDevice(ctx context.Context, db sqlx.Execer, d *Device) error {
 	d.CreatedAt = now
 	d.UpdatedAt = now
 
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		insert into device (
 			dev_eui,
 			created_at,
@@ -101,7 +118,7 @@ func GetDevice(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, forUp
 		fu = " for update"
 	}
 
-	err := sqlx.Get(db, &d, "select * from device where dev_eui = $1"+fu, devEUI[:])
+	err := getContext(ctx, db, &d, "select * from device where dev_eui = $1"+fu, devEUI[:])
 	if err != nil {
 		return d, handlePSQLError(err, "select error")
 	}
@@ -112,7 +129,7 @@ func GetDevice(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, forUp
 // UpdateDevice updates the given device.
 func UpdateDevice(ctx context.Context, db sqlx.Execer, d *Device) error {
 	d.UpdatedAt = time.Now()
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update device set
 			updated_at = $2,
 			device_profile_id = $3,
@@ -154,7 +171,7 @@ func UpdateDevice(ctx context.Context, db sqlx.Execer, d *Device) error {
 
 // DeleteDevice deletes the device matching the given DevEUI.
 func DeleteDevice(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64) error {
-	res, err := db.Exec("delete from device where dev_eui = $1", devEUI[:])
+	res, err := execContext(ctx, db, "delete from device where dev_eui = $1", devEUI[:])
 	if err != nil {
 		return handlePSQLError(err, "delete error")
 	}
@@ -174,11 +191,96 @@ func DeleteDevice(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64) err
 	return nil
 }
 
+// GetDevicesForDevEUIs returns a map of the devices matching the given
+// DevEUIs. DevEUIs for which no device exists are not included.
+func GetDevicesForDevEUIs(ctx context.Context, db sqlx.Queryer, devEUIs []lorawan.EUI64) (map[lorawan.EUI64]Device, error) {
+	var devEUIsB [][]byte
+	for i := range devEUIs {
+		devEUIsB = append(devEUIsB, devEUIs[i][:])
+	}
+
+	var devices []Device
+	err := selectContext(ctx, db, &devices, `
+		select
+			dev_eui,
+			created_at,
+			updated_at,
+			device_profile_id,
+			service_profile_id,
+			routing_profile_id,
+			skip_fcnt_check,
+			reference_altitude,
+			mode,
+			is_disabled
+		from
+			device
+		where
+			dev_eui = any($1)`,
+		pq.ByteaArray(devEUIsB),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	out := make(map[lorawan.EUI64]Device, len(devices))
+	for _, d := range devices {
+		out[d.DevEUI] = d
+	}
+
+	return out, nil
+}
+
+// GetDevices returns the devices matching the given filters, ordered by
+// DevEUI.
+func GetDevices(ctx context.Context, db sqlx.Queryer, filters DeviceFilters) ([]Device, error) {
+	var wb whereBuilder
+	if filters.DeviceProfileID != nil {
+		wb.add("device_profile_id = $%d", *filters.DeviceProfileID)
+	}
+	if filters.ServiceProfileID != nil {
+		wb.add("service_profile_id = $%d", *filters.ServiceProfileID)
+	}
+	if filters.RoutingProfileID != nil {
+		wb.add("routing_profile_id = $%d", *filters.RoutingProfileID)
+	}
+	if filters.Mode != nil {
+		wb.add("mode = $%d", *filters.Mode)
+	}
+	if filters.IsDisabled != nil {
+		wb.add("is_disabled = $%d", *filters.IsDisabled)
+	}
+	if filters.After != nil {
+		wb.add("dev_eui > $%d", filters.After[:])
+	}
+
+	query := `
+		select
+			dev_eui,
+			created_at,
+			updated_at,
+			device_profile_id,
+			service_profile_id,
+			routing_profile_id,
+			skip_fcnt_check,
+			reference_altitude,
+			mode,
+			is_disabled
+		from
+			device` + wb.where() + " order by dev_eui" + wb.limit(filters.Limit)
+
+	var devices []Device
+	if err := selectContext(ctx, db, &devices, query, wb.args...); err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return devices, nil
+}
+
 // CreateDeviceActivation creates the given device-activation.
 func CreateDeviceActivation(ctx context.Context, db sqlx.Queryer, da *DeviceActivation) error {
 	da.CreatedAt = time.Now()
 
-	err := sqlx.Get(db, &da.ID, `
+	err := getContext(ctx, db, &da.ID, `
 		insert into device_activation (
 			created_at,
 			dev_eui,
@@ -217,7 +319,7 @@ func CreateDeviceActivation(ctx context.Context, db sqlx.Queryer, da *DeviceActi
 // DeleteDeviceActivationsForDevice removes the device-activation for the given
 // DevEUI.
 func DeleteDeviceActivationsForDevice(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64) error {
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		delete
 		from
 			device_activation
@@ -239,7 +341,7 @@ func DeleteDeviceActivationsForDevice(ctx context.Context, db sqlx.Execer, devEU
 // for the given DevEUI.
 func GetLastDeviceActivationForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceActivation, error) {
 	var da DeviceActivation
-	err := sqlx.Get(db, &da, `
+	err := getContext(ctx, db, &da, `
 		select
 			*
 		from device_activation
@@ -261,7 +363,7 @@ func GetLastDeviceActivationForDevEUI(ctx context.Context, db sqlx.Queryer, devE
 // DevEUI / JoinEUI combination.
 func ValidateDevNonce(ctx context.Context, db sqlx.Queryer, joinEUI, devEUI lorawan.EUI64, nonce lorawan.DevNonce, joinType lorawan.JoinType) error {
 	var count int
-	err := sqlx.Get(db, &count, `
+	err := getContext(ctx, db, &count, `
 		select
 			count(*)
 		from
@@ -290,7 +392,7 @@ func ValidateDevNonce(ctx context.Context, db sqlx.Queryer, joinEUI, devEUI lora
 // ClearDeviceNoncesForDevice removes the device DevNonce from device-activation for the given
 // DevEUI.
 func ClearDeviceNoncesForDevice(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64) error {
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		delete
 		from
 			device_activation
@@ -14,7 +14,7 @@ import (
 
 // AddDeviceToMulticastGroup adds the given device to the given multicast-group.
 func AddDeviceToMulticastGroup(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64, multicastGroupID uuid.UUID) error {
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		insert into device_multicast_group (
 			dev_eui,
 			multicast_group_id,
@@ -37,7 +37,7 @@ func AddDeviceToMulticastGroup(ctx context.Context, db sqlx.Execer, devEUI loraw
 // RemoveDeviceFromMulticastGroup removes the given device from the given
 // multicast-group.
 func RemoveDeviceFromMulticastGroup(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64, multicastGroupID uuid.UUID) error {
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		delete from
 			device_multicast_group
 		where
@@ -73,7 +73,7 @@ func RemoveDeviceFromMulticastGroup(ctx context.Context, db sqlx.Execer, devEUI
 func GetMulticastGroupsForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) ([]uuid.UUID, error) {
 	var out []uuid.UUID
 
-	err := sqlx.Select(db, &out, `
+	err := selectContext(ctx, db, &out, `
 		select
 			multicast_group_id
 		from
@@ -93,7 +93,7 @@ func GetMulticastGroupsForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lo
 func GetDevEUIsForMulticastGroup(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) ([]lorawan.EUI64, error) {
 	var out []lorawan.EUI64
 
-	err := sqlx.Select(db, &out, `
+	err := selectContext(ctx, db, &out, `
 		select
 			dev_eui
 		from
@@ -22,29 +22,33 @@ const (
 
 // DeviceProfile defines the backend.DeviceProfile with some extra meta-data
 type DeviceProfile struct {
-	CreatedAt          time.Time `db:"created_at"`
-	UpdatedAt          time.Time `db:"updated_at"`
-	ID                 uuid.UUID `db:"device_profile_id"`
-	SupportsClassB     bool      `db:"supports_class_b"`
-	ClassBTimeout      int       `db:"class_b_timeout"` // Unit: seconds
-	PingSlotPeriod     int       `db:"ping_slot_period"`
-	PingSlotDR         int       `db:"ping_slot_dr"`
-	PingSlotFreq       uint32    `db:"ping_slot_freq"` // in Hz
-	SupportsClassC     bool      `db:"supports_class_c"`
-	ClassCTimeout      int       `db:"class_c_timeout"`     // Unit: seconds
-	MACVersion         string    `db:"mac_version"`         // Example: "1.0.2" [LW102]
-	RegParamsRevision  string    `db:"reg_params_revision"` // Example: "B" [RP102B]
-	RXDelay1           int       `db:"rx_delay_1"`
-	RXDROffset1        int       `db:"rx_dr_offset_1"`
-	RXDataRate2        int       `db:"rx_data_rate_2"`       // Unit: bits-per-second
-	RXFreq2            uint32    `db:"rx_freq_2"`            // In Hz
-	FactoryPresetFreqs []uint32  `db:"factory_preset_freqs"` // In Hz
-	MaxEIRP            int       `db:"max_eirp"`             // In dBm
-	MaxDutyCycle       int       `db:"max_duty_cycle"`       // Example: 10 indicates 10%
-	SupportsJoin       bool      `db:"supports_join"`
-	RFRegion           string    `db:"rf_region"`
-	Supports32bitFCnt  bool      `db:"supports_32bit_fcnt"`
-	ADRAlgorithmID     string    `db:"adr_algorithm_id"`
+	CreatedAt          time.Time    `db:"created_at"`
+	UpdatedAt          time.Time    `db:"updated_at"`
+	ID                 uuid.UUID    `db:"device_profile_id"`
+	SupportsClassB     bool         `db:"supports_class_b"`
+	ClassBTimeout      int          `db:"class_b_timeout"` // Unit: seconds
+	PingSlotPeriod     int          `db:"ping_slot_period"`
+	PingSlotDR         int          `db:"ping_slot_dr"`
+	PingSlotFreq       uint32       `db:"ping_slot_freq"` // in Hz
+	SupportsClassC     bool         `db:"supports_class_c"`
+	ClassCTimeout      int          `db:"class_c_timeout"`     // Unit: seconds
+	MACVersion         string       `db:"mac_version"`         // Example: "1.0.2" [LW102]
+	RegParamsRevision  string       `db:"reg_params_revision"` // Example: "B" [RP102B]
+	RXDelay1           int          `db:"rx_delay_1"`
+	RXDROffset1        int          `db:"rx_dr_offset_1"`
+	RXDataRate2        int          `db:"rx_data_rate_2"`       // Unit: bits-per-second
+	RXFreq2            uint32       `db:"rx_freq_2"`            // In Hz
+	FactoryPresetFreqs []uint32     `db:"factory_preset_freqs"` // In Hz
+	MaxEIRP            int          `db:"max_eirp"`             // In dBm
+	MaxDutyCycle       int          `db:"max_duty_cycle"`       // Example: 10 indicates 10%
+	SupportsJoin       bool         `db:"supports_join"`
+	RFRegion           string       `db:"rf_region"`
+	Supports32bitFCnt  bool         `db:"supports_32bit_fcnt"`
+	ADRAlgorithmID     string       `db:"adr_algorithm_id"`
+	UplinkHistorySize  int          `db:"uplink_history_size"` // 0 = default (UplinkHistorySize)
+	ADRScript          string       `db:"adr_script"`
+	Quirks             DeviceQuirks `db:"quirks"`
+	ChannelPlanID      *uuid.UUID   `db:"channel_plan_id"`
 }
 
 // CreateDeviceProfile creates the given device-profile.
@@ -62,7 +66,7 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 	dp.CreatedAt = now
 	dp.UpdatedAt = now
 
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
         insert into device_profile (
             created_at,
             updated_at,
@@ -87,8 +91,12 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
             supports_join,
             rf_region,
             supports_32bit_fcnt,
-			adr_algorithm_id
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`,
+			adr_algorithm_id,
+			uplink_history_size,
+			adr_script,
+			quirks,
+			channel_plan_id
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)`,
 		dp.CreatedAt,
 		dp.UpdatedAt,
 		dp.ID,
@@ -112,6 +120,10 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 		dp.RFRegion,
 		dp.Supports32bitFCnt,
 		dp.ADRAlgorithmID,
+		dp.UplinkHistorySize,
+		dp.ADRScript,
+		dp.Quirks,
+		dp.ChannelPlanID,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -210,8 +222,37 @@ func GetAndCacheDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID
 // GetDeviceProfile returns the device-profile matching the given id.
 func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (DeviceProfile, error) {
 	var dp DeviceProfile
+	var factoryPresetFreqs []int64
 
-	row := db.QueryRowx(`
+	err := scanRowContext(ctx, db, []interface{}{
+		&dp.CreatedAt,
+		&dp.UpdatedAt,
+		&dp.ID,
+		&dp.SupportsClassB,
+		&dp.ClassBTimeout,
+		&dp.PingSlotPeriod,
+		&dp.PingSlotDR,
+		&dp.PingSlotFreq,
+		&dp.SupportsClassC,
+		&dp.ClassCTimeout,
+		&dp.MACVersion,
+		&dp.RegParamsRevision,
+		&dp.RXDelay1,
+		&dp.RXDROffset1,
+		&dp.RXDataRate2,
+		&dp.RXFreq2,
+		pq.Array(&factoryPresetFreqs),
+		&dp.MaxEIRP,
+		&dp.MaxDutyCycle,
+		&dp.SupportsJoin,
+		&dp.RFRegion,
+		&dp.Supports32bitFCnt,
+		&dp.ADRAlgorithmID,
+		&dp.UplinkHistorySize,
+		&dp.ADRScript,
+		&dp.Quirks,
+		&dp.ChannelPlanID,
+	}, `
         select
             created_at,
             updated_at,
@@ -236,39 +277,15 @@ func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Devic
             supports_join,
             rf_region,
             supports_32bit_fcnt,
-			adr_algorithm_id
+			adr_algorithm_id,
+			uplink_history_size,
+			adr_script,
+			quirks,
+			channel_plan_id
         from device_profile
         where
             device_profile_id = $1
         `, id)
-
-	var factoryPresetFreqs []int64
-
-	err := row.Scan(
-		&dp.CreatedAt,
-		&dp.UpdatedAt,
-		&dp.ID,
-		&dp.SupportsClassB,
-		&dp.ClassBTimeout,
-		&dp.PingSlotPeriod,
-		&dp.PingSlotDR,
-		&dp.PingSlotFreq,
-		&dp.SupportsClassC,
-		&dp.ClassCTimeout,
-		&dp.MACVersion,
-		&dp.RegParamsRevision,
-		&dp.RXDelay1,
-		&dp.RXDROffset1,
-		&dp.RXDataRate2,
-		&dp.RXFreq2,
-		pq.Array(&factoryPresetFreqs),
-		&dp.MaxEIRP,
-		&dp.MaxDutyCycle,
-		&dp.SupportsJoin,
-		&dp.RFRegion,
-		&dp.Supports32bitFCnt,
-		&dp.ADRAlgorithmID,
-	)
 	if err != nil {
 		return dp, handlePSQLError(err, "select error")
 	}
@@ -284,7 +301,7 @@ func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Devic
 func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile) error {
 	dp.UpdatedAt = time.Now()
 
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
         update device_profile set
             updated_at = $2,
 
@@ -307,7 +324,11 @@ func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
             supports_join = $19,
             rf_region = $20,
             supports_32bit_fcnt = $21,
-			adr_algorithm_id = $22
+			adr_algorithm_id = $22,
+			uplink_history_size = $23,
+			adr_script = $24,
+			quirks = $25,
+			channel_plan_id = $26
         where
             device_profile_id = $1`,
 		dp.ID,
@@ -332,6 +353,10 @@ func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 		dp.RFRegion,
 		dp.Supports32bitFCnt,
 		dp.ADRAlgorithmID,
+		dp.UplinkHistorySize,
+		dp.ADRScript,
+		dp.Quirks,
+		dp.ChannelPlanID,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -351,9 +376,15 @@ func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 	return nil
 }
 
+// GetDeviceProfiles returns the device-profiles matching the given filters,
+// ordered by ID.
+func GetDeviceProfiles(ctx context.Context, db sqlx.Queryer, filters ProfileFilters) ([]ProfileListItem, error) {
+	return getProfileListItems(ctx, db, "device_profile", "device_profile_id", filters)
+}
+
 // DeleteDeviceProfile deletes the device-profile matching the given id.
 func DeleteDeviceProfile(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
-	res, err := db.Exec("delete from device_profile where device_profile_id = $1", id)
+	res, err := execContext(ctx, db, "delete from device_profile where device_profile_id = $1", id)
 	if err != nil {
 		return handlePSQLError(err, "delete error")
 	}
@@ -45,6 +45,9 @@ func TestDeviceProfile(t *testing.T) {
 				RFRegion:           "EU868",
 				Supports32bitFCnt:  true,
 				ADRAlgorithmID:     "default",
+				Quirks: DeviceQuirks{
+					TXPower0Unsupported: true,
+				},
 			}
 
 			So(CreateDeviceProfile(context.Background(), DB(), &dp), ShouldBeNil)
@@ -107,6 +110,11 @@ func TestDeviceProfile(t *testing.T) {
 				dp.RFRegion = "US902"
 				dp.Supports32bitFCnt = false
 				dp.ADRAlgorithmID = "new_algorithm"
+				dp.Quirks = DeviceQuirks{
+					IgnoreChannelMaskNACK: true,
+					NoRXTimingSetup:       true,
+					MaxFOptsLen:           10,
+				}
 
 				So(UpdateDeviceProfile(context.Background(), DB(), &dp), ShouldBeNil)
 				dp.UpdatedAt = dp.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -18,6 +18,12 @@ const (
 	classBScheduleMargin = 5 * time.Second
 )
 
+// deviceQueueOrderBy defines the order in which the device-queue items are
+// transmitted. The pending item (if any) comes first, followed by the
+// items with the highest priority. Items with the same priority are
+// transmitted in the order in which they were created.
+const deviceQueueOrderBy = "is_pending desc, priority desc, created_at, id"
+
 // DeviceQueueItem represents an item in the device queue (downlink).
 type DeviceQueueItem struct {
 	ID                      int64           `db:"id"`
@@ -33,6 +39,7 @@ type DeviceQueueItem struct {
 	EmitAtTimeSinceGPSEpoch *time.Duration  `db:"emit_at_time_since_gps_epoch"`
 	TimeoutAfter            *time.Time      `db:"timeout_after"`
 	RetryAfter              *time.Time      `db:"retry_after"`
+	Priority                int             `db:"priority"`
 }
 
 // Validate validates the DeviceQueueItem.
@@ -85,7 +92,7 @@ func CreateDeviceQueueItem(ctx context.Context, db sqlx.Queryer, qi *DeviceQueue
 	qi.CreatedAt = now
 	qi.UpdatedAt = now
 
-	err := sqlx.Get(db, &qi.ID, `
+	err := getContext(ctx, db, &qi.ID, `
         insert into device_queue (
             created_at,
             updated_at,
@@ -98,8 +105,9 @@ func CreateDeviceQueueItem(ctx context.Context, db sqlx.Queryer, qi *DeviceQueue
             emit_at_time_since_gps_epoch,
             is_pending,
             timeout_after,
-			retry_after
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			retry_after,
+			priority
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
         returning id`,
 		qi.CreatedAt,
 		qi.UpdatedAt,
@@ -113,15 +121,17 @@ func CreateDeviceQueueItem(ctx context.Context, db sqlx.Queryer, qi *DeviceQueue
 		qi.IsPending,
 		qi.TimeoutAfter,
 		qi.RetryAfter,
+		qi.Priority,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
 	}
 
 	log.WithFields(log.Fields{
-		"dev_eui": qi.DevEUI,
-		"f_cnt":   qi.FCnt,
-		"ctx_id":  ctx.Value(logging.ContextIDKey),
+		"dev_eui":  qi.DevEUI,
+		"f_cnt":    qi.FCnt,
+		"priority": qi.Priority,
+		"ctx_id":   ctx.Value(logging.ContextIDKey),
 	}).Info("device-queue item created")
 
 	return nil
@@ -130,7 +140,7 @@ func CreateDeviceQueueItem(ctx context.Context, db sqlx.Queryer, qi *DeviceQueue
 // GetDeviceQueueItem returns the device-queue item matching the given id.
 func GetDeviceQueueItem(ctx context.Context, db sqlx.Queryer, id int64) (DeviceQueueItem, error) {
 	var qi DeviceQueueItem
-	err := sqlx.Get(db, &qi, "select * from device_queue where id = $1", id)
+	err := getContext(ctx, db, &qi, "select * from device_queue where id = $1", id)
 	if err != nil {
 		return qi, handlePSQLError(err, "select error")
 	}
@@ -141,7 +151,7 @@ func GetDeviceQueueItem(ctx context.Context, db sqlx.Queryer, id int64) (DeviceQ
 func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueItem) error {
 	qi.UpdatedAt = time.Now()
 
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
         update device_queue
         set
             updated_at = $2,
@@ -154,7 +164,8 @@ func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueI
             is_pending = $9,
             timeout_after = $10,
 			dev_addr = $11,
-			retry_after = $12
+			retry_after = $12,
+			priority = $13
         where
             id = $1`,
 		qi.ID,
@@ -169,6 +180,7 @@ func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueI
 		qi.TimeoutAfter,
 		qi.DevAddr[:],
 		qi.RetryAfter,
+		qi.Priority,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -195,7 +207,7 @@ func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueI
 
 // DeleteDeviceQueueItem deletes the device-queue item matching the given id.
 func DeleteDeviceQueueItem(ctx context.Context, db sqlx.Execer, id int64) error {
-	res, err := db.Exec("delete from device_queue where id = $1", id)
+	res, err := execContext(ctx, db, "delete from device_queue where id = $1", id)
 	if err != nil {
 		return handlePSQLError(err, "delete error")
 	}
@@ -217,7 +229,7 @@ func DeleteDeviceQueueItem(ctx context.Context, db sqlx.Execer, id int64) error
 
 // FlushDeviceQueueForDevEUI deletes all device-queue items for the given DevEUI.
 func FlushDeviceQueueForDevEUI(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64) error {
-	_, err := db.Exec("delete from device_queue where dev_eui = $1", devEUI[:])
+	_, err := execContext(ctx, db, "delete from device_queue where dev_eui = $1", devEUI[:])
 	if err != nil {
 		return handlePSQLError(err, "delete error")
 	}
@@ -231,11 +243,14 @@ func FlushDeviceQueueForDevEUI(ctx context.Context, db sqlx.Execer, devEUI loraw
 }
 
 // GetNextDeviceQueueItemForDevEUI returns the next device-queue item for the
-// given DevEUI, ordered by f_cnt and a bool indicating if more items exist in
-// the queue (note that the f_cnt should never roll over).
+// given DevEUI and a bool indicating if more items exist in the queue.
+// A pending item is always returned first, the other items are ordered by
+// priority (highest first) and creation time. Note that the returned item
+// does not necessarily have the expected frame-counter, in which case the
+// queue must be re-encrypted.
 func GetNextDeviceQueueItemForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceQueueItem, bool, error) {
 	var items []DeviceQueueItem
-	err := sqlx.Select(db, &items, `
+	err := selectContext(ctx, db, &items, `
         select
             *
         from
@@ -243,7 +258,7 @@ func GetNextDeviceQueueItemForDevEUI(ctx context.Context, db sqlx.Queryer, devEU
         where
             dev_eui = $1
         order by
-            f_cnt
+            `+deviceQueueOrderBy+`
         limit 2`,
 		devEUI[:],
 	)
@@ -271,7 +286,7 @@ func GetNextDeviceQueueItemForDevEUI(ctx context.Context, db sqlx.Queryer, devEU
 // given DevEUI.
 func GetPendingDeviceQueueItemForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceQueueItem, error) {
 	var qi DeviceQueueItem
-	err := sqlx.Get(db, &qi, `
+	err := getContext(ctx, db, &qi, `
         select
             *
         from
@@ -279,7 +294,7 @@ func GetPendingDeviceQueueItemForDevEUI(ctx context.Context, db sqlx.Queryer, de
         where
             dev_eui = $1
         order by
-            f_cnt
+            `+deviceQueueOrderBy+`
         limit 1`,
 		devEUI[:],
 	)
@@ -295,10 +310,10 @@ func GetPendingDeviceQueueItemForDevEUI(ctx context.Context, db sqlx.Queryer, de
 }
 
 // GetDeviceQueueItemsForDevEUI returns all device-queue items for the given
-// DevEUI, ordered by id (keep in mind FCnt rollover).
+// DevEUI, in the order in which they will be transmitted.
 func GetDeviceQueueItemsForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) ([]DeviceQueueItem, error) {
 	var items []DeviceQueueItem
-	err := sqlx.Select(db, &items, `
+	err := selectContext(ctx, db, &items, `
         select
             *
         from
@@ -306,7 +321,7 @@ func GetDeviceQueueItemsForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI l
         where
             dev_eui = $1
         order by
-            f_cnt`,
+            `+deviceQueueOrderBy,
 		devEUI,
 	)
 	if err != nil {
@@ -320,7 +335,7 @@ func GetDeviceQueueItemsForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI l
 // the given DevEUI.
 func GetDeviceQueueItemCountForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) (int, error) {
 	var count int
-	err := sqlx.Get(db, &count, `
+	err := getContext(ctx, db, &count, `
 		select
 			count(*)
 		from
@@ -343,7 +358,7 @@ func GetDevicesWithClassBOrClassCDeviceQueueItems(ctx context.Context, db sqlx.E
 	gpsEpochScheduleTime := gps.Time(time.Now().Add(schedulerInterval * 2)).TimeSinceGPSEpoch()
 
 	var devices []Device
-	err := sqlx.Select(db, &devices, `
+	err := selectContext(ctx, db, &devices, `
         select
             d.*
         from
@@ -401,7 +416,7 @@ func GetDevicesWithClassBOrClassCDeviceQueueItems(ctx context.Context, db sqlx.E
 // epoch scheduling timestamp for the given DevEUI.
 func GetMaxEmitAtTimeSinceGPSEpochForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) (time.Duration, error) {
 	var timeSinceGPSEpoch time.Duration
-	err := sqlx.Get(db, &timeSinceGPSEpoch, `
+	err := getContext(ctx, db, &timeSinceGPSEpoch, `
 		select
 			coalesce(max(emit_at_time_since_gps_epoch), 0)
 		from
@@ -134,9 +134,11 @@ func (ts *StorageTestSuite) TestDeviceQueue() {
 			queueItems, err := GetDeviceQueueItemsForDevEUI(context.Background(), ts.Tx(), d.DevEUI)
 			assert.NoError(err)
 			assert.Len(queueItems, len(items))
+
+			// ordered by creation time
 			assert.EqualValues(1, queueItems[0].FCnt)
-			assert.EqualValues(2, queueItems[1].FCnt)
-			assert.EqualValues(3, queueItems[2].FCnt)
+			assert.EqualValues(3, queueItems[1].FCnt)
+			assert.EqualValues(2, queueItems[2].FCnt)
 		})
 
 		t.Run("GetNextDeviceQueueItemForDevEUI", func(t *testing.T) {
@@ -148,6 +150,28 @@ func (ts *StorageTestSuite) TestDeviceQueue() {
 			assert.True(more)
 		})
 
+		t.Run("Priority", func(t *testing.T) {
+			assert := require.New(t)
+
+			items[2].Priority = 10
+			assert.NoError(UpdateDeviceQueueItem(context.Background(), ts.Tx(), &items[2]))
+
+			qi, more, err := GetNextDeviceQueueItemForDevEUI(context.Background(), ts.Tx(), d.DevEUI)
+			assert.NoError(err)
+			assert.Equal(items[2].ID, qi.ID)
+			assert.Equal(10, qi.Priority)
+			assert.True(more)
+
+			queueItems, err := GetDeviceQueueItemsForDevEUI(context.Background(), ts.Tx(), d.DevEUI)
+			assert.NoError(err)
+			assert.Equal(items[2].ID, queueItems[0].ID)
+			assert.Equal(items[0].ID, queueItems[1].ID)
+			assert.Equal(items[1].ID, queueItems[2].ID)
+
+			items[2].Priority = 0
+			assert.NoError(UpdateDeviceQueueItem(context.Background(), ts.Tx(), &items[2]))
+		})
+
 		t.Run("First item in queue is pending and timeout in future", func(t *testing.T) {
 			assert := require.New(t)
 
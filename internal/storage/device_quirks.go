@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// MaxFOptsLen defines the max. length (bytes) of the FOpts field.
+const MaxFOptsLen = 15
+
+// DeviceQuirks contains the work-arounds for (firmware) issues of the
+// devices using a device-profile.
+type DeviceQuirks struct {
+	// TXPower0Unsupported indicates that the device does not support
+	// tx-power index 0 and that it nACKs a LinkADRReq requesting it,
+	// rather than operating at its max. power (e.g. RN2483 firmware 1.0.3).
+	// This is enabled for the device-profiles created before the quirks
+	// were introduced, as the work-around was previously always applied.
+	TXPower0Unsupported bool `json:"tx_power_0_unsupported,omitempty"`
+
+	// IgnoreChannelMaskNACK indicates that the channel-mask nACK of the
+	// LinkADRAns must be ignored, as the device applies the channel-mask
+	// but incorrectly nACKs it.
+	IgnoreChannelMaskNACK bool `json:"ignore_channel_mask_nack,omitempty"`
+
+	// NoRXTimingSetup indicates that the RXTimingSetupReq must not be sent
+	// to the device.
+	NoRXTimingSetup bool `json:"no_rxtimingsetup,omitempty"`
+
+	// MaxFOptsLen defines the max. number of mac-command bytes that the
+	// device accepts in the FOpts field. When the mac-commands exceed this
+	// size, they are sent as FRMPayload. 0 = MaxFOptsLen.
+	MaxFOptsLen int `json:"max_fopts_len,omitempty"`
+}
+
+// GetMaxFOptsLen returns the max. FOpts length for the device.
+func (q DeviceQuirks) GetMaxFOptsLen() int {
+	if q.MaxFOptsLen > 0 && q.MaxFOptsLen < MaxFOptsLen {
+		return q.MaxFOptsLen
+	}
+	return MaxFOptsLen
+}
+
+// Validate validates the device quirks.
+func (q DeviceQuirks) Validate() error {
+	if q.MaxFOptsLen < 0 || q.MaxFOptsLen > MaxFOptsLen {
+		return fmt.Errorf("max_fopts_len must be between 0 and %d", MaxFOptsLen)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (q DeviceQuirks) Value() (driver.Value, error) {
+	b, err := json.Marshal(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return b, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (q *DeviceQuirks) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	*q = DeviceQuirks{}
+	return json.Unmarshal(b, q)
+}
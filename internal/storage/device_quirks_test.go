@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceQuirks(t *testing.T) {
+	t.Run("Value and Scan", func(t *testing.T) {
+		assert := require.New(t)
+
+		q := DeviceQuirks{
+			TXPower0Unsupported: true,
+			MaxFOptsLen:         10,
+		}
+
+		v, err := q.Value()
+		assert.NoError(err)
+		assert.Equal(`{"tx_power_0_unsupported":true,"max_fopts_len":10}`, string(v.([]byte)))
+
+		qScan := DeviceQuirks{NoRXTimingSetup: true}
+		assert.NoError(qScan.Scan(v))
+		assert.Equal(q, qScan)
+
+		assert.NoError(qScan.Scan([]byte("{}")))
+		assert.Equal(DeviceQuirks{}, qScan)
+	})
+
+	t.Run("GetMaxFOptsLen", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Equal(MaxFOptsLen, DeviceQuirks{}.GetMaxFOptsLen())
+		assert.Equal(5, DeviceQuirks{MaxFOptsLen: 5}.GetMaxFOptsLen())
+		assert.Equal(MaxFOptsLen, DeviceQuirks{MaxFOptsLen: 20}.GetMaxFOptsLen())
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DeviceQuirks{MaxFOptsLen: 15}.Validate())
+		assert.Error(DeviceQuirks{MaxFOptsLen: 16}.Validate())
+		assert.Error(DeviceQuirks{MaxFOptsLen: -1}.Validate())
+	})
+}
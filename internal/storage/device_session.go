@@ -12,6 +12,7 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/gofrs/uuid"
 	proto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
@@ -28,7 +29,8 @@ const (
 	deviceGatewayRXInfoSetKeyTempl = "lora:ns:device:%s:gwrx" // contains gateway meta-data from the last uplink
 )
 
-// UplinkHistorySize contains the number of frames to store
+// UplinkHistorySize contains the default number of frames to store.
+// This can be overridden per device-profile.
 const UplinkHistorySize = 20
 
 // RXWindow defines the RX window option.
@@ -66,6 +68,23 @@ type UplinkHistory struct {
 	MaxRSSI      int32
 	TXPowerIndex int
 	GatewayCount int
+	DR           int
+	Frequency    uint32
+	Channel      int // UplinkHistoryUnknownChannel when unknown
+	Time         time.Time
+	Gateways     []UplinkHistoryGateway
+}
+
+// UplinkHistoryUnknownChannel is the uplink history channel index when the
+// channel of the uplink is unknown.
+const UplinkHistoryUnknownChannel = -1
+
+// UplinkHistoryGateway contains the meta-data of an uplink transmission as
+// received by a single gateway.
+type UplinkHistoryGateway struct {
+	GatewayID lorawan.EUI64
+	SNR       float64
+	RSSI      int32
 }
 
 // KeyEnvelope defined a key-envelope.
@@ -136,7 +155,12 @@ type DeviceSession struct {
 	EnabledUplinkChannels []int                    // channels that are activated on the node
 	ExtraUplinkChannels   map[int]loraband.Channel // extra uplink channels, configured by the user
 	ChannelFrequencies    []uint32                 // frequency of each channel
-	UplinkHistory         []UplinkHistory          // contains the last 20 transmissions
+	UplinkHistory         []UplinkHistory          // contains the last transmissions (see AppendUplinkHistory)
+
+	// ExtraDownlinkFrequencies contains the RX1 frequency by uplink channel
+	// index, for the channels of which the downlink frequency has been
+	// changed using the DlChannelReq mac-command.
+	ExtraDownlinkFrequencies map[int]uint32
 
 	// LastDevStatusRequest contains the timestamp when the last device-status
 	// request was made.
@@ -177,15 +201,23 @@ type DeviceSession struct {
 	// Delayed mac-commands.
 	MACCommandErrorCount map[lorawan.CID]int
 
+	// MACCommandErrorFCntUp contains the uplink frame-counter of the last
+	// mac-command error (by CID), used for the mac-command backoff.
+	MACCommandErrorFCntUp map[lorawan.CID]uint32
+
 	// Device is disabled.
 	IsDisabled bool
 }
 
 // AppendUplinkHistory appends an UplinkHistory item and makes sure the list
-// never exceeds 20 records. In case more records are present, only the most
-// recent ones will be preserved. In case of a re-transmission, the record with
-// the best MaxSNR is stored.
-func (s *DeviceSession) AppendUplinkHistory(up UplinkHistory) {
+// never exceeds the given size (UplinkHistorySize when size <= 0). In case
+// more records are present, only the most recent ones will be preserved.
+// In case of a re-transmission, the record with the best MaxSNR is stored.
+func (s *DeviceSession) AppendUplinkHistory(up UplinkHistory, size int) {
+	if size <= 0 {
+		size = UplinkHistorySize
+	}
+
 	if count := len(s.UplinkHistory); count > 0 {
 		// ignore re-transmissions we don't know the source of the
 		// re-transmission (it might be a replay-attack)
@@ -195,8 +227,8 @@ func (s *DeviceSession) AppendUplinkHistory(up UplinkHistory) {
 	}
 
 	s.UplinkHistory = append(s.UplinkHistory, up)
-	if count := len(s.UplinkHistory); count > UplinkHistorySize {
-		s.UplinkHistory = s.UplinkHistory[count-UplinkHistorySize : count]
+	if count := len(s.UplinkHistory); count > size {
+		s.UplinkHistory = s.UplinkHistory[count-size : count]
 	}
 }
 
@@ -250,6 +282,7 @@ func (s *DeviceSession) ResetToBootParameters(dp DeviceProfile) {
 	s.MinSupportedTXPowerIndex = 0
 	s.MaxSupportedTXPowerIndex = 0
 	s.ExtraUplinkChannels = make(map[int]loraband.Channel)
+	s.ExtraDownlinkFrequencies = nil
 	s.RXDelay = uint8(dp.RXDelay1)
 	s.RX1DROffset = uint8(dp.RXDROffset1)
 	s.RX2DR = uint8(dp.RXDataRate2)
@@ -325,6 +358,10 @@ func SaveDeviceSession(ctx context.Context, s DeviceSession) error {
 	devSessKey := GetRedisKey(deviceSessionKeyTempl, s.DevEUI)
 
 	dsPB := deviceSessionToPB(s)
+	if err := encryptDeviceSessionKeys(dsPB); err != nil {
+		return errors.Wrap(err, "encrypt device-session keys error")
+	}
+
 	b, err := proto.Marshal(dsPB)
 	if err != nil {
 		return errors.Wrap(err, "protobuf encode error")
@@ -358,6 +395,12 @@ func SaveDeviceSession(ctx context.Context, s DeviceSession) error {
 		return errors.Wrap(err, "set error")
 	}
 
+	if persistenceEnabled {
+		if err := persistDeviceSession(ctx, s.DevEUI, s.DevAddr, b); err != nil {
+			return errors.Wrap(err, "persist device-session error")
+		}
+	}
+
 	log.WithFields(log.Fields{
 		"dev_eui":  s.DevEUI,
 		"dev_addr": s.DevAddr,
@@ -375,6 +418,9 @@ func GetDeviceSession(ctx context.Context, devEUI lorawan.EUI64) (DeviceSession,
 	val, err := RedisClient().Get(ctx, key).Bytes()
 	if err != nil {
 		if err == redis.Nil {
+			if persistenceEnabled {
+				return restoreDeviceSession(ctx, devEUI)
+			}
 			return DeviceSession{}, ErrDoesNotExist
 		}
 		return DeviceSession{}, errors.Wrap(err, "get error")
@@ -385,7 +431,25 @@ func GetDeviceSession(ctx context.Context, devEUI lorawan.EUI64) (DeviceSession,
 		return DeviceSession{}, errors.Wrap(err, "unmarshal protobuf error")
 	}
 
-	return deviceSessionFromPB(&dsPB), nil
+	reEncrypt, err := decryptDeviceSessionKeys(&dsPB)
+	if err != nil {
+		return DeviceSession{}, errors.Wrap(err, "decrypt device-session keys error")
+	}
+
+	ds := deviceSessionFromPB(&dsPB)
+
+	// The keys are not encrypted using the configured KEK (e.g. after
+	// a KEK rotation), re-encrypt them lazily.
+	if reEncrypt {
+		if err := reEncryptDeviceSession(ctx, key, val, &dsPB); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).Error("re-encrypt device-session keys error")
+		}
+	}
+
+	return ds, nil
 }
 
 // DeleteDeviceSession deletes the device-session matching the given DevEUI.
@@ -396,6 +460,13 @@ func DeleteDeviceSession(ctx context.Context, devEUI lorawan.EUI64) error {
 	if err != nil {
 		return errors.Wrap(err, "delete error")
 	}
+
+	if persistenceEnabled {
+		if err := deletePersistedDeviceSession(ctx, devEUI); err != nil {
+			return errors.Wrap(err, "delete persisted device-session error")
+		}
+	}
+
 	if val == 0 {
 		return ErrDoesNotExist
 	}
@@ -470,6 +541,12 @@ func GetDevEUIsForDevAddr(ctx context.Context, devAddr lorawan.DevAddr) ([]loraw
 		return nil, errors.Wrap(err, "get deveuis for devaddr error")
 	}
 
+	// The DevAddr set might have been lost (e.g. after a Redis flush), in
+	// which case the persisted device-sessions are used.
+	if len(val) == 0 && persistenceEnabled {
+		return getPersistedDevEUIsForDevAddr(ctx, devAddr)
+	}
+
 	var out []lorawan.EUI64
 	for i := range val {
 		var devEUI lorawan.EUI64
@@ -745,18 +822,49 @@ func deviceSessionToPB(d DeviceSession) *DeviceSessionPB {
 		}
 	}
 
+	if len(d.ExtraDownlinkFrequencies) != 0 {
+		out.ExtraDownlinkFrequencies = make(map[uint32]uint32)
+		for i, f := range d.ExtraDownlinkFrequencies {
+			out.ExtraDownlinkFrequencies[uint32(i)] = f
+		}
+	}
+
 	for _, c := range d.ChannelFrequencies {
 		out.ChannelFrequencies = append(out.ChannelFrequencies, uint32(c))
 	}
 
 	for _, h := range d.UplinkHistory {
-		out.UplinkAdrHistory = append(out.UplinkAdrHistory, &DeviceSessionPBUplinkADRHistory{
+		hPB := DeviceSessionPBUplinkADRHistory{
 			FCnt:         h.FCnt,
 			MaxSnr:       float32(h.MaxSNR),
 			TxPowerIndex: uint32(h.TXPowerIndex),
 			GatewayCount: uint32(h.GatewayCount),
 			MaxRssi:      h.MaxRSSI,
-		})
+			Dr:           uint32(h.DR),
+			Frequency:    h.Frequency,
+		}
+
+		if h.Channel == UplinkHistoryUnknownChannel {
+			hPB.ChannelUnknown = true
+		} else {
+			hPB.Channel = uint32(h.Channel)
+		}
+
+		if !h.Time.IsZero() {
+			if ts, err := ptypes.TimestampProto(h.Time); err == nil {
+				hPB.Time = ts
+			}
+		}
+
+		for _, gw := range h.Gateways {
+			hPB.Gateways = append(hPB.Gateways, &DeviceSessionPBUplinkADRHistoryGateway{
+				GatewayId: gw.GatewayID[:],
+				Snr:       float32(gw.SNR),
+				Rssi:      gw.RSSI,
+			})
+		}
+
+		out.UplinkAdrHistory = append(out.UplinkAdrHistory, &hPB)
 	}
 
 	if d.PendingRejoinDeviceSession != nil {
@@ -773,6 +881,13 @@ func deviceSessionToPB(d DeviceSession) *DeviceSessionPB {
 		out.MacCommandErrorCount[uint32(k)] = uint32(v)
 	}
 
+	if len(d.MACCommandErrorFCntUp) != 0 {
+		out.MacCommandErrorFCntUp = make(map[uint32]uint32)
+		for k, v := range d.MACCommandErrorFCntUp {
+			out.MacCommandErrorFCntUp[uint32(k)] = v
+		}
+	}
+
 	return &out
 }
 
@@ -859,18 +974,51 @@ func deviceSessionFromPB(d *DeviceSessionPB) DeviceSession {
 		}
 	}
 
+	if len(d.ExtraDownlinkFrequencies) != 0 {
+		out.ExtraDownlinkFrequencies = make(map[int]uint32)
+		for i, f := range d.ExtraDownlinkFrequencies {
+			out.ExtraDownlinkFrequencies[int(i)] = f
+		}
+	}
+
 	for _, c := range d.ChannelFrequencies {
 		out.ChannelFrequencies = append(out.ChannelFrequencies, c)
 	}
 
 	for _, h := range d.UplinkAdrHistory {
-		out.UplinkHistory = append(out.UplinkHistory, UplinkHistory{
+		uh := UplinkHistory{
 			FCnt:         h.FCnt,
 			MaxSNR:       float64(h.MaxSnr),
 			TXPowerIndex: int(h.TxPowerIndex),
 			GatewayCount: int(h.GatewayCount),
 			MaxRSSI:      h.MaxRssi,
-		})
+			DR:           int(h.Dr),
+			Frequency:    h.Frequency,
+			Channel:      int(h.Channel),
+		}
+
+		if h.ChannelUnknown {
+			uh.Channel = UplinkHistoryUnknownChannel
+		}
+
+		if h.Time != nil {
+			if t, err := ptypes.Timestamp(h.Time); err == nil {
+				uh.Time = t
+			}
+		}
+
+		for _, gw := range h.Gateways {
+			var gatewayID lorawan.EUI64
+			copy(gatewayID[:], gw.GatewayId)
+
+			uh.Gateways = append(uh.Gateways, UplinkHistoryGateway{
+				GatewayID: gatewayID,
+				SNR:       float64(gw.Snr),
+				RSSI:      gw.Rssi,
+			})
+		}
+
+		out.UplinkHistory = append(out.UplinkHistory, uh)
 	}
 
 	if len(d.PendingRejoinDeviceSession) != 0 {
@@ -887,6 +1035,13 @@ func deviceSessionFromPB(d *DeviceSessionPB) DeviceSession {
 		out.MACCommandErrorCount[lorawan.CID(k)] = int(v)
 	}
 
+	if len(d.MacCommandErrorFCntUp) != 0 {
+		out.MACCommandErrorFCntUp = make(map[lorawan.CID]uint32)
+		for k, v := range d.MacCommandErrorFCntUp {
+			out.MACCommandErrorFCntUp[lorawan.CID(k)] = v
+		}
+	}
+
 	return out
 }
 
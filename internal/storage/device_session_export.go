@@ -0,0 +1,304 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/lorawan"
+)
+
+// DeviceSessionExportVersion defines the version of the device-session
+// export format.
+const DeviceSessionExportVersion = 1
+
+// maxExportRecordSize defines the max. size of a single export record.
+const maxExportRecordSize = 10 * 1024 * 1024
+
+// DeviceSessionExportHeader defines the header (first line) of a
+// device-session export.
+type DeviceSessionExportHeader struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeviceSessionExportRecord contains the Redis stored state of a single
+// device. The values are stored as-is, this means that the device-session
+// keys are still encrypted in case a device-session KEK is configured.
+type DeviceSessionExportRecord struct {
+	DevEUI           lorawan.EUI64 `json:"dev_eui"`
+	DeviceSession    []byte        `json:"device_session"`
+	GatewayRXInfoSet []byte        `json:"gateway_rx_info_set,omitempty"`
+	MACCommandQueue  [][]byte      `json:"mac_command_queue,omitempty"`
+}
+
+// DeviceSessionExportFilters provides filters for exporting device-sessions.
+type DeviceSessionExportFilters struct {
+	// DevEUIs limits the export to the given DevEUIs.
+	DevEUIs []lorawan.EUI64
+
+	// DeviceProfileID limits the export to the device-sessions of the given
+	// device-profile.
+	DeviceProfileID *uuid.UUID
+
+	// KeyPrefix overrides the configured Redis key prefix.
+	KeyPrefix *string
+}
+
+// DeviceSessionImportOptions provides the options for importing
+// device-sessions.
+type DeviceSessionImportOptions struct {
+	// KeyPrefix overrides the configured Redis key prefix.
+	KeyPrefix *string
+
+	// Overwrite existing device-sessions. By default, existing device-sessions
+	// are skipped as these could be more recent than the imported state.
+	Overwrite bool
+}
+
+// DeviceSessionImportResult contains the result of an import.
+type DeviceSessionImportResult struct {
+	Imported int
+	Skipped  int
+}
+
+// ExportDeviceSessions writes the device-sessions, device gateway rx-info
+// sets and mac-command queues matching the given filters to w. The export
+// starts with a DeviceSessionExportHeader line, followed by one
+// DeviceSessionExportRecord line per device. It returns the number of
+// exported device-sessions.
+func ExportDeviceSessions(ctx context.Context, w io.Writer, filters DeviceSessionExportFilters) (int, error) {
+	prefix := keyPrefix
+	if filters.KeyPrefix != nil {
+		prefix = *filters.KeyPrefix
+	}
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(DeviceSessionExportHeader{
+		Version:   DeviceSessionExportVersion,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return 0, errors.Wrap(err, "write header error")
+	}
+
+	var count int
+	export := func(devEUI lorawan.EUI64) error {
+		rec, ok, err := getDeviceSessionExportRecord(ctx, prefix, devEUI, filters.DeviceProfileID)
+		if err != nil || !ok {
+			return err
+		}
+
+		if err := enc.Encode(rec); err != nil {
+			return errors.Wrap(err, "write record error")
+		}
+		count++
+		return nil
+	}
+
+	if len(filters.DevEUIs) != 0 {
+		for _, devEUI := range filters.DevEUIs {
+			if err := export(devEUI); err != nil {
+				return count, err
+			}
+		}
+		return count, nil
+	}
+
+	dsPrefix := prefix + fmt.Sprintf(deviceSessionKeyTempl, "")
+	err := scanKeys(ctx, dsPrefix+"*", func(key string) error {
+		// Skip the other keys sharing the same prefix (e.g. the
+		// device gateway rx-info set).
+		suffix := strings.TrimPrefix(key, dsPrefix)
+		if strings.Contains(suffix, ":") {
+			return nil
+		}
+
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(suffix)); err != nil {
+			log.WithError(err).WithField("key", key).Warning("storage: skipping device-session with invalid DevEUI")
+			return nil
+		}
+
+		return export(devEUI)
+	})
+
+	return count, err
+}
+
+// getDeviceSessionExportRecord returns the export record for the given
+// DevEUI. It returns false when there is no device-session or when it does
+// not match the given device-profile ID.
+func getDeviceSessionExportRecord(ctx context.Context, prefix string, devEUI lorawan.EUI64, deviceProfileID *uuid.UUID) (DeviceSessionExportRecord, bool, error) {
+	rec := DeviceSessionExportRecord{
+		DevEUI: devEUI,
+	}
+
+	var err error
+	rec.DeviceSession, err = RedisClient().Get(ctx, prefix+fmt.Sprintf(deviceSessionKeyTempl, devEUI)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return rec, false, nil
+		}
+		return rec, false, errors.Wrap(err, "get device-session error")
+	}
+
+	if deviceProfileID != nil {
+		var dsPB DeviceSessionPB
+		if err := proto.Unmarshal(rec.DeviceSession, &dsPB); err != nil {
+			return rec, false, errors.Wrap(err, "unmarshal device-session error")
+		}
+
+		if dsPB.DeviceProfileId != deviceProfileID.String() {
+			return rec, false, nil
+		}
+	}
+
+	rec.GatewayRXInfoSet, err = RedisClient().Get(ctx, prefix+fmt.Sprintf(deviceGatewayRXInfoSetKeyTempl, devEUI)).Bytes()
+	if err != nil && err != redis.Nil {
+		return rec, false, errors.Wrap(err, "get device gateway rx-info set error")
+	}
+
+	items, err := RedisClient().LRange(ctx, prefix+fmt.Sprintf(macCommandQueueTempl, devEUI), 0, -1).Result()
+	if err != nil {
+		return rec, false, errors.Wrap(err, "get mac-command queue error")
+	}
+	for _, item := range items {
+		rec.MACCommandQueue = append(rec.MACCommandQueue, []byte(item))
+	}
+
+	return rec, true, nil
+}
+
+// ImportDeviceSessions imports the device-sessions, device gateway rx-info
+// sets and mac-command queues from the given export.
+func ImportDeviceSessions(ctx context.Context, r io.Reader, opts DeviceSessionImportOptions) (DeviceSessionImportResult, error) {
+	var res DeviceSessionImportResult
+
+	prefix := keyPrefix
+	if opts.KeyPrefix != nil {
+		prefix = *opts.KeyPrefix
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxExportRecordSize)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return res, errors.Wrap(err, "read header error")
+		}
+		return res, errors.New("export is empty")
+	}
+
+	var header DeviceSessionExportHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return res, errors.Wrap(err, "decode header error")
+	}
+	if header.Version != DeviceSessionExportVersion {
+		return res, fmt.Errorf("unsupported export version: %d", header.Version)
+	}
+
+	for line := 2; scanner.Scan(); line++ {
+		var rec DeviceSessionExportRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return res, errors.Wrapf(err, "decode record error (line %d)", line)
+		}
+
+		imported, err := importDeviceSessionExportRecord(ctx, prefix, rec, opts.Overwrite)
+		if err != nil {
+			return res, errors.Wrapf(err, "import device-session error (dev_eui: %s)", rec.DevEUI)
+		}
+
+		if imported {
+			res.Imported++
+		} else {
+			res.Skipped++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return res, errors.Wrap(err, "read record error")
+	}
+
+	return res, nil
+}
+
+// importDeviceSessionExportRecord imports the given record. It returns false
+// when the device-session already exists and overwrite is false.
+func importDeviceSessionExportRecord(ctx context.Context, prefix string, rec DeviceSessionExportRecord, overwrite bool) (bool, error) {
+	dsKey := prefix + fmt.Sprintf(deviceSessionKeyTempl, rec.DevEUI)
+
+	var dsPB DeviceSessionPB
+	if err := proto.Unmarshal(rec.DeviceSession, &dsPB); err != nil {
+		return false, errors.Wrap(err, "unmarshal device-session error")
+	}
+
+	devAddrs := [][]byte{dsPB.DevAddr}
+	if len(dsPB.PendingRejoinDeviceSession) != 0 {
+		var pendingPB DeviceSessionPB
+		if err := proto.Unmarshal(dsPB.PendingRejoinDeviceSession, &pendingPB); err != nil {
+			return false, errors.Wrap(err, "unmarshal pending rejoin device-session error")
+		}
+		devAddrs = append(devAddrs, pendingPB.DevAddr)
+	}
+
+	if overwrite {
+		if err := RedisClient().Set(ctx, dsKey, rec.DeviceSession, deviceSessionTTL).Err(); err != nil {
+			return false, errors.Wrap(err, "set device-session error")
+		}
+	} else {
+		set, err := RedisClient().SetNX(ctx, dsKey, rec.DeviceSession, deviceSessionTTL).Result()
+		if err != nil {
+			return false, errors.Wrap(err, "set device-session error")
+		}
+		if !set {
+			return false, nil
+		}
+	}
+
+	// Note that we must execute the DevAddr set related operations in
+	// multiple tx pipelines in order to support Redis Cluster.
+	for _, b := range devAddrs {
+		var devAddr lorawan.DevAddr
+		copy(devAddr[:], b)
+		devAddrKey := prefix + fmt.Sprintf(devAddrKeyTempl, devAddr)
+
+		pipe := RedisClient().TxPipeline()
+		pipe.SAdd(ctx, devAddrKey, rec.DevEUI[:])
+		pipe.PExpire(ctx, devAddrKey, deviceSessionTTL)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return false, errors.Wrap(err, "add DevEUI to DevAddr set error")
+		}
+	}
+
+	if len(rec.GatewayRXInfoSet) != 0 {
+		key := prefix + fmt.Sprintf(deviceGatewayRXInfoSetKeyTempl, rec.DevEUI)
+		if err := RedisClient().Set(ctx, key, rec.GatewayRXInfoSet, deviceSessionTTL).Err(); err != nil {
+			return false, errors.Wrap(err, "set device gateway rx-info set error")
+		}
+	}
+
+	macKey := prefix + fmt.Sprintf(macCommandQueueTempl, rec.DevEUI)
+	pipe := RedisClient().TxPipeline()
+	pipe.Del(ctx, macKey)
+	if len(rec.MACCommandQueue) != 0 {
+		for _, item := range rec.MACCommandQueue {
+			pipe.RPush(ctx, macKey, item)
+		}
+		pipe.PExpire(ctx, macKey, deviceSessionTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, errors.Wrap(err, "set mac-command queue error")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/lorawan"
+)
+
+func TestImportDeviceSessionsHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: "",
+			err:   "export is empty",
+		},
+		{
+			name:  "invalid header",
+			input: "foo\n",
+			err:   "decode header error",
+		},
+		{
+			name:  "unsupported version",
+			input: `{"version":2}` + "\n",
+			err:   "unsupported export version: 2",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := ImportDeviceSessions(context.Background(), strings.NewReader(tst.input), DeviceSessionImportOptions{})
+			assert.Error(err)
+			assert.Contains(err.Error(), tst.err)
+		})
+	}
+}
+
+func (ts *StorageTestSuite) TestExportImportDeviceSessions() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	dpID, _ := uuid.NewV4()
+	ds1 := DeviceSession{
+		DevEUI:          lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+		DevAddr:         lorawan.DevAddr{1, 2, 3, 4},
+		DeviceProfileID: dpID,
+		FCntUp:          10,
+	}
+	ds2 := DeviceSession{
+		DevEUI:  lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+		DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+		FCntUp:  20,
+	}
+	assert.NoError(SaveDeviceSession(ctx, ds1))
+	assert.NoError(SaveDeviceSession(ctx, ds2))
+	assert.NoError(SaveDeviceGatewayRXInfoSet(ctx, DeviceGatewayRXInfoSet{DevEUI: ds1.DevEUI, DR: 3}))
+	assert.NoError(CreateMACCommandQueueItem(ctx, ds1.DevEUI, MACCommandBlock{
+		CID: lorawan.DevStatusReq,
+		MACCommands: MACCommands{
+			{CID: lorawan.DevStatusReq},
+		},
+	}))
+
+	ts.T().Run("Export all", func(t *testing.T) {
+		assert := require.New(t)
+
+		var buf bytes.Buffer
+		count, err := ExportDeviceSessions(ctx, &buf, DeviceSessionExportFilters{})
+		assert.NoError(err)
+		assert.Equal(2, count)
+		assert.Len(strings.Split(strings.TrimSpace(buf.String()), "\n"), 3)
+	})
+
+	ts.T().Run("Export for DevEUIs", func(t *testing.T) {
+		assert := require.New(t)
+
+		var buf bytes.Buffer
+		count, err := ExportDeviceSessions(ctx, &buf, DeviceSessionExportFilters{
+			DevEUIs: []lorawan.EUI64{ds2.DevEUI, {3, 3, 3, 3, 3, 3, 3, 3}},
+		})
+		assert.NoError(err)
+		assert.Equal(1, count)
+	})
+
+	ts.T().Run("Export for device-profile and import under different prefix", func(t *testing.T) {
+		assert := require.New(t)
+
+		var buf bytes.Buffer
+		count, err := ExportDeviceSessions(ctx, &buf, DeviceSessionExportFilters{
+			DeviceProfileID: &dpID,
+		})
+		assert.NoError(err)
+		assert.Equal(1, count)
+
+		prefix := "restore:"
+		res, err := ImportDeviceSessions(ctx, bytes.NewReader(buf.Bytes()), DeviceSessionImportOptions{
+			KeyPrefix: &prefix,
+		})
+		assert.NoError(err)
+		assert.Equal(DeviceSessionImportResult{Imported: 1}, res)
+
+		keyPrefix = prefix
+		defer func() { keyPrefix = "" }()
+
+		dsGet, err := GetDeviceSession(ctx, ds1.DevEUI)
+		assert.NoError(err)
+		assert.EqualValues(10, dsGet.FCntUp)
+
+		devEUIs, err := GetDevEUIsForDevAddr(ctx, ds1.DevAddr)
+		assert.NoError(err)
+		assert.Equal([]lorawan.EUI64{ds1.DevEUI}, devEUIs)
+
+		rxInfoSet, err := GetDeviceGatewayRXInfoSet(ctx, ds1.DevEUI)
+		assert.NoError(err)
+		assert.Equal(3, rxInfoSet.DR)
+
+		blocks, err := GetMACCommandQueueItems(ctx, ds1.DevEUI)
+		assert.NoError(err)
+		assert.Len(blocks, 1)
+
+		// the device-session already exists
+		res, err = ImportDeviceSessions(ctx, bytes.NewReader(buf.Bytes()), DeviceSessionImportOptions{})
+		assert.NoError(err)
+		assert.Equal(DeviceSessionImportResult{Skipped: 1}, res)
+	})
+}
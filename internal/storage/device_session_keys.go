@@ -0,0 +1,308 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	proto "github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/chirpstack-api/go/v3/common"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/lorawan"
+	"github.com/liuhw0/lorawan/backend"
+)
+
+// deviceSessionKEKLabel holds the label of the KEK used for encrypting the
+// device-session keys. When empty, keys are stored in plaintext.
+var deviceSessionKEKLabel string
+
+// deviceSessionKEKs holds the KEKs (by label) which can be used for
+// decrypting the device-session keys.
+var deviceSessionKEKs map[string][]byte
+
+func setupDeviceSessionKEKs(c config.Config) error {
+	conf := c.NetworkServer.DeviceSessionKEK
+
+	deviceSessionKEKLabel = conf.Label
+	deviceSessionKEKs = make(map[string][]byte)
+
+	for _, k := range conf.Set {
+		kek, err := hex.DecodeString(k.KEK)
+		if err != nil {
+			return errors.Wrap(err, "decode device-session kek error")
+		}
+
+		switch len(kek) {
+		case 16, 24, 32:
+		default:
+			return fmt.Errorf("device-session kek %s must be 16, 24 or 32 bytes", k.Label)
+		}
+
+		deviceSessionKEKs[k.Label] = kek
+	}
+
+	if deviceSessionKEKLabel != "" {
+		if _, ok := deviceSessionKEKs[deviceSessionKEKLabel]; !ok {
+			return fmt.Errorf("device-session kek label %s is not in the kek set", deviceSessionKEKLabel)
+		}
+	}
+
+	return nil
+}
+
+// encryptDeviceSessionKeys encrypts the session-keys of the given
+// device-session using the configured KEK. When no KEK is configured, the
+// keys are kept in plaintext. This includes the pending rejoin
+// device-session.
+func encryptDeviceSessionKeys(d *DeviceSessionPB) error {
+	if len(d.PendingRejoinDeviceSession) != 0 {
+		var dsPB DeviceSessionPB
+		if err := proto.Unmarshal(d.PendingRejoinDeviceSession, &dsPB); err != nil {
+			return errors.Wrap(err, "unmarshal pending rejoin device-session error")
+		}
+
+		if err := encryptDeviceSessionKeys(&dsPB); err != nil {
+			return errors.Wrap(err, "encrypt pending rejoin device-session keys error")
+		}
+
+		b, err := proto.Marshal(&dsPB)
+		if err != nil {
+			return errors.Wrap(err, "marshal pending rejoin device-session error")
+		}
+		d.PendingRejoinDeviceSession = b
+	}
+
+	if deviceSessionKEKLabel == "" {
+		return nil
+	}
+
+	kek := deviceSessionKEKs[deviceSessionKEKLabel]
+
+	for _, k := range []struct {
+		key      *[]byte
+		envelope **common.KeyEnvelope
+	}{
+		{&d.FNwkSIntKey, &d.FNwkSIntKeyEnvelope},
+		{&d.SNwkSIntKey, &d.SNwkSIntKeyEnvelope},
+		{&d.NwkSEncKey, &d.NwkSEncKeyEnvelope},
+	} {
+		var key lorawan.AES128Key
+		copy(key[:], *k.key)
+
+		ke, err := backend.NewKeyEnvelope(deviceSessionKEKLabel, kek, key)
+		if err != nil {
+			return errors.Wrap(err, "new key envelope error")
+		}
+
+		*k.key = nil
+		*k.envelope = &common.KeyEnvelope{
+			KekLabel: ke.KEKLabel,
+			AesKey:   ke.AESKey[:],
+		}
+	}
+
+	return nil
+}
+
+// decryptDeviceSessionKeys decrypts the session-keys of the given
+// device-session. It returns true when the stored keys are not encrypted
+// using the configured KEK (e.g. after a KEK rotation, or when the keys
+// are stored in plaintext) and the device-session must be re-encrypted.
+// This includes the pending rejoin device-session.
+func decryptDeviceSessionKeys(d *DeviceSessionPB) (bool, error) {
+	var reEncrypt bool
+
+	if len(d.PendingRejoinDeviceSession) != 0 {
+		var dsPB DeviceSessionPB
+		if err := proto.Unmarshal(d.PendingRejoinDeviceSession, &dsPB); err != nil {
+			return false, errors.Wrap(err, "unmarshal pending rejoin device-session error")
+		}
+
+		re, err := decryptDeviceSessionKeys(&dsPB)
+		if err != nil {
+			return false, errors.Wrap(err, "decrypt pending rejoin device-session keys error")
+		}
+		reEncrypt = reEncrypt || re
+
+		b, err := proto.Marshal(&dsPB)
+		if err != nil {
+			return false, errors.Wrap(err, "marshal pending rejoin device-session error")
+		}
+		d.PendingRejoinDeviceSession = b
+	}
+
+	for _, k := range []struct {
+		key      *[]byte
+		envelope **common.KeyEnvelope
+	}{
+		{&d.FNwkSIntKey, &d.FNwkSIntKeyEnvelope},
+		{&d.SNwkSIntKey, &d.SNwkSIntKeyEnvelope},
+		{&d.NwkSEncKey, &d.NwkSEncKeyEnvelope},
+	} {
+		if *k.envelope == nil {
+			// the key is stored in plaintext
+			if deviceSessionKEKLabel != "" {
+				reEncrypt = true
+			}
+			continue
+		}
+
+		ke := backend.KeyEnvelope{
+			KEKLabel: (*k.envelope).KekLabel,
+			AESKey:   backend.HEXBytes((*k.envelope).AesKey),
+		}
+
+		if ke.KEKLabel == "" {
+			*k.key = ke.AESKey[:]
+		} else {
+			kek, ok := deviceSessionKEKs[ke.KEKLabel]
+			if !ok {
+				return false, fmt.Errorf("unknown device-session kek label: %s", ke.KEKLabel)
+			}
+
+			key, err := ke.Unwrap(kek)
+			if err != nil {
+				return false, errors.Wrap(err, "unwrap key error")
+			}
+			*k.key = key[:]
+		}
+
+		if ke.KEKLabel != deviceSessionKEKLabel {
+			reEncrypt = true
+		}
+		*k.envelope = nil
+	}
+
+	return reEncrypt, nil
+}
+
+// reEncryptDeviceSession re-encrypts the device-session keys stored under
+// the given key, using the configured KEK. The device-session is only
+// updated when it has not been modified since it was read (b), to avoid
+// overwriting concurrent updates. The TTL of the key is preserved.
+func reEncryptDeviceSession(ctx context.Context, key string, b []byte, dsPB *DeviceSessionPB) error {
+	if err := encryptDeviceSessionKeys(dsPB); err != nil {
+		return errors.Wrap(err, "encrypt device-session keys error")
+	}
+
+	newB, err := proto.Marshal(dsPB)
+	if err != nil {
+		return errors.Wrap(err, "protobuf encode error")
+	}
+
+	err = RedisClient().Watch(ctx, func(tx *redis.Tx) error {
+		val, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			if err == redis.Nil {
+				return nil
+			}
+			return errors.Wrap(err, "get error")
+		}
+
+		// The device-session has been updated in the meantime, which
+		// means it has already been stored using the configured KEK.
+		if !bytes.Equal(val, b) {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, newB, redis.KeepTTL)
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil && err != redis.TxFailedErr {
+		return errors.Wrap(err, "watch error")
+	}
+
+	return nil
+}
+
+// ReEncryptDeviceSessions re-encrypts the keys of all the device-sessions
+// which are not encrypted using the configured KEK. This can be used after
+// a KEK rotation, or after enabling the device-session key encryption, to
+// migrate the existing device-sessions without waiting for each device to
+// send an uplink. It returns the number of re-encrypted device-sessions.
+func ReEncryptDeviceSessions(ctx context.Context) (int, error) {
+	var count int
+
+	prefix := GetRedisKey(deviceSessionKeyTempl, "")
+	err := scanKeys(ctx, prefix+"*", func(key string) error {
+		// Skip the other keys sharing the same prefix (e.g. the
+		// device gateway rx-info set).
+		if strings.Contains(strings.TrimPrefix(key, prefix), ":") {
+			return nil
+		}
+
+		b, err := RedisClient().Get(ctx, key).Bytes()
+		if err != nil {
+			if err == redis.Nil {
+				return nil
+			}
+			return errors.Wrap(err, "get error")
+		}
+
+		var dsPB DeviceSessionPB
+		if err := proto.Unmarshal(b, &dsPB); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"key":    key,
+				"ctx_id": ctx.Value(logging.ContextIDKey),
+			}).Error("unmarshal device-session error")
+			return nil
+		}
+
+		reEncrypt, err := decryptDeviceSessionKeys(&dsPB)
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"key":    key,
+				"ctx_id": ctx.Value(logging.ContextIDKey),
+			}).Error("decrypt device-session keys error")
+			return nil
+		}
+
+		if !reEncrypt {
+			return nil
+		}
+
+		if err := reEncryptDeviceSession(ctx, key, b, &dsPB); err != nil {
+			return err
+		}
+		count++
+
+		return nil
+	})
+
+	return count, err
+}
+
+// scanKeys calls the given function for each key matching the given pattern.
+// In case of a Redis Cluster, all master nodes are scanned.
+func scanKeys(ctx context.Context, pattern string, f func(key string) error) error {
+	scan := func(ctx context.Context, c redis.Cmdable) error {
+		iter := c.Scan(ctx, 0, pattern, 1000).Iterator()
+		for iter.Next(ctx) {
+			if err := f(iter.Val()); err != nil {
+				return err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return errors.Wrap(err, "scan error")
+		}
+		return nil
+	}
+
+	if c, ok := RedisClient().(*redis.ClusterClient); ok {
+		return c.ForEachMaster(ctx, func(ctx context.Context, c *redis.Client) error {
+			return scan(ctx, c)
+		})
+	}
+
+	return scan(ctx, RedisClient())
+}
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	proto "github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/lorawan"
+	loraband "github.com/liuhw0/lorawan/band"
+)
+
+func TestDeviceSessionKeysEncryption(t *testing.T) {
+	assert := require.New(t)
+
+	var conf config.Config
+	conf.NetworkServer.DeviceSessionKEK.Set = []config.KEK{
+		{Label: "kek-1", KEK: "01020304050607080102030405060708"},
+		{Label: "kek-2", KEK: "08070605040302010807060504030201"},
+	}
+
+	pending := DeviceSession{
+		DevAddr:              lorawan.DevAddr{4, 3, 2, 1},
+		FNwkSIntKey:          lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+		ExtraUplinkChannels:  map[int]loraband.Channel{},
+		MACCommandErrorCount: make(map[lorawan.CID]int),
+	}
+
+	ds := DeviceSession{
+		DevAddr:                    lorawan.DevAddr{1, 2, 3, 4},
+		DevEUI:                     lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		FNwkSIntKey:                lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		SNwkSIntKey:                lorawan.AES128Key{2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1},
+		NwkSEncKey:                 lorawan.AES128Key{3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2},
+		ExtraUplinkChannels:        map[int]loraband.Channel{},
+		MACCommandErrorCount:       make(map[lorawan.CID]int),
+		PendingRejoinDeviceSession: &pending,
+	}
+
+	// encode returns the device-session as it would be stored in Redis.
+	encode := func(ds DeviceSession) *DeviceSessionPB {
+		dsPB := deviceSessionToPB(ds)
+		assert.NoError(encryptDeviceSessionKeys(dsPB))
+		b, err := proto.Marshal(dsPB)
+		assert.NoError(err)
+
+		var out DeviceSessionPB
+		assert.NoError(proto.Unmarshal(b, &out))
+		return &out
+	}
+
+	t.Run("Unknown KEK label", func(t *testing.T) {
+		assert := require.New(t)
+		c := conf
+		c.NetworkServer.DeviceSessionKEK.Label = "kek-3"
+		assert.Error(setupDeviceSessionKEKs(c))
+	})
+
+	t.Run("Plaintext", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(setupDeviceSessionKEKs(conf))
+
+		dsPB := encode(ds)
+		assert.Equal(ds.FNwkSIntKey[:], dsPB.FNwkSIntKey)
+		assert.Nil(dsPB.FNwkSIntKeyEnvelope)
+
+		reEncrypt, err := decryptDeviceSessionKeys(dsPB)
+		assert.NoError(err)
+		assert.False(reEncrypt)
+		assert.Equal(ds, deviceSessionFromPB(dsPB))
+
+		t.Run("Enable encryption", func(t *testing.T) {
+			assert := require.New(t)
+			c := conf
+			c.NetworkServer.DeviceSessionKEK.Label = "kek-1"
+			assert.NoError(setupDeviceSessionKEKs(c))
+
+			dsPB := encode(ds)
+			assert.Nil(dsPB.FNwkSIntKey)
+			assert.Nil(dsPB.SNwkSIntKey)
+			assert.Nil(dsPB.NwkSEncKey)
+			assert.Equal("kek-1", dsPB.FNwkSIntKeyEnvelope.KekLabel)
+			assert.Equal("kek-1", dsPB.SNwkSIntKeyEnvelope.KekLabel)
+			assert.Equal("kek-1", dsPB.NwkSEncKeyEnvelope.KekLabel)
+
+			var pendingPB DeviceSessionPB
+			assert.NoError(proto.Unmarshal(dsPB.PendingRejoinDeviceSession, &pendingPB))
+			assert.Nil(pendingPB.FNwkSIntKey)
+			assert.Equal("kek-1", pendingPB.FNwkSIntKeyEnvelope.KekLabel)
+
+			reEncrypt, err := decryptDeviceSessionKeys(dsPB)
+			assert.NoError(err)
+			assert.False(reEncrypt)
+			assert.Equal(ds, deviceSessionFromPB(dsPB))
+
+			t.Run("Rotate KEK", func(t *testing.T) {
+				assert := require.New(t)
+				dsPB := encode(ds)
+
+				c.NetworkServer.DeviceSessionKEK.Label = "kek-2"
+				assert.NoError(setupDeviceSessionKEKs(c))
+
+				reEncrypt, err := decryptDeviceSessionKeys(dsPB)
+				assert.NoError(err)
+				assert.True(reEncrypt)
+				assert.Equal(ds, deviceSessionFromPB(dsPB))
+
+				dsPB = encode(ds)
+				assert.Equal("kek-2", dsPB.FNwkSIntKeyEnvelope.KekLabel)
+
+				t.Run("Removed KEK", func(t *testing.T) {
+					assert := require.New(t)
+
+					c := conf
+					c.NetworkServer.DeviceSessionKEK.Label = "kek-1"
+					c.NetworkServer.DeviceSessionKEK.Set = c.NetworkServer.DeviceSessionKEK.Set[:1]
+					assert.NoError(setupDeviceSessionKEKs(c))
+
+					_, err := decryptDeviceSessionKeys(dsPB)
+					assert.Error(err)
+				})
+			})
+
+			t.Run("Plaintext stored keys", func(t *testing.T) {
+				assert := require.New(t)
+				assert.NoError(setupDeviceSessionKEKs(c))
+
+				dsPB := deviceSessionToPB(ds)
+				reEncrypt, err := decryptDeviceSessionKeys(dsPB)
+				assert.NoError(err)
+				assert.True(reEncrypt)
+				assert.Equal(ds, deviceSessionFromPB(dsPB))
+			})
+		})
+	})
+
+	assert.NoError(setupDeviceSessionKEKs(config.Config{}))
+}
+
+func (ts *StorageTestSuite) TestReEncryptDeviceSessions() {
+	assert := require.New(ts.T())
+
+	var conf config.Config
+	conf.NetworkServer.DeviceSessionKEK.Set = []config.KEK{
+		{Label: "kek-1", KEK: "01020304050607080102030405060708"},
+	}
+	assert.NoError(setupDeviceSessionKEKs(conf))
+	defer setupDeviceSessionKEKs(config.Config{})
+
+	ds := DeviceSession{
+		DevAddr:              lorawan.DevAddr{1, 2, 3, 4},
+		DevEUI:               lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		FNwkSIntKey:          lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		ExtraUplinkChannels:  map[int]loraband.Channel{},
+		MACCommandErrorCount: make(map[lorawan.CID]int),
+	}
+	ds2 := ds
+	ds2.DevEUI = lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+
+	assert.NoError(SaveDeviceSession(context.Background(), ds))
+	assert.NoError(SaveDeviceSession(context.Background(), ds2))
+
+	getStored := func(devEUI lorawan.EUI64) *DeviceSessionPB {
+		var dsPB DeviceSessionPB
+		b, err := RedisClient().Get(context.Background(), GetRedisKey(deviceSessionKeyTempl, devEUI)).Bytes()
+		assert.NoError(err)
+		assert.NoError(proto.Unmarshal(b, &dsPB))
+		return &dsPB
+	}
+
+	ts.T().Run("Enable encryption", func(t *testing.T) {
+		assert := require.New(t)
+		conf.NetworkServer.DeviceSessionKEK.Label = "kek-1"
+		assert.NoError(setupDeviceSessionKEKs(conf))
+
+		t.Run("Lazy re-encryption", func(t *testing.T) {
+			assert := require.New(t)
+
+			dsGet, err := GetDeviceSession(context.Background(), ds.DevEUI)
+			assert.NoError(err)
+			assert.Equal(ds, dsGet)
+
+			dsPB := getStored(ds.DevEUI)
+			assert.Nil(dsPB.FNwkSIntKey)
+			assert.Equal("kek-1", dsPB.FNwkSIntKeyEnvelope.KekLabel)
+
+			dsPB = getStored(ds2.DevEUI)
+			assert.Nil(dsPB.FNwkSIntKeyEnvelope)
+		})
+
+		t.Run("ReEncryptDeviceSessions", func(t *testing.T) {
+			assert := require.New(t)
+
+			count, err := ReEncryptDeviceSessions(context.Background())
+			assert.NoError(err)
+			assert.Equal(1, count)
+
+			dsPB := getStored(ds2.DevEUI)
+			assert.Equal("kek-1", dsPB.FNwkSIntKeyEnvelope.KekLabel)
+
+			dsGet, err := GetDeviceSession(context.Background(), ds2.DevEUI)
+			assert.NoError(err)
+			assert.Equal(ds2, dsGet)
+		})
+	})
+}
@@ -0,0 +1,342 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/lorawan"
+)
+
+var (
+	// persistenceEnabled defines if device-session snapshots are persisted
+	// to PostgreSQL.
+	persistenceEnabled bool
+
+	// persistenceFlushInterval defines the interval in which the pending
+	// snapshots are written to PostgreSQL. When set to 0, the snapshots are
+	// written synchronously.
+	persistenceFlushInterval time.Duration
+
+	// persistenceFCntGap defines the value by which the downlink
+	// frame-counters of a restored device-session are incremented.
+	persistenceFCntGap uint32
+
+	persistenceLoopOnce sync.Once
+)
+
+// persistenceMissTTL defines how long a DevAddr for which no persisted
+// device-session exists is cached, to avoid a PostgreSQL lookup for every
+// uplink using an unknown DevAddr.
+const persistenceMissTTL = time.Minute
+
+const devAddrMissKeyTempl = "lora:ns:devaddr:%s:miss"
+
+// deviceSessionSnapshot contains a pending device-session snapshot.
+type deviceSessionSnapshot struct {
+	devAddr   lorawan.DevAddr
+	b         []byte
+	deleted   bool
+	createdAt time.Time
+}
+
+// pendingSnapshots contains the snapshots which must be written to
+// PostgreSQL. Only the last snapshot of each device-session is kept.
+var pendingSnapshots = struct {
+	sync.Mutex
+	items map[lorawan.EUI64]deviceSessionSnapshot
+}{
+	items: make(map[lorawan.EUI64]deviceSessionSnapshot),
+}
+
+func setupDeviceSessionPersistence(c config.Config) error {
+	conf := c.NetworkServer.DeviceSessionPersistence
+
+	// With write-behind, the pending snapshots are lost when the process
+	// exits, thus the persisted snapshot might be older than the
+	// device-session that was lost.
+	if conf.Enabled && conf.FlushInterval > 0 && conf.FCntGap == 0 {
+		return errors.New("f_cnt_gap must be set when flush_interval is set")
+	}
+
+	persistenceEnabled = conf.Enabled
+	persistenceFlushInterval = conf.FlushInterval
+	persistenceFCntGap = conf.FCntGap
+
+	if persistenceEnabled && persistenceFlushInterval > 0 {
+		persistenceLoopOnce.Do(func() {
+			go persistenceLoop()
+		})
+	}
+
+	return nil
+}
+
+func persistenceLoop() {
+	for {
+		time.Sleep(persistenceFlushInterval)
+
+		if err := FlushDeviceSessionSnapshots(context.Background()); err != nil {
+			log.WithError(err).Error("storage: flush device-session snapshots error")
+		}
+	}
+}
+
+// persistDeviceSession persists the given (marshaled) device-session. In
+// write-behind mode the snapshot is queued, else it is written directly.
+func persistDeviceSession(ctx context.Context, devEUI lorawan.EUI64, devAddr lorawan.DevAddr, b []byte) error {
+	if persistenceFlushInterval > 0 {
+		queueDeviceSessionSnapshot(devEUI, devAddr, b)
+		return nil
+	}
+
+	return writeDeviceSessionSnapshot(ctx, DB(), devEUI, deviceSessionSnapshot{
+		devAddr:   devAddr,
+		b:         b,
+		createdAt: time.Now(),
+	})
+}
+
+// deletePersistedDeviceSession deletes the persisted device-session. In
+// write-behind mode the deletion is queued, else it is executed directly.
+func deletePersistedDeviceSession(ctx context.Context, devEUI lorawan.EUI64) error {
+	if persistenceFlushInterval > 0 {
+		queueDeviceSessionDelete(devEUI)
+		return nil
+	}
+
+	return writeDeviceSessionSnapshot(ctx, DB(), devEUI, deviceSessionSnapshot{
+		deleted:   true,
+		createdAt: time.Now(),
+	})
+}
+
+// queueDeviceSessionSnapshot queues the given (marshaled) device-session
+// for persistence.
+func queueDeviceSessionSnapshot(devEUI lorawan.EUI64, devAddr lorawan.DevAddr, b []byte) {
+	pendingSnapshots.Lock()
+	defer pendingSnapshots.Unlock()
+
+	pendingSnapshots.items[devEUI] = deviceSessionSnapshot{
+		devAddr:   devAddr,
+		b:         b,
+		createdAt: time.Now(),
+	}
+}
+
+// queueDeviceSessionDelete queues the deletion of the persisted
+// device-session.
+func queueDeviceSessionDelete(devEUI lorawan.EUI64) {
+	pendingSnapshots.Lock()
+	defer pendingSnapshots.Unlock()
+
+	pendingSnapshots.items[devEUI] = deviceSessionSnapshot{
+		deleted:   true,
+		createdAt: time.Now(),
+	}
+}
+
+// getPendingDeviceSessionSnapshot returns the pending snapshot for the given
+// DevEUI.
+func getPendingDeviceSessionSnapshot(devEUI lorawan.EUI64) (deviceSessionSnapshot, bool) {
+	pendingSnapshots.Lock()
+	defer pendingSnapshots.Unlock()
+
+	s, ok := pendingSnapshots.items[devEUI]
+	return s, ok
+}
+
+// FlushDeviceSessionSnapshots writes the pending device-session snapshots to
+// PostgreSQL. In case of an error, the snapshots are re-queued (unless a
+// more recent snapshot was queued in the meantime).
+func FlushDeviceSessionSnapshots(ctx context.Context) error {
+	pendingSnapshots.Lock()
+	items := pendingSnapshots.items
+	pendingSnapshots.items = make(map[lorawan.EUI64]deviceSessionSnapshot)
+	pendingSnapshots.Unlock()
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	err := Transaction(func(tx sqlx.Ext) error {
+		for devEUI, s := range items {
+			if err := writeDeviceSessionSnapshot(ctx, tx, devEUI, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		pendingSnapshots.Lock()
+		for devEUI, s := range items {
+			if _, ok := pendingSnapshots.items[devEUI]; !ok {
+				pendingSnapshots.items[devEUI] = s
+			}
+		}
+		pendingSnapshots.Unlock()
+
+		return err
+	}
+
+	log.WithField("count", len(items)).Debug("storage: device-session snapshots persisted")
+
+	return nil
+}
+
+// writeDeviceSessionSnapshot writes the given snapshot to PostgreSQL.
+func writeDeviceSessionSnapshot(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64, s deviceSessionSnapshot) error {
+	if s.deleted {
+		_, err := execContext(ctx, db, "delete from device_session where dev_eui = $1", devEUI[:])
+		if err != nil {
+			return handlePSQLError(err, "delete error")
+		}
+		return nil
+	}
+
+	_, err := execContext(ctx, db, `
+		insert into device_session (
+			dev_eui,
+			dev_addr,
+			device_session,
+			updated_at
+		) values ($1, $2, $3, $4)
+		on conflict (dev_eui) do update
+		set
+			dev_addr = excluded.dev_addr,
+			device_session = excluded.device_session,
+			updated_at = excluded.updated_at
+		where
+			device_session.updated_at <= excluded.updated_at`,
+		devEUI[:],
+		s.devAddr[:],
+		s.b,
+		s.createdAt,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	return nil
+}
+
+// getPersistedDeviceSession returns the persisted (marshaled) device-session
+// for the given DevEUI. A pending snapshot takes precedence over the
+// snapshot stored in PostgreSQL. Snapshots older than the device-session
+// TTL are ignored, as the device-session would have expired in Redis.
+func getPersistedDeviceSession(ctx context.Context, devEUI lorawan.EUI64) ([]byte, error) {
+	if s, ok := getPendingDeviceSessionSnapshot(devEUI); ok {
+		if s.deleted || s.createdAt.Before(persistenceExpiredBefore()) {
+			return nil, ErrDoesNotExist
+		}
+		return s.b, nil
+	}
+
+	var b []byte
+	err := getContext(ctx, DB(), &b, `
+		select
+			device_session
+		from
+			device_session
+		where
+			dev_eui = $1
+			and updated_at >= $2`,
+		devEUI[:],
+		persistenceExpiredBefore(),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return b, nil
+}
+
+// getPersistedDevEUIsForDevAddr returns the DevEUIs of the (not expired)
+// persisted device-sessions using the given DevAddr. DevAddrs without
+// persisted device-session are cached in Redis for persistenceMissTTL.
+func getPersistedDevEUIsForDevAddr(ctx context.Context, devAddr lorawan.DevAddr) ([]lorawan.EUI64, error) {
+	missKey := GetRedisKey(devAddrMissKeyTempl, devAddr)
+
+	n, err := RedisClient().Exists(ctx, missKey).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "exists error")
+	}
+	if n != 0 {
+		return nil, nil
+	}
+
+	var out []lorawan.EUI64
+	err = selectContext(ctx, DB(), &out, `
+		select
+			dev_eui
+		from
+			device_session
+		where
+			dev_addr = $1
+			and updated_at >= $2`,
+		devAddr[:],
+		persistenceExpiredBefore(),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	if len(out) == 0 {
+		if err := RedisClient().Set(ctx, missKey, "", persistenceMissTTL).Err(); err != nil {
+			return nil, errors.Wrap(err, "set error")
+		}
+	}
+
+	return out, nil
+}
+
+// persistenceExpiredBefore returns the timestamp before which persisted
+// device-sessions are considered expired.
+func persistenceExpiredBefore() time.Time {
+	return time.Now().Add(-deviceSessionTTL)
+}
+
+// restoreDeviceSession restores the device-session from its persisted
+// snapshot and stores it in Redis. As the snapshot might be older than the
+// lost device-session, the downlink frame-counters are incremented by the
+// configured gap so that these are never re-used.
+func restoreDeviceSession(ctx context.Context, devEUI lorawan.EUI64) (DeviceSession, error) {
+	b, err := getPersistedDeviceSession(ctx, devEUI)
+	if err != nil {
+		return DeviceSession{}, err
+	}
+
+	var dsPB DeviceSessionPB
+	if err := proto.Unmarshal(b, &dsPB); err != nil {
+		return DeviceSession{}, errors.Wrap(err, "unmarshal protobuf error")
+	}
+
+	if _, err := decryptDeviceSessionKeys(&dsPB); err != nil {
+		return DeviceSession{}, errors.Wrap(err, "decrypt device-session keys error")
+	}
+
+	ds := deviceSessionFromPB(&dsPB)
+	ds.NFCntDown += persistenceFCntGap
+	ds.AFCntDown += persistenceFCntGap
+
+	if err := SaveDeviceSession(ctx, ds); err != nil {
+		return DeviceSession{}, errors.Wrap(err, "save device-session error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":     devEUI,
+		"dev_addr":    ds.DevAddr,
+		"n_fcnt_down": ds.NFCntDown,
+		"a_fcnt_down": ds.AFCntDown,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Warning("storage: device-session restored from PostgreSQL")
+
+	return ds, nil
+}
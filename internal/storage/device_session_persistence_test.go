@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/lorawan"
+)
+
+func TestPendingDeviceSessionSnapshots(t *testing.T) {
+	assert := require.New(t)
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	deviceSessionTTL = time.Hour
+	defer func() {
+		deviceSessionTTL = 0
+	}()
+
+	_, ok := getPendingDeviceSessionSnapshot(devEUI)
+	assert.False(ok)
+
+	queueDeviceSessionSnapshot(devEUI, lorawan.DevAddr{1, 2, 3, 4}, []byte{1})
+	queueDeviceSessionSnapshot(devEUI, lorawan.DevAddr{1, 2, 3, 4}, []byte{2})
+
+	s, ok := getPendingDeviceSessionSnapshot(devEUI)
+	assert.True(ok)
+	assert.Equal([]byte{2}, s.b)
+
+	b, err := getPersistedDeviceSession(context.Background(), devEUI)
+	assert.NoError(err)
+	assert.Equal([]byte{2}, b)
+
+	pendingSnapshots.Lock()
+	s = pendingSnapshots.items[devEUI]
+	s.createdAt = time.Now().Add(-2 * time.Hour)
+	pendingSnapshots.items[devEUI] = s
+	pendingSnapshots.Unlock()
+
+	_, err = getPersistedDeviceSession(context.Background(), devEUI)
+	assert.Equal(ErrDoesNotExist, err, "expired snapshot must not be restored")
+
+	queueDeviceSessionDelete(devEUI)
+	_, err = getPersistedDeviceSession(context.Background(), devEUI)
+	assert.Equal(ErrDoesNotExist, err)
+
+	pendingSnapshots.Lock()
+	delete(pendingSnapshots.items, devEUI)
+	pendingSnapshots.Unlock()
+}
+
+func (ts *StorageTestSuite) TestDeviceSessionPersistence() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	persistenceEnabled = true
+	persistenceFCntGap = 10
+	defer func() {
+		persistenceEnabled = false
+		persistenceFCntGap = 0
+	}()
+
+	ds := DeviceSession{
+		DevEUI:    lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+		FCntUp:    100,
+		NFCntDown: 20,
+		AFCntDown: 30,
+	}
+	assert.NoError(SaveDeviceSession(ctx, ds))
+	assert.NoError(FlushDeviceSessionSnapshots(ctx))
+
+	ts.T().Run("Restore after Redis flush", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(RedisClient().FlushAll(ctx).Err())
+
+		devEUIs, err := GetDevEUIsForDevAddr(ctx, ds.DevAddr)
+		assert.NoError(err)
+		assert.Equal([]lorawan.EUI64{ds.DevEUI}, devEUIs)
+
+		dsGet, err := GetDeviceSession(ctx, ds.DevEUI)
+		assert.NoError(err)
+		assert.EqualValues(100, dsGet.FCntUp)
+		assert.EqualValues(30, dsGet.NFCntDown)
+		assert.EqualValues(40, dsGet.AFCntDown)
+
+		// the device-session has been restored in Redis
+		exists, err := DeviceSessionExists(ctx, ds.DevEUI)
+		assert.NoError(err)
+		assert.True(exists)
+	})
+
+	ts.T().Run("Expired snapshot is not restored", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(RedisClient().FlushAll(ctx).Err())
+
+		_, err := DB().Exec("update device_session set updated_at = $1 where dev_eui = $2", time.Now().Add(-deviceSessionTTL-time.Minute), ds.DevEUI[:])
+		assert.NoError(err)
+
+		devEUIs, err := GetDevEUIsForDevAddr(ctx, ds.DevAddr)
+		assert.NoError(err)
+		assert.Len(devEUIs, 0)
+
+		// the miss has been cached
+		n, err := RedisClient().Exists(ctx, GetRedisKey(devAddrMissKeyTempl, ds.DevAddr)).Result()
+		assert.NoError(err)
+		assert.EqualValues(1, n)
+
+		_, err = GetDeviceSession(ctx, ds.DevEUI)
+		assert.Equal(ErrDoesNotExist, err)
+
+		assert.NoError(SaveDeviceSession(ctx, ds))
+	})
+
+	ts.T().Run("Delete", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DeleteDeviceSession(ctx, ds.DevEUI))
+		assert.NoError(FlushDeviceSessionSnapshots(ctx))
+
+		_, err := GetDeviceSession(ctx, ds.DevEUI)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+}
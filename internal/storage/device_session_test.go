@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/require"
@@ -41,13 +42,32 @@ func TestGetRandomDevAddr(t *testing.T) {
 	})
 }
 
+func TestUplinkHistoryUnknownChannel(t *testing.T) {
+	assert := require.New(t)
+
+	ds := DeviceSession{
+		UplinkHistory: []UplinkHistory{
+			{FCnt: 1, Channel: 0},
+			{FCnt: 2, Channel: UplinkHistoryUnknownChannel},
+		},
+	}
+
+	dsPB := deviceSessionToPB(ds)
+	assert.False(dsPB.UplinkAdrHistory[0].ChannelUnknown)
+	assert.True(dsPB.UplinkAdrHistory[1].ChannelUnknown)
+
+	uh := deviceSessionFromPB(dsPB).UplinkHistory
+	assert.Equal(0, uh[0].Channel)
+	assert.Equal(UplinkHistoryUnknownChannel, uh[1].Channel)
+}
+
 func TestUplinkHistory(t *testing.T) {
 	Convey("Given an empty device-session", t, func() {
 		s := DeviceSession{}
 
 		Convey("When appending 30 items to the UplinkHistory", func() {
 			for i := uint32(0); i < 30; i++ {
-				s.AppendUplinkHistory(UplinkHistory{FCnt: i})
+				s.AppendUplinkHistory(UplinkHistory{FCnt: i}, 0)
 			}
 
 			Convey("Then only the last 20 items are preserved", func() {
@@ -57,9 +77,21 @@ func TestUplinkHistory(t *testing.T) {
 			})
 		})
 
+		Convey("When appending 30 items with a history size of 25", func() {
+			for i := uint32(0); i < 30; i++ {
+				s.AppendUplinkHistory(UplinkHistory{FCnt: i}, 25)
+			}
+
+			Convey("Then only the last 25 items are preserved", func() {
+				So(s.UplinkHistory, ShouldHaveLength, 25)
+				So(s.UplinkHistory[24].FCnt, ShouldEqual, 29)
+				So(s.UplinkHistory[0].FCnt, ShouldEqual, 5)
+			})
+		})
+
 		Convey("In case of adding the same FCnt twice", func() {
-			s.AppendUplinkHistory(UplinkHistory{FCnt: 10, MaxSNR: 5})
-			s.AppendUplinkHistory(UplinkHistory{FCnt: 10, MaxSNR: 6})
+			s.AppendUplinkHistory(UplinkHistory{FCnt: 10, MaxSNR: 5}, 0)
+			s.AppendUplinkHistory(UplinkHistory{FCnt: 10, MaxSNR: 6}, 0)
 
 			Convey("Then the first record is kept", func() {
 				So(s.UplinkHistory, ShouldHaveLength, 1)
@@ -70,16 +102,16 @@ func TestUplinkHistory(t *testing.T) {
 		Convey("When appending 20 items, with two missing frames", func() {
 			for i := uint32(0); i < 20; i++ {
 				if i < 5 {
-					s.AppendUplinkHistory(UplinkHistory{FCnt: i})
+					s.AppendUplinkHistory(UplinkHistory{FCnt: i}, 0)
 					continue
 				}
 
 				if i < 10 {
-					s.AppendUplinkHistory(UplinkHistory{FCnt: i + 1})
+					s.AppendUplinkHistory(UplinkHistory{FCnt: i + 1}, 0)
 					continue
 				}
 
-				s.AppendUplinkHistory(UplinkHistory{FCnt: i + 2})
+				s.AppendUplinkHistory(UplinkHistory{FCnt: i + 2}, 0)
 			}
 
 			Convey("Then the packet-loss is 10%", func() {
@@ -148,11 +180,31 @@ func (ts *StorageTestSuite) TestDeviceGatewayRXInfoSet() {
 
 func (ts *StorageTestSuite) TestDeviceSession() {
 	s := DeviceSession{
-		DevAddr:              lorawan.DevAddr{1, 2, 3, 4},
-		DevEUI:               lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
-		ExtraUplinkChannels:  map[int]loraband.Channel{},
+		DevAddr:             lorawan.DevAddr{1, 2, 3, 4},
+		DevEUI:              lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ExtraUplinkChannels: map[int]loraband.Channel{},
+		ExtraDownlinkFrequencies: map[int]uint32{
+			3: 869000000,
+		},
 		RX2Frequency:         869525000,
 		MACCommandErrorCount: make(map[lorawan.CID]int),
+		UplinkHistory: []UplinkHistory{
+			{
+				FCnt:         10,
+				MaxSNR:       7.5,
+				MaxRSSI:      -60,
+				TXPowerIndex: 1,
+				GatewayCount: 2,
+				DR:           3,
+				Frequency:    868300000,
+				Channel:      1,
+				Time:         time.Unix(1600000000, 0).UTC(),
+				Gateways: []UplinkHistoryGateway{
+					{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, SNR: 7.5, RSSI: -60},
+					{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, SNR: -2.5, RSSI: -110},
+				},
+			},
+		},
 	}
 
 	ts.T().Run("Get non existing", func(t *testing.T) {
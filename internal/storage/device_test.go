@@ -166,3 +166,84 @@ func (ts *StorageTestSuite) TestDeviceActivation() {
 	})
 
 }
+
+func (ts *StorageTestSuite) TestGetDevices() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	sp := ServiceProfile{}
+	dp1 := DeviceProfile{}
+	dp2 := DeviceProfile{}
+	rp := RoutingProfile{}
+
+	assert.NoError(CreateServiceProfile(ctx, ts.Tx(), &sp))
+	assert.NoError(CreateDeviceProfile(ctx, ts.Tx(), &dp1))
+	assert.NoError(CreateDeviceProfile(ctx, ts.Tx(), &dp2))
+	assert.NoError(CreateRoutingProfile(ctx, ts.Tx(), &rp))
+
+	devices := []Device{
+		{DevEUI: lorawan.EUI64{1}, DeviceProfileID: dp1.ID, Mode: DeviceModeA},
+		{DevEUI: lorawan.EUI64{2}, DeviceProfileID: dp2.ID, Mode: DeviceModeC},
+		{DevEUI: lorawan.EUI64{3}, DeviceProfileID: dp1.ID, Mode: DeviceModeA, IsDisabled: true},
+	}
+	for i := range devices {
+		devices[i].ServiceProfileID = sp.ID
+		devices[i].RoutingProfileID = rp.ID
+		assert.NoError(CreateDevice(ctx, ts.Tx(), &devices[i]))
+	}
+
+	modeC := DeviceModeC
+	disabled := true
+
+	tests := []struct {
+		name            string
+		filters         DeviceFilters
+		expectedDevEUIs []lorawan.EUI64
+	}{
+		{
+			name:            "service-profile",
+			filters:         DeviceFilters{ServiceProfileID: &sp.ID},
+			expectedDevEUIs: []lorawan.EUI64{{1}, {2}, {3}},
+		},
+		{
+			name:            "device-profile",
+			filters:         DeviceFilters{DeviceProfileID: &dp1.ID},
+			expectedDevEUIs: []lorawan.EUI64{{1}, {3}},
+		},
+		{
+			name:            "mode",
+			filters:         DeviceFilters{ServiceProfileID: &sp.ID, Mode: &modeC},
+			expectedDevEUIs: []lorawan.EUI64{{2}},
+		},
+		{
+			name:            "disabled",
+			filters:         DeviceFilters{DeviceProfileID: &dp1.ID, IsDisabled: &disabled},
+			expectedDevEUIs: []lorawan.EUI64{{3}},
+		},
+		{
+			name:            "limit",
+			filters:         DeviceFilters{ServiceProfileID: &sp.ID, Limit: 2},
+			expectedDevEUIs: []lorawan.EUI64{{1}, {2}},
+		},
+		{
+			name:            "after",
+			filters:         DeviceFilters{ServiceProfileID: &sp.ID, After: &lorawan.EUI64{1}, Limit: 1},
+			expectedDevEUIs: []lorawan.EUI64{{2}},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := GetDevices(ctx, ts.Tx(), tst.filters)
+			assert.NoError(err)
+
+			var devEUIs []lorawan.EUI64
+			for _, d := range out {
+				devEUIs = append(devEUIs, d.DevEUI)
+			}
+			assert.Equal(tst.expectedDevEUIs, devEUIs)
+		})
+	}
+}
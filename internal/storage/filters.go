@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// ProfileFilters provides filters for listing profiles.
+type ProfileFilters struct {
+	// After returns the profiles with an ID greater than the given ID
+	// (keyset pagination).
+	After *uuid.UUID
+
+	// Limit defines the max. number of profiles to return (0 = no limit).
+	Limit int
+}
+
+// ProfileListItem defines the profile as returned when listing profiles.
+type ProfileListItem struct {
+	ID        uuid.UUID `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// whereBuilder builds the where clause and arguments of list queries.
+type whereBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add adds the given condition, in which %d is replaced by the position of
+// the given argument.
+func (w *whereBuilder) add(condition string, arg interface{}) {
+	w.args = append(w.args, arg)
+	w.conditions = append(w.conditions, fmt.Sprintf(condition, len(w.args)))
+}
+
+// limit returns the limit clause for the given limit (0 = no limit).
+func (w *whereBuilder) limit(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+
+	w.args = append(w.args, limit)
+	return fmt.Sprintf(" limit $%d", len(w.args))
+}
+
+// where returns the where clause (or an empty string when there are no
+// conditions).
+func (w *whereBuilder) where() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+
+	return " where " + strings.Join(w.conditions, " and ")
+}
+
+// getProfileListItems returns the profiles of the given table, ordered by
+// the given ID column.
+func getProfileListItems(ctx context.Context, db sqlx.Queryer, table, idColumn string, filters ProfileFilters) ([]ProfileListItem, error) {
+	var wb whereBuilder
+	if filters.After != nil {
+		wb.add(idColumn+" > $%d", *filters.After)
+	}
+
+	query := fmt.Sprintf("select %s as id, created_at, updated_at from %s", idColumn, table) + wb.where() + " order by " + idColumn
+	query += wb.limit(filters.Limit)
+
+	var items []ProfileListItem
+	if err := selectContext(ctx, db, &items, query, wb.args...); err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return items, nil
+}
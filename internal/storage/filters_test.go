@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhereBuilder(t *testing.T) {
+	assert := require.New(t)
+
+	var wb whereBuilder
+	assert.Equal("", wb.where())
+
+	wb.add("mode = $%d", "A")
+	wb.add("is_disabled = $%d", true)
+	limit := wb.limit(10)
+
+	assert.Equal(" where mode = $1 and is_disabled = $2", wb.where())
+	assert.Equal(" limit $3", limit)
+	assert.Equal([]interface{}{"A", true, 10}, wb.args)
+
+	var noLimit whereBuilder
+	assert.Equal("", noLimit.limit(0))
+	assert.Len(noLimit.args, 0)
+}
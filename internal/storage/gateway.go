@@ -22,7 +22,8 @@ import (
 
 // template used for generating Redis keys
 const (
-	gatewayMetaKeyTempl = "lora:ns:gw:meta:%s"
+	gatewayMetaKeyTempl     = "lora:ns:gw:meta:%s"
+	gatewayTimeSyncKeyTempl = "lora:ns:gw:timesync:%s"
 )
 
 // GPSPoint contains a GPS point.
@@ -49,18 +50,35 @@ func (l *GPSPoint) Scan(src interface{}) error {
 
 // Gateway represents a gateway.
 type Gateway struct {
-	GatewayID        lorawan.EUI64  `db:"gateway_id"`
-	RoutingProfileID uuid.UUID      `db:"routing_profile_id"`
-	ServiceProfileID *uuid.UUID     `db:"service_profile_id"`
-	GatewayProfileID *uuid.UUID     `db:"gateway_profile_id"`
-	CreatedAt        time.Time      `db:"created_at"`
-	UpdatedAt        time.Time      `db:"updated_at"`
-	FirstSeenAt      *time.Time     `db:"first_seen_at"`
-	LastSeenAt       *time.Time     `db:"last_seen_at"`
-	Location         GPSPoint       `db:"location"`
-	Altitude         float64        `db:"altitude"`
-	TLSCert          []byte         `db:"tls_cert"`
-	Boards           []GatewayBoard `db:"-"`
+	GatewayID        lorawan.EUI64    `db:"gateway_id"`
+	RoutingProfileID uuid.UUID        `db:"routing_profile_id"`
+	ServiceProfileID *uuid.UUID       `db:"service_profile_id"`
+	GatewayProfileID *uuid.UUID       `db:"gateway_profile_id"`
+	CreatedAt        time.Time        `db:"created_at"`
+	UpdatedAt        time.Time        `db:"updated_at"`
+	FirstSeenAt      *time.Time       `db:"first_seen_at"`
+	LastSeenAt       *time.Time       `db:"last_seen_at"`
+	Location         GPSPoint         `db:"location"`
+	Altitude         float64          `db:"altitude"`
+	TLSCert          []byte           `db:"tls_cert"`
+	Overrides        GatewayOverrides `db:"overrides"`
+	Boards           []GatewayBoard   `db:"-"`
+}
+
+// GatewayFilters provides filters for listing gateways.
+type GatewayFilters struct {
+	RoutingProfileID *uuid.UUID
+	ServiceProfileID *uuid.UUID
+	GatewayProfileID *uuid.UUID
+	LastSeenAfter    *time.Time
+	LastSeenBefore   *time.Time
+
+	// After returns the gateways with a Gateway ID greater than the given
+	// Gateway ID (keyset pagination).
+	After *lorawan.EUI64
+
+	// Limit defines the max. number of gateways to return (0 = no limit).
+	Limit int
 }
 
 // GatewayBoard holds the gateway board configuration.
@@ -72,14 +90,15 @@ type GatewayBoard struct {
 // GatewayMeta represents the gateway meta-data.
 // This is used for adding additional context to received uplinks.
 type GatewayMeta struct {
-	GatewayID        lorawan.EUI64  `db:"gateway_id"`
-	RoutingProfileID uuid.UUID      `db:"routing_profile_id"`
-	GatewayProfileID *uuid.UUID     `db:"gateway_profile_id"`
-	ServiceProfileID *uuid.UUID     `db:"service_profile_id"`
-	Location         GPSPoint       `db:"location"`
-	Altitude         float64        `db:"altitude"`
-	IsPrivate        bool           `db:"is_private"`
-	Boards           []GatewayBoard `db:"-"`
+	GatewayID        lorawan.EUI64    `db:"gateway_id"`
+	RoutingProfileID uuid.UUID        `db:"routing_profile_id"`
+	GatewayProfileID *uuid.UUID       `db:"gateway_profile_id"`
+	ServiceProfileID *uuid.UUID       `db:"service_profile_id"`
+	Location         GPSPoint         `db:"location"`
+	Altitude         float64          `db:"altitude"`
+	IsPrivate        bool             `db:"is_private"`
+	Overrides        GatewayOverrides `db:"overrides"`
+	Boards           []GatewayBoard   `db:"-"`
 }
 
 // CreateGateway creates the given gateway.
@@ -88,7 +107,7 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 	gw.CreatedAt = now
 	gw.UpdatedAt = now
 
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		insert into gateway (
 			gateway_id,
 			created_at,
@@ -100,8 +119,9 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 			gateway_profile_id,
 			routing_profile_id,
 			tls_cert,
-			service_profile_id
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			service_profile_id,
+			overrides
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
 		gw.GatewayID[:],
 		gw.CreatedAt,
 		gw.UpdatedAt,
@@ -113,13 +133,14 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		gw.RoutingProfileID,
 		gw.TLSCert,
 		gw.ServiceProfileID,
+		gw.Overrides,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
 	}
 
 	for i, board := range gw.Boards {
-		_, err := db.Exec(`
+		_, err := execContext(ctx, db, `
 			insert into gateway_board (
 				id,
 				gateway_id,
@@ -199,7 +220,7 @@ func FlushGatewayMetaCache(ctx context.Context, gatewayID lorawan.EUI64) error {
 func FlushGatewayMetaCacheForServiceProfile(ctx context.Context, db sqlx.Queryer, serviceProfileID uuid.UUID) error {
 	var gwIDs []lorawan.EUI64
 
-	err := sqlx.Select(db, &gwIDs, `
+	err := selectContext(ctx, db, &gwIDs, `
 		select
 			g.gateway_id
 		from
@@ -262,12 +283,12 @@ func GetAndCacheGatewayMeta(ctx context.Context, db sqlx.Queryer, gatewayID lora
 // GetGateway returns the gateway for the given Gateway ID.
 func GetGateway(ctx context.Context, db sqlx.Queryer, id lorawan.EUI64) (Gateway, error) {
 	var gw Gateway
-	err := sqlx.Get(db, &gw, "select * from gateway where gateway_id = $1", id[:])
+	err := getContext(ctx, db, &gw, "select * from gateway where gateway_id = $1", id[:])
 	if err != nil {
 		return gw, handlePSQLError(err, "select error")
 	}
 
-	err = sqlx.Select(db, &gw.Boards, `
+	err = selectContext(ctx, db, &gw.Boards, `
 		select
 			fpga_id,
 			fine_timestamp_key
@@ -292,7 +313,7 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 	now := time.Now()
 	gw.UpdatedAt = now
 
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update gateway set
 			updated_at = $2,
 			first_seen_at = $3,
@@ -302,7 +323,8 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 			gateway_profile_id = $7,
 			routing_profile_id = $8,
 			tls_cert = $9,
-			service_profile_id = $10
+			service_profile_id = $10,
+			overrides = $11
 		where gateway_id = $1`,
 		gw.GatewayID[:],
 		gw.UpdatedAt,
@@ -314,6 +336,7 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		gw.RoutingProfileID,
 		gw.TLSCert,
 		gw.ServiceProfileID,
+		gw.Overrides,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -326,7 +349,7 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		return ErrDoesNotExist
 	}
 
-	_, err = db.Exec(`
+	_, err = execContext(ctx, db, `
 		delete from gateway_board where gateway_id = $1`,
 		gw.GatewayID,
 	)
@@ -335,7 +358,7 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 	}
 
 	for i, board := range gw.Boards {
-		_, err := db.Exec(`
+		_, err := execContext(ctx, db, `
 			insert into gateway_board (
 				id,
 				gateway_id,
@@ -381,7 +404,7 @@ func UpdateGatewayState(ctx context.Context, db sqlx.Execer, id lorawan.EUI64, l
 	// * only update first_seen_at when the current value is NULL
 	// * only update the location when the given value is not NULL
 	// * only update the altitude when the given value is not NULL
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update gateway set
 			first_seen_at = coalesce(first_seen_at, $2),
 			last_seen_at = $3,
@@ -420,7 +443,7 @@ func UpdateGatewayState(ctx context.Context, db sqlx.Execer, id lorawan.EUI64, l
 
 // DeleteGateway deletes the gateway matching the given Gateway ID.
 func DeleteGateway(ctx context.Context, db sqlx.Execer, id lorawan.EUI64) error {
-	res, err := db.Exec("delete from gateway where gateway_id = $1", id[:])
+	res, err := execContext(ctx, db, "delete from gateway where gateway_id = $1", id[:])
 	if err != nil {
 		return handlePSQLError(err, "delete error")
 	}
@@ -436,6 +459,10 @@ func DeleteGateway(ctx context.Context, db sqlx.Execer, id lorawan.EUI64) error
 		return errors.Wrap(err, "flush gateway cache error")
 	}
 
+	if err := FlushGatewayConfigStateCache(ctx, id); err != nil {
+		return errors.Wrap(err, "flush gateway config state cache error")
+	}
+
 	log.WithFields(log.Fields{
 		"gateway_id": id,
 		"ctx_id":     ctx.Value(logging.ContextIDKey),
@@ -452,7 +479,7 @@ func GetGatewaysForIDs(ctx context.Context, db sqlx.Queryer, ids []lorawan.EUI64
 	}
 
 	var gws []Gateway
-	err := sqlx.Select(db, &gws, "select * from gateway where gateway_id = any($1)", pq.ByteaArray(idsB))
+	err := selectContext(ctx, db, &gws, "select * from gateway where gateway_id = any($1)", pq.ByteaArray(idsB))
 	if err != nil {
 		return nil, handlePSQLError(err, "select error")
 	}
@@ -468,10 +495,58 @@ func GetGatewaysForIDs(ctx context.Context, db sqlx.Queryer, ids []lorawan.EUI64
 	return out, nil
 }
 
+// GetGateways returns the gateways matching the given filters, ordered by
+// Gateway ID. Note that the gateway boards are not returned.
+func GetGateways(ctx context.Context, db sqlx.Queryer, filters GatewayFilters) ([]Gateway, error) {
+	var wb whereBuilder
+	if filters.RoutingProfileID != nil {
+		wb.add("routing_profile_id = $%d", *filters.RoutingProfileID)
+	}
+	if filters.ServiceProfileID != nil {
+		wb.add("service_profile_id = $%d", *filters.ServiceProfileID)
+	}
+	if filters.GatewayProfileID != nil {
+		wb.add("gateway_profile_id = $%d", *filters.GatewayProfileID)
+	}
+	if filters.LastSeenAfter != nil {
+		wb.add("last_seen_at >= $%d", *filters.LastSeenAfter)
+	}
+	if filters.LastSeenBefore != nil {
+		wb.add("last_seen_at < $%d", *filters.LastSeenBefore)
+	}
+	if filters.After != nil {
+		wb.add("gateway_id > $%d", filters.After[:])
+	}
+
+	query := `
+		select
+			gateway_id,
+			routing_profile_id,
+			service_profile_id,
+			gateway_profile_id,
+			created_at,
+			updated_at,
+			first_seen_at,
+			last_seen_at,
+			location,
+			altitude,
+			tls_cert,
+			overrides
+		from
+			gateway` + wb.where() + " order by gateway_id" + wb.limit(filters.Limit)
+
+	var gws []Gateway
+	if err := selectContext(ctx, db, &gws, query, wb.args...); err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return gws, nil
+}
+
 // GetGatewayMeta returns the GatewayMeta object for the given gateway ID.
 func GetGatewayMeta(ctx context.Context, db sqlx.Queryer, id lorawan.EUI64) (GatewayMeta, error) {
 	var gw GatewayMeta
-	err := sqlx.Get(db, &gw, `
+	err := getContext(ctx, db, &gw, `
 		select
 			g.gateway_id,
 			g.location,
@@ -479,6 +554,7 @@ func GetGatewayMeta(ctx context.Context, db sqlx.Queryer, id lorawan.EUI64) (Gat
 			g.service_profile_id,
 			g.gateway_profile_id,
 			g.routing_profile_id,
+			g.overrides,
 			coalesce(sp.gws_private, false) as is_private
 		from
 			gateway g
@@ -492,7 +568,7 @@ func GetGatewayMeta(ctx context.Context, db sqlx.Queryer, id lorawan.EUI64) (Gat
 		return gw, handlePSQLError(err, "select error")
 	}
 
-	err = sqlx.Select(db, &gw.Boards, `
+	err = selectContext(ctx, db, &gw.Boards, `
 		select
 			fpga_id,
 			fine_timestamp_key
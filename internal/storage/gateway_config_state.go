@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
+	"github.com/liuhw0/lorawan"
+)
+
+// template used for generating Redis keys
+const (
+	gatewayConfigStateKeyTempl = "lora:ns:gw:config:state:%s"
+	gatewayConfigExecKeyTempl  = "lora:ns:gw:config:exec:%s"
+)
+
+// GatewayConfigState contains the state of the configuration of a gateway,
+// as derived from its gateway-profile.
+type GatewayConfigState struct {
+	GatewayID lorawan.EUI64 `db:"gateway_id"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+
+	// Translator defines the configuration translator used for rendering
+	// and sending the configuration to the gateway. When empty, the
+	// translator is detected from the gateway stats (if possible).
+	Translator string `db:"translator"`
+
+	// AppliedVersion holds the last configuration version reported by the
+	// gateway.
+	AppliedVersion string     `db:"applied_version"`
+	AppliedAt      *time.Time `db:"applied_at"`
+
+	// PendingVersion holds the configuration version which has been sent to
+	// the gateway, but which has not (yet) been reported by the gateway.
+	PendingVersion string     `db:"pending_version"`
+	PendingAt      *time.Time `db:"pending_at"`
+}
+
+// GetGatewayConfigState returns the configuration state of the given
+// gateway.
+func GetGatewayConfigState(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (GatewayConfigState, error) {
+	var s GatewayConfigState
+	err := getContext(ctx, db, &s, `
+		select
+			gateway_id,
+			created_at,
+			updated_at,
+			translator,
+			applied_version,
+			applied_at,
+			pending_version,
+			pending_at
+		from gateway_config_state
+		where
+			gateway_id = $1`,
+		gatewayID[:],
+	)
+	if err != nil {
+		return s, handlePSQLError(err, "select error")
+	}
+
+	return s, nil
+}
+
+// SaveGatewayConfigState creates or updates the given gateway configuration
+// state.
+func SaveGatewayConfigState(ctx context.Context, db sqlx.Execer, s *GatewayConfigState) error {
+	now := time.Now()
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = now
+	}
+	s.UpdatedAt = now
+
+	_, err := execContext(ctx, db, `
+		insert into gateway_config_state (
+			gateway_id,
+			created_at,
+			updated_at,
+			translator,
+			applied_version,
+			applied_at,
+			pending_version,
+			pending_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)
+		on conflict (gateway_id) do update
+		set
+			updated_at = excluded.updated_at,
+			translator = excluded.translator,
+			applied_version = excluded.applied_version,
+			applied_at = excluded.applied_at,
+			pending_version = excluded.pending_version,
+			pending_at = excluded.pending_at`,
+		s.GatewayID[:],
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.Translator,
+		s.AppliedVersion,
+		s.AppliedAt,
+		s.PendingVersion,
+		s.PendingAt,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	return nil
+}
+
+// CreateGatewayConfigStateCache caches the given gateway configuration state
+// in Redis. The TTL is the same as that of the device-sessions.
+func CreateGatewayConfigStateCache(ctx context.Context, s GatewayConfigState) error {
+	key := GetRedisKey(gatewayConfigStateKeyTempl, s.GatewayID)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		return errors.Wrap(err, "gob encode gateway config state error")
+	}
+
+	err := RedisClient().Set(ctx, key, buf.Bytes(), deviceSessionTTL).Err()
+	if err != nil {
+		return errors.Wrap(err, "set gateway config state error")
+	}
+
+	return nil
+}
+
+// GetGatewayConfigStateCache returns a cached gateway configuration state.
+func GetGatewayConfigStateCache(ctx context.Context, gatewayID lorawan.EUI64) (GatewayConfigState, error) {
+	var s GatewayConfigState
+	key := GetRedisKey(gatewayConfigStateKeyTempl, gatewayID)
+
+	val, err := RedisClient().Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return s, ErrDoesNotExist
+		}
+		return s, errors.Wrap(err, "get error")
+	}
+
+	err = gob.NewDecoder(bytes.NewReader(val)).Decode(&s)
+	if err != nil {
+		return s, errors.Wrap(err, "gob decode error")
+	}
+
+	return s, nil
+}
+
+// FlushGatewayConfigStateCache flushes the gateway configuration state cache.
+func FlushGatewayConfigStateCache(ctx context.Context, gatewayID lorawan.EUI64) error {
+	key := GetRedisKey(gatewayConfigStateKeyTempl, gatewayID)
+
+	err := RedisClient().Del(ctx, key).Err()
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+
+	return nil
+}
+
+// GetAndCacheGatewayConfigState returns the gateway configuration state from
+// the cache in case it is available. In case it is not cached, it will be
+// retrieved from the database and then cached.
+func GetAndCacheGatewayConfigState(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (GatewayConfigState, error) {
+	s, err := GetGatewayConfigStateCache(ctx, gatewayID)
+	if err == nil {
+		return s, nil
+	}
+
+	if err != ErrDoesNotExist {
+		log.WithFields(log.Fields{
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+			"gateway_id": gatewayID,
+		}).WithError(err).Error("storage: get gateway config state cache error")
+		// we don't return the error as we can still fall-back onto db retrieval
+	}
+
+	s, err = GetGatewayConfigState(ctx, db, gatewayID)
+	if err != nil {
+		return s, err
+	}
+
+	err = CreateGatewayConfigStateCache(ctx, s)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+			"gateway_id": gatewayID,
+		}).WithError(err).Error("storage: create gateway config state cache error")
+	}
+
+	return s, nil
+}
+
+// SaveGatewayConfigExec stores the configuration version which has been sent
+// to the gateway using the command execution request with the given ID, so
+// that it can be marked as applied on the command execution response.
+func SaveGatewayConfigExec(ctx context.Context, execID uuid.UUID, version string, ttl time.Duration) error {
+	key := GetRedisKey(gatewayConfigExecKeyTempl, execID)
+
+	err := RedisClient().Set(ctx, key, version, ttl).Err()
+	if err != nil {
+		return errors.Wrap(err, "set gateway config exec error")
+	}
+
+	return nil
+}
+
+// GetGatewayConfigExec returns the configuration version which has been sent
+// to the gateway using the command execution request with the given ID.
+func GetGatewayConfigExec(ctx context.Context, execID uuid.UUID) (string, error) {
+	key := GetRedisKey(gatewayConfigExecKeyTempl, execID)
+
+	val, err := RedisClient().Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", ErrDoesNotExist
+		}
+		return "", errors.Wrap(err, "get error")
+	}
+
+	return val, nil
+}
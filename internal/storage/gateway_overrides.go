@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+)
+
+// maxBoardRadios defines the max. number of radios per gateway board.
+const maxBoardRadios = 2
+
+// GatewayOverrides contains the per-gateway configuration overrides, which
+// are merged with the (shared) gateway-profile of the gateway.
+type GatewayOverrides struct {
+	// Channels contains the frequencies (Hz) of the gateway-profile channels
+	// to configure on the gateway. When empty, all the gateway-profile
+	// channels are configured.
+	Channels []uint32 `json:"channels,omitempty"`
+
+	// Boards contains the overrides per gateway board.
+	Boards []GatewayBoardOverrides `json:"boards,omitempty"`
+
+	// AntennaGain (dBi) of the gateway antenna. The downlink TX power is
+	// reduced by this gain, so that the emitted EIRP is not exceeded.
+	AntennaGain int `json:"antenna_gain,omitempty"`
+
+	// MaxTXPower defines the max. downlink EIRP (dBm) for the gateway, e.g.
+	// to comply with the regulatory limits of the site.
+	MaxTXPower *int `json:"max_tx_power,omitempty"`
+
+	// LBT contains the listen-before-talk configuration. When nil, LBT is
+	// disabled.
+	LBT *GatewayLBT `json:"lbt,omitempty"`
+
+	// DownlinkDisabled indicates that the gateway must not be used for
+	// downlink.
+	DownlinkDisabled bool `json:"downlink_disabled,omitempty"`
+}
+
+// GatewayBoardOverrides contains the configuration overrides of a gateway
+// board.
+type GatewayBoardOverrides struct {
+	// RadioFrequencies contains the center frequencies (Hz) of the board
+	// radios. When empty, these are calculated from the channels.
+	RadioFrequencies []uint32 `json:"radio_frequencies,omitempty"`
+
+	// DownlinkDisabled indicates that the board must not be used for
+	// downlink.
+	DownlinkDisabled bool `json:"downlink_disabled,omitempty"`
+}
+
+// GatewayLBT contains the listen-before-talk configuration of a gateway.
+type GatewayLBT struct {
+	// RSSITarget (dBm) defines the RSSI below which the channel is
+	// considered free.
+	RSSITarget int `json:"rssi_target"`
+
+	// ScanTime (µs) defines the channel scan time (128 or 5000).
+	ScanTime int `json:"scan_time_us"`
+}
+
+// GetVersion returns the configuration version of the gateway, given its
+// gateway-profile. Only the overrides which are part of the configuration
+// sent to the gateway are taken into account, the downlink settings (antenna
+// gain, max. TX power and downlink disabled) are handled by the network-server.
+// Without such overrides, this equals the gateway-profile version.
+func (o GatewayOverrides) GetVersion(p GatewayProfile) string {
+	gwOverrides := struct {
+		Channels         []uint32    `json:"channels,omitempty"`
+		RadioFrequencies []uint32    `json:"radio_frequencies,omitempty"`
+		LBT              *GatewayLBT `json:"lbt,omitempty"`
+	}{
+		Channels: o.Channels,
+		LBT:      o.LBT,
+	}
+	if len(o.Boards) != 0 {
+		gwOverrides.RadioFrequencies = o.Boards[0].RadioFrequencies
+	}
+
+	b, err := json.Marshal(gwOverrides)
+	if err != nil || string(b) == "{}" {
+		return p.GetVersion()
+	}
+
+	h := fnv.New32a()
+	h.Write(b)
+
+	return fmt.Sprintf("%s-o%08x", p.GetVersion(), h.Sum32())
+}
+
+// GetDownlinkTXPower returns the TX power to use for the gateway, given the
+// downlink EIRP (dBm). The EIRP is capped by the max. TX power and reduced
+// by the antenna gain.
+func (o GatewayOverrides) GetDownlinkTXPower(eirp int) int {
+	if o.MaxTXPower != nil && eirp > *o.MaxTXPower {
+		eirp = *o.MaxTXPower
+	}
+
+	return eirp - o.AntennaGain
+}
+
+// IsDownlinkDisabled returns true when downlink has been disabled for the
+// gateway or for the given board.
+func (o GatewayOverrides) IsDownlinkDisabled(board uint32) bool {
+	if o.DownlinkDisabled {
+		return true
+	}
+
+	if int(board) < len(o.Boards) {
+		return o.Boards[int(board)].DownlinkDisabled
+	}
+
+	return false
+}
+
+// Validate validates the gateway overrides.
+func (o GatewayOverrides) Validate() error {
+	for i, b := range o.Boards {
+		if len(b.RadioFrequencies) > maxBoardRadios {
+			return fmt.Errorf("board %d: max. %d radio frequencies are supported", i, maxBoardRadios)
+		}
+
+		// the configuration translators only render the configuration of
+		// the first board
+		if i != 0 && len(b.RadioFrequencies) != 0 {
+			return fmt.Errorf("board %d: radio frequencies are only supported for board 0", i)
+		}
+	}
+
+	if o.LBT != nil {
+		if o.LBT.ScanTime != 128 && o.LBT.ScanTime != 5000 {
+			return errors.New("lbt scan time must be 128 or 5000 µs")
+		}
+		if o.LBT.RSSITarget > 0 {
+			return errors.New("lbt rssi target must be <= 0 dBm")
+		}
+	}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (o GatewayOverrides) Value() (driver.Value, error) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return b, nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (o *GatewayOverrides) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	*o = GatewayOverrides{}
+	return json.Unmarshal(b, o)
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayOverrides(t *testing.T) {
+	t.Run("Value and Scan", func(t *testing.T) {
+		assert := require.New(t)
+
+		maxTXPower := 20
+		o := GatewayOverrides{
+			Channels:   []uint32{868100000},
+			MaxTXPower: &maxTXPower,
+		}
+
+		v, err := o.Value()
+		assert.NoError(err)
+		assert.Equal(`{"channels":[868100000],"max_tx_power":20}`, string(v.([]byte)))
+
+		oScan := GatewayOverrides{DownlinkDisabled: true}
+		assert.NoError(oScan.Scan(v))
+		assert.Equal(o, oScan)
+
+		assert.NoError(oScan.Scan([]byte("{}")))
+		assert.Equal(GatewayOverrides{}, oScan)
+	})
+
+	t.Run("GetVersion", func(t *testing.T) {
+		assert := require.New(t)
+
+		gp := GatewayProfile{
+			ID:        uuid.Must(uuid.NewV4()),
+			UpdatedAt: time.Now(),
+		}
+
+		assert.Equal(gp.GetVersion(), GatewayOverrides{}.GetVersion(gp))
+
+		v1 := GatewayOverrides{Channels: []uint32{868100000}}.GetVersion(gp)
+		v2 := GatewayOverrides{Channels: []uint32{868300000}}.GetVersion(gp)
+		assert.NotEqual(gp.GetVersion(), v1)
+		assert.NotEqual(v1, v2)
+		assert.Equal(v1, GatewayOverrides{Channels: []uint32{868100000}}.GetVersion(gp))
+
+		v3 := GatewayOverrides{Boards: []GatewayBoardOverrides{{RadioFrequencies: []uint32{867500000}}}}.GetVersion(gp)
+		assert.NotEqual(gp.GetVersion(), v3)
+		assert.NotEqual(gp.GetVersion(), GatewayOverrides{LBT: &GatewayLBT{RSSITarget: -80, ScanTime: 128}}.GetVersion(gp))
+
+		// the downlink overrides are not sent to the gateway
+		maxTXPower := 14
+		assert.Equal(gp.GetVersion(), GatewayOverrides{
+			AntennaGain:      3,
+			MaxTXPower:       &maxTXPower,
+			DownlinkDisabled: true,
+			Boards:           []GatewayBoardOverrides{{DownlinkDisabled: true}},
+		}.GetVersion(gp))
+	})
+
+	t.Run("GetDownlinkTXPower", func(t *testing.T) {
+		assert := require.New(t)
+
+		maxTXPower := 14
+		assert.Equal(27, GatewayOverrides{}.GetDownlinkTXPower(27))
+		assert.Equal(24, GatewayOverrides{AntennaGain: 3}.GetDownlinkTXPower(27))
+		assert.Equal(14, GatewayOverrides{MaxTXPower: &maxTXPower}.GetDownlinkTXPower(27))
+		assert.Equal(11, GatewayOverrides{MaxTXPower: &maxTXPower, AntennaGain: 3}.GetDownlinkTXPower(27))
+		assert.Equal(9, GatewayOverrides{MaxTXPower: &maxTXPower, AntennaGain: 3}.GetDownlinkTXPower(12))
+	})
+
+	t.Run("IsDownlinkDisabled", func(t *testing.T) {
+		assert := require.New(t)
+
+		o := GatewayOverrides{
+			Boards: []GatewayBoardOverrides{
+				{},
+				{DownlinkDisabled: true},
+			},
+		}
+
+		assert.False(o.IsDownlinkDisabled(0))
+		assert.True(o.IsDownlinkDisabled(1))
+		assert.False(o.IsDownlinkDisabled(2))
+		assert.True(GatewayOverrides{DownlinkDisabled: true}.IsDownlinkDisabled(0))
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(GatewayOverrides{}.Validate())
+		assert.NoError(GatewayOverrides{LBT: &GatewayLBT{RSSITarget: -80, ScanTime: 128}}.Validate())
+		assert.Error(GatewayOverrides{LBT: &GatewayLBT{RSSITarget: -80, ScanTime: 100}}.Validate())
+		assert.Error(GatewayOverrides{LBT: &GatewayLBT{RSSITarget: 10, ScanTime: 5000}}.Validate())
+		assert.Error(GatewayOverrides{Boards: []GatewayBoardOverrides{
+			{RadioFrequencies: []uint32{867500000, 868500000, 869500000}},
+		}}.Validate())
+		assert.NoError(GatewayOverrides{Boards: []GatewayBoardOverrides{
+			{RadioFrequencies: []uint32{867500000, 868500000}},
+			{DownlinkDisabled: true},
+		}}.Validate())
+		assert.Error(GatewayOverrides{Boards: []GatewayBoardOverrides{
+			{},
+			{RadioFrequencies: []uint32{867500000, 868500000}},
+		}}.Validate())
+	})
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -60,7 +61,7 @@ func CreateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 		}
 	}
 
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		insert into gateway_profile (
 			gateway_profile_id,
 			created_at,
@@ -79,7 +80,7 @@ func CreateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 	}
 
 	for _, ec := range c.ExtraChannels {
-		_, err := db.Exec(`
+		_, err := execContext(ctx, db, `
 			insert into gateway_profile_extra_channel (
 				gateway_profile_id,
 				modulation,
@@ -112,7 +113,13 @@ func CreateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 // given ID.
 func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (GatewayProfile, error) {
 	var c GatewayProfile
-	err := db.QueryRowx(`
+	err := scanRowContext(ctx, db, []interface{}{
+		&c.ID,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		pq.Array(&c.Channels),
+		&c.StatsInterval,
+	}, `
 		select
 			gateway_profile_id,
 			created_at,
@@ -123,18 +130,26 @@ func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Gate
 		where
 			gateway_profile_id = $1`,
 		id,
-	).Scan(
-		&c.ID,
-		&c.CreatedAt,
-		&c.UpdatedAt,
-		pq.Array(&c.Channels),
-		&c.StatsInterval,
 	)
 	if err != nil {
 		return c, handlePSQLError(err, "select error")
 	}
 
-	rows, err := db.Query(`
+	err = scanRowsContext(ctx, db, func(rows *sql.Rows) error {
+		var ec ExtraChannel
+		err := rows.Scan(
+			&ec.Modulation,
+			&ec.Frequency,
+			&ec.Bandwidth,
+			&ec.Bitrate,
+			pq.Array(&ec.SpreadingFactors),
+		)
+		if err != nil {
+			return err
+		}
+		c.ExtraChannels = append(c.ExtraChannels, ec)
+		return nil
+	}, `
 		select
 			modulation,
 			frequency,
@@ -150,22 +165,6 @@ func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Gate
 	if err != nil {
 		return c, handlePSQLError(err, "select error")
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var ec ExtraChannel
-		err := rows.Scan(
-			&ec.Modulation,
-			&ec.Frequency,
-			&ec.Bandwidth,
-			&ec.Bitrate,
-			pq.Array(&ec.SpreadingFactors),
-		)
-		if err != nil {
-			return c, handlePSQLError(err, "select error")
-		}
-		c.ExtraChannels = append(c.ExtraChannels, ec)
-	}
 
 	return c, nil
 }
@@ -175,7 +174,7 @@ func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Gate
 // this within a transaction.
 func UpdateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile) error {
 	c.UpdatedAt = time.Now()
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update gateway_profile
 		set
 			updated_at = $2,
@@ -204,7 +203,7 @@ func UpdateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 	// and the wanted. As it is not likely that this data changes really often
 	// the 'simple' solution of re-creating all the extra channels has been
 	// implemented.
-	_, err = db.Exec(`
+	_, err = execContext(ctx, db, `
 		delete from gateway_profile_extra_channel
 		where
 			gateway_profile_id = $1`,
@@ -214,7 +213,7 @@ func UpdateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 		return handlePSQLError(err, "delete error")
 	}
 	for _, ec := range c.ExtraChannels {
-		_, err := db.Exec(`
+		_, err := execContext(ctx, db, `
 			insert into gateway_profile_extra_channel (
 				gateway_profile_id,
 				modulation,
@@ -246,7 +245,7 @@ func UpdateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 // DeleteGatewayProfile deletes the gateway-profile matching the
 // given ID.
 func DeleteGatewayProfile(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		delete from gateway_profile
 		where
 			gateway_profile_id = $1`,
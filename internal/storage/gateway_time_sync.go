@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/liuhw0/lorawan"
+)
+
+// SetGatewayTimeSync stores if the gateway is time-synchronized (e.g. GPS
+// locked), as reported by the gateway stats. This is used for scheduling
+// downlinks which must be emitted at a GPS epoch timestamp (e.g. Class-B).
+// The TTL is the same as that of the device-sessions.
+func SetGatewayTimeSync(ctx context.Context, gatewayID lorawan.EUI64, synced bool) error {
+	key := GetRedisKey(gatewayTimeSyncKeyTempl, gatewayID)
+
+	val := "0"
+	if synced {
+		val = "1"
+	}
+
+	if err := RedisClient().Set(ctx, key, val, deviceSessionTTL).Err(); err != nil {
+		return errors.Wrap(err, "set gateway time-sync error")
+	}
+
+	return nil
+}
+
+// GetGatewayTimeSyncForGatewayIDs returns the time-sync state for the given
+// gateway IDs. Gateways for which the time-sync state is unknown (e.g. the
+// gateway does not report it) are not included in the returned map.
+func GetGatewayTimeSyncForGatewayIDs(ctx context.Context, gatewayIDs []lorawan.EUI64) (map[lorawan.EUI64]bool, error) {
+	out := make(map[lorawan.EUI64]bool)
+	if len(gatewayIDs) == 0 {
+		return out, nil
+	}
+
+	pipe := RedisClient().Pipeline()
+	cmds := make([]*redis.StringCmd, len(gatewayIDs))
+	for i, id := range gatewayIDs {
+		cmds[i] = pipe.Get(ctx, GetRedisKey(gatewayTimeSyncKeyTempl, id))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, errors.Wrap(err, "get gateway time-sync error")
+	}
+
+	for i, cmd := range cmds {
+		val, err := cmd.Result()
+		if err != nil {
+			if err == redis.Nil {
+				continue
+			}
+			return nil, errors.Wrap(err, "get gateway time-sync error")
+		}
+
+		out[gatewayIDs[i]] = val == "1"
+	}
+
+	return out, nil
+}
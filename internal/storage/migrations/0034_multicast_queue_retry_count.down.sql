@@ -0,0 +1,2 @@
+alter table multicast_queue
+    drop column retry_count;
@@ -0,0 +1,2 @@
+alter table multicast_queue
+    add column retry_count smallint not null default 0;
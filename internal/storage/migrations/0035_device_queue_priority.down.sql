@@ -0,0 +1,4 @@
+drop index idx_device_queue_priority_created_at;
+
+alter table device_queue
+    drop column priority;
@@ -0,0 +1,4 @@
+alter table device_queue
+    add column priority smallint not null default 0;
+
+create index idx_device_queue_priority_created_at on device_queue(dev_eui, priority desc, created_at);
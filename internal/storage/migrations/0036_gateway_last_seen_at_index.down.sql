@@ -0,0 +1 @@
+drop index idx_gateway_last_seen_at;
@@ -0,0 +1 @@
+create index idx_gateway_last_seen_at on gateway(last_seen_at);
@@ -0,0 +1,2 @@
+drop index idx_device_session_dev_addr;
+drop table device_session;
@@ -0,0 +1,8 @@
+create table device_session (
+	dev_eui bytea primary key,
+	dev_addr bytea not null,
+	device_session bytea not null,
+	updated_at timestamp with time zone not null
+);
+
+create index idx_device_session_dev_addr on device_session(dev_addr);
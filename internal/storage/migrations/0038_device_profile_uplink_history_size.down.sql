@@ -0,0 +1,2 @@
+alter table device_profile
+    drop column uplink_history_size;
@@ -0,0 +1,2 @@
+alter table device_profile
+    add column uplink_history_size integer not null default 0;
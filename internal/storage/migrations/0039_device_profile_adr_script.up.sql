@@ -0,0 +1,2 @@
+alter table device_profile
+    add column adr_script text not null default '';
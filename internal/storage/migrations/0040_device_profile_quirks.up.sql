@@ -0,0 +1,7 @@
+alter table device_profile
+    add column quirks jsonb not null default '{}';
+
+-- Existing device-profiles keep the RN2483 tx-power 0 work-around, which was
+-- previously applied to all devices.
+update device_profile
+    set quirks = '{"tx_power_0_unsupported": true}';
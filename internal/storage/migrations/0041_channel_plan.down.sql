@@ -0,0 +1,6 @@
+drop index idx_device_profile_channel_plan_id;
+
+alter table device_profile
+    drop column channel_plan_id;
+
+drop table channel_plan;
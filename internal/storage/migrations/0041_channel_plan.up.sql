@@ -0,0 +1,12 @@
+create table channel_plan (
+    channel_plan_id uuid primary key,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    name varchar(100) not null,
+    channels jsonb not null
+);
+
+alter table device_profile
+    add column channel_plan_id uuid references channel_plan on delete set null;
+
+create index idx_device_profile_channel_plan_id on device_profile(channel_plan_id);
@@ -0,0 +1 @@
+drop table gateway_config_state;
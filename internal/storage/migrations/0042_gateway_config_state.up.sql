@@ -0,0 +1,10 @@
+create table gateway_config_state (
+    gateway_id bytea primary key references gateway on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    translator varchar(50) not null default '',
+    applied_version varchar(100) not null default '',
+    applied_at timestamp with time zone,
+    pending_version varchar(100) not null default '',
+    pending_at timestamp with time zone
+);
@@ -0,0 +1,2 @@
+alter table gateway
+    add column overrides jsonb not null default '{}';
@@ -38,6 +38,21 @@ type MulticastGroup struct {
 	ServiceProfileID uuid.UUID          `db:"service_profile_id"`
 }
 
+// MulticastGroupFilters provides filters for listing multicast-groups.
+type MulticastGroupFilters struct {
+	ServiceProfileID *uuid.UUID
+	RoutingProfileID *uuid.UUID
+	GroupType        *MulticastGroupType
+
+	// After returns the multicast-groups with an ID greater than the given
+	// ID (keyset pagination).
+	After *uuid.UUID
+
+	// Limit defines the max. number of multicast-groups to return
+	// (0 = no limit).
+	Limit int
+}
+
 // MulticastQueueItem defines a multicast queue-item.
 type MulticastQueueItem struct {
 	ID                      int64          `db:"id"`
@@ -51,6 +66,7 @@ type MulticastQueueItem struct {
 	FPort                   uint8          `db:"f_port"`
 	FRMPayload              []byte         `db:"frm_payload"`
 	RetryAfter              *time.Time     `db:"retry_after"`
+	RetryCount              int            `db:"retry_count"`
 }
 
 // Validate validates the MulticastQueueItem.
@@ -75,7 +91,7 @@ func CreateMulticastGroup(ctx context.Context, db sqlx.Execer, mg *MulticastGrou
 		}
 	}
 
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		insert into multicast_group (
 			id,
 			created_at,
@@ -124,7 +140,7 @@ func GetMulticastGroup(ctx context.Context, db sqlx.Queryer, id uuid.UUID, forUp
 		fu = " for update"
 	}
 
-	err := sqlx.Get(db, &mg, `
+	err := getContext(ctx, db, &mg, `
 		select
 			*
 		from
@@ -139,11 +155,53 @@ func GetMulticastGroup(ctx context.Context, db sqlx.Queryer, id uuid.UUID, forUp
 	return mg, nil
 }
 
+// GetMulticastGroups returns the multicast-groups matching the given
+// filters, ordered by ID.
+func GetMulticastGroups(ctx context.Context, db sqlx.Queryer, filters MulticastGroupFilters) ([]MulticastGroup, error) {
+	var wb whereBuilder
+	if filters.ServiceProfileID != nil {
+		wb.add("service_profile_id = $%d", *filters.ServiceProfileID)
+	}
+	if filters.RoutingProfileID != nil {
+		wb.add("routing_profile_id = $%d", *filters.RoutingProfileID)
+	}
+	if filters.GroupType != nil {
+		wb.add("group_type = $%d", *filters.GroupType)
+	}
+	if filters.After != nil {
+		wb.add("id > $%d", *filters.After)
+	}
+
+	query := `
+		select
+			id,
+			created_at,
+			updated_at,
+			mc_addr,
+			mc_nwk_s_key,
+			f_cnt,
+			group_type,
+			dr,
+			frequency,
+			ping_slot_period,
+			routing_profile_id,
+			service_profile_id
+		from
+			multicast_group` + wb.where() + " order by id" + wb.limit(filters.Limit)
+
+	var mgs []MulticastGroup
+	if err := selectContext(ctx, db, &mgs, query, wb.args...); err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return mgs, nil
+}
+
 // UpdateMulticastGroup updates the given multicast-grup.
 func UpdateMulticastGroup(ctx context.Context, db sqlx.Execer, mg *MulticastGroup) error {
 	mg.UpdatedAt = time.Now()
 
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update
 			multicast_group
 		set
@@ -192,7 +250,7 @@ func UpdateMulticastGroup(ctx context.Context, db sqlx.Execer, mg *MulticastGrou
 
 // DeleteMulticastGroup deletes the multicast-group matching the given ID.
 func DeleteMulticastGroup(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		delete from
 			multicast_group
 		where
@@ -229,7 +287,7 @@ func CreateMulticastQueueItem(ctx context.Context, db sqlx.Queryer, qi *Multicas
 	qi.CreatedAt = now
 	qi.UpdatedAt = now
 
-	err := sqlx.Get(db, &qi.ID, `
+	err := getContext(ctx, db, &qi.ID, `
 		insert into multicast_queue (
 			created_at,
 			updated_at,
@@ -240,8 +298,9 @@ func CreateMulticastQueueItem(ctx context.Context, db sqlx.Queryer, qi *Multicas
 			f_cnt,
 			f_port,
 			frm_payload,
-			retry_after
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			retry_after,
+			retry_count
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		returning
 			id
 		`,
@@ -255,6 +314,7 @@ func CreateMulticastQueueItem(ctx context.Context, db sqlx.Queryer, qi *Multicas
 		qi.FPort,
 		qi.FRMPayload,
 		qi.RetryAfter,
+		qi.RetryCount,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -274,7 +334,7 @@ func CreateMulticastQueueItem(ctx context.Context, db sqlx.Queryer, qi *Multicas
 // GetMulticastQueueItem returns the multicast queue-item for the given ID.
 func GetMulticastQueueItem(ctx context.Context, db sqlx.Queryer, id int64) (MulticastQueueItem, error) {
 	var qi MulticastQueueItem
-	err := sqlx.Get(db, &qi, "select * from multicast_queue where id = $1", id)
+	err := getContext(ctx, db, &qi, "select * from multicast_queue where id = $1", id)
 	if err != nil {
 		return qi, handlePSQLError(err, "select error")
 	}
@@ -285,7 +345,7 @@ func GetMulticastQueueItem(ctx context.Context, db sqlx.Queryer, id int64) (Mult
 func UpdateMulticastQueueItem(ctx context.Context, db sqlx.Execer, qi *MulticastQueueItem) error {
 	qi.UpdatedAt = time.Now()
 
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update multicast_queue
 		set
 			updated_at = $2,
@@ -296,7 +356,8 @@ func UpdateMulticastQueueItem(ctx context.Context, db sqlx.Execer, qi *Multicast
 			f_cnt = $7,
 			f_port = $8,
 			frm_payload = $9,
-			retry_after = $10
+			retry_after = $10,
+			retry_count = $11
 		where
 			id = $1`,
 		qi.ID,
@@ -309,6 +370,7 @@ func UpdateMulticastQueueItem(ctx context.Context, db sqlx.Execer, qi *Multicast
 		qi.FPort,
 		qi.FRMPayload,
 		qi.RetryAfter,
+		qi.RetryCount,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -335,7 +397,7 @@ func UpdateMulticastQueueItem(ctx context.Context, db sqlx.Execer, qi *Multicast
 
 // DeleteMulticastQueueItem deletes the queue-item given an id.
 func DeleteMulticastQueueItem(ctx context.Context, db sqlx.Execer, id int64) error {
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		delete from
 			multicast_queue
 		where
@@ -363,7 +425,7 @@ func DeleteMulticastQueueItem(ctx context.Context, db sqlx.Execer, id int64) err
 // FlushMulticastQueueForMulticastGroup flushes the multicast-queue given
 // a multicast-group id.
 func FlushMulticastQueueForMulticastGroup(ctx context.Context, db sqlx.Execer, multicastGroupID uuid.UUID) error {
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		delete from
 			multicast_queue
 		where
@@ -386,7 +448,7 @@ func FlushMulticastQueueForMulticastGroup(ctx context.Context, db sqlx.Execer, m
 func GetMulticastQueueItemsForMulticastGroup(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) ([]MulticastQueueItem, error) {
 	var items []MulticastQueueItem
 
-	err := sqlx.Select(db, &items, `
+	err := selectContext(ctx, db, &items, `
 		select
 			*
 		from
@@ -404,18 +466,20 @@ func GetMulticastQueueItemsForMulticastGroup(ctx context.Context, db sqlx.Querye
 }
 
 // GetSchedulableMulticastQueueItems returns a slice of multicast-queue items
-// for scheduling.
+// for scheduling. Queue-items with a retry_after timestamp in the future are
+// excluded.
 // The returned queue-items will be locked for update so that this query can
 // be executed in parallel.
 func GetSchedulableMulticastQueueItems(ctx context.Context, db sqlx.Ext, count int) ([]MulticastQueueItem, error) {
 	var items []MulticastQueueItem
-	err := sqlx.Select(db, &items, `
+	err := selectContext(ctx, db, &items, `
 		select
 			*
 		from
 			multicast_queue
 		where
 			schedule_at <= $2
+			and (retry_after is null or retry_after <= $2)
 		order by
 			id
 		limit $1
@@ -432,7 +496,7 @@ func GetSchedulableMulticastQueueItems(ctx context.Context, db sqlx.Ext, count i
 // epoch scheduling timestamp for the given multicast-group.
 func GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) (time.Duration, error) {
 	var timeSinceGPSEpoch time.Duration
-	err := sqlx.Get(db, &timeSinceGPSEpoch, `
+	err := getContext(ctx, db, &timeSinceGPSEpoch, `
 		select
 			coalesce(max(emit_at_time_since_gps_epoch), 0)
 		from
@@ -448,13 +512,14 @@ func GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(ctx context.Context, db sqlx
 }
 
 // GetMaxScheduleAtForMulticastGroup returns the maximum schedule at timestamp
-// for the given multicast-group.
+// for the given multicast-group. For queue-items that have been re-scheduled,
+// the retry_after timestamp is used when it is after the schedule at timestamp.
 func GetMaxScheduleAtForMulticastGroup(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) (time.Time, error) {
 	ts := new(time.Time)
 
-	err := sqlx.Get(db, &ts, `
+	err := getContext(ctx, db, &ts, `
 		select
-			max(schedule_at)
+			max(greatest(schedule_at, retry_after))
 		from
 			multicast_queue
 		where
@@ -168,6 +168,7 @@ func (ts *StorageTestSuite) TestMulticastQueue() {
 
 			now := time.Now().Truncate(time.Millisecond)
 			qi1.RetryAfter = &now
+			qi1.RetryCount = 1
 			assert.NoError(UpdateMulticastQueueItem(context.Background(), ts.Tx(), &qi1))
 			qi1.CreatedAt = qi1.CreatedAt.UTC().Truncate(time.Millisecond)
 			qi1.UpdatedAt = qi1.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -183,6 +184,19 @@ func (ts *StorageTestSuite) TestMulticastQueue() {
 			assert.Equal(qi1, qi)
 		})
 
+		t.Run("Schedulable multicast queue-items with retry after", func(t *testing.T) {
+			assert := require.New(t)
+
+			retryAfter := time.Now().Add(time.Minute)
+			qi1.RetryAfter = &retryAfter
+			assert.NoError(UpdateMulticastQueueItem(context.Background(), ts.Tx(), &qi1))
+
+			items, err := GetSchedulableMulticastQueueItems(context.Background(), ts.Tx(), 10)
+			assert.NoError(err)
+			assert.Len(items, 1)
+			assert.Equal(qi2.FCnt, items[0].FCnt)
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			assert := require.New(t)
 
@@ -54,7 +54,7 @@ func CreateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfil
 	rp.CreatedAt = now
 	rp.UpdatedAt = now
 
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		insert into routing_profile (
 			created_at,
 			updated_at,
@@ -88,7 +88,7 @@ func CreateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfil
 // GetRoutingProfile returns the routing-profile matching the given id.
 func GetRoutingProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (RoutingProfile, error) {
 	var rp RoutingProfile
-	err := sqlx.Get(db, &rp, "select * from routing_profile where routing_profile_id = $1", id)
+	err := getContext(ctx, db, &rp, "select * from routing_profile where routing_profile_id = $1", id)
 	if err != nil {
 		return rp, handlePSQLError(err, "select error")
 	}
@@ -99,7 +99,7 @@ func GetRoutingProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Rout
 // UpdateRoutingProfile updates the given routing-profile.
 func UpdateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfile) error {
 	rp.UpdatedAt = time.Now()
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update routing_profile set
 			updated_at = $2,
 			as_id = $3,
@@ -133,9 +133,15 @@ func UpdateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfil
 	return nil
 }
 
+// GetRoutingProfiles returns the routing-profiles matching the given filters,
+// ordered by ID.
+func GetRoutingProfiles(ctx context.Context, db sqlx.Queryer, filters ProfileFilters) ([]ProfileListItem, error) {
+	return getProfileListItems(ctx, db, "routing_profile", "routing_profile_id", filters)
+}
+
 // DeleteRoutingProfile deletes the routing-profile matching the given id.
 func DeleteRoutingProfile(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
-	res, err := db.Exec("delete from routing_profile where routing_profile_id = $1", id)
+	res, err := execContext(ctx, db, "delete from routing_profile where routing_profile_id = $1", id)
 	if err != nil {
 		return handlePSQLError(err, "delete error")
 	}
@@ -158,7 +164,7 @@ func DeleteRoutingProfile(ctx context.Context, db sqlx.Execer, id uuid.UUID) err
 // GetAllRoutingProfiles returns all the available routing-profiles.
 func GetAllRoutingProfiles(ctx context.Context, db sqlx.Queryer) ([]RoutingProfile, error) {
 	var rps []RoutingProfile
-	err := sqlx.Select(db, &rps, "select * from routing_profile")
+	err := selectContext(ctx, db, &rps, "select * from routing_profile")
 	if err != nil {
 		return nil, handlePSQLError(err, "select error")
 	}
@@ -71,7 +71,7 @@ func CreateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 	sp.CreatedAt = now
 	sp.UpdatedAt = now
 
-	_, err := db.Exec(`
+	_, err := execContext(ctx, db, `
 		insert into service_profile (
 			created_at,
 			updated_at,
@@ -230,7 +230,7 @@ func GetAndCacheServiceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUI
 // GetServiceProfile returns the service-profile matching the given id.
 func GetServiceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (ServiceProfile, error) {
 	var sp ServiceProfile
-	err := sqlx.Get(db, &sp, "select * from service_profile where service_profile_id = $1", id)
+	err := getContext(ctx, db, &sp, "select * from service_profile where service_profile_id = $1", id)
 	if err != nil {
 		return sp, handlePSQLError(err, "select error")
 	}
@@ -242,7 +242,7 @@ func GetServiceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Serv
 func UpdateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfile) error {
 	sp.UpdatedAt = time.Now()
 
-	res, err := db.Exec(`
+	res, err := execContext(ctx, db, `
 		update service_profile set
 			updated_at = $2,
 
@@ -309,9 +309,15 @@ func UpdateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 	return nil
 }
 
+// GetServiceProfiles returns the service-profiles matching the given filters,
+// ordered by ID.
+func GetServiceProfiles(ctx context.Context, db sqlx.Queryer, filters ProfileFilters) ([]ProfileListItem, error) {
+	return getProfileListItems(ctx, db, "service_profile", "service_profile_id", filters)
+}
+
 // DeleteServiceProfile deletes the service-profile matching the given id.
 func DeleteServiceProfile(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
-	res, err := db.Exec("delete from service_profile where service_profile_id = $1", id)
+	res, err := execContext(ctx, db, "delete from service_profile where service_profile_id = $1", id)
 	if err != nil {
 		return handlePSQLError(err, "delete error")
 	}
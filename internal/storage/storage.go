@@ -18,6 +18,7 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/migrations/code"
 	codemig "github.com/liuhw0/chirpstack-network-server/v3/internal/storage/migrations/code"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 )
 
 // Migrations
@@ -42,6 +43,14 @@ func Setup(c config.Config) error {
 	schedulerInterval = c.NetworkServer.Scheduler.SchedulerInterval
 	keyPrefix = c.Redis.KeyPrefix
 
+	if err := setupDeviceSessionKEKs(c); err != nil {
+		return errors.Wrap(err, "storage: setup device-session kek error")
+	}
+
+	if err := setupDeviceSessionPersistence(c); err != nil {
+		return errors.Wrap(err, "storage: setup device-session persistence error")
+	}
+
 	log.Info("storage: setting up Redis client")
 	if len(c.Redis.Servers) == 0 {
 		return errors.New("at least one redis server must be configured")
@@ -81,6 +90,10 @@ func Setup(c config.Config) error {
 		})
 	}
 
+	if c.Monitoring.Tracing.Enabled {
+		redisClient.AddHook(tracing.RedisHook{})
+	}
+
 	log.Info("storage: connecting to PostgreSQL")
 	d, err := sqlx.Open("postgres", c.PostgreSQL.DSN)
 	if err != nil {
@@ -142,6 +155,27 @@ func Transaction(f func(tx sqlx.Ext) error) error {
 	return nil
 }
 
+// Savepoint executes the given function within a savepoint of the given
+// transaction. When the function returns an error, the transaction is rolled
+// back to the savepoint so that the transaction can be continued.
+func Savepoint(tx sqlx.Execer, name string, f func() error) error {
+	if _, err := tx.Exec("savepoint " + name); err != nil {
+		return errors.Wrap(err, "storage: create savepoint error")
+	}
+
+	if err := f(); err != nil {
+		if _, rbErr := tx.Exec("rollback to savepoint " + name); rbErr != nil {
+			return errors.Wrap(rbErr, "storage: rollback to savepoint error")
+		}
+		return err
+	}
+
+	if _, err := tx.Exec("release savepoint " + name); err != nil {
+		return errors.Wrap(err, "storage: release savepoint error")
+	}
+	return nil
+}
+
 // MigrateUp configure postgres migration up
 func MigrateUp(db *sqlx.DB) error {
 	log.Info("storage: applying PostgreSQL data migrations")
@@ -0,0 +1,225 @@
+// Package supervisor implements the supervision of the (go-plugin) plugin
+// processes. A plugin which has exited is restarted in the background and the
+// plugin requests are executed with a deadline, so that an unavailable or
+// unresponsive plugin does not block its callers.
+package supervisor
+
+import (
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrUnavailable is returned when the plugin has exited and has not
+	// (yet) been restarted.
+	ErrUnavailable = errors.New("plugin is not available")
+
+	// ErrTimeout is returned when the plugin did not respond in time.
+	ErrTimeout = errors.New("plugin timeout")
+
+	// ErrCrashed is returned when the plugin exited while handling the
+	// request.
+	ErrCrashed = errors.New("plugin crashed")
+)
+
+var (
+	// RestartInterval defines the min. interval between two restarts of a
+	// crashed plugin.
+	RestartInterval = 10 * time.Second
+
+	// Timeout defines the max. duration of a plugin request.
+	Timeout = time.Second
+
+	// StartTimeout defines the max. duration of starting the plugin process
+	// (the go-plugin default is one minute).
+	StartTimeout = 10 * time.Second
+)
+
+// Config defines the plugin configuration.
+type Config struct {
+	// Path of the plugin executable.
+	Path string
+
+	// HandshakeConfig and Plugins are passed to the go-plugin client.
+	HandshakeConfig plugin.HandshakeConfig
+	Plugins         map[string]plugin.Plugin
+
+	// Load dispenses the plugin using the given RPC client and returns the
+	// plugin and its ID.
+	Load func(rpcClient plugin.ClientProtocol) (interface{}, string, error)
+
+	// OnRestart is called after each restart attempt with the restart error
+	// (nil on success). This is optional.
+	OnRestart func(err error)
+}
+
+// Plugin implements a supervised plugin. When the plugin process has exited,
+// it is restarted in the background on the next request. Until the plugin
+// has been restarted, ErrUnavailable is returned.
+type Plugin struct {
+	conf Config
+	id   string
+
+	mu          sync.Mutex
+	client      *plugin.Client
+	raw         interface{}
+	lastRestart time.Time
+	restarting  bool
+	closed      bool
+}
+
+// Start starts the given plugin.
+func Start(conf Config) (*Plugin, error) {
+	client, raw, id, err := start(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plugin{
+		conf:   conf,
+		id:     id,
+		client: client,
+		raw:    raw,
+	}, nil
+}
+
+func start(conf Config) (*plugin.Client, interface{}, string, error) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: conf.HandshakeConfig,
+		Plugins:         conf.Plugins,
+		Cmd:             exec.Command(conf.Path),
+		StartTimeout:    StartTimeout,
+	})
+
+	// connect via RPC
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, nil, "", errors.Wrap(err, "plugin rpc client error")
+	}
+
+	raw, id, err := conf.Load(rpcClient)
+	if err != nil {
+		client.Kill()
+		return nil, nil, "", err
+	}
+
+	return client, raw, id, nil
+}
+
+// ID returns the ID of the plugin.
+func (p *Plugin) ID() string {
+	return p.id
+}
+
+// Call calls the given function with the dispensed plugin. When the function
+// does not return within the timeout, ErrTimeout is returned. The call is not
+// canceled on timeout (the plugin RPC does not support this), its result is
+// then ignored.
+func (p *Plugin) Call(f func(raw interface{}) error) error {
+	raw, err := p.get()
+	if err != nil {
+		return err
+	}
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- f(raw)
+	}()
+
+	select {
+	case err := <-errC:
+		if err != nil && p.exited() {
+			// The plugin will be restarted on the next request.
+			return errors.Wrap(ErrCrashed, err.Error())
+		}
+		return err
+	case <-time.After(Timeout):
+		return ErrTimeout
+	}
+}
+
+// get returns the dispensed plugin. When the plugin has exited, its restart
+// is started in the background.
+func (p *Plugin) get() (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, errors.Wrap(ErrUnavailable, "plugin has been stopped")
+	}
+
+	if !p.client.Exited() {
+		return p.raw, nil
+	}
+
+	if !p.restarting && time.Since(p.lastRestart) >= RestartInterval {
+		p.restarting = true
+		p.lastRestart = time.Now()
+		go p.restart()
+	}
+
+	return nil, errors.Wrap(ErrUnavailable, "plugin has exited, waiting for restart")
+}
+
+// restart restarts the plugin. The plugin is started without holding the
+// lock, so that the requests are not blocked while the plugin starts.
+func (p *Plugin) restart() {
+	client, raw, id, err := start(p.conf)
+	if err == nil && id != p.id {
+		client.Kill()
+		err = errors.Errorf("restarted plugin returned a different id: %s", id)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.restarting = false
+
+	if p.conf.OnRestart != nil {
+		p.conf.OnRestart(err)
+	}
+
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"plugin": p.conf.Path,
+			"id":     p.id,
+		}).Error("supervisor: restart plugin error")
+		return
+	}
+
+	if p.closed {
+		client.Kill()
+		return
+	}
+
+	p.client = client
+	p.raw = raw
+
+	log.WithFields(log.Fields{
+		"plugin": p.conf.Path,
+		"id":     p.id,
+	}).Warning("supervisor: plugin restarted")
+}
+
+func (p *Plugin) exited() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.client.Exited()
+}
+
+// Close stops the plugin.
+func (p *Plugin) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	p.client.Kill()
+	return nil
+}
@@ -101,6 +101,9 @@ func (t *ApplicationClient) HandleError(ctx context.Context, in *as.HandleErrorR
 
 // HandleDownlinkACK method.
 func (t *ApplicationClient) HandleDownlinkACK(ctx context.Context, in *as.HandleDownlinkACKRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if t.HandleDownlinkACKErr != nil {
+		return nil, t.HandleDownlinkACKErr
+	}
 	t.HandleDownlinkACKChan <- *in
 	return &t.HandleDownlinkACKResponse, nil
 }
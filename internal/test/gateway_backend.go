@@ -7,6 +7,8 @@ type GatewayBackend struct {
 	rxPacketChan            chan gw.UplinkFrame
 	TXPacketChan            chan gw.DownlinkFrame
 	GatewayConfigPacketChan chan gw.GatewayConfiguration
+	CommandExecRequestChan  chan gw.GatewayCommandExecRequest
+	RawCommandChan          chan gw.RawPacketForwarderCommand
 	statsPacketChan         chan gw.GatewayStats
 	downlinkTXAckChan       chan gw.DownlinkTXAck
 }
@@ -17,6 +19,8 @@ func NewGatewayBackend() *GatewayBackend {
 		rxPacketChan:            make(chan gw.UplinkFrame, 100),
 		TXPacketChan:            make(chan gw.DownlinkFrame, 100),
 		GatewayConfigPacketChan: make(chan gw.GatewayConfiguration, 100),
+		CommandExecRequestChan:  make(chan gw.GatewayCommandExecRequest, 100),
+		RawCommandChan:          make(chan gw.RawPacketForwarderCommand, 100),
 		downlinkTXAckChan:       make(chan gw.DownlinkTXAck, 100),
 	}
 }
@@ -33,6 +37,18 @@ func (b *GatewayBackend) SendGatewayConfigPacket(config gw.GatewayConfiguration)
 	return nil
 }
 
+// SendGatewayCommandExecRequest method.
+func (b *GatewayBackend) SendGatewayCommandExecRequest(req gw.GatewayCommandExecRequest) error {
+	b.CommandExecRequestChan <- req
+	return nil
+}
+
+// SendRawPacketForwarderCommand method.
+func (b *GatewayBackend) SendRawPacketForwarderCommand(cmd gw.RawPacketForwarderCommand) error {
+	b.RawCommandChan <- cmd
+	return nil
+}
+
 // RXPacketChan method.
 func (b *GatewayBackend) RXPacketChan() chan gw.UplinkFrame {
 	return b.rxPacketChan
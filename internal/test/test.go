@@ -44,9 +44,19 @@ func GetConfig() config.Config {
 	c.NetworkServer.NetworkSettings.MaxMACCommandErrorCount = 3
 
 	c.NetworkServer.Scheduler.SchedulerInterval = time.Second
+	c.NetworkServer.Scheduler.MulticastMaxAttempts = 3
 	c.NetworkServer.Scheduler.ClassC.DeviceDownlinkLockDuration = time.Second * 3
 	c.NetworkServer.Scheduler.ClassC.GatewayDownlinkLockDuration = time.Second * 3
 
+	c.ApplicationServer.Retry.MaxAttempts = 3
+	c.ApplicationServer.Retry.Interval = 10 * time.Millisecond
+	c.ApplicationServer.Retry.DeliveryTimeout = time.Second
+	c.ApplicationServer.Retry.QueueSize = 10
+	c.ApplicationServer.Retry.BufferMaxSize = 10
+	c.ApplicationServer.Retry.BufferTTL = time.Hour
+
+	c.NetworkServer.Gateway.PacketForwarderConfigCommand = "set_packet_forwarder_config"
+	c.NetworkServer.Gateway.ConfigPendingTimeout = time.Hour
 	c.NetworkServer.Gateway.Backend.MultiDownlinkFeature = "multi_only"
 	c.NetworkServer.Gateway.Backend.MQTT.Server = "tcp://127.0.0.1:1883"
 	c.NetworkServer.Gateway.Backend.MQTT.CleanSession = true
@@ -292,11 +292,18 @@ func AssertDeviceSessionTXPowerIndex(txPowerIndex int) Assertion {
 }
 
 // AssertDeviceSessionUplinkHistory asserts the given UplinkHistory.
+// As the receive timestamp is set by the network-server, it is only asserted
+// to be set.
 func AssertDeviceSessionUplinkHistory(uh []storage.UplinkHistory) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
 		sess, err := storage.GetDeviceSession(context.Background(), ts.Device.DevEUI)
 		assert.NoError(err)
 
+		for i := range sess.UplinkHistory {
+			assert.False(sess.UplinkHistory[i].Time.IsZero())
+			sess.UplinkHistory[i].Time = time.Time{}
+		}
+
 		assert.Equal(uh, sess.UplinkHistory)
 	}
 }
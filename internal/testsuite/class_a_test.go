@@ -3116,7 +3116,18 @@ func (ts *ClassATestSuite) TestLW10ADR() {
 				AssertDeviceSessionDR(0),
 				AssertDeviceSessionTXPowerIndex(0),
 				AssertDeviceSessionUplinkHistory([]storage.UplinkHistory{
-					{FCnt: 10, MaxSNR: 7, TXPowerIndex: 0, GatewayCount: 1},
+					{
+						FCnt:         10,
+						MaxSNR:       7,
+						TXPowerIndex: 0,
+						GatewayCount: 1,
+						DR:           0,
+						Frequency:    868100000,
+						Channel:      0,
+						Gateways: []storage.UplinkHistoryGateway{
+							{GatewayID: ts.Gateway.GatewayID, SNR: 7},
+						},
+					},
 				}),
 			},
 		},
@@ -2,6 +2,7 @@ package testsuite
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -1033,18 +1034,102 @@ func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 				},
 				Assert: []Assertion{
 					AssertMulticastGroupFCntDown(30),
-					/*
-						AssertMulticastQueueItems([]storage.MulticastQueueItem{
-							{ScheduleAt: time.Now(), MulticastGroupID: ts.MulticastGroup.ID, GatewayID: ts.Gateway.GatewayID, FCnt: 30, FPort: 2},
-						}),
-					*/
-					// For now the item is removed from the queue.
 					AssertMulticastQueueItems([]storage.MulticastQueueItem{}),
+					AssertASHandleErrorRequest(as.HandleErrorRequest{
+						FCnt:  30,
+						Type:  as.ErrorType_DATA_DOWN_GATEWAY,
+						Error: fmt.Sprintf("multicast-group %s: %s", ts.MulticastGroup.ID, gw.TxAckStatus_TX_FREQ),
+					}),
+					AssertASNoHandleTxAckRequest(),
+					AssertNCNoHandleDownlinkMetaDataRequest(),
+				},
+			},
+			{
+				Name: "retryable nack for multicast frame",
+				DownlinkTXAck: &gw.DownlinkTXAck{
+					Token: 123,
+					Items: []*gw.DownlinkTXAckItem{
+						{
+							Status: gw.TxAckStatus_COLLISION_PACKET,
+						},
+					},
+				},
+				DownlinkFrame: &storage.DownlinkFrame{
+					Token:            123,
+					MulticastGroupId: ts.MulticastGroup.ID[:],
+					RoutingProfileId: ts.RoutingProfile.ID[:],
+					DownlinkFrame: &gw.DownlinkFrame{
+						Token:     123,
+						GatewayId: ts.Gateway.GatewayID[:],
+						Items: []*gw.DownlinkFrameItem{
+							{
+								PhyPayload: ts.getPHYPayload(lorawan.UnconfirmedDataDown, &fPort2, nil, []lorawan.Payload{
+									&lorawan.DataPayload{Bytes: []byte{1, 2, 3}},
+								}),
+								TxInfo: &gw.DownlinkTXInfo{
+									Frequency: 868100000,
+								},
+							},
+						},
+					},
+				},
+				MulticastQueueItems: []storage.MulticastQueueItem{
+					{ScheduleAt: time.Now(), MulticastGroupID: ts.MulticastGroup.ID, GatewayID: ts.Gateway.GatewayID, FCnt: 30, FPort: 2},
+				},
+				Assert: []Assertion{
+					AssertMulticastGroupFCntDown(30),
+					AssertMulticastQueueItems([]storage.MulticastQueueItem{
+						{ScheduleAt: time.Now(), MulticastGroupID: ts.MulticastGroup.ID, GatewayID: ts.Gateway.GatewayID, FCnt: 30, FPort: 2, RetryCount: 1},
+					}),
 					AssertASNoHandleErrorRequest(),
 					AssertASNoHandleTxAckRequest(),
 					AssertNCNoHandleDownlinkMetaDataRequest(),
 				},
 			},
+			{
+				Name: "retryable nack for multicast frame - max attempts reached",
+				DownlinkTXAck: &gw.DownlinkTXAck{
+					Token: 123,
+					Items: []*gw.DownlinkTXAckItem{
+						{
+							Status: gw.TxAckStatus_COLLISION_PACKET,
+						},
+					},
+				},
+				DownlinkFrame: &storage.DownlinkFrame{
+					Token:            123,
+					MulticastGroupId: ts.MulticastGroup.ID[:],
+					RoutingProfileId: ts.RoutingProfile.ID[:],
+					DownlinkFrame: &gw.DownlinkFrame{
+						Token:     123,
+						GatewayId: ts.Gateway.GatewayID[:],
+						Items: []*gw.DownlinkFrameItem{
+							{
+								PhyPayload: ts.getPHYPayload(lorawan.UnconfirmedDataDown, &fPort2, nil, []lorawan.Payload{
+									&lorawan.DataPayload{Bytes: []byte{1, 2, 3}},
+								}),
+								TxInfo: &gw.DownlinkTXInfo{
+									Frequency: 868100000,
+								},
+							},
+						},
+					},
+				},
+				MulticastQueueItems: []storage.MulticastQueueItem{
+					{ScheduleAt: time.Now(), MulticastGroupID: ts.MulticastGroup.ID, GatewayID: ts.Gateway.GatewayID, FCnt: 30, FPort: 2, RetryCount: 2},
+				},
+				Assert: []Assertion{
+					AssertMulticastGroupFCntDown(30),
+					AssertMulticastQueueItems([]storage.MulticastQueueItem{}),
+					AssertASHandleErrorRequest(as.HandleErrorRequest{
+						FCnt:  30,
+						Type:  as.ErrorType_DATA_DOWN_GATEWAY,
+						Error: fmt.Sprintf("multicast-group %s: %s", ts.MulticastGroup.ID, gw.TxAckStatus_COLLISION_PACKET),
+					}),
+					AssertASNoHandleTxAckRequest(),
+					AssertNCNoHandleDownlinkMetaDataRequest(),
+				},
+			},
 		}
 
 		for _, tst := range tests {
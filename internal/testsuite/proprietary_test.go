@@ -31,6 +31,18 @@ func (ts *ProprietaryTestCase) SetupTest() {
 func (ts *ProprietaryTestCase) TestDownlink() {
 	gatewayID := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
 
+	antennaGainGatewayID := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 2}
+	ts.CreateGateway(storage.Gateway{
+		GatewayID: antennaGainGatewayID,
+		Overrides: storage.GatewayOverrides{AntennaGain: 3},
+	})
+
+	downlinkDisabledGatewayID := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 3}
+	ts.CreateGateway(storage.Gateway{
+		GatewayID: downlinkDisabledGatewayID,
+		Overrides: storage.GatewayOverrides{DownlinkDisabled: true},
+	})
+
 	tests := []DownlinkProprietaryTest{
 		{
 			Name: "send proprietary payload (iPol true)",
@@ -108,6 +120,56 @@ func (ts *ProprietaryTestCase) TestDownlink() {
 				}),
 			},
 		},
+		{
+			Name: "send proprietary payload (antenna gain)",
+			SendProprietaryPayloadRequest: ns.SendProprietaryPayloadRequest{
+				MacPayload:  []byte{1, 2, 3, 4},
+				Mic:         []byte{5, 6, 7, 8},
+				GatewayMacs: [][]byte{antennaGainGatewayID[:]},
+				Frequency:   868100000,
+				Dr:          5,
+			},
+
+			Assert: []Assertion{
+				AssertDownlinkFrame(antennaGainGatewayID, gw.DownlinkTXInfo{
+					Frequency:  868100000,
+					Power:      11,
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							Bandwidth:       125,
+							SpreadingFactor: 7,
+							CodeRate:        "4/5",
+						},
+					},
+					Timing: gw.DownlinkTiming_IMMEDIATELY,
+					TimingInfo: &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
+						ImmediatelyTimingInfo: &gw.ImmediatelyTimingInfo{},
+					},
+				}, lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						Major: lorawan.LoRaWANR1,
+						MType: lorawan.Proprietary,
+					},
+					MACPayload: &lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}},
+					MIC:        lorawan.MIC{5, 6, 7, 8},
+				}),
+			},
+		},
+		{
+			Name: "send proprietary payload (downlink disabled)",
+			SendProprietaryPayloadRequest: ns.SendProprietaryPayloadRequest{
+				MacPayload:  []byte{1, 2, 3, 4},
+				Mic:         []byte{5, 6, 7, 8},
+				GatewayMacs: [][]byte{downlinkDisabledGatewayID[:]},
+				Frequency:   868100000,
+				Dr:          5,
+			},
+
+			Assert: []Assertion{
+				AssertNoDownlinkFrame,
+			},
+		},
 	}
 
 	for _, tst := range tests {
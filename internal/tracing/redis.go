@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook implements a redis.Hook creating a span for each Redis command
+// and pipeline. Spans are only created when the context contains a
+// recording span, to avoid creating a trace for each (background) command.
+// The span ends after the reply has been read (go-redis calls AfterProcess
+// after reading the reply).
+type RedisHook struct{}
+
+type redisSpanKey struct{}
+
+// startRedisSpan starts the command or pipeline span. The span is stored
+// under a dedicated key, so that AfterProcess only ends spans that were
+// started by the hook and never the (parent) span of the caller.
+func startRedisSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) context.Context {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx
+	}
+
+	ctx, span := tracer.Start(ctx, name, opts...)
+	return context.WithValue(ctx, redisSpanKey{}, span)
+}
+
+// BeforeProcess starts the command span.
+func (RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return startRedisSpan(ctx, "redis."+cmd.FullName(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBStatementKey.String(cmd.Name()),
+		),
+	), nil
+}
+
+// AfterProcess ends the command span.
+func (RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endRedisSpan(ctx, cmd.Err())
+	return nil
+}
+
+// BeforeProcessPipeline starts the pipeline span.
+func (RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	var names []string
+	for _, cmd := range cmds {
+		names = append(names, cmd.Name())
+	}
+
+	return startRedisSpan(ctx, "redis.pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBStatementKey.String(strings.Join(names, " ")),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		),
+	), nil
+}
+
+// AfterProcessPipeline ends the pipeline span.
+func (RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmd.Err() != nil {
+			err = cmd.Err()
+			break
+		}
+	}
+	endRedisSpan(ctx, err)
+	return nil
+}
+
+func endRedisSpan(ctx context.Context, err error) {
+	span, ok := ctx.Value(redisSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+
+	// redis.Nil is not an error, it indicates that the key does not exist
+	if err == redis.Nil {
+		err = nil
+	}
+	End(span, err)
+}
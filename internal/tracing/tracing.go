@@ -0,0 +1,178 @@
+// Package tracing implements the OpenTelemetry tracing of the uplink and
+// downlink handling.
+package tracing
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+)
+
+const instrumentationName = "github.com/liuhw0/chirpstack-network-server/v3"
+
+var (
+	enabled        bool
+	tracerProvider *sdktrace.TracerProvider
+
+	// The global tracer delegates to the tracer-provider once it has been
+	// configured.
+	tracer = otel.Tracer(instrumentationName)
+
+	taskNames sync.Map
+)
+
+// Setup configures the tracer-provider and the OTLP exporter.
+func Setup(c config.Config) error {
+	conf := c.Monitoring.Tracing
+	if !conf.Enabled {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"otlp_endpoint":  conf.OTLPEndpoint,
+		"sampling_ratio": conf.SamplingRatio,
+	}).Info("tracing: setting up OpenTelemetry tracing")
+
+	clientOpts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(conf.OTLPEndpoint),
+	}
+	if conf.OTLPInsecure {
+		clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+	} else {
+		clientOpts = append(clientOpts, otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	}
+
+	exporter, err := otlptrace.New(context.Background(), otlptracegrpc.NewClient(clientOpts...))
+	if err != nil {
+		return errors.Wrap(err, "new otlp exporter error")
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SamplingRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(conf.ServiceName),
+		)),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	enabled = true
+
+	return nil
+}
+
+// Shutdown exports the pending spans and stops the tracer-provider.
+func Shutdown(ctx context.Context) error {
+	if tracerProvider == nil {
+		return nil
+	}
+
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "shutdown tracer-provider error")
+	}
+
+	return nil
+}
+
+// Start starts a new span. The returned context contains the new span.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, opts...)
+}
+
+// StartChild starts a new span, only when the given context contains a
+// recording span. This avoids creating a new trace for each operation that
+// is not part of the uplink or downlink handling (e.g. background jobs).
+// When no span is started, the returned span is the (non-recording) span
+// from the context.
+func StartChild(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return ctx, span
+	}
+	return tracer.Start(ctx, name, opts...)
+}
+
+// End ends the given span. When err is not nil, it is recorded on the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// RunTask calls f within a span named after the given task function. The
+// context passed to f contains the task span. Errors returned by f are
+// recorded on the span, except for the given ignored errors (e.g. an error
+// used to abort the task chain).
+func RunTask(ctx context.Context, task interface{}, f func(ctx context.Context) error, ignore ...error) error {
+	if !enabled {
+		return f(ctx)
+	}
+
+	ctx, span := tracer.Start(ctx, TaskName(task))
+	err := f(ctx)
+
+	recordErr := err
+	for _, e := range ignore {
+		if err == e {
+			recordErr = nil
+		}
+	}
+	End(span, recordErr)
+
+	return err
+}
+
+// TaskName returns the name of the given task function, e.g.
+// 'data.getDeviceProfile' or 'join.(*joinContext).getDeviceProfile'.
+func TaskName(task interface{}) string {
+	pc := reflect.ValueOf(task).Pointer()
+	if name, ok := taskNames.Load(pc); ok {
+		return name.(string)
+	}
+
+	var name string
+	if f := runtime.FuncForPC(pc); f != nil {
+		name = f.Name()
+	}
+
+	// strip the package path and method value suffix
+	if i := strings.LastIndex(name, "/"); i != -1 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(name, "-fm")
+
+	taskNames.Store(pc, name)
+	return name
+}
+
+// UnaryClientInterceptor returns the gRPC client interceptor creating a span
+// for each call. The trace context is propagated using the gRPC meta-data.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return otelgrpc.UnaryClientInterceptor()
+}
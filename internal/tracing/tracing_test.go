@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// The global tracer only delegates to the first configured tracer-provider,
+// thus all tests share the same exporter.
+var (
+	testExporter     *tracetest.InMemoryExporter
+	testExporterOnce sync.Once
+)
+
+func getTestExporter() *tracetest.InMemoryExporter {
+	testExporterOnce.Do(func() {
+		testExporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(testExporter)))
+	})
+	testExporter.Reset()
+	return testExporter
+}
+
+type testContext struct{}
+
+func (*testContext) getDeviceProfile() error {
+	return nil
+}
+
+func getServiceProfile(*testContext) error {
+	return nil
+}
+
+func TestTaskName(t *testing.T) {
+	assert := require.New(t)
+	tctx := &testContext{}
+
+	assert.Equal("tracing.getServiceProfile", TaskName(getServiceProfile))
+	assert.Equal("tracing.(*testContext).getDeviceProfile", TaskName(tctx.getDeviceProfile))
+}
+
+func TestRunTask(t *testing.T) {
+	assert := require.New(t)
+
+	exporter := getTestExporter()
+	enabled = true
+	defer func() {
+		enabled = false
+	}()
+
+	errAbort := errors.New("abort")
+	errTest := errors.New("test error")
+
+	ctx, span := Start(context.Background(), "handle")
+	assert.Equal(errAbort, RunTask(ctx, getServiceProfile, func(ctx context.Context) error {
+		// this span is a child of the task span
+		_, span := StartChild(ctx, "query")
+		End(span, nil)
+		return errAbort
+	}, errAbort))
+	assert.Equal(errTest, RunTask(ctx, getServiceProfile, func(ctx context.Context) error {
+		return errTest
+	}, errAbort))
+	End(span, nil)
+
+	// no span is started without a recording parent span
+	_, span = StartChild(context.Background(), "query")
+	assert.False(span.IsRecording())
+
+	spans := exporter.GetSpans()
+	assert.Len(spans, 4)
+
+	assert.Equal("query", spans[0].Name)
+	assert.Equal(spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+
+	assert.Equal("tracing.getServiceProfile", spans[1].Name)
+	assert.Equal(codes.Unset, spans[1].Status.Code)
+	assert.Equal(spans[3].SpanContext.SpanID(), spans[1].Parent.SpanID())
+
+	assert.Equal("tracing.getServiceProfile", spans[2].Name)
+	assert.Equal(codes.Error, spans[2].Status.Code)
+
+	assert.Equal("handle", spans[3].Name)
+}
+
+func TestRedisHook(t *testing.T) {
+	assert := require.New(t)
+
+	exporter := getTestExporter()
+
+	var hook RedisHook
+
+	// no span is started without a recording parent span
+	cmd := redis.NewStringCmd(context.Background(), "get", "key")
+	ctx, err := hook.BeforeProcess(context.Background(), cmd)
+	assert.NoError(err)
+	assert.NoError(hook.AfterProcess(ctx, cmd))
+	assert.Len(exporter.GetSpans(), 0)
+
+	parentCtx, parent := Start(context.Background(), "handle")
+	ctx, err = hook.BeforeProcess(parentCtx, cmd)
+	assert.NoError(err)
+	assert.NoError(hook.AfterProcess(ctx, cmd))
+
+	// only the command span has ended
+	spans := exporter.GetSpans()
+	assert.Len(spans, 1)
+	assert.Equal("redis.get", spans[0].Name)
+	assert.True(parent.IsRecording())
+	End(parent, nil)
+}
@@ -18,10 +18,15 @@ import (
 	"github.com/liuhw0/lorawan"
 )
 
+// deduplicationPollInterval defines the interval in which the
+// de-duplication set is polled when using adaptive de-duplication.
+const deduplicationPollInterval = 10 * time.Millisecond
+
 // Templates used for generating Redis keys
 const (
-	CollectKeyTempl     = "lora:ns:rx:collect:%s:%s"
-	CollectLockKeyTempl = "lora:ns:rx:collect:%s:%s:lock"
+	CollectKeyTempl         = "lora:ns:rx:collect:%s:%s"
+	CollectLockKeyTempl     = "lora:ns:rx:collect:%s:%s:lock"
+	CollectGatewaysKeyTempl = "lora:ns:rx:collect:%s:%s:gw"
 )
 
 // collectAndCallOnce collects the package, sleeps the configured duration and
@@ -42,6 +47,7 @@ func collectAndCallOnce(rxPacket gw.UplinkFrame, callback func(packet models.RXP
 
 	key := storage.GetRedisKey(CollectKeyTempl, txInfoHEX, phyKey)
 	lockKey := storage.GetRedisKey(CollectLockKeyTempl, txInfoHEX, phyKey)
+	gatewaysKey := storage.GetRedisKey(CollectGatewaysKeyTempl, txInfoHEX, phyKey)
 
 	// this way we can set a really low DeduplicationDelay for testing, without
 	// the risk that the set already expired in redis on read
@@ -50,7 +56,7 @@ func collectAndCallOnce(rxPacket gw.UplinkFrame, callback func(packet models.RXP
 		deduplicationTTL = time.Millisecond * 200
 	}
 
-	if err := collectAndCallOncePut(key, deduplicationTTL, rxPacket); err != nil {
+	if err := collectAndCallOncePut(key, gatewaysKey, deduplicationTTL, rxPacket); err != nil {
 		return err
 	}
 
@@ -61,10 +67,10 @@ func collectAndCallOnce(rxPacket gw.UplinkFrame, callback func(packet models.RXP
 
 	// wait the configured amount of time, more packets might be received
 	// from other gateways
-	time.Sleep(deduplicationDelay)
+	collectAndCallOnceWait(gatewaysKey, rxPacket.PhyPayload)
 
 	// collect all packets from the set
-	payloads, err := collectAndCallOnceCollect(key)
+	payloads, err := collectAndCallOnceCollect(key, gatewaysKey)
 	if err != nil {
 		return errors.Wrap(err, "get deduplication set members error")
 	}
@@ -113,7 +119,149 @@ func collectAndCallOnce(rxPacket gw.UplinkFrame, callback func(packet models.RXP
 	return callback(out)
 }
 
-func collectAndCallOncePut(key string, ttl time.Duration, rxPacket gw.UplinkFrame) error {
+// collectAndCallOnceWait waits for the de-duplication to complete. In case
+// adaptive de-duplication is enabled and the gateways expected to receive
+// the uplink are known, it returns as soon as these gateways have reported
+// the uplink. The configured de-duplication delay is used as upper bound.
+// The adaptive de-duplication min. delay is always waited, so that gateways
+// which did not receive the previous uplink are still able to report the
+// uplink (and become part of the expected gateways for the next uplink).
+func collectAndCallOnceWait(gatewaysKey string, phyPayload []byte) {
+	start := time.Now()
+	result := "fixed"
+
+	if adaptiveDeduplication {
+		if adaptiveDeduplicationMinDelay > 0 {
+			time.Sleep(adaptiveDeduplicationMinDelay)
+		}
+
+		expected, err := getExpectedGatewayIDs(context.Background(), phyPayload)
+		if err != nil {
+			log.WithError(err).Error("uplink: get expected gateways error")
+		}
+
+		if len(expected) != 0 {
+			complete, err := waitForGatewayIDs(gatewaysKey, expected, start.Add(deduplicationDelay))
+			if err != nil {
+				log.WithError(err).Error("uplink: wait for expected gateways error")
+			}
+
+			if complete {
+				result = "complete"
+			} else {
+				result = "timeout"
+			}
+		}
+	}
+
+	if d := deduplicationDelay - time.Since(start); result != "complete" && d > 0 {
+		time.Sleep(d)
+	}
+
+	deduplicationWaitHistogram(result).Observe(time.Since(start).Seconds())
+}
+
+// getExpectedGatewayIDs returns the IDs of the gateways that are expected to
+// receive the given data uplink. These are the gateways that received the
+// previous uplink of the device(s) using the same DevAddr. It returns nil
+// when this can't be predicted (e.g. for a join-request).
+func getExpectedGatewayIDs(ctx context.Context, phyPayload []byte) (map[lorawan.EUI64]struct{}, error) {
+	mType := getMType(phyPayload)
+	if (mType != lorawan.UnconfirmedDataUp && mType != lorawan.ConfirmedDataUp) || len(phyPayload) < 5 {
+		return nil, nil
+	}
+
+	var devAddr lorawan.DevAddr
+	if err := devAddr.UnmarshalBinary(phyPayload[1:5]); err != nil {
+		return nil, errors.Wrap(err, "unmarshal DevAddr error")
+	}
+
+	devEUIs, err := storage.GetDevEUIsForDevAddr(ctx, devAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "get DevEUIs for DevAddr error")
+	}
+
+	rxInfoSets, err := storage.GetDeviceGatewayRXInfoSetForDevEUIs(ctx, devEUIs)
+	if err != nil {
+		return nil, errors.Wrap(err, "get device gateway rx-info sets error")
+	}
+
+	// The expected gateways can't be predicted when one of the devices has
+	// no rx-info set, as it could be the device sending the uplink.
+	if len(rxInfoSets) != len(devEUIs) {
+		return nil, nil
+	}
+
+	out := make(map[lorawan.EUI64]struct{})
+	for _, rxInfoSet := range rxInfoSets {
+		for _, item := range rxInfoSet.Items {
+			out[item.GatewayID] = struct{}{}
+		}
+	}
+
+	return out, nil
+}
+
+// waitForGatewayIDs polls the set of gateways that have reported the
+// uplink, until all the expected gateways have reported, or until the
+// deadline has been reached. It returns true when all the expected gateways
+// have reported. The set members are only fetched once the number of
+// reported gateways reaches the number of expected gateways.
+func waitForGatewayIDs(gatewaysKey string, expected map[lorawan.EUI64]struct{}, deadline time.Time) (bool, error) {
+	for {
+		n, err := storage.RedisClient().SCard(context.Background(), gatewaysKey).Result()
+		if err != nil {
+			return false, errors.Wrap(err, "get set cardinality error")
+		}
+
+		if int(n) >= len(expected) {
+			received, err := collectAndCallOnceGatewayIDs(gatewaysKey)
+			if err != nil {
+				return false, err
+			}
+
+			complete := true
+			for id := range expected {
+				if _, ok := received[id]; !ok {
+					complete = false
+					break
+				}
+			}
+			if complete {
+				return true, nil
+			}
+		}
+
+		d := time.Until(deadline)
+		if d <= 0 {
+			return false, nil
+		}
+		if d > deduplicationPollInterval {
+			d = deduplicationPollInterval
+		}
+		time.Sleep(d)
+	}
+}
+
+// collectAndCallOnceGatewayIDs returns the IDs of the gateways that have
+// reported the uplink so far.
+func collectAndCallOnceGatewayIDs(gatewaysKey string) (map[lorawan.EUI64]struct{}, error) {
+	vals, err := storage.RedisClient().SMembers(context.Background(), gatewaysKey).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "get set members error")
+	}
+
+	out := make(map[lorawan.EUI64]struct{})
+	for _, val := range vals {
+		var id lorawan.EUI64
+		copy(id[:], val)
+		out[id] = struct{}{}
+	}
+
+	return out, nil
+}
+
+func collectAndCallOncePut(key, gatewaysKey string, ttl time.Duration, rxPacket gw.UplinkFrame) error {
 	b, err := proto.Marshal(&rxPacket)
 	if err != nil {
 		return errors.Wrap(err, "marshal uplink frame error")
@@ -122,6 +270,10 @@ func collectAndCallOncePut(key string, ttl time.Duration, rxPacket gw.UplinkFram
 	pipe := storage.RedisClient().TxPipeline()
 	pipe.SAdd(context.Background(), key, b)
 	pipe.PExpire(context.Background(), key, ttl)
+	if rxPacket.RxInfo != nil {
+		pipe.SAdd(context.Background(), gatewaysKey, rxPacket.RxInfo.GatewayId)
+		pipe.PExpire(context.Background(), gatewaysKey, ttl)
+	}
 
 	_, err = pipe.Exec(context.Background())
 	if err != nil {
@@ -149,10 +301,13 @@ func collectAndCallOnceLocked(key string, ttl time.Duration) (bool, error) {
 	return !set, nil
 }
 
-func collectAndCallOnceCollect(key string) ([][]byte, error) {
+func collectAndCallOnceCollect(key, gatewaysKey string) ([][]byte, error) {
 	pipe := storage.RedisClient().Pipeline()
 	val := pipe.SMembers(context.Background(), key)
+	// the keys are deleted one by one, as these might be stored in different
+	// hash slots when using Redis Cluster
 	pipe.Del(context.Background(), key)
+	pipe.Del(context.Background(), gatewaysKey)
 
 	if _, err := pipe.Exec(context.Background()); err != nil {
 		return nil, errors.Wrap(err, "get set members error")
@@ -179,6 +179,158 @@ func (ts *CollectTestSuite) TestDeduplication() {
 	}
 }
 
+func (ts *CollectTestSuite) TestCollectAndCallOnceCollect() {
+	assert := require.New(ts.T())
+	storage.RedisClient().FlushAll(context.Background())
+
+	key := storage.GetRedisKey(CollectKeyTempl, "0102", "0304")
+	gatewaysKey := storage.GetRedisKey(CollectGatewaysKeyTempl, "0102", "0304")
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	assert.NoError(collectAndCallOncePut(key, gatewaysKey, time.Minute, gw.UplinkFrame{
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+		},
+	}))
+
+	out, err := collectAndCallOnceCollect(key, gatewaysKey)
+	assert.NoError(err)
+	assert.Len(out, 1)
+
+	n, err := storage.RedisClient().Exists(context.Background(), key, gatewaysKey).Result()
+	assert.NoError(err)
+	assert.EqualValues(0, n)
+}
+
+func (ts *CollectTestSuite) TestAdaptiveDeduplication() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	adaptiveDeduplication = true
+	defer func() {
+		adaptiveDeduplication = false
+	}()
+
+	assert.NoError(storage.RedisClient().FlushAll(ctx).Err())
+
+	ds := storage.DeviceSession{
+		DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+	}
+	assert.NoError(storage.SaveDeviceSession(ctx, ds))
+	assert.NoError(storage.SaveDeviceGatewayRXInfoSet(ctx, storage.DeviceGatewayRXInfoSet{
+		DevEUI: ds.DevEUI,
+		Items: []storage.DeviceGatewayRXInfo{
+			{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}},
+			{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+		},
+	}))
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MIC: [4]byte{5, 6, 7, 8},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ds.DevAddr,
+			},
+		},
+	}
+	phyB, err := phy.MarshalBinary()
+	assert.NoError(err)
+
+	ts.T().Run("Expected gateways", func(t *testing.T) {
+		assert := require.New(t)
+
+		expected, err := getExpectedGatewayIDs(ctx, phyB)
+		assert.NoError(err)
+		assert.Equal(map[lorawan.EUI64]struct{}{
+			{1, 1, 1, 1, 1, 1, 1, 1}: {},
+			{2, 2, 2, 2, 2, 2, 2, 2}: {},
+		}, expected)
+
+		// join-request
+		expected, err = getExpectedGatewayIDs(ctx, []byte{0x00, 1, 2, 3, 4})
+		assert.NoError(err)
+		assert.Nil(expected)
+	})
+
+	ts.T().Run("Early release", func(t *testing.T) {
+		assert := require.New(t)
+
+		var received int
+		cb := func(packet models.RXPacket) error {
+			received = len(packet.RXInfoSet)
+			return nil
+		}
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for _, id := range []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}, {2, 2, 2, 2, 2, 2, 2, 2}} {
+			packet := gw.UplinkFrame{
+				RxInfo: &gw.UplinkRXInfo{
+					GatewayId: id[:],
+				},
+				TxInfo:     &gw.UplinkTXInfo{},
+				PhyPayload: phyB,
+			}
+			assert.NoError(helpers.SetUplinkTXInfoDataRate(packet.TxInfo, 0, band.Band()))
+
+			wg.Add(1)
+			go func(packet gw.UplinkFrame) {
+				defer wg.Done()
+				assert.NoError(collectAndCallOnce(packet, cb))
+			}(packet)
+		}
+		wg.Wait()
+
+		assert.Equal(2, received)
+		assert.True(time.Since(start) < deduplicationDelay)
+	})
+
+	ts.T().Run("Min. delay", func(t *testing.T) {
+		assert := require.New(t)
+
+		adaptiveDeduplicationMinDelay = deduplicationDelay / 2
+		defer func() {
+			adaptiveDeduplicationMinDelay = 0
+		}()
+
+		var received int
+		cb := func(packet models.RXPacket) error {
+			received = len(packet.RXInfoSet)
+			return nil
+		}
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for _, id := range []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}, {2, 2, 2, 2, 2, 2, 2, 2}, {3, 3, 3, 3, 3, 3, 3, 3}} {
+			packet := gw.UplinkFrame{
+				RxInfo: &gw.UplinkRXInfo{
+					GatewayId: id[:],
+				},
+				TxInfo:     &gw.UplinkTXInfo{},
+				PhyPayload: phyB,
+			}
+			assert.NoError(helpers.SetUplinkTXInfoDataRate(packet.TxInfo, 0, band.Band()))
+
+			wg.Add(1)
+			go func(packet gw.UplinkFrame) {
+				defer wg.Done()
+				assert.NoError(collectAndCallOnce(packet, cb))
+			}(packet)
+		}
+		wg.Wait()
+
+		// the gateway that was not expected has been collected too
+		assert.Equal(3, received)
+		assert.True(time.Since(start) >= adaptiveDeduplicationMinDelay)
+		assert.True(time.Since(start) < deduplicationDelay)
+	})
+}
+
 func TestCollect(t *testing.T) {
 	suite.Run(t, new(CollectTestSuite))
 }
@@ -12,9 +12,12 @@ import (
 	"github.com/brocaar/chirpstack-api/go/v3/as"
 	"github.com/brocaar/chirpstack-api/go/v3/common"
 	"github.com/brocaar/chirpstack-api/go/v3/nc"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver/retry"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/controller"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/channels"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
 	datadown "github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/data"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/framelog"
@@ -24,12 +27,12 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/roaming"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 	"github.com/liuhw0/lorawan"
 )
 
 const (
-	applicationClientTimeout = time.Second
-	downlinkLockKey          = "lora:ns:device:%s:down:lock"
+	downlinkLockKey = "lora:ns:device:%s:down:lock"
 )
 
 // ErrAbort is used to abort the flow without error
@@ -48,6 +51,7 @@ var tasks = []func(*dataContext) error{
 	decryptFRMPayloadMACCommands,
 	logUplinkFrame,
 	getApplicationServerClientForDataUp,
+	flagDisallowedUplinkChannel,
 	setADR,
 	setUplinkDataRate,
 	setBeaconLocked,
@@ -112,13 +116,24 @@ func isRoaming(r bool, tasks ...func(*dataContext) error) func(*dataContext) err
 
 // Handle handles an uplink data frame
 func Handle(ctx context.Context, rxPacket models.RXPacket) error {
+	ctx, span := tracing.Start(ctx, "uplink.data.Handle")
+	err := handle(ctx, rxPacket)
+	tracing.End(span, err)
+	return err
+}
+
+func handle(ctx context.Context, rxPacket models.RXPacket) error {
 	dctx := dataContext{
 		ctx:      ctx,
 		RXPacket: rxPacket,
 	}
 
 	for _, t := range tasks {
-		if err := t(&dctx); err != nil {
+		t := t
+		if err := tracing.RunTask(ctx, t, func(ctx context.Context) error {
+			dctx.ctx = ctx
+			return t(&dctx)
+		}, ErrAbort); err != nil {
 			if err == ErrAbort {
 				return nil
 			}
@@ -317,21 +332,50 @@ func setUplinkDataRate(ctx *dataContext) error {
 // its TXPower and if one of the mac-commands contains a LinkADRReq ACK
 // this will update the TXPowerIndex on the device-session).
 func appendMetaDataToUplinkHistory(ctx *dataContext) error {
-	var maxSNR float64
+	// The uplink has already been accepted, an unknown channel must not
+	// abort the uplink handling.
+	txCh, err := band.Band().GetUplinkChannelIndexForFrequencyDR(ctx.RXPacket.TXInfo.Frequency, ctx.RXPacket.DR)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui":   ctx.DeviceSession.DevEUI,
+			"frequency": ctx.RXPacket.TXInfo.Frequency,
+			"dr":        ctx.RXPacket.DR,
+			"ctx_id":    ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("uplink/data: get uplink channel error, channel is not stored in uplink history")
+		txCh = storage.UplinkHistoryUnknownChannel
+	}
+
+	uh := storage.UplinkHistory{
+		FCnt:         ctx.MACPayload.FHDR.FCnt,
+		GatewayCount: len(ctx.RXPacket.RXInfoSet),
+		TXPowerIndex: ctx.DeviceSession.TXPowerIndex,
+		DR:           ctx.RXPacket.DR,
+		Frequency:    ctx.RXPacket.TXInfo.Frequency,
+		Channel:      txCh,
+		Time:         time.Now(),
+	}
+
 	for i, rxInfo := range ctx.RXPacket.RXInfoSet {
 		// as the default value is 0 and the LoRaSNR can be negative, we always
 		// set it when i == 0 (the first item from the slice)
-		if i == 0 || rxInfo.LoraSnr > maxSNR {
-			maxSNR = rxInfo.LoraSnr
+		if i == 0 || rxInfo.LoraSnr > uh.MaxSNR {
+			uh.MaxSNR = rxInfo.LoraSnr
+		}
+		if i == 0 || rxInfo.Rssi > uh.MaxRSSI {
+			uh.MaxRSSI = rxInfo.Rssi
 		}
+
+		var gatewayID lorawan.EUI64
+		copy(gatewayID[:], rxInfo.GatewayId)
+
+		uh.Gateways = append(uh.Gateways, storage.UplinkHistoryGateway{
+			GatewayID: gatewayID,
+			SNR:       rxInfo.LoraSnr,
+			RSSI:      rxInfo.Rssi,
+		})
 	}
 
-	ctx.DeviceSession.AppendUplinkHistory(storage.UplinkHistory{
-		FCnt:         ctx.MACPayload.FHDR.FCnt,
-		GatewayCount: len(ctx.RXPacket.RXInfoSet),
-		MaxSNR:       maxSNR,
-		TXPowerIndex: ctx.DeviceSession.TXPowerIndex,
-	})
+	ctx.DeviceSession.AppendUplinkHistory(uh, ctx.DeviceProfile.UplinkHistorySize)
 
 	return nil
 }
@@ -377,6 +421,61 @@ func getApplicationServerClientForDataUp(ctx *dataContext) error {
 	return nil
 }
 
+// flagDisallowedUplinkChannel flags uplinks received on a channel which is
+// not allowed by the service-profile channel-mask. The uplink is still
+// handled, as the device might not have processed the channel
+// reconfiguration yet.
+func flagDisallowedUplinkChannel(ctx *dataContext) error {
+	if len(ctx.ServiceProfile.ChannelMask) == 0 {
+		return nil
+	}
+
+	txCh, err := band.Band().GetUplinkChannelIndexForFrequencyDR(ctx.RXPacket.TXInfo.Frequency, ctx.RXPacket.DR)
+	if err != nil {
+		// This is best-effort, the uplink must not be rejected because of
+		// this.
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui":   ctx.DeviceSession.DevEUI,
+			"frequency": ctx.RXPacket.TXInfo.Frequency,
+			"dr":        ctx.RXPacket.DR,
+			"ctx_id":    ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("uplink/data: get uplink channel error, skipping channel-mask check")
+		return nil
+	}
+
+	if channels.ChannelMaskAllows(ctx.ServiceProfile.ChannelMask, txCh) {
+		return nil
+	}
+
+	disallowedChannelCounter().Inc()
+
+	log.WithFields(log.Fields{
+		"dev_eui":            ctx.DeviceSession.DevEUI,
+		"channel":            txCh,
+		"service_profile_id": ctx.ServiceProfile.ID,
+		"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+	}).Warning("uplink/data: uplink received on channel not allowed by service-profile channel-mask")
+
+	req := as.HandleErrorRequest{
+		DevEui: ctx.DeviceSession.DevEUI[:],
+		Type:   as.ErrorType_GENERIC,
+		Error:  fmt.Sprintf("uplink received on channel %d, which is not allowed by the service-profile channel-mask", txCh),
+		FCnt:   ctx.MACPayload.FHDR.FCnt,
+	}
+
+	// send async to as, so that the uplink handling is not delayed
+	go func(ctx context.Context, devEUI lorawan.EUI64, asClient as.ApplicationServerServiceClient) {
+		if _, err := asClient.HandleError(ctx, &req); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).Error("uplink/data: send error to application-server error")
+		}
+	}(ctx.ctx, ctx.DeviceSession.DevEUI, ctx.ApplicationServerClient)
+
+	return nil
+}
+
 func decryptFOptsMACCommands(ctx *dataContext) error {
 	if ctx.DeviceSession.GetMACVersion() == lorawan.LoRaWAN1_0 {
 		if err := ctx.RXPacket.PHYPayload.DecodeFOptsToMACCommands(); err != nil {
@@ -415,10 +514,12 @@ func setBeaconLocked(ctx *dataContext) error {
 		if err != nil {
 			return errors.Wrap(err, "get device")
 		}
+		modeBefore := d.Mode
 		d.Mode = storage.DeviceModeB
 		if err := storage.UpdateDevice(ctx.ctx, storage.DB(), &d); err != nil {
 			return errors.Wrap(err, "update device error")
 		}
+		audit.LogModeChange(ctx.ctx, d.DevEUI, modeBefore, d.Mode, "beacon_locked")
 
 		// Re-create device-queue items.
 		// Note that the CreateDeviceQueueItem function will take care of setting
@@ -454,10 +555,12 @@ func setBeaconLocked(ctx *dataContext) error {
 		if err != nil {
 			return errors.Wrap(err, "get device")
 		}
+		modeBefore := d.Mode
 		d.Mode = storage.DeviceModeA
 		if err := storage.UpdateDevice(ctx.ctx, storage.DB(), &d); err != nil {
 			return errors.Wrap(err, "update device error")
 		}
+		audit.LogModeChange(ctx.ctx, d.DevEUI, modeBefore, d.Mode, "beacon_locked")
 
 		log.WithFields(log.Fields{
 			"dev_eui": ctx.DeviceSession.DevEUI,
@@ -628,16 +731,7 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 		publishDataUpReq.Data = dataPL.Bytes
 	}
 
-	go func(ctx context.Context, asClient as.ApplicationServerServiceClient, publishDataUpReq as.HandleUplinkDataRequest) {
-		ctxTimeout, cancel := context.WithTimeout(ctx, applicationClientTimeout)
-		defer cancel()
-
-		if _, err := asClient.HandleUplinkData(ctxTimeout, &publishDataUpReq); err != nil {
-			log.WithFields(log.Fields{
-				"ctx_id": ctx.Value(logging.ContextIDKey),
-			}).WithError(err).Error("publish uplink data to application-server error")
-		}
-	}(ctx.ctx, ctx.ApplicationServerClient, publishDataUpReq)
+	retry.HandleUplinkData(ctx.ctx, ctx.DeviceSession.RoutingProfileID, ctx.ApplicationServerClient, &publishDataUpReq)
 
 	ctx.DeviceSession.AppSKeyEvelope = nil
 
@@ -682,14 +776,11 @@ func handleUplinkACK(ctx *dataContext) error {
 		return errors.Wrap(err, "delete device-queue item error")
 	}
 
-	_, err = ctx.ApplicationServerClient.HandleDownlinkACK(ctx.ctx, &as.HandleDownlinkACKRequest{
+	retry.HandleDownlinkACK(ctx.ctx, ctx.DeviceSession.RoutingProfileID, ctx.ApplicationServerClient, &as.HandleDownlinkACKRequest{
 		DevEui:       ctx.DeviceSession.DevEUI[:],
 		FCnt:         qi.FCnt,
 		Acknowledged: true,
 	})
-	if err != nil {
-		return errors.Wrap(err, "application-server client error")
-	}
 
 	return nil
 }
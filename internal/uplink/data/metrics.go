@@ -0,0 +1,17 @@
+package data
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "uplink_data_disallowed_channel_count",
+		Help: "The number of uplink data frames received on a channel not allowed by the service-profile channel-mask.",
+	})
+)
+
+func disallowedChannelCounter() prometheus.Counter {
+	return dc
+}
@@ -12,9 +12,11 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/brocaar/chirpstack-api/go/v3/as"
 	"github.com/brocaar/chirpstack-api/go/v3/nc"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/controller"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/joinserver"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
@@ -26,6 +28,7 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/roaming"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 	"github.com/liuhw0/lorawan"
 	"github.com/liuhw0/lorawan/backend"
 	loraband "github.com/liuhw0/lorawan/band"
@@ -50,6 +53,12 @@ type joinContext struct {
 
 	PRStartReqPayload *backend.PRStartReqPayload
 	PRStartAnsPayload *backend.PRStartAnsPayload
+
+	// auditBefore contains the audit state before the join.
+	auditBefore audit.State
+
+	// joined is set when the join has been handled (and not aborted).
+	joined bool
 }
 
 var (
@@ -83,12 +92,20 @@ func Setup(conf config.Config) error {
 
 // Handle handles a join-request
 func Handle(ctx context.Context, rxPacket models.RXPacket) error {
-	return storage.Transaction(func(tx sqlx.Ext) error {
-		jctx := joinContext{
-			ctx:      ctx,
-			tx:       tx,
-			RXPacket: rxPacket,
-		}
+	ctx, span := tracing.Start(ctx, "uplink.join.Handle")
+	err := handle(ctx, rxPacket)
+	tracing.End(span, err)
+	return err
+}
+
+func handle(ctx context.Context, rxPacket models.RXPacket) error {
+	jctx := joinContext{
+		ctx:      ctx,
+		RXPacket: rxPacket,
+	}
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		jctx.tx = tx
 
 		for _, f := range []func() error{
 			jctx.setContextFromJoinRequestPHYPayload,
@@ -108,7 +125,11 @@ func Handle(ctx context.Context, rxPacket models.RXPacket) error {
 			jctx.setDeviceMode,
 			jctx.sendJoinAcceptDownlink,
 		} {
-			if err := f(); err != nil {
+			f := f
+			if err := tracing.RunTask(ctx, f, func(ctx context.Context) error {
+				jctx.ctx = ctx
+				return f()
+			}, ErrAbort); err != nil {
 				if err == ErrAbort {
 					return nil
 				}
@@ -117,8 +138,21 @@ func Handle(ctx context.Context, rxPacket models.RXPacket) error {
 			}
 		}
 
+		jctx.joined = true
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// The join event is logged after the transaction has been committed,
+	// as the join is rolled back when the commit fails.
+	if jctx.joined {
+		jctx.ctx = ctx
+		jctx.logJoinEvent()
+	}
+
+	return nil
 }
 
 func (ctx *joinContext) setContextFromJoinRequestPHYPayload() error {
@@ -317,7 +351,9 @@ func (ctx *joinContext) getJoinAcceptFromAS() error {
 		return errors.Wrap(err, "get join-server client error")
 	}
 
-	ctx.JoinAnsPayload, err = jsClient.JoinReq(ctx.ctx, joinReqPL)
+	jsCtx, span := tracing.StartChild(ctx.ctx, "joinserver.JoinReq", trace.WithSpanKind(trace.SpanKindClient))
+	ctx.JoinAnsPayload, err = jsClient.JoinReq(jsCtx, joinReqPL)
+	tracing.End(span, err)
 	if err != nil {
 		returnErr := errors.Wrap(err, "join-request to join-server error")
 		req := as.HandleErrorRequest{
@@ -394,6 +430,17 @@ func (ctx *joinContext) flushDeviceQueue() error {
 }
 
 func (ctx *joinContext) createDeviceSession() error {
+	ctx.auditBefore = audit.State{
+		"mode": string(ctx.Device.Mode),
+	}
+	if prevDS, err := storage.GetDeviceSession(ctx.ctx, ctx.JoinRequestPayload.DevEUI); err == nil {
+		for k, v := range audit.GetState(prevDS) {
+			ctx.auditBefore[k] = v
+		}
+	} else if errors.Cause(err) != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get device-session error")
+	}
+
 	ds := storage.DeviceSession{
 		DeviceProfileID:  ctx.Device.DeviceProfileID,
 		ServiceProfileID: ctx.Device.ServiceProfileID,
@@ -545,6 +592,21 @@ func (ctx *joinContext) setDeviceMode() error {
 	return nil
 }
 
+func (ctx *joinContext) logJoinEvent() {
+	after := audit.GetState(ctx.DeviceSession)
+	after["mode"] = string(ctx.Device.Mode)
+
+	audit.Log(ctx.ctx, audit.Event{
+		DevEUI: ctx.DeviceSession.DevEUI,
+		Type:   audit.EventJoin,
+		Before: ctx.auditBefore,
+		After:  after,
+		Details: map[string]interface{}{
+			"dev_nonce": ctx.JoinRequestPayload.DevNonce,
+		},
+	})
+}
+
 func (ctx *joinContext) sendJoinAcceptDownlink() error {
 	var phy lorawan.PHYPayload
 	if err := phy.UnmarshalBinary(ctx.JoinAnsPayload.PHYPayload[:]); err != nil {
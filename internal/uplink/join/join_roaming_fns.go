@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/joinserver"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
@@ -16,6 +17,7 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/roaming"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 	"github.com/liuhw0/lorawan"
 	"github.com/liuhw0/lorawan/backend"
 )
@@ -80,7 +82,9 @@ func (ctx *startPRFNSContext) getHomeNetID() error {
 	nsReq := backend.HomeNSReqPayload{
 		DevEUI: ctx.joinRequestPayload.DevEUI,
 	}
-	nsAns, err := jsClient.HomeNSReq(ctx.ctx, nsReq)
+	jsCtx, span := tracing.StartChild(ctx.ctx, "joinserver.HomeNSReq", trace.WithSpanKind(trace.SpanKindClient))
+	nsAns, err := jsClient.HomeNSReq(jsCtx, nsReq)
+	tracing.End(span, err)
 	if err != nil {
 		return errors.Wrap(err, "request home netid error")
 	}
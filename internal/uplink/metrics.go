@@ -10,8 +10,39 @@ var (
 		Name: "uplink_counter",
 		Help: "The number of handled uplink frames by the Server (per message type).",
 	}, []string{"mType"})
+
+	usc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "uplink_shed_counter",
+		Help: "The number of received frames dropped because the worker-pool queue was full (per message type).",
+	}, []string{"mType"})
+
+	dwh = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "uplink_deduplication_wait_seconds",
+		Help: "The time spent waiting for the uplink de-duplication (per result: fixed, complete or timeout).",
+	}, []string{"result"})
+
+	wpql = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "uplink_worker_pool_queue_length",
+		Help: "The number of received frames waiting to be handled by the worker-pool (per pool and priority).",
+	}, []string{"pool", "priority"})
 )
 
 func uplinkFrameCounter(e string) prometheus.Counter {
 	return uc.With(prometheus.Labels{"mType": e})
 }
+
+func uplinkShedCounter(e string) prometheus.Counter {
+	return usc.With(prometheus.Labels{"mType": e})
+}
+
+func deduplicationWaitHistogram(result string) prometheus.Observer {
+	return dwh.With(prometheus.Labels{"result": result})
+}
+
+func workerPoolQueueLength(pool string, prio priority) prometheus.Gauge {
+	p := "low"
+	if prio == priorityHigh {
+		p = "high"
+	}
+	return wpql.With(prometheus.Labels{"pool": pool, "priority": p})
+}
@@ -0,0 +1,147 @@
+package uplink
+
+import (
+	"sync"
+
+	"github.com/liuhw0/lorawan"
+)
+
+// priority defines the priority in which received frames are handled.
+type priority int
+
+// Available priorities.
+const (
+	priorityLow priority = iota
+	priorityHigh
+)
+
+// getMType returns the message-type of the given PHYPayload, without
+// decoding the complete PHYPayload.
+func getMType(phyPayload []byte) lorawan.MType {
+	if len(phyPayload) == 0 {
+		return lorawan.Proprietary
+	}
+	return lorawan.MType(phyPayload[0] >> 5)
+}
+
+// getPriority returns the priority for the given message-type. Join-,
+// rejoin- and confirmed uplink frames are handled before unconfirmed and
+// proprietary uplink frames, as the latter can be lost without requiring
+// a retransmission by the device.
+func getPriority(mType lorawan.MType) priority {
+	switch mType {
+	case lorawan.JoinRequest, lorawan.RejoinRequest, lorawan.ConfirmedDataUp:
+		return priorityHigh
+	default:
+		return priorityLow
+	}
+}
+
+// workerPool handles the submitted functions using a fixed number of
+// workers. When the number of workers is 0, each function is handled within
+// its own go-routine.
+type workerPool struct {
+	name    string
+	workers int
+	high    chan func()
+	low     chan func()
+	wg      sync.WaitGroup
+}
+
+// newWorkerPool creates and starts a new worker-pool. The queue-size
+// defines the max. number of pending functions per priority.
+func newWorkerPool(name string, workers, queueSize int) *workerPool {
+	p := workerPool{
+		name:    name,
+		workers: workers,
+	}
+
+	if workers == 0 {
+		return &p
+	}
+
+	p.high = make(chan func(), queueSize)
+	p.low = make(chan func(), queueSize)
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+
+	return &p
+}
+
+// submit queues f for handling. It returns false when the queue is full, in
+// which case f has been dropped.
+func (p *workerPool) submit(prio priority, f func()) bool {
+	if p.workers == 0 {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			f()
+		}()
+		return true
+	}
+
+	queue := p.low
+	if prio == priorityHigh {
+		queue = p.high
+	}
+
+	select {
+	case queue <- f:
+		workerPoolQueueLength(p.name, prio).Inc()
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops accepting new functions and blocks until all the queued
+// functions have been handled.
+func (p *workerPool) close() {
+	if p.workers != 0 {
+		close(p.high)
+		close(p.low)
+	}
+	p.wg.Wait()
+}
+
+func (p *workerPool) worker() {
+	defer p.wg.Done()
+
+	high, low := p.high, p.low
+	for high != nil || low != nil {
+		// Always give precedence to the high priority queue.
+		select {
+		case f, ok := <-high:
+			if !ok {
+				high = nil
+				continue
+			}
+			p.handle(priorityHigh, f)
+			continue
+		default:
+		}
+
+		select {
+		case f, ok := <-high:
+			if !ok {
+				high = nil
+				continue
+			}
+			p.handle(priorityHigh, f)
+		case f, ok := <-low:
+			if !ok {
+				low = nil
+				continue
+			}
+			p.handle(priorityLow, f)
+		}
+	}
+}
+
+func (p *workerPool) handle(prio priority, f func()) {
+	workerPoolQueueLength(p.name, prio).Dec()
+	f()
+}
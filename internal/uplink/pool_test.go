@@ -0,0 +1,90 @@
+package uplink
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/liuhw0/lorawan"
+)
+
+func TestGetPriority(t *testing.T) {
+	tests := []struct {
+		phyPayload []byte
+		mType      lorawan.MType
+		priority   priority
+	}{
+		{[]byte{0x00}, lorawan.JoinRequest, priorityHigh},
+		{[]byte{0xc0}, lorawan.RejoinRequest, priorityHigh},
+		{[]byte{0x80}, lorawan.ConfirmedDataUp, priorityHigh},
+		{[]byte{0x40}, lorawan.UnconfirmedDataUp, priorityLow},
+		{[]byte{0xe0}, lorawan.Proprietary, priorityLow},
+		{nil, lorawan.Proprietary, priorityLow},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.mType.String(), func(t *testing.T) {
+			assert := require.New(t)
+			mType := getMType(tst.phyPayload)
+			assert.Equal(tst.mType, mType)
+			assert.Equal(tst.priority, getPriority(mType))
+		})
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("Unbounded", func(t *testing.T) {
+		assert := require.New(t)
+		p := newWorkerPool("test", 0, 0)
+
+		var mu sync.Mutex
+		var count int
+		for i := 0; i < 10; i++ {
+			assert.True(p.submit(priorityLow, func() {
+				mu.Lock()
+				count++
+				mu.Unlock()
+			}))
+		}
+
+		p.close()
+		assert.Equal(10, count)
+	})
+
+	t.Run("Priority and load shedding", func(t *testing.T) {
+		assert := require.New(t)
+		p := newWorkerPool("test", 1, 2)
+
+		// block the single worker
+		started := make(chan struct{})
+		release := make(chan struct{})
+		assert.True(p.submit(priorityHigh, func() {
+			close(started)
+			<-release
+		}))
+		<-started
+
+		var mu sync.Mutex
+		var handled []string
+		handle := func(s string) func() {
+			return func() {
+				mu.Lock()
+				handled = append(handled, s)
+				mu.Unlock()
+			}
+		}
+
+		assert.True(p.submit(priorityLow, handle("low1")))
+		assert.True(p.submit(priorityLow, handle("low2")))
+		assert.False(p.submit(priorityLow, handle("low3")))
+		assert.True(p.submit(priorityHigh, handle("high1")))
+		assert.True(p.submit(priorityHigh, handle("high2")))
+		assert.False(p.submit(priorityHigh, handle("high3")))
+
+		close(release)
+		p.close()
+
+		assert.Equal([]string{"high1", "high2", "low1", "low2"}, handled)
+	})
+}
@@ -0,0 +1,186 @@
+package proprietary
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/config"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/supervisor"
+	"github.com/liuhw0/chirpstack-network-server/v3/proprietary"
+)
+
+var (
+	mu       sync.RWMutex
+	handlers []proprietary.Handler
+)
+
+// pluginHandler implements a supervised proprietary uplink plugin. When the
+// plugin is not available (e.g. while it is being restarted after a crash),
+// does not respond in time or crashed, an error is returned so that the
+// plugin is skipped.
+type pluginHandler struct {
+	path   string
+	id     string
+	name   string
+	plugin *supervisor.Plugin
+}
+
+// Setup loads the configured proprietary uplink plugins. The plugins are
+// only activated when all of them have been loaded successfully, in which
+// case the previously loaded plugins are stopped.
+//
+// On a configuration reload, this is called while holding the configuration
+// lock. As the uplinks are handled while holding the read-lock, the previously
+// loaded plugins are not in use when these are stopped (except for requests
+// that already timed out).
+func Setup(conf config.Config) error {
+	var newHandlers []proprietary.Handler
+
+	for _, path := range conf.NetworkServer.ProprietaryPlugins {
+		h, err := newPluginHandler(path)
+		if err != nil {
+			closeAll(newHandlers)
+			return errors.Wrapf(err, "load proprietary plugin error (plugin: %s)", path)
+		}
+		newHandlers = append(newHandlers, h)
+
+		log.WithFields(log.Fields{
+			"plugin": path,
+			"id":     h.id,
+		}).Info("uplink/proprietary: plugin loaded")
+	}
+
+	mu.Lock()
+	oldHandlers := handlers
+	handlers = newHandlers
+	mu.Unlock()
+
+	closeAll(oldHandlers)
+
+	return nil
+}
+
+// Validate validates the proprietary uplink plugins configuration, without
+// loading the plugins.
+func Validate(conf config.Config) error {
+	for _, path := range conf.NetworkServer.ProprietaryPlugins {
+		if _, err := exec.LookPath(path); err != nil {
+			return errors.Wrapf(err, "proprietary plugin error (plugin: %s)", path)
+		}
+	}
+
+	return nil
+}
+
+// Close stops the loaded proprietary uplink plugins.
+func Close() {
+	mu.Lock()
+	oldHandlers := handlers
+	handlers = nil
+	mu.Unlock()
+
+	closeAll(oldHandlers)
+}
+
+// newPluginHandler starts the given plugin.
+func newPluginHandler(path string) (*pluginHandler, error) {
+	sp, err := supervisor.Start(supervisor.Config{
+		Path:            path,
+		HandshakeConfig: proprietary.HandshakeConfig,
+		Plugins: map[string]plugin.Plugin{
+			"handler": &proprietary.HandlerPlugin{},
+		},
+		Load: loadPlugin,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := pluginHandler{
+		path:   path,
+		id:     sp.ID(),
+		plugin: sp,
+	}
+
+	err = sp.Call(func(raw interface{}) error {
+		var err error
+		p.name, err = raw.(proprietary.Handler).Name()
+		return err
+	})
+	if err != nil {
+		sp.Close()
+		return nil, errors.Wrap(err, "get plugin name error")
+	}
+
+	return &p, nil
+}
+
+func loadPlugin(rpcClient plugin.ClientProtocol) (interface{}, string, error) {
+	// request the plugin
+	raw, err := rpcClient.Dispense("handler")
+	if err != nil {
+		return nil, "", errors.Wrap(err, "request handler plugin error")
+	}
+
+	// cast to Handler.
+	h, ok := raw.(proprietary.Handler)
+	if !ok {
+		return nil, "", fmt.Errorf("expected proprietary.Handler, got: %T", raw)
+	}
+
+	// get ID.
+	id, err := h.ID()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "get plugin id error")
+	}
+
+	return h, id, nil
+}
+
+// ID returns the ID.
+func (p *pluginHandler) ID() (string, error) {
+	return p.id, nil
+}
+
+// Name returns the name.
+func (p *pluginHandler) Name() (string, error) {
+	return p.name, nil
+}
+
+// Handle handles the proprietary uplink.
+func (p *pluginHandler) Handle(req proprietary.HandleRequest) (proprietary.HandleResponse, error) {
+	var resp proprietary.HandleResponse
+	err := p.plugin.Call(func(raw interface{}) error {
+		var err error
+		resp, err = raw.(proprietary.Handler).Handle(req)
+		return err
+	})
+	return resp, err
+}
+
+// Close stops the plugin.
+func (p *pluginHandler) Close() error {
+	return p.plugin.Close()
+}
+
+func closeAll(handlers []proprietary.Handler) {
+	for _, h := range handlers {
+		if c, ok := h.(io.Closer); ok {
+			c.Close()
+		}
+	}
+}
+
+// getHandlers returns the loaded proprietary uplink plugins.
+func getHandlers() []proprietary.Handler {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return handlers
+}
@@ -1,24 +1,31 @@
 package proprietary
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
+	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/chirpstack-api/go/v3/as"
 	"github.com/brocaar/chirpstack-api/go/v3/common"
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/applicationserver"
+	proprietarydown "github.com/liuhw0/chirpstack-network-server/v3/internal/downlink/proprietary"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/proprietary"
 	"github.com/liuhw0/lorawan"
 )
 
 var tasks = []func(*proprietaryContext) error{
 	setContextFromProprietaryPHYPayload,
+	handleProprietaryPlugins,
 	sendProprietaryPayloadToApplicationServer,
+	sendProprietaryDownlink,
 }
 
 type proprietaryContext struct {
@@ -26,6 +33,10 @@ type proprietaryContext struct {
 
 	RXPacket    models.RXPacket
 	DataPayload *lorawan.DataPayload
+
+	// PluginResponse holds the response of the plugin which handled the
+	// frame. It is nil when none of the plugins handled the frame.
+	PluginResponse *proprietary.HandleResponse
 }
 
 // Handle handles a proprietary uplink frame.
@@ -53,11 +64,106 @@ func setContextFromProprietaryPHYPayload(ctx *proprietaryContext) error {
 	return nil
 }
 
+// handleProprietaryPlugins passes the frame to the proprietary uplink
+// plugins, until one of the plugins handles the frame. A plugin returning an
+// error, not responding in time or returning an invalid response is skipped.
+func handleProprietaryPlugins(ctx *proprietaryContext) error {
+	handlers := getHandlers()
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	req := proprietary.HandleRequest{
+		MACPayload: ctx.DataPayload.Bytes,
+		MIC:        ctx.RXPacket.PHYPayload.MIC,
+		Frequency:  ctx.RXPacket.TXInfo.GetFrequency(),
+		DR:         ctx.RXPacket.DR,
+	}
+
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		var id lorawan.EUI64
+		copy(id[:], rxInfo.GatewayId)
+
+		req.RXInfo = append(req.RXInfo, proprietary.RXInfo{
+			GatewayID: id,
+			RSSI:      rxInfo.Rssi,
+			LoRaSNR:   float32(rxInfo.LoraSnr),
+			Context:   rxInfo.Context,
+		})
+	}
+
+	for _, h := range handlers {
+		id, _ := h.ID()
+
+		resp, err := h.Handle(req)
+		if err == nil {
+			err = validatePluginResponse(ctx.RXPacket, resp)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"plugin_id": id,
+				"ctx_id":    ctx.ctx.Value(logging.ContextIDKey),
+			}).WithError(err).Error("uplink/proprietary: plugin error")
+			continue
+		}
+
+		if resp.Action == proprietary.ActionPass {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"plugin_id": id,
+			"action":    resp.Action,
+			"ctx_id":    ctx.ctx.Value(logging.ContextIDKey),
+		}).Info("uplink/proprietary: frame handled by plugin")
+
+		ctx.PluginResponse = &resp
+		return nil
+	}
+
+	return nil
+}
+
+// validatePluginResponse validates that the downlink gateways of the plugin
+// response are within the gateways that received the uplink.
+func validatePluginResponse(rxPacket models.RXPacket, resp proprietary.HandleResponse) error {
+	if resp.Downlink == nil {
+		return nil
+	}
+
+	for _, id := range resp.Downlink.GatewayIDs {
+		var found bool
+		for _, rxInfo := range rxPacket.RXInfoSet {
+			if bytes.Equal(rxInfo.GatewayId, id[:]) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("downlink gateway %s did not receive the uplink", id)
+		}
+	}
+
+	return nil
+}
+
 func sendProprietaryPayloadToApplicationServer(ctx *proprietaryContext) error {
 	var ids []lorawan.EUI64
 
+	macPayload := ctx.DataPayload.Bytes
+	if ctx.PluginResponse != nil {
+		if ctx.PluginResponse.Action == proprietary.ActionDrop {
+			return nil
+		}
+
+		if len(ctx.PluginResponse.MACPayload) != 0 {
+			macPayload = ctx.PluginResponse.MACPayload
+		}
+	}
+
 	handleReq := as.HandleProprietaryUplinkRequest{
-		MacPayload: ctx.DataPayload.Bytes,
+		MacPayload: macPayload,
 		Mic:        ctx.RXPacket.PHYPayload.MIC[:],
 		TxInfo:     ctx.RXPacket.TXInfo,
 		RxInfo:     ctx.RXPacket.RXInfoSet,
@@ -93,12 +199,16 @@ func sendProprietaryPayloadToApplicationServer(ctx *proprietaryContext) error {
 
 	// send proprietary to all application servers, as the network-server
 	// has know knowledge / state about which application-server is responsible
-	// for this frame
+	// for this frame, unless a plugin has selected the routing-profiles
 	rps, err := storage.GetAllRoutingProfiles(ctx.ctx, storage.DB())
 	if err != nil {
 		return errors.Wrap(err, "get all routing-profiles error")
 	}
 
+	if ctx.PluginResponse != nil {
+		rps = filterRoutingProfiles(rps, ctx.PluginResponse.RoutingProfileIDs)
+	}
+
 	for _, rp := range rps {
 		go func(ctx context.Context, rp storage.RoutingProfile, handleReq as.HandleProprietaryUplinkRequest) {
 			asClient, err := applicationserver.Pool().Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
@@ -118,3 +228,70 @@ func sendProprietaryPayloadToApplicationServer(ctx *proprietaryContext) error {
 
 	return nil
 }
+
+// sendProprietaryDownlink sends the proprietary downlink returned by the
+// plugin which handled the frame.
+func sendProprietaryDownlink(ctx *proprietaryContext) error {
+	if ctx.PluginResponse == nil || ctx.PluginResponse.Downlink == nil {
+		return nil
+	}
+
+	dl := ctx.PluginResponse.Downlink
+
+	gatewayIDs := dl.GatewayIDs
+	if len(gatewayIDs) == 0 {
+		id, ok := getBestGatewayID(ctx.RXPacket.RXInfoSet)
+		if !ok {
+			return errors.New("no gateway available for proprietary downlink")
+		}
+		gatewayIDs = []lorawan.EUI64{id}
+	}
+
+	frequency := dl.Frequency
+	if frequency == 0 {
+		frequency = ctx.RXPacket.TXInfo.GetFrequency()
+	}
+
+	if err := proprietarydown.Handle(ctx.ctx, dl.MACPayload, dl.MIC, gatewayIDs, dl.PolarizationInversion, frequency, dl.DR); err != nil {
+		return errors.Wrap(err, "send proprietary downlink error")
+	}
+
+	return nil
+}
+
+// filterRoutingProfiles returns the routing-profiles matching the given IDs.
+// When no IDs are given, all routing-profiles are returned.
+func filterRoutingProfiles(rps []storage.RoutingProfile, ids []uuid.UUID) []storage.RoutingProfile {
+	if len(ids) == 0 {
+		return rps
+	}
+
+	var out []storage.RoutingProfile
+	for _, rp := range rps {
+		for _, id := range ids {
+			if rp.ID == id {
+				out = append(out, rp)
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+// getBestGatewayID returns the ID of the receiving gateway with the best SNR.
+func getBestGatewayID(rxInfoSet []*gw.UplinkRXInfo) (lorawan.EUI64, bool) {
+	var id lorawan.EUI64
+	var found bool
+	var snr float64
+
+	for _, rxInfo := range rxInfoSet {
+		if !found || rxInfo.LoraSnr > snr {
+			copy(id[:], rxInfo.GatewayId)
+			snr = rxInfo.LoraSnr
+			found = true
+		}
+	}
+
+	return id, found
+}
@@ -0,0 +1,166 @@
+package proprietary
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/chirpstack-api/go/v3/gw"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/proprietary"
+	"github.com/liuhw0/lorawan"
+)
+
+type testHandler struct {
+	resp proprietary.HandleResponse
+	err  error
+	req  *proprietary.HandleRequest
+}
+
+func (h *testHandler) ID() (string, error) {
+	return "test", nil
+}
+
+func (h *testHandler) Name() (string, error) {
+	return "Test", nil
+}
+
+func (h *testHandler) Handle(req proprietary.HandleRequest) (proprietary.HandleResponse, error) {
+	h.req = &req
+	return h.resp, h.err
+}
+
+func TestHandleProprietaryPlugins(t *testing.T) {
+	rxPacket := models.RXPacket{
+		DR: 5,
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				Major: lorawan.LoRaWANR1,
+				MType: lorawan.Proprietary,
+			},
+			MACPayload: &lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}},
+			MIC:        lorawan.MIC{5, 6, 7, 8},
+		},
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{
+				GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Rssi:      -10,
+				LoraSnr:   5,
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		handlers         []*testHandler
+		expectedResponse *proprietary.HandleResponse
+	}{
+		{
+			name: "no plugins",
+		},
+		{
+			name: "passed by all plugins",
+			handlers: []*testHandler{
+				{resp: proprietary.HandleResponse{Action: proprietary.ActionPass}},
+			},
+		},
+		{
+			name: "plugin error is skipped",
+			handlers: []*testHandler{
+				{err: errors.New("boom")},
+				{resp: proprietary.HandleResponse{Action: proprietary.ActionDrop}},
+			},
+			expectedResponse: &proprietary.HandleResponse{Action: proprietary.ActionDrop},
+		},
+		{
+			name: "plugin downlink through non-receiving gateway is skipped",
+			handlers: []*testHandler{
+				{resp: proprietary.HandleResponse{Action: proprietary.ActionDrop, Downlink: &proprietary.Downlink{GatewayIDs: []lorawan.EUI64{{8, 7, 6, 5, 4, 3, 2, 1}}}}},
+				{resp: proprietary.HandleResponse{Action: proprietary.ActionDrop, Downlink: &proprietary.Downlink{GatewayIDs: []lorawan.EUI64{{1, 2, 3, 4, 5, 6, 7, 8}}}}},
+			},
+			expectedResponse: &proprietary.HandleResponse{Action: proprietary.ActionDrop, Downlink: &proprietary.Downlink{GatewayIDs: []lorawan.EUI64{{1, 2, 3, 4, 5, 6, 7, 8}}}},
+		},
+		{
+			name: "first handling plugin wins",
+			handlers: []*testHandler{
+				{resp: proprietary.HandleResponse{Action: proprietary.ActionPass}},
+				{resp: proprietary.HandleResponse{Action: proprietary.ActionForward, MACPayload: []byte{4, 3, 2, 1}}},
+				{resp: proprietary.HandleResponse{Action: proprietary.ActionDrop}},
+			},
+			expectedResponse: &proprietary.HandleResponse{Action: proprietary.ActionForward, MACPayload: []byte{4, 3, 2, 1}},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			mu.Lock()
+			handlers = nil
+			for _, h := range tst.handlers {
+				handlers = append(handlers, h)
+			}
+			mu.Unlock()
+			defer Close()
+
+			ctx := proprietaryContext{
+				ctx:      context.Background(),
+				RXPacket: rxPacket,
+			}
+			assert.NoError(setContextFromProprietaryPHYPayload(&ctx))
+			assert.NoError(handleProprietaryPlugins(&ctx))
+			assert.Equal(tst.expectedResponse, ctx.PluginResponse)
+
+			if len(tst.handlers) != 0 {
+				assert.Equal(&proprietary.HandleRequest{
+					MACPayload: []byte{1, 2, 3, 4},
+					MIC:        lorawan.MIC{5, 6, 7, 8},
+					Frequency:  868100000,
+					DR:         5,
+					RXInfo: []proprietary.RXInfo{
+						{
+							GatewayID: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+							RSSI:      -10,
+							LoRaSNR:   5,
+						},
+					},
+				}, tst.handlers[0].req)
+			}
+		})
+	}
+}
+
+func TestFilterRoutingProfiles(t *testing.T) {
+	assert := require.New(t)
+
+	rps := []storage.RoutingProfile{
+		{ID: uuid.Must(uuid.NewV4())},
+		{ID: uuid.Must(uuid.NewV4())},
+	}
+
+	assert.Equal(rps, filterRoutingProfiles(rps, nil))
+	assert.Equal(rps[1:], filterRoutingProfiles(rps, []uuid.UUID{rps[1].ID}))
+	assert.Len(filterRoutingProfiles(rps, []uuid.UUID{uuid.Must(uuid.NewV4())}), 0)
+}
+
+func TestGetBestGatewayID(t *testing.T) {
+	assert := require.New(t)
+
+	_, ok := getBestGatewayID(nil)
+	assert.False(ok)
+
+	id, ok := getBestGatewayID([]*gw.UplinkRXInfo{
+		{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, LoraSnr: -5},
+		{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, LoraSnr: 3},
+		{GatewayId: []byte{3, 3, 3, 3, 3, 3, 3, 3}, LoraSnr: 1},
+	})
+	assert.True(ok)
+	assert.Equal(lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, id)
+}
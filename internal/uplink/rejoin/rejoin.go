@@ -10,8 +10,10 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/brocaar/chirpstack-api/go/v3/nc"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/audit"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/controller"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/backend/joinserver"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/band"
@@ -22,6 +24,7 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 	"github.com/liuhw0/lorawan"
 	"github.com/liuhw0/lorawan/backend"
 	loraband "github.com/liuhw0/lorawan/band"
@@ -55,6 +58,7 @@ var tasks = []func(*rejoinContext) error{
 	),
 	createDeviceActivation,
 	sendJoinAcceptDownlink,
+	logRejoinEvent,
 }
 
 type rejoinContext struct {
@@ -109,13 +113,24 @@ func Setup(conf config.Config) error {
 
 // Handle handles a rejoin-request.
 func Handle(ctx context.Context, rxPacket models.RXPacket) error {
+	ctx, span := tracing.Start(ctx, "uplink.rejoin.Handle")
+	err := handle(ctx, rxPacket)
+	tracing.End(span, err)
+	return err
+}
+
+func handle(ctx context.Context, rxPacket models.RXPacket) error {
 	rjctx := rejoinContext{
 		ctx:      ctx,
 		RXPacket: rxPacket,
 	}
 
 	for _, t := range tasks {
-		if err := t(&rjctx); err != nil {
+		t := t
+		if err := tracing.RunTask(ctx, t, func(ctx context.Context) error {
+			rjctx.ctx = ctx
+			return t(&rjctx)
+		}, ErrAbort); err != nil {
 			if err == ErrAbort {
 				return nil
 			}
@@ -339,7 +354,9 @@ func getRejoinAcceptFromJS(ctx *rejoinContext) error {
 		return errors.Wrap(err, "get join-server client error")
 	}
 
-	ctx.RejoinAnsPayload, err = jsClient.RejoinReq(ctx.ctx, rejoinReqPL)
+	jsCtx, span := tracing.StartChild(ctx.ctx, "joinserver.RejoinReq", trace.WithSpanKind(trace.SpanKindClient))
+	ctx.RejoinAnsPayload, err = jsClient.RejoinReq(jsCtx, rejoinReqPL)
+	tracing.End(span, err)
 	if err != nil {
 		return errors.Wrap(err, "rejoin-request to join-server error")
 	}
@@ -603,6 +620,25 @@ func sendJoinAcceptDownlink(ctx *rejoinContext) error {
 	return nil
 }
 
+func logRejoinEvent(ctx *rejoinContext) error {
+	if ctx.DeviceSession.PendingRejoinDeviceSession == nil {
+		return nil
+	}
+
+	audit.Log(ctx.ctx, audit.Event{
+		DevEUI: ctx.DeviceSession.DevEUI,
+		Type:   audit.EventRejoin,
+		Before: audit.GetState(ctx.DeviceSession),
+		After:  audit.GetState(*ctx.DeviceSession.PendingRejoinDeviceSession),
+		Details: map[string]interface{}{
+			"rejoin_type": ctx.RejoinType.String(),
+			"rj_count":    ctx.RJCount,
+		},
+	})
+
+	return nil
+}
+
 func errNotSupported(ctx *rejoinContext) error {
 	return fmt.Errorf("rejoin not implemented for type: %s", ctx.RejoinType)
 }
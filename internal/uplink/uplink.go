@@ -10,6 +10,8 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/brocaar/chirpstack-api/go/v3/common"
 	"github.com/brocaar/chirpstack-api/go/v3/gw"
@@ -25,6 +27,7 @@ import (
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/logging"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/models"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/storage"
+	"github.com/liuhw0/chirpstack-network-server/v3/internal/tracing"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/uplink/data"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/uplink/join"
 	"github.com/liuhw0/chirpstack-network-server/v3/internal/uplink/proprietary"
@@ -33,7 +36,12 @@ import (
 )
 
 var (
-	deduplicationDelay time.Duration
+	deduplicationDelay            time.Duration
+	adaptiveDeduplication         bool
+	adaptiveDeduplicationMinDelay time.Duration
+
+	workerPoolWorkers   int
+	workerPoolQueueSize int
 )
 
 // Setup configures the package.
@@ -51,6 +59,10 @@ func Setup(conf config.Config) error {
 	}
 
 	deduplicationDelay = conf.NetworkServer.DeduplicationDelay
+	adaptiveDeduplication = conf.NetworkServer.AdaptiveDeduplication
+	adaptiveDeduplicationMinDelay = conf.NetworkServer.AdaptiveDeduplicationMinDelay
+	workerPoolWorkers = conf.NetworkServer.UplinkWorkerPool.Workers
+	workerPoolQueueSize = conf.NetworkServer.UplinkWorkerPool.QueueSize
 
 	return nil
 }
@@ -92,12 +104,19 @@ func (s *Server) Stop() error {
 	return nil
 }
 
-// HandleUplinkFrames consumes received packets by the gateway and handles them
-// in a separate go-routine. Errors are logged.
+// HandleUplinkFrames consumes packets received by the gateway and handles
+// them using the uplink worker-pool. When the queue of the worker-pool is
+// full, the packet is dropped before de-duplication. Errors are logged.
 func HandleUplinkFrames(wg *sync.WaitGroup) {
+	pool := newWorkerPool("uplink", workerPoolWorkers, workerPoolQueueSize)
+	defer pool.close()
+
 	for uplinkFrame := range gwbackend.Backend().RXPacketChan() {
-		go func(uplinkFrame gw.UplinkFrame) {
-			wg.Add(1)
+		uplinkFrame := uplinkFrame
+		mType := getMType(uplinkFrame.PhyPayload)
+
+		wg.Add(1)
+		ok := pool.submit(getPriority(mType), func() {
 			defer wg.Done()
 
 			// The ctxID will be available as context value "ctx_id" so that
@@ -111,26 +130,52 @@ func HandleUplinkFrames(wg *sync.WaitGroup) {
 			ctx := context.Background()
 			ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
 
+			config.RLock()
+			defer config.RUnlock()
+
 			if err := HandleUplinkFrame(ctx, uplinkFrame); err != nil {
 				log.WithFields(log.Fields{
 					"ctx_id": ctxID,
 				}).WithError(err).Error("uplink: processing uplink frame error")
 			}
-		}(uplinkFrame)
+		})
+		if !ok {
+			wg.Done()
+			uplinkShedCounter(mType.String()).Inc()
+			log.WithField("mtype", mType).Debug("uplink: worker-pool queue is full, uplink frame dropped")
+		}
 	}
 }
 
 // HandleUplinkFrame handles a single uplink frame.
 func HandleUplinkFrame(ctx context.Context, uplinkFrame gw.UplinkFrame) error {
-	return collectUplinkFrames(ctx, uplinkFrame)
+	var gatewayID string
+	if uplinkFrame.RxInfo != nil {
+		gatewayID = hex.EncodeToString(uplinkFrame.RxInfo.GatewayId)
+	}
+
+	ctx, span := tracing.Start(ctx, "uplink.HandleUplinkFrame", trace.WithAttributes(
+		attribute.String("ctx_id", fmt.Sprintf("%s", ctx.Value(logging.ContextIDKey))),
+		attribute.String("gateway_id", gatewayID),
+		attribute.String("mtype", getMType(uplinkFrame.PhyPayload).String()),
+	))
+	err := collectUplinkFrames(ctx, uplinkFrame)
+	tracing.End(span, err)
+
+	return err
 }
 
 // HandleDownlinkTXAcks consumes received downlink tx acknowledgements from
-// the gateway.
+// the gateway and handles them using the downlink tx ack worker-pool.
 func HandleDownlinkTXAcks(wg *sync.WaitGroup) {
+	pool := newWorkerPool("downlink_tx_ack", workerPoolWorkers, workerPoolQueueSize)
+	defer pool.close()
+
 	for downlinkTXAck := range gwbackend.Backend().DownlinkTXAckChan() {
-		go func(downlinkTXAck gw.DownlinkTXAck) {
-			wg.Add(1)
+		downlinkTXAck := downlinkTXAck
+
+		wg.Add(1)
+		ok := pool.submit(priorityHigh, func() {
 			defer wg.Done()
 
 			// The ctxID will be available as context value "ctx_id" so that
@@ -144,6 +189,9 @@ func HandleDownlinkTXAcks(wg *sync.WaitGroup) {
 			ctx := context.Background()
 			ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
 
+			config.RLock()
+			defer config.RUnlock()
+
 			if err := ack.HandleDownlinkTXAck(ctx, &downlinkTXAck); err != nil {
 				log.WithFields(log.Fields{
 					"gateway_id": hex.EncodeToString(downlinkTXAck.GatewayId),
@@ -151,8 +199,12 @@ func HandleDownlinkTXAcks(wg *sync.WaitGroup) {
 					"ctx_id":     ctxID,
 				}).WithError(err).Error("uplink: handle downlink tx ack error")
 			}
-
-		}(downlinkTXAck)
+		})
+		if !ok {
+			wg.Done()
+			uplinkShedCounter("DownlinkTXAck").Inc()
+			log.WithField("gateway_id", hex.EncodeToString(downlinkTXAck.GatewayId)).Warning("uplink: worker-pool queue is full, downlink tx ack dropped")
+		}
 	}
 }
 
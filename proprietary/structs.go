@@ -0,0 +1,173 @@
+package proprietary
+
+import (
+	"net/rpc"
+
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/go-plugin"
+	"github.com/liuhw0/lorawan"
+)
+
+// HandshakeConfig for proprietary uplink plugins.
+var HandshakeConfig = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "PROPRIETARY_PLUGIN",
+	MagicCookieValue: "PROPRIETARY_PLUGIN",
+}
+
+// Handler defines the proprietary uplink handler interface.
+type Handler interface {
+	ID() (string, error)
+	Name() (string, error)
+	Handle(HandleRequest) (HandleResponse, error)
+}
+
+// Action defines what must happen with the proprietary uplink frame.
+type Action int
+
+// Available actions.
+const (
+	// ActionPass passes the frame to the next plugin. When none of the
+	// plugins handles the frame, it is forwarded to all application-servers.
+	ActionPass Action = iota
+
+	// ActionForward forwards the frame to the application-servers of the
+	// given routing-profiles.
+	ActionForward
+
+	// ActionDrop drops the frame.
+	ActionDrop
+)
+
+// HandleRequest implements the proprietary uplink handle request.
+type HandleRequest struct {
+	// MACPayload holds the (proprietary) MACPayload bytes.
+	MACPayload []byte
+
+	// MIC holds the MIC.
+	MIC lorawan.MIC
+
+	// Frequency holds the uplink frequency (Hz).
+	Frequency uint32
+
+	// DR holds the uplink data-rate.
+	DR int
+
+	// RXInfo contains the meta-data per receiving gateway.
+	RXInfo []RXInfo
+}
+
+// RXInfo contains the meta-data of a proprietary uplink frame as received
+// by a single gateway.
+type RXInfo struct {
+	GatewayID lorawan.EUI64
+	RSSI      int32
+	LoRaSNR   float32
+
+	// Context holds the gateway context of the uplink.
+	Context []byte
+}
+
+// HandleResponse implements the proprietary uplink handle response.
+type HandleResponse struct {
+	// Action defines what must happen with the frame.
+	Action Action
+
+	// RoutingProfileIDs contains the routing-profiles to which the frame
+	// must be forwarded in case of ActionForward. When empty, the frame is
+	// forwarded to all routing-profiles.
+	RoutingProfileIDs []uuid.UUID
+
+	// MACPayload holds the (e.g. decoded) MACPayload to forward to the
+	// application-servers. When empty, the received MACPayload is forwarded.
+	MACPayload []byte
+
+	// Downlink holds the (optional) proprietary downlink which must be sent
+	// immediately.
+	Downlink *Downlink
+}
+
+// Downlink defines a proprietary downlink.
+type Downlink struct {
+	// MACPayload holds the (proprietary) MACPayload bytes.
+	MACPayload []byte
+
+	// MIC holds the MIC.
+	MIC lorawan.MIC
+
+	// GatewayIDs contains the gateways through which the downlink must be
+	// sent. These must be within the gateways that received the uplink.
+	// When empty, the receiving gateway with the best SNR is used.
+	GatewayIDs []lorawan.EUI64
+
+	// PolarizationInversion defines if the LoRa polarization must be inverted.
+	PolarizationInversion bool
+
+	// Frequency holds the downlink frequency (Hz). When 0, the uplink
+	// frequency is used.
+	Frequency uint32
+
+	// DR holds the downlink data-rate.
+	DR int
+}
+
+// HandlerRPCServer implements the RPC server for the Handler interface.
+type HandlerRPCServer struct {
+	// Impl holds the interface implementation.
+	Impl Handler
+}
+
+func (s *HandlerRPCServer) ID(req interface{}, resp *string) error {
+	var err error
+	*resp, err = s.Impl.ID()
+	return err
+}
+
+func (s *HandlerRPCServer) Name(req interface{}, resp *string) error {
+	var err error
+	*resp, err = s.Impl.Name()
+	return err
+}
+
+func (s *HandlerRPCServer) Handle(req HandleRequest, resp *HandleResponse) error {
+	var err error
+	*resp, err = s.Impl.Handle(req)
+	return err
+}
+
+// HandlerRPC implements the RPC client for the Handler interface.
+type HandlerRPC struct {
+	client *rpc.Client
+}
+
+func (r *HandlerRPC) ID() (string, error) {
+	var resp string
+	err := r.client.Call("Plugin.ID", new(interface{}), &resp)
+	return resp, err
+}
+
+func (r *HandlerRPC) Name() (string, error) {
+	var resp string
+	err := r.client.Call("Plugin.Name", new(interface{}), &resp)
+	return resp, err
+}
+
+func (r *HandlerRPC) Handle(req HandleRequest) (HandleResponse, error) {
+	var resp HandleResponse
+	err := r.client.Call("Plugin.Handle", req, &resp)
+	return resp, err
+}
+
+// HandlerPlugin implements plugin.Plugin.
+type HandlerPlugin struct {
+	// Impl holds the interface implementation.
+	Impl Handler
+}
+
+func (p *HandlerPlugin) Server(*plugin.MuxBroker) (interface{}, error) {
+	return &HandlerRPCServer{Impl: p.Impl}, nil
+}
+
+func (p *HandlerPlugin) Client(b *plugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	return &HandlerRPC{client: c}, nil
+}